@@ -0,0 +1,93 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const annotateDesc = `
+This command changes the release-level settings of a release.
+
+'--protect' protects the release from deletion: Tiller refuses to delete it,
+including with 'helm delete --selector' or '--filter', unless the deletion
+passes '--force-protected'. Upgrades and rollbacks keep the protection, and
+'--unprotect' lifts it.
+
+    $ helm annotate --protect payments
+`
+
+type annotateCmd struct {
+	name      string
+	protect   bool
+	unprotect bool
+	out       io.Writer
+	client    helm.Interface
+}
+
+func newAnnotateCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	annotate := &annotateCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "annotate [flags] RELEASE_NAME",
+		Short:   "Change the release-level settings of a release, such as its protection from deletion",
+		Long:    annotateDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			if annotate.protect == annotate.unprotect {
+				return errors.New("exactly one of --protect and --unprotect is required")
+			}
+			annotate.name = args[0]
+			annotate.client = ensureHelmClient(annotate.client)
+			return annotate.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.BoolVar(&annotate.protect, "protect", false, "Protect the release from deletion")
+	f.BoolVar(&annotate.unprotect, "unprotect", false, "Lift the protection of the release from deletion")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (a *annotateCmd) run() error {
+	if _, err := a.client.ProtectRelease(a.name, a.protect); err != nil {
+		return prettyError(err)
+	}
+	if a.protect {
+		fmt.Fprintf(a.out, "release %q is protected from deletion\n", a.name)
+	} else {
+		fmt.Fprintf(a.out, "release %q is no longer protected from deletion\n", a.name)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestAnnotateCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "protect a release",
+			args:     []string{"aeneas"},
+			flags:    []string{"--protect"},
+			expected: `release "aeneas" is protected from deletion`,
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:     "unprotect a release",
+			args:     []string{"aeneas"},
+			flags:    []string{"--unprotect"},
+			expected: `release "aeneas" is no longer protected from deletion`,
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name: "annotate without a setting",
+			args: []string{"aeneas"},
+			err:  true,
+			rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:  "protect and unprotect",
+			args:  []string{"aeneas"},
+			flags: []string{"--protect", "--unprotect"},
+			err:   true,
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:  "protect a missing release",
+			args:  []string{"dido"},
+			flags: []string{"--protect"},
+			err:   true,
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:  "annotate without release",
+			flags: []string{"--protect"},
+			err:   true,
+		},
+	}
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newAnnotateCmd(c, out)
+	})
+}
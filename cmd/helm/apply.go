@@ -0,0 +1,345 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/renderutil"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+const applyDesc = `
+This command installs and upgrades the releases listed in a file, so that the
+cluster matches it. The file lists the releases with their chart and values:
+
+	releases:
+	- name: frontend
+	  chart: stable/nginx-ingress
+	  version: 1.6.0
+	  namespace: web
+	  values:
+	  - values/frontend.yaml
+	  set:
+	  - controller.replicaCount=2
+	- name: backend
+	  chart: ./charts/backend
+
+Releases that do not exist are installed. Releases that exist are upgraded,
+unless the upgrade would change neither their chart, their values nor their
+manifests. Paths to charts and values files are relative to the directory of
+the file.
+
+Releases installed by 'helm apply' are labeled with the name of the file,
+without its extension, as in 'helm.sh/apply-file=releases'. If '--prune' is
+set, the deployed releases of the namespaces used in the file that carry this
+label but are no longer listed in it are deleted. Releases installed otherwise,
+or from files of another name, are never pruned. Use '--dry-run' to see what
+would be done without changing anything.
+`
+
+// applyFileLabel is the label of the releases installed by 'helm apply',
+// whose value names the file that installed them.
+const applyFileLabel = "helm.sh/apply-file"
+
+// applyFile is the file read by 'helm apply'.
+type applyFile struct {
+	Releases []*applyRelease `json:"releases"`
+}
+
+// applyRelease is a release listed in an applyFile.
+type applyRelease struct {
+	Name      string   `json:"name"`
+	Chart     string   `json:"chart"`
+	Version   string   `json:"version,omitempty"`
+	Namespace string   `json:"namespace,omitempty"`
+	Values    []string `json:"values,omitempty"`
+	Set       []string `json:"set,omitempty"`
+}
+
+type applyCmd struct {
+	file        string
+	dryRun      bool
+	prune       bool
+	timeout     int64
+	wait        bool
+	waitForJobs bool
+
+	out    io.Writer
+	client helm.Interface
+}
+
+func newApplyCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	apply := &applyCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "apply [flags] -f FILE",
+		Short:   "Install, upgrade and delete releases to match a file listing them",
+		Long:    applyDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if apply.file == "" {
+				return errors.New("a file listing the releases is required, use --file")
+			}
+			if apply.waitForJobs {
+				apply.wait = true
+			}
+			apply.client = ensureHelmClient(apply.client)
+			return apply.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVarP(&apply.file, "file", "f", "", "File listing the releases to apply")
+	f.BoolVar(&apply.dryRun, "dry-run", false, "Show what would be installed, upgraded and deleted without doing it")
+	f.BoolVar(&apply.prune, "prune", false, "Delete the deployed releases installed from the file that are no longer listed in it")
+	f.Int64Var(&apply.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.BoolVar(&apply.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking a release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&apply.waitForJobs, "wait-for-jobs", false, "If set, will also wait until all Jobs of a release have completed, also sets --wait flag")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (a *applyCmd) run() error {
+	releases, err := loadApplyFile(a.file)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(a.file)
+
+	for _, r := range releases {
+		action, err := a.apply(r, dir)
+		if err != nil {
+			return fmt.Errorf("release %s: %s", r.Name, prettyError(err))
+		}
+		a.report(r.Name, action)
+	}
+
+	if a.prune {
+		return a.deleteUnlisted(releases)
+	}
+	return nil
+}
+
+// loadApplyFile reads and checks a file listing releases. Releases without a
+// namespace are given the default one.
+func loadApplyFile(path string) ([]*applyRelease, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f applyFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i, r := range f.Releases {
+		if r.Name == "" || r.Chart == "" {
+			return nil, fmt.Errorf("%s: release %d needs a name and a chart", path, i+1)
+		}
+		if seen[r.Name] {
+			return nil, fmt.Errorf("%s: release %s is listed more than once", path, r.Name)
+		}
+		seen[r.Name] = true
+		if r.Namespace == "" {
+			r.Namespace = defaultNamespace()
+		}
+	}
+	return f.Releases, nil
+}
+
+// apply installs or upgrades a release, and returns what was done.
+func (a *applyCmd) apply(r *applyRelease, dir string) (string, error) {
+	chartPath, err := locateChartPath("", "", "", relativeTo(dir, r.Chart), r.Version, false, defaultKeyring(), "", "", "")
+	if err != nil {
+		return "", err
+	}
+	ch, err := chartutil.Load(chartPath)
+	if err != nil {
+		return "", err
+	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := renderutil.CheckDependencies(ch, req); err != nil {
+			return "", err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return "", fmt.Errorf("cannot load requirements: %v", err)
+	}
+
+	var valueFiles valueFiles
+	for _, v := range r.Values {
+		valueFiles = append(valueFiles, relativeTo(dir, v))
+	}
+	rawVals, err := vals(valueFiles, r.Set, nil, nil, nil, nil, "", "", "")
+	if err != nil {
+		return "", err
+	}
+
+	res, err := a.client.ReleaseContent(r.Name)
+	if err != nil && !strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(r.Name).Error()) {
+		return "", err
+	}
+	if err != nil || res.Release.Info.Status.Code == release.Status_DELETED {
+		// A deleted release is replaced by the new one.
+		deleted := err == nil
+		_, err := a.client.InstallReleaseFromChart(ch, r.Namespace,
+			helm.ReleaseName(r.Name),
+			helm.ValueOverrides(rawVals),
+			helm.InstallLabels(map[string]string{applyFileLabel: applyFileLabelValue(a.file)}),
+			helm.InstallReuseName(deleted),
+			helm.InstallDryRun(a.dryRun),
+			helm.InstallTimeout(a.timeout),
+			helm.InstallWait(a.wait),
+			helm.InstallWaitForJobs(a.waitForJobs),
+		)
+		return "installed", err
+	}
+
+	current := res.Release
+	if current.Namespace != r.Namespace {
+		return "", fmt.Errorf("the release is deployed to namespace %s, not %s", current.Namespace, r.Namespace)
+	}
+
+	opts := []helm.UpdateOption{
+		helm.UpdateValueOverrides(rawVals),
+		helm.UpgradeTimeout(a.timeout),
+		helm.UpgradeWait(a.wait),
+		helm.UpgradeWaitForJobs(a.waitForJobs),
+	}
+	target, err := a.client.UpdateReleaseFromChart(r.Name, ch, append(opts, helm.UpgradeDryRun(true))...)
+	if err != nil {
+		return "", err
+	}
+	if !releaseChanged(current, target.Release) {
+		return "unchanged", nil
+	}
+	if a.dryRun {
+		return "upgraded", nil
+	}
+	_, err = a.client.UpdateReleaseFromChart(r.Name, ch, append(opts, helm.UpgradeDryRun(false))...)
+	return "upgraded", err
+}
+
+// deleteUnlisted deletes the deployed releases of the namespaces of the given
+// releases that are not among them, if they were installed from the file.
+func (a *applyCmd) deleteUnlisted(releases []*applyRelease) error {
+	managed := applyFileLabelValue(a.file)
+	listed := map[string]bool{}
+	namespaces := map[string]bool{}
+	for _, r := range releases {
+		listed[r.Name] = true
+		namespaces[r.Namespace] = true
+	}
+
+	for ns := range namespaces {
+		res, err := a.client.ListReleases(
+			helm.ReleaseListNamespace(ns),
+			helm.ReleaseListStatuses([]release.Status_Code{release.Status_DEPLOYED, release.Status_FAILED}),
+			helm.ReleaseListSelector(applyFileLabel+"="+managed),
+		)
+		if err != nil {
+			return prettyError(err)
+		}
+		for _, rel := range res.GetReleases() {
+			// The label is checked again in case Tiller ignored the selector.
+			if listed[rel.Name] || rel.Namespace != ns || rel.Labels[applyFileLabel] != managed {
+				continue
+			}
+			if _, err := a.client.DeleteRelease(rel.Name, helm.DeleteDryRun(a.dryRun), helm.DeleteTimeout(a.timeout)); err != nil {
+				return fmt.Errorf("release %s: %s", rel.Name, prettyError(err))
+			}
+			a.report(rel.Name, "deleted")
+		}
+	}
+	return nil
+}
+
+// applyFileLabelValue returns the value of applyFileLabel for a file: its
+// base name without extension, made a valid label value.
+func applyFileLabelValue(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	value := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.", r)) {
+			return r
+		}
+		return '-'
+	}, name)
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "-_.")
+}
+
+func (a *applyCmd) report(name, action string) {
+	if a.dryRun {
+		fmt.Fprintf(a.out, "%s: %s (dry run)\n", name, action)
+		return
+	}
+	fmt.Fprintf(a.out, "%s: %s\n", name, action)
+}
+
+// releaseChanged reports whether an upgrade from current to target changes
+// the chart, the values or the manifests of the release.
+func releaseChanged(current, target *release.Release) bool {
+	return chartID(current.Chart) != chartID(target.Chart) ||
+		current.GetConfig().GetRaw() != target.GetConfig().GetRaw() ||
+		releaseManifests(current) != releaseManifests(target)
+}
+
+func chartID(ch *chart.Chart) string {
+	md := ch.GetMetadata()
+	return md.GetName() + "-" + md.GetVersion()
+}
+
+// relativeTo resolves a path of the apply file relative to its directory.
+// Chart references, URLs and absolute paths are returned as they are.
+func relativeTo(dir, path string) string {
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		return path
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestApplyCmd(t *testing.T) {
+	alpine, err := chartutil.Load("testdata/testcharts/alpine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployed := func(name, values string) *release.Release {
+		return helm.ReleaseMock(&helm.MockReleaseOptions{
+			Name:   name,
+			Chart:  alpine,
+			Config: &chart.Config{Raw: values},
+		})
+	}
+
+	applied := func(name, file string) *release.Release {
+		rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: name})
+		rel.Labels = map[string]string{applyFileLabel: file}
+		return rel
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "install missing releases",
+			flags:    []string{"-f", "testdata/apply/releases.yaml"},
+			expected: "frontend: installed\nbackend: installed\n",
+		},
+		{
+			name:     "upgrade changed releases only",
+			flags:    []string{"-f", "testdata/apply/releases.yaml"},
+			rels:     []*release.Release{deployed("frontend", "name: value\n"), deployed("backend", "name: frontend\n")},
+			expected: "frontend: unchanged\nbackend: upgraded\n",
+		},
+		{
+			name:     "upgrade to a new chart",
+			flags:    []string{"-f", "testdata/apply/releases.yaml"},
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "frontend"})},
+			expected: "frontend: upgraded\nbackend: installed\n",
+		},
+		{
+			name:     "dry run",
+			flags:    []string{"-f", "testdata/apply/releases.yaml", "--dry-run"},
+			rels:     []*release.Release{deployed("backend", "name: frontend\n")},
+			expected: "frontend: installed \\(dry run\\)\nbackend: upgraded \\(dry run\\)\n",
+		},
+		{
+			name:     "prune unlisted releases",
+			flags:    []string{"-f", "testdata/apply/releases.yaml", "--prune"},
+			rels:     []*release.Release{deployed("frontend", "name: value\n"), applied("stale", "releases")},
+			expected: "frontend: unchanged\nbackend: installed\nstale: deleted\n$",
+		},
+		{
+			name:     "keep unlisted releases the file did not install",
+			flags:    []string{"-f", "testdata/apply/releases.yaml", "--prune"},
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "manual"}), applied("other", "databases")},
+			expected: "frontend: installed\nbackend: installed\n$",
+		},
+		{
+			name:     "keep unlisted releases without prune",
+			flags:    []string{"-f", "testdata/apply/releases.yaml"},
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "stale"})},
+			expected: "frontend: installed\nbackend: installed\n$",
+		},
+		{
+			name:  "releases listed twice",
+			flags: []string{"-f", "testdata/apply/duplicate.yaml"},
+			err:   true,
+		},
+		{
+			name: "no file",
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newApplyCmd(c, out)
+	})
+}
+
+func TestApplyFileLabelValue(t *testing.T) {
+	tests := map[string]string{
+		"testdata/apply/releases.yaml": "releases",
+		"envs/prod+eu.yml":             "prod-eu",
+		"_staging.yaml":                "staging",
+	}
+	for path, expected := range tests {
+		if got := applyFileLabelValue(path); got != expected {
+			t.Errorf("%s: expected %q, got %q", path, expected, got)
+		}
+	}
+}
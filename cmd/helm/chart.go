@@ -0,0 +1,116 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+const chartDesc = `
+Work with the format of chart directories.
+`
+
+const chartConvertDesc = `
+Convert chart directories to apiVersion v2.
+
+A v2 chart lists its dependencies in the 'dependencies' field of Chart.yaml,
+which takes the same entries as requirements.yaml, and locks them in Chart.lock:
+
+    # Chart.yaml
+    apiVersion: v2
+    name: mychart
+    version: 0.1.0
+    dependencies:
+    - name: nginx
+      version: "1.2.3"
+      repository: "https://example.com/charts"
+
+This command moves the dependencies of requirements.yaml to Chart.yaml,
+renames requirements.lock to Chart.lock, and sets the apiVersion. The
+converted chart is validated first: it must have a semantic version, and each
+of its dependencies a name, a version and a unique name or alias. Charts
+that are already v2 are left unchanged.
+
+The charts in the 'charts/' directory are not converted, as v1 and v2 charts
+can depend on each other.
+`
+
+func newChartCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chart convert",
+		Short: "Work with the format of chart directories",
+		Long:  chartDesc,
+	}
+
+	cmd.AddCommand(newChartConvertCmd(out))
+
+	return cmd
+}
+
+type chartConvertCmd struct {
+	out    io.Writer
+	charts []string
+}
+
+func newChartConvertCmd(out io.Writer) *cobra.Command {
+	cc := &chartConvertCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "convert [flags] [CHART_PATH] [...]",
+		Short: "Convert chart directories to apiVersion v2",
+		Long:  chartConvertDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cc.charts = []string{"."}
+			if len(args) > 0 {
+				cc.charts = args
+			}
+			return cc.run()
+		},
+	}
+	return cmd
+}
+
+func (c *chartConvertCmd) run() error {
+	for _, path := range c.charts {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if ok, err := chartutil.IsChartDir(path); !ok {
+			return err
+		}
+		cf, err := chartutil.LoadChartfile(filepath.Join(path, chartutil.ChartfileName))
+		if err != nil {
+			return err
+		}
+		if cf.ApiVersion == chartutil.ApiVersionV2 {
+			fmt.Fprintf(c.out, "%s is already apiVersion %s\n", path, chartutil.ApiVersionV2)
+			continue
+		}
+		if err := chartutil.ConvertChartfileToV2(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "Converted %s to apiVersion %s\n", path, chartutil.ApiVersionV2)
+	}
+	return nil
+}
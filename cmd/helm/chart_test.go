@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestChartConvertCmd(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-chart-convert-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	c, err := chartutil.Load("testdata/testcharts/reqtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chartutil.SaveDir(c, tdir); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(tdir, "reqtest")
+
+	for _, expect := range []string{"Converted", "is already apiVersion v2"} {
+		var buf bytes.Buffer
+		cmd := newChartConvertCmd(&buf)
+		if err := cmd.RunE(cmd, []string{dir}); err != nil {
+			t.Fatalf("Failed to run convert: %s", err)
+		}
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("Expected %q, got %q", expect, buf.String())
+		}
+	}
+
+	c, err = chartutil.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Metadata.ApiVersion != chartutil.ApiVersionV2 {
+		t.Errorf("Expected apiVersion v2, got %q", c.Metadata.ApiVersion)
+	}
+	reqs, err := chartutil.LoadRequirements(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs.Dependencies) != 3 {
+		t.Errorf("Expected 3 dependencies, got %d", len(reqs.Dependencies))
+	}
+	if _, err := chartutil.LoadRequirementsLock(c); err != nil {
+		t.Errorf("Expected Chart.lock: %s", err)
+	}
+}
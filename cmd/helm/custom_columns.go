@@ -0,0 +1,136 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/gosuri/uitable"
+	"k8s.io/client-go/util/jsonpath"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// customColumnsPrefix starts the output formats that print the given fields
+// of each release as the columns of a table, as kubectl does.
+const customColumnsPrefix = "custom-columns="
+
+// customColumn is a column of a custom-columns output: a header and the
+// JSONPath expression of its value.
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// isCustomColumns reports whether format is a custom-columns output format.
+func isCustomColumns(format string) bool {
+	return strings.HasPrefix(format, customColumnsPrefix)
+}
+
+// parseCustomColumns parses a custom-columns output format, such as
+// "custom-columns=NAME:.name,CHART:.chart.metadata.version". The paths may be
+// written with or without the braces of JSONPath templates.
+func parseCustomColumns(format string) ([]customColumn, error) {
+	spec := strings.TrimPrefix(format, customColumnsPrefix)
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format requires at least one HEADER:PATH column, such as %sNAME:.name", customColumnsPrefix)
+	}
+
+	var columns []customColumn
+	for _, col := range strings.Split(spec, ",") {
+		parts := strings.SplitN(col, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid custom column %q: expected HEADER:PATH", col)
+		}
+		expr := parts[1]
+		if !strings.HasPrefix(expr, "{") {
+			expr = "{" + expr + "}"
+		}
+		path := jsonpath.New(parts[0]).AllowMissingKeys(true)
+		if err := path.Parse(expr); err != nil {
+			return nil, fmt.Errorf("invalid path in custom column %q: %s", col, err)
+		}
+		columns = append(columns, customColumn{header: parts[0], path: path})
+	}
+	return columns, nil
+}
+
+// writeCustomColumns prints a table of the given columns of each release.
+// Missing values are shown as <none>.
+func writeCustomColumns(out io.Writer, columns []customColumn, rels []*release.Release, colWidth uint) error {
+	table := uitable.New()
+	table.MaxColWidth = colWidth
+	var row []interface{}
+	for _, c := range columns {
+		row = append(row, c.header)
+	}
+	table.AddRow(row...)
+
+	for _, rel := range rels {
+		obj, err := releaseFields(rel)
+		if err != nil {
+			return err
+		}
+		row = row[:0]
+		for _, c := range columns {
+			results, err := c.path.FindResults(obj)
+			if err != nil {
+				return fmt.Errorf("cannot get column %s of release %s: %s", c.header, rel.GetName(), err)
+			}
+			var values []string
+			for _, r := range results {
+				for _, v := range r {
+					values = append(values, fmt.Sprint(v.Interface()))
+				}
+			}
+			if len(values) == 0 {
+				values = []string{"<none>"}
+			}
+			row = append(row, strings.Join(values, ","))
+		}
+		table.AddRow(row...)
+	}
+	return encodeTable(out, table)
+}
+
+// releaseFields returns the fields of a release that the custom columns are
+// evaluated against: its protobuf JSON form, with field names as in the
+// .proto files, status codes by name and timestamps in RFC 3339 format. Only
+// the metadata and values of the chart are kept, as its files cannot be
+// converted to JSON.
+func releaseFields(rel *release.Release) (interface{}, error) {
+	r := *rel
+	if rel.Chart != nil {
+		r.Chart = &chart.Chart{Metadata: rel.Chart.Metadata, Values: rel.Chart.Values}
+	}
+
+	m := jsonpb.Marshaler{OrigName: true}
+	data, err := m.MarshalToString(&r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert release %s to JSON: %s", rel.GetName(), err)
+	}
+	var obj interface{}
+	if err := json.Unmarshal([]byte(data), &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -20,10 +20,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
 )
 
 const deleteDesc = `
@@ -32,15 +35,56 @@ It removes all of the resources associated with the last release of the chart.
 
 Use the '--dry-run' flag to see which releases will be deleted without actually
 deleting them.
+
+Use the '--wait' flag to block until the resources of the release, including the
+hooks deleted by the deletion, are removed from the cluster, which may take a
+while for the ones with finalizers. It waits for as long as '--timeout'. This
+makes it safe to install a release with the same name right after deleting it
+with '--purge'.
+
+The deletion of the resources propagates to their dependents, such as the pods
+of a Deployment, as '--cascade' says: 'background' deletes the dependents after
+the resources, 'foreground' deletes them before the resources and 'orphan'
+leaves them in the cluster. With '--wait', 'foreground' also waits for the
+dependents to be removed.
+
+Releases protected with 'helm annotate --protect' are only deleted with
+'--force-protected'.
+
+Instead of release names, '--selector' and '--filter' select the releases to
+delete by their labels or by a regular expression matching their names. Tiller
+deletes all of them in one call, for example to clean up preview environments:
+
+    $ helm delete --purge --selector team=ci-ephemeral --filter '^pr-[0-9]+-'
+
+Combine them with '--dry-run' to list the matching releases first.
+
+Resources annotated with 'helm.sh/resource-policy: keep' are left in the
+cluster, and the command lists them along with their namespace once the
+releases are deleted. With '--output json' or '--output yaml', it prints the
+deleted releases and the kept resources as a single document instead. Use
+'--ignore-resource-policy' to delete those resources too, for example to purge
+a release completely.
 `
 
 type deleteCmd struct {
-	name         string
-	dryRun       bool
-	disableHooks bool
-	purge        bool
-	timeout      int64
-	description  string
+	name           string
+	dryRun         bool
+	disableHooks   bool
+	purge          bool
+	timeout        int64
+	wait           bool
+	description    string
+	cascade        string
+	forceProtected bool
+	selector       string
+	filter         string
+	ignorePolicy   bool
+	output         string
+
+	// deleted and kept accumulate the results of the deletions.
+	deleted []string
+	kept    []*services.KeptResource
 
 	out    io.Writer
 	client helm.Interface
@@ -60,6 +104,13 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		Long:       deleteDesc,
 		PreRunE:    func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if del.selector != "" || del.filter != "" {
+				if len(args) > 0 {
+					return errors.New("release names cannot be combined with --selector or --filter")
+				}
+				del.client = ensureHelmClient(del.client)
+				return del.runMany()
+			}
 			if len(args) == 0 {
 				return errors.New("command 'delete' requires a release name")
 			}
@@ -71,9 +122,12 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 					return err
 				}
 
-				fmt.Fprintf(out, "release \"%s\" deleted\n", del.name)
+				del.deleted = append(del.deleted, del.name)
+				if outputFormat(del.output) == outputTable {
+					fmt.Fprintf(out, "release \"%s\" deleted\n", del.name)
+				}
 			}
-			return nil
+			return del.printResult()
 		},
 	}
 
@@ -83,7 +137,14 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&del.disableHooks, "no-hooks", false, "Prevent hooks from running during deletion")
 	f.BoolVar(&del.purge, "purge", false, "Remove the release from the store and make its name free for later use")
 	f.Int64Var(&del.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
+	f.BoolVar(&del.wait, "wait", false, "If set, will wait until all the resources of the release are removed from the cluster. It will wait for as long as --timeout")
 	f.StringVar(&del.description, "description", "", "Specify a description for the release")
+	f.StringVar(&del.cascade, "cascade", "background", "How to delete the dependents of the resources: background, foreground or orphan")
+	f.BoolVar(&del.forceProtected, "force-protected", false, "Delete the release even if it is protected with 'helm annotate --protect'")
+	f.StringVarP(&del.selector, "selector", "l", "", "Delete the releases whose labels match the selector, such as team=ci-ephemeral, instead of named ones")
+	f.StringVar(&del.filter, "filter", "", "Delete the releases whose names match this regular expression, instead of named ones")
+	f.BoolVar(&del.ignorePolicy, "ignore-resource-policy", false, "Also delete the resources annotated with 'helm.sh/resource-policy: keep'")
+	bindOutputFlag(cmd, &del.output)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -91,18 +152,117 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	return cmd
 }
 
-func (d *deleteCmd) run() error {
-	opts := []helm.DeleteOption{
+func (d *deleteCmd) options() []helm.DeleteOption {
+	return []helm.DeleteOption{
 		helm.DeleteDryRun(d.dryRun),
 		helm.DeleteDisableHooks(d.disableHooks),
 		helm.DeletePurge(d.purge),
 		helm.DeleteTimeout(d.timeout),
+		helm.DeleteWait(d.wait),
 		helm.DeleteDescription(d.description),
+		helm.DeleteCascade(d.cascade),
+		helm.DeleteForceProtected(d.forceProtected),
+		helm.DeleteIgnoreResourcePolicy(d.ignorePolicy),
 	}
-	res, err := d.client.DeleteRelease(d.name, opts...)
-	if res != nil && res.Info != "" {
-		fmt.Fprintln(d.out, res.Info)
+}
+
+func (d *deleteCmd) run() error {
+	res, err := d.client.DeleteRelease(d.name, d.options()...)
+	if res != nil {
+		d.addKept(res.Info, res.Kept)
 	}
 
 	return prettyError(err)
 }
+
+// addKept records the resources a deletion kept. Tillers that predate the
+// list of kept resources only describe them in info, which is printed as is.
+func (d *deleteCmd) addKept(info string, kept []*services.KeptResource) {
+	d.kept = append(d.kept, kept...)
+	if len(kept) == 0 && info != "" && outputFormat(d.output) == outputTable {
+		fmt.Fprintln(d.out, info)
+	}
+}
+
+// printResult prints the resources kept by the deletions, or with a
+// structured output, the deleted releases along with them.
+func (d *deleteCmd) printResult() error {
+	return write(d.out, &deleteWriter{deleted: d.deleted, kept: d.kept}, outputFormat(d.output))
+}
+
+// runMany deletes the releases matching the selector and the filter.
+func (d *deleteCmd) runMany() error {
+	opts := append(d.options(), helm.DeleteSelector(d.selector), helm.DeleteFilter(d.filter))
+	res, err := d.client.DeleteReleases(opts...)
+	if res != nil {
+		d.addKept(res.Info, res.Kept)
+		for _, r := range res.Releases {
+			d.deleted = append(d.deleted, r.Name)
+			if outputFormat(d.output) == outputTable {
+				fmt.Fprintf(d.out, "release \"%s\" deleted\n", r.Name)
+			}
+		}
+		if len(res.Releases) == 0 && len(res.Failed) == 0 && err == nil && outputFormat(d.output) == outputTable {
+			fmt.Fprintln(d.out, "No releases matched.")
+		}
+	}
+	if err != nil {
+		return prettyError(err)
+	}
+	if len(res.Failed) > 0 {
+		es := make([]string, 0, len(res.Failed))
+		for _, f := range res.Failed {
+			es = append(es, fmt.Sprintf("%s: %s", f.Name, f.Error))
+		}
+		return fmt.Errorf("failed to delete %d of %d releases: %s", len(res.Failed), len(res.Failed)+len(res.Releases), strings.Join(es, "; "))
+	}
+
+	return d.printResult()
+}
+
+type deleteWriter struct {
+	deleted []string
+	kept    []*services.KeptResource
+}
+
+type deleteElement struct {
+	Deleted []string      `json:"deleted"`
+	Kept    []keptElement `json:"kept"`
+}
+
+type keptElement struct {
+	Release   string `json:"release"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func (w *deleteWriter) WriteTable(out io.Writer) error {
+	if len(w.kept) == 0 {
+		return nil
+	}
+	fmt.Fprintln(out, "These resources were kept due to the resource policy, use --ignore-resource-policy to delete them:")
+	table := uitable.New()
+	table.AddRow("RELEASE", "RESOURCE", "NAMESPACE")
+	for _, k := range w.kept {
+		table.AddRow(k.Release, k.Kind+"/"+k.Name, k.Namespace)
+	}
+	return encodeTable(out, table)
+}
+
+func (w *deleteWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, w.element())
+}
+
+func (w *deleteWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, w.element())
+}
+
+func (w *deleteWriter) element() deleteElement {
+	e := deleteElement{Deleted: []string{}, Kept: []keptElement{}}
+	e.Deleted = append(e.Deleted, w.deleted...)
+	for _, k := range w.kept {
+		e.Kept = append(e.Kept, keptElement{Release: k.Release, Kind: k.Kind, Name: k.Name, Namespace: k.Namespace})
+	}
+	return e
+}
@@ -17,13 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
 func TestDelete(t *testing.T) {
@@ -45,6 +48,14 @@ func TestDelete(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:     "delete with wait",
+			args:     []string{"aeneas"},
+			flags:    []string{"--purge", "--wait"},
+			expected: "",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
 		{
 			name:     "delete without hooks",
 			args:     []string{"aeneas"},
@@ -69,13 +80,144 @@ func TestDelete(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:  "delete orphaning the dependents",
+			args:  []string{"aeneas"},
+			flags: []string{"--cascade", "orphan"},
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name: "delete a protected release",
+			args: []string{"aeneas"},
+			err:  true,
+			rels: []*release.Release{protectedReleaseMock("aeneas")},
+		},
+		{
+			name:     "delete a protected release by force",
+			args:     []string{"aeneas"},
+			flags:    []string{"--force-protected"},
+			expected: `release "aeneas" deleted`,
+			rels:     []*release.Release{protectedReleaseMock("aeneas")},
+		},
 		{
 			name: "delete without release",
 			args: []string{},
 			err:  true,
 		},
+		{
+			name:     "delete by selector",
+			flags:    []string{"--selector", "team=ci-ephemeral"},
+			expected: `release "pr-12-web" deleted`,
+			rels: []*release.Release{
+				func() *release.Release {
+					r := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "pr-12-web"})
+					r.Labels = map[string]string{"team": "ci-ephemeral"}
+					return r
+				}(),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "payments"}),
+			},
+		},
+		{
+			name:     "delete by filter",
+			flags:    []string{"--filter", "^pr-[0-9]+-"},
+			expected: `release "pr-12-web" deleted\nrelease "pr-13-web" deleted`,
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "pr-12-web"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "pr-13-web"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "payments"}),
+			},
+		},
+		{
+			name:     "delete by filter with a protected release",
+			flags:    []string{"--filter", "^pr-[0-9]+-"},
+			expected: `release "pr-12-web" deleted`,
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "pr-12-web"}),
+				protectedReleaseMock("pr-13-web"),
+			},
+			err: true,
+		},
+		{
+			name:     "dry run delete by filter with a protected release",
+			flags:    []string{"--filter", "^pr-[0-9]+-", "--dry-run"},
+			expected: `release "pr-12-web" deleted`,
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "pr-12-web"}),
+				protectedReleaseMock("pr-13-web"),
+			},
+			err: true,
+		},
+		{
+			name:     "delete by filter without matches",
+			flags:    []string{"--filter", "^pr-"},
+			expected: "No releases matched.",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "payments"})},
+		},
+		{
+			name:  "delete names and a selector",
+			args:  []string{"aeneas"},
+			flags: []string{"--selector", "team=ci-ephemeral"},
+			err:   true,
+		},
 	}
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
 		return newDeleteCmd(c, out)
 	})
 }
+
+func TestDeleteKeptResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		flags    []string
+		expected string
+	}{
+		{
+			name:     "delete listing the kept resources",
+			args:     []string{"aeneas"},
+			expected: `(?s)^release "aeneas" deleted\nThese resources were kept due to the resource policy.*\nRELEASE\s+RESOURCE\s+NAMESPACE\s*\naeneas\s+PersistentVolumeClaim/data\s+sicily\s*\n$`,
+		},
+		{
+			name:     "delete ignoring the resource policy",
+			args:     []string{"aeneas"},
+			flags:    []string{"--ignore-resource-policy"},
+			expected: `^release "aeneas" deleted\n$`,
+		},
+		{
+			name:     "delete printing the kept resources as JSON",
+			args:     []string{"aeneas"},
+			flags:    []string{"--output", "json"},
+			expected: `^\{"deleted":\["aeneas"\],"kept":\[\{"release":"aeneas","kind":"PersistentVolumeClaim","name":"data","namespace":"sicily"\}\]\}\n$`,
+		},
+		{
+			name:     "delete by filter printing the kept resources as YAML",
+			flags:    []string{"--filter", "^aen", "--output", "yaml"},
+			expected: `^deleted:\n- aeneas\nkept:\n- kind: PersistentVolumeClaim\n  name: data\n  namespace: sicily\n  release: aeneas\n$`,
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		c := &helm.FakeClient{
+			Rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+			KeptResources: map[string][]*rls.KeptResource{
+				"aeneas": {{Kind: "PersistentVolumeClaim", Name: "data", Namespace: "sicily", Release: "aeneas"}},
+			},
+		}
+		cmd := newDeleteCmd(c, &buf)
+		cmd.ParseFlags(tt.flags)
+		if err := cmd.RunE(cmd, tt.args); err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+		if !regexp.MustCompile(tt.expected).MatchString(buf.String()) {
+			t.Errorf("%s: expected %q to match %q", tt.name, buf.String(), tt.expected)
+		}
+		buf.Reset()
+	}
+}
+
+func protectedReleaseMock(name string) *release.Release {
+	r := helm.ReleaseMock(&helm.MockReleaseOptions{Name: name})
+	r.Protected = true
+	return r
+}
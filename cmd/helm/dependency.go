@@ -75,6 +75,10 @@ the dependency charts stored locally. The path should start with a prefix of
 If the dependency chart is retrieved locally, it is not required to have the
 repository added to helm by "helm repo add". Version matching is also supported
 for this case.
+
+Charts with 'apiVersion: v2' list their dependencies in the 'dependencies' field
+of Chart.yaml instead, with the same entries, and have a 'Chart.lock' file in
+place of 'requirements.lock'. 'helm chart convert' converts a chart to v2.
 `
 
 const dependencyListDesc = `
@@ -149,7 +153,7 @@ func (l *dependencyListCmd) run() error {
 
 	l.printRequirements(r, l.out)
 	fmt.Fprintln(l.out)
-	l.printMissing(r)
+	l.printMissing(r, chartutil.RequirementsFileName(c))
 	return nil
 }
 
@@ -239,7 +243,7 @@ func (l *dependencyListCmd) printRequirements(reqs *chartutil.Requirements, out
 }
 
 // printMissing prints warnings about charts that are present on disk, but are not in the requirements.
-func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements) {
+func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements, source string) {
 	folder := filepath.Join(l.chartpath, "charts/*")
 	files, err := filepath.Glob(folder)
 	if err != nil {
@@ -269,7 +273,7 @@ func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements) {
 			}
 		}
 		if !found {
-			fmt.Fprintf(l.out, "WARNING: %q is not in requirements.yaml.\n", f)
+			fmt.Fprintf(l.out, "WARNING: %q is not in %s.\n", f, source)
 		}
 	}
 
@@ -0,0 +1,325 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	diffContext = 3
+
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+)
+
+// diffSummary counts the resources that differ between two manifests.
+type diffSummary struct {
+	added, changed, removed int
+}
+
+// diffManifests writes a unified diff of the resources of two rendered
+// release manifests to out, one resource at a time, followed by a summary.
+// Resources are matched by kind and name.
+func diffManifests(out io.Writer, current, target string, color bool) diffSummary {
+	from := manifestsByResource(current)
+	to := manifestsByResource(target)
+
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	p := &diffPrinter{out: out, color: color}
+	var sum diffSummary
+	for _, k := range keys {
+		a, inFrom := from[k]
+		b, inTo := to[k]
+		switch {
+		case !inFrom:
+			sum.added++
+			p.file("/dev/null", "b/"+k)
+		case !inTo:
+			sum.removed++
+			p.file("a/"+k, "/dev/null")
+		case a == b:
+			continue
+		default:
+			sum.changed++
+			p.file("a/"+k, "b/"+k)
+		}
+		p.hunks(diffLines(splitLines(a), splitLines(b)))
+	}
+
+	if sum == (diffSummary{}) {
+		fmt.Fprintln(out, "No changes to the release manifests.")
+	}
+	fmt.Fprintf(out, "SUMMARY: %d to add, %d to change, %d to remove\n", sum.added, sum.changed, sum.removed)
+	return sum
+}
+
+// manifestsByResource splits a manifest into its documents, keyed by the kind
+// and name of the resource they describe.
+func manifestsByResource(manifest string) map[string]string {
+	res := map[string]string{}
+	for name, doc := range releaseutil.SplitManifests(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil && head.Metadata != nil && head.Metadata.Name != "" {
+			name = head.Kind + "/" + head.Metadata.Name
+		}
+		res[name] = doc
+	}
+	return res
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLine is a single line of a diff. Op is one of ' ', '-' or '+'.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines computes the line diff between a and b from their longest common
+// subsequence.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
+
+type diffPrinter struct {
+	out   io.Writer
+	color bool
+}
+
+func (p *diffPrinter) println(color, s string) {
+	if p.color && color != "" {
+		fmt.Fprintf(p.out, "%s%s%s\n", color, s, colorReset)
+		return
+	}
+	fmt.Fprintln(p.out, s)
+}
+
+func (p *diffPrinter) file(from, to string) {
+	p.println(colorYellow, "--- "+from)
+	p.println(colorYellow, "+++ "+to)
+}
+
+// hunks prints the changed lines with diffContext lines of context around
+// them, merging changes that are close to each other into a single hunk.
+func (p *diffPrinter) hunks(lines []diffLine) {
+	for start := 0; start < len(lines); {
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			return
+		}
+
+		// extend the hunk while the next change is within reach of its context
+		last := first
+		for k := first + 1; k < len(lines) && k <= last+2*diffContext; k++ {
+			if lines[k].op != ' ' {
+				last = k
+			}
+		}
+
+		from := first - diffContext
+		if from < start {
+			from = start
+		}
+		to := last + diffContext + 1
+		if to > len(lines) {
+			to = len(lines)
+		}
+		p.hunk(lines, from, to)
+		start = to
+	}
+}
+
+func (p *diffPrinter) hunk(lines []diffLine, from, to int) {
+	var aStart, bStart, aCount, bCount int
+	for _, l := range lines[:from] {
+		if l.op != '+' {
+			aStart++
+		}
+		if l.op != '-' {
+			bStart++
+		}
+	}
+	for _, l := range lines[from:to] {
+		if l.op != '+' {
+			aCount++
+		}
+		if l.op != '-' {
+			bCount++
+		}
+	}
+	if aCount > 0 {
+		aStart++
+	}
+	if bCount > 0 {
+		bStart++
+	}
+
+	p.println(colorCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aCount, bStart, bCount))
+	for _, l := range lines[from:to] {
+		switch l.op {
+		case '-':
+			p.println(colorRed, "-"+l.text)
+		case '+':
+			p.println(colorGreen, "+"+l.text)
+		default:
+			p.println("", " "+l.text)
+		}
+	}
+}
+
+// isTerminal reports whether out is a terminal, in which case diffs are
+// colored.
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
+// diffValues writes the values that differ between two sets of values to out,
+// one line per changed key in the dotted notation of --set, and returns how
+// many keys differ. Maps are compared key by key, lists as a whole.
+func diffValues(out io.Writer, current, target map[string]interface{}, color bool) int {
+	from := map[string]interface{}{}
+	flattenValues("", current, from)
+	to := map[string]interface{}{}
+	flattenValues("", target, to)
+
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	p := &diffPrinter{out: out, color: color}
+	var changed int
+	for _, k := range keys {
+		a, inFrom := from[k]
+		b, inTo := to[k]
+		switch {
+		case !inFrom:
+			p.println(colorGreen, fmt.Sprintf("+ %s: %s", k, formatValue(b)))
+		case !inTo:
+			p.println(colorRed, fmt.Sprintf("- %s: %s", k, formatValue(a)))
+		case formatValue(a) == formatValue(b):
+			continue
+		default:
+			p.println(colorYellow, fmt.Sprintf("~ %s: %s -> %s", k, formatValue(a), formatValue(b)))
+		}
+		changed++
+	}
+	return changed
+}
+
+// flattenValues adds the leaves of the nested maps of values to res, keyed
+// by their dotted path under prefix.
+func flattenValues(prefix string, values map[string]interface{}, res map[string]interface{}) {
+	for k, v := range values {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		switch m := v.(type) {
+		case map[string]interface{}:
+			if len(m) > 0 {
+				flattenValues(k, m, res)
+				continue
+			}
+		case chartutil.Values:
+			if len(m) > 0 {
+				flattenValues(k, m, res)
+				continue
+			}
+		}
+		res[k] = v
+	}
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
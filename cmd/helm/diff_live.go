@@ -0,0 +1,140 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+const diffLiveHelp = `
+This command compares the manifest of a release with the objects currently in
+the cluster, to detect changes made outside of Helm, such as with kubectl edit.
+
+For each resource that drifted, it prints a unified diff from the manifest to
+the live values of the fields the manifest sets. Defaults filled in by the API
+server and fields added by controllers, such as the status, are not drift.
+Resources missing from the cluster are shown as removed.
+
+The latest revision is compared, unless another one is given with '--revision'.
+`
+
+type diffLiveCmd struct {
+	release string
+	out     io.Writer
+	client  helm.Interface
+	version int32
+	output  string
+}
+
+// driftElement is a drifted resource as printed with --output.
+type driftElement struct {
+	Resource string `json:"resource"`
+	Missing  bool   `json:"missing"`
+	Manifest string `json:"manifest"`
+	Live     string `json:"live"`
+}
+
+func newDiffLiveCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	diff := &diffLiveCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diff-live [flags] RELEASE_NAME",
+		Short:   "Show how the resources of a release drifted from its manifest in the cluster",
+		Long:    diffLiveHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			diff.release = args[0]
+			diff.client = ensureHelmClient(diff.client)
+			return diff.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.Int32Var(&diff.version, "revision", 0, "Compare the named release with revision")
+	f.StringVarP(&diff.output, "output", "o", "", "Output the drifted resources in the specified format (json or yaml)")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (d *diffLiveCmd) run() error {
+	res, err := d.client.ReleaseDetail(d.release, helm.DetailReleaseVersion(d.version), helm.DetailDiff(true))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	if d.output != "" {
+		elements := []driftElement{}
+		for _, drift := range res.Drifts {
+			elements = append(elements, driftElement{
+				Resource: drift.Resource,
+				Missing:  drift.Live == "",
+				Manifest: drift.Manifest,
+				Live:     drift.Live,
+			})
+		}
+		switch outputFormat(d.output) {
+		case outputJSON:
+			return encodeJSON(d.out, elements)
+		case outputYAML:
+			return encodeYAML(d.out, elements)
+		}
+		return fmt.Errorf("unknown output format %q", d.output)
+	}
+
+	printDrifts(d.out, res, isTerminal(d.out))
+	return nil
+}
+
+// printDrifts writes a unified diff of each drifted resource, from its
+// manifest to its live state, followed by a summary.
+func printDrifts(out io.Writer, res *rls.GetReleaseDetailResponse, color bool) {
+	p := &diffPrinter{out: out, color: color}
+	missing := 0
+	for _, drift := range res.Drifts {
+		if drift.Live == "" {
+			missing++
+			p.file("a/"+drift.Resource, "/dev/null")
+		} else {
+			p.file("a/"+drift.Resource, "b/"+drift.Resource+" (live)")
+		}
+		manifest := strings.TrimSuffix(drift.Manifest, "\n")
+		live := strings.TrimSuffix(drift.Live, "\n")
+		p.hunks(diffLines(splitLines(manifest), splitLines(live)))
+	}
+
+	if len(res.Drifts) == 0 {
+		fmt.Fprintln(out, "No resources drifted from the release manifest.")
+	}
+	fmt.Fprintf(out, "SUMMARY: %d of %d resources drifted, %d missing from the cluster\n", len(res.Drifts), res.ResourceCount, missing)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestDiffLiveCmd(t *testing.T) {
+	drifted := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"})
+	drifted.Manifest = "kind: Deployment\nmetadata:\n  name: web\n---\nkind: Secret\nmetadata:\n  name: fixture\n---\nkind: Service\nmetadata:\n  name: web\n"
+	c := &helm.FakeClient{
+		Rels: []*release.Release{
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			drifted,
+		},
+		DriftedResources: map[string][]string{"thomas-guide": {"Deployment/web", "Secret/fixture"}},
+		Drifts: map[string][]*rls.ResourceDrift{"thomas-guide": {
+			{Resource: "Deployment/web", Manifest: "spec:\n  replicas: 2\n", Live: "spec:\n  replicas: 5\n"},
+			{Resource: "Secret/fixture", Manifest: "kind: Secret\n"},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		flags    []string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "drifted release",
+			args:     []string{"thomas-guide"},
+			expected: "^--- a/Deployment/web\n\\+\\+\\+ b/Deployment/web \\(live\\)\n@@ .* @@\n spec:\n-  replicas: 2\n\\+  replicas: 5\n(.|\n)*--- a/Secret/fixture\n\\+\\+\\+ /dev/null\n@@ -1,1 \\+0,0 @@\n-kind: Secret\nSUMMARY: 2 of 3 resources drifted, 1 missing from the cluster\n$",
+		},
+		{
+			name:     "release without drift",
+			args:     []string{"atlas"},
+			expected: "^No resources drifted from the release manifest.\nSUMMARY: 0 of 1 resources drifted, 0 missing from the cluster\n$",
+		},
+		{
+			name:     "drifted release in json",
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--output", "json"},
+			expected: regexp.QuoteMeta(`[{"resource":"Deployment/web","missing":false,"manifest":"spec:\n  replicas: 2\n","live":"spec:\n  replicas: 5\n"},{"resource":"Secret/fixture","missing":true,"manifest":"kind: Secret\n","live":""}]`),
+		},
+		{
+			name: "missing release",
+			args: []string{"carabiner"},
+			err:  true,
+		},
+		{
+			name: "without release",
+			err:  true,
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		cmd := newDiffLiveCmd(c, &buf)
+		cmd.ParseFlags(tt.flags)
+		err := cmd.RunE(cmd, tt.args)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: expected error %t, got %v", tt.name, tt.err, err)
+		}
+		if !regexp.MustCompile(tt.expected).MatchString(buf.String()) {
+			t.Errorf("%s: expected %q to match %q", tt.name, buf.String(), tt.expected)
+		}
+		buf.Reset()
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiffManifests(t *testing.T) {
+	current := `kind: ConfigMap
+metadata:
+  name: kept
+data:
+  a: "1"
+  b: "2"
+  c: "3"
+  d: "4"
+  e: "5"
+  f: "6"
+  g: "7"
+  h: "8"
+  i: "9"
+---
+kind: Secret
+metadata:
+  name: removed
+---
+kind: Service
+metadata:
+  name: unchanged
+`
+	target := `kind: ConfigMap
+metadata:
+  name: kept
+data:
+  a: "one"
+  b: "2"
+  c: "3"
+  d: "4"
+  e: "5"
+  f: "6"
+  g: "7"
+  h: "8"
+  i: "nine"
+---
+kind: Service
+metadata:
+  name: unchanged
+---
+kind: Deployment
+metadata:
+  name: added
+`
+	expected := `--- a/ConfigMap/kept
++++ b/ConfigMap/kept
+@@ -2,7 +2,7 @@
+ metadata:
+   name: kept
+ data:
+-  a: "1"
++  a: "one"
+   b: "2"
+   c: "3"
+   d: "4"
+@@ -10,4 +10,4 @@
+   f: "6"
+   g: "7"
+   h: "8"
+-  i: "9"
++  i: "nine"
+--- /dev/null
++++ b/Deployment/added
+@@ -0,0 +1,3 @@
++kind: Deployment
++metadata:
++  name: added
+--- a/Secret/removed
++++ /dev/null
+@@ -1,3 +0,0 @@
+-kind: Secret
+-metadata:
+-  name: removed
+SUMMARY: 1 to add, 1 to change, 1 to remove
+`
+
+	var buf bytes.Buffer
+	sum := diffManifests(&buf, current, target, false)
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+	if sum != (diffSummary{added: 1, changed: 1, removed: 1}) {
+		t.Errorf("unexpected summary %+v", sum)
+	}
+
+	buf.Reset()
+	diffManifests(&buf, current, current, false)
+	if expected := "No changes to the release manifests.\nSUMMARY: 0 to add, 0 to change, 0 to remove\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	current := map[string]interface{}{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "1.15"},
+		"debug": true,
+		"hosts": []interface{}{"a.example.com"},
+	}
+	target := map[string]interface{}{
+		"image":   map[string]interface{}{"repository": "nginx", "tag": "1.16"},
+		"hosts":   []interface{}{"a.example.com", "b.example.com"},
+		"ingress": map[string]interface{}{"enabled": true},
+	}
+	expected := `- debug: true
+~ hosts: ["a.example.com"] -> ["a.example.com","b.example.com"]
+~ image.tag: "1.15" -> "1.16"
++ ingress.enabled: true
+`
+
+	var buf bytes.Buffer
+	if changed := diffValues(&buf, current, target, false); changed != 4 {
+		t.Errorf("expected 4 changed values, got %d", changed)
+	}
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if changed := diffValues(&buf, current, current, false); changed != 0 || buf.Len() != 0 {
+		t.Errorf("expected no changes, got %d:\n%s", changed, buf.String())
+	}
+}
@@ -43,6 +43,10 @@ directory for the chart and uncompress into that directory.
 If the --verify flag is specified, the requested chart MUST have a provenance
 file, and MUST pass the verification process. Failure in any part of this will
 result in an error, and the chart will not be saved locally.
+
+Charts can also be fetched from OCI registries with a reference such as
+oci://registry.example.com/charts/nginx:1.2.3. Registries do not serve
+provenance files, so such charts cannot be verified.
 `
 
 type fetchCmd struct {
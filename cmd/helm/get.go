@@ -76,10 +76,12 @@ func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
 	f.StringVar(&get.template, "template", "", "Go template for formatting the output, eg: {{.Release.Name}}")
 
+	cmd.AddCommand(newGetAllCmd(nil, out))
 	cmd.AddCommand(newGetValuesCmd(nil, out))
 	cmd.AddCommand(newGetManifestCmd(nil, out))
 	cmd.AddCommand(newGetHooksCmd(nil, out))
 	cmd.AddCommand(newGetNotesCmd(nil, out))
+	cmd.AddCommand(newGetDiffCmd(nil, out))
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -0,0 +1,144 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+var getAllHelp = `
+This command downloads the hooks, the manifest, the values and the notes of a
+named release at once.
+
+With '--output json' or '--output yaml' they are printed as a single document,
+for example to collect them in a support bundle:
+
+	$ helm get all --output json happy-panda > happy-panda.json
+`
+
+type getAllCmd struct {
+	release string
+	out     io.Writer
+	client  helm.Interface
+	version int32
+	output  string
+}
+
+// releaseElement is a release as printed with --output.
+type releaseElement struct {
+	Name           string                 `json:"name"`
+	Namespace      string                 `json:"namespace"`
+	Revision       int32                  `json:"revision"`
+	Updated        string                 `json:"updated"`
+	Status         string                 `json:"status"`
+	Chart          string                 `json:"chart"`
+	Values         map[string]interface{} `json:"values"`
+	ComputedValues map[string]interface{} `json:"computed_values"`
+	Hooks          []hookElement          `json:"hooks"`
+	Manifest       string                 `json:"manifest"`
+	Notes          string                 `json:"notes"`
+}
+
+func newGetAllCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	get := &getAllCmd{
+		out:    out,
+		client: client,
+	}
+	cmd := &cobra.Command{
+		Use:     "all [flags] RELEASE_NAME",
+		Short:   "Download the hooks, manifest, values and notes of a named release",
+		Long:    getAllHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			get.release = args[0]
+			get.client = ensureHelmClient(get.client)
+			return get.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
+	f.StringVarP(&get.output, "output", "o", "", "Output the release in the specified format (json or yaml)")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (g *getAllCmd) run() error {
+	res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(g.version))
+	if err != nil {
+		return prettyError(err)
+	}
+	rel := res.Release
+
+	if g.output == "" {
+		if err := printRelease(g.out, rel); err != nil {
+			return err
+		}
+		if notes := rel.GetInfo().GetStatus().GetNotes(); notes != "" {
+			fmt.Fprintf(g.out, "NOTES:\n%s\n", notes)
+		}
+		return nil
+	}
+
+	values, err := chartutil.ReadValues([]byte(rel.GetConfig().GetRaw()))
+	if err != nil {
+		return err
+	}
+	computed, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return err
+	}
+	var updated string
+	if ts := rel.GetInfo().GetLastDeployed(); ts != nil {
+		updated = timeconv.Time(ts).UTC().Format(time.RFC3339)
+	}
+	element := releaseElement{
+		Name:           rel.Name,
+		Namespace:      rel.Namespace,
+		Revision:       rel.Version,
+		Updated:        updated,
+		Status:         rel.GetInfo().GetStatus().GetCode().String(),
+		Chart:          fmt.Sprintf("%s-%s", rel.GetChart().GetMetadata().GetName(), rel.GetChart().GetMetadata().GetVersion()),
+		Values:         values,
+		ComputedValues: computed,
+		Hooks:          hookElements(rel.Hooks),
+		Manifest:       rel.Manifest,
+		Notes:          rel.GetInfo().GetStatus().GetNotes(),
+	}
+	switch outputFormat(g.output) {
+	case outputJSON:
+		return encodeJSON(g.out, element)
+	case outputYAML:
+		return encodeYAML(g.out, element)
+	}
+	return fmt.Errorf("unknown output format %q", g.output)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetAllCmd(t *testing.T) {
+	noted := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "dido"})
+	noted.Info.Status.Notes = "Release notes"
+
+	tests := []releaseCase{
+		{
+			name:     "get all with release",
+			args:     []string{"dido"},
+			expected: "(?s)REVISION: 1\nRELEASED: (.*)\nCHART: foo-0.1.0-beta.1\nUSER-SUPPLIED VALUES:\nname: \"value\"\nCOMPUTED VALUES:\nname: value\n\nHOOKS:\n---\n# pre-install-hook\n" + helm.MockHookTemplate + "\nMANIFEST:\n(.*)NOTES:\nRelease notes\n",
+			rels:     []*release.Release{noted},
+		},
+		{
+			name:     "get all in json",
+			args:     []string{"dido"},
+			flags:    []string{"--output", "json"},
+			expected: `^{"name":"dido","namespace":"default","revision":1,"updated":"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z","status":"DEPLOYED","chart":"foo-0.1.0-beta.1","values":{"name":"value"},"computed_values":{"name":"value"},"hooks":\[{"name":"pre-install-hook",.*\],"manifest":".*","notes":"Release notes"}`,
+			rels:     []*release.Release{noted},
+		},
+		{
+			name:     "get all in yaml",
+			args:     []string{"dido"},
+			flags:    []string{"-o", "yaml"},
+			expected: "notes: Release notes\nrevision: 1\nstatus: DEPLOYED\nupdated: \"?\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}Z\"?\nvalues:\n  name: value\n",
+			rels:     []*release.Release{noted},
+		},
+		{
+			name:  "get all with an invalid output format",
+			args:  []string{"dido"},
+			flags: []string{"-o", "table"},
+			rels:  []*release.Release{noted},
+			err:   true,
+		},
+		{
+			name: "get all without args",
+			args: []string{},
+			err:  true,
+		},
+	}
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newGetAllCmd(c, out)
+	})
+}
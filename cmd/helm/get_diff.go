@@ -0,0 +1,104 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+)
+
+var getDiffHelp = `
+This command shows how two revisions of a named release differ.
+
+The computed values of the revisions are compared key by key, followed by a
+unified diff of their manifests, one resource at a time:
+
+	$ helm get diff --revisions 3,5 happy-panda
+`
+
+type getDiffCmd struct {
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	revisions []int
+}
+
+func newGetDiffCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	get := &getDiffCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diff [flags] RELEASE_NAME",
+		Short:   "Show the differences between two revisions of a named release",
+		Long:    getDiffHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			if len(get.revisions) != 2 || get.revisions[0] <= 0 || get.revisions[1] <= 0 {
+				return errors.New("--revisions requires two revisions, such as 3,5")
+			}
+			get.release = args[0]
+			get.client = ensureHelmClient(get.client)
+			return get.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.IntSliceVar(&get.revisions, "revisions", []int{}, "The two revisions to compare, separated by a comma")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (g *getDiffCmd) run() error {
+	var manifests []string
+	var values []map[string]interface{}
+	for _, version := range g.revisions {
+		res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(int32(version)))
+		if err != nil {
+			return prettyError(err)
+		}
+		vals, err := chartutil.CoalesceValues(res.Release.Chart, res.Release.Config)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, res.Release.Manifest)
+		values = append(values, vals)
+	}
+
+	color := isTerminal(g.out)
+	fmt.Fprintln(g.out, "VALUES:")
+	if diffValues(g.out, values[0], values[1], color) == 0 {
+		fmt.Fprintln(g.out, "No changes to the values.")
+	}
+	fmt.Fprintln(g.out, "\nMANIFESTS:")
+	diffManifests(g.out, manifests[0], manifests[1], color)
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetDiffCmd(t *testing.T) {
+	older := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name:    "happy-panda",
+		Version: 3,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "foo", Version: "0.1.0"},
+			Values:   &chart.Config{Raw: "replicas: 1\nimage: nginx:1.15\n"},
+		},
+		Config: &chart.Config{Raw: "replicas: 2"},
+	})
+	newer := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name:    "happy-panda",
+		Version: 5,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "foo", Version: "0.2.0"},
+			Values:   &chart.Config{Raw: "replicas: 1\nimage: nginx:1.16\n"},
+		},
+		Config: &chart.Config{Raw: "replicas: 2"},
+	})
+	newer.Manifest = "apiVersion: v1\nkind: Secret\nmetadata:\n  name: fixture\ndata:\n  key: dmFsdWU=\n"
+
+	var buf bytes.Buffer
+	cmd := &getDiffCmd{
+		release:   "happy-panda",
+		out:       &buf,
+		client:    &sequentialContentClient{FakeClient: &helm.FakeClient{Rels: []*release.Release{older, newer}}},
+		revisions: []int{3, 5},
+	}
+	if err := cmd.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `VALUES:
+~ image: "nginx:1.15" -> "nginx:1.16"
+
+MANIFESTS:
+--- a/Secret/fixture
++++ b/Secret/fixture
+@@ -2,3 +2,5 @@
+ kind: Secret
+ metadata:
+   name: fixture
++data:
++  key: dmFsdWU=
+SUMMARY: 0 to add, 1 to change, 0 to remove
+`
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
@@ -19,16 +19,27 @@ package main
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 const getHooksHelp = `
 This command downloads hooks for a given release.
 
 Hooks are formatted in YAML and separated by the YAML '---\n' separator.
+
+With '--output json' or '--output yaml' the hooks are printed as a list that
+also holds their kind, template path, events and weight.
+
+The '--event' flag only downloads the hooks that run on one of the given
+events, named as in the 'helm.sh/hook' annotation:
+
+	$ helm get hooks --event pre-upgrade,post-upgrade happy-panda
 `
 
 type getHooksCmd struct {
@@ -36,6 +47,18 @@ type getHooksCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+	output  string
+	events  []string
+}
+
+// hookElement is a hook as printed with --output.
+type hookElement struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Path     string   `json:"path"`
+	Events   []string `json:"events"`
+	Weight   int32    `json:"weight"`
+	Manifest string   `json:"manifest"`
 }
 
 func newGetHooksCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -60,6 +83,8 @@ func newGetHooksCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&ghc.version, "revision", 0, "Get the named release with revision")
+	f.StringVarP(&ghc.output, "output", "o", "", "Output the hooks in the specified format (json or yaml)")
+	f.StringSliceVar(&ghc.events, "event", []string{}, "Only download the hooks that run on these events (can specify multiple or separate values with commas: pre-upgrade,post-upgrade)")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -73,9 +98,82 @@ func (g *getHooksCmd) run() error {
 		fmt.Fprintln(g.out, g.release)
 		return prettyError(err)
 	}
+	selected, err := filterHooks(res.Release.Hooks, g.events)
+	if err != nil {
+		return err
+	}
+
+	if g.output == "" {
+		for _, hook := range selected {
+			fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
+		}
+		return nil
+	}
+
+	elements := hookElements(selected)
+	switch outputFormat(g.output) {
+	case outputJSON:
+		return encodeJSON(g.out, elements)
+	case outputYAML:
+		return encodeYAML(g.out, elements)
+	}
+	return fmt.Errorf("unknown output format %q", g.output)
+}
+
+// hookElements converts hooks to the elements printed with --output.
+func hookElements(hooks []*release.Hook) []hookElement {
+	elements := []hookElement{}
+	for _, hook := range hooks {
+		h := hookElement{
+			Name:     hook.Name,
+			Kind:     hook.Kind,
+			Path:     hook.Path,
+			Events:   []string{},
+			Weight:   hook.Weight,
+			Manifest: hook.Manifest,
+		}
+		for _, e := range hook.Events {
+			h.Events = append(h.Events, hookEventName(e))
+		}
+		elements = append(elements, h)
+	}
+	return elements
+}
 
-	for _, hook := range res.Release.Hooks {
-		fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
+// hookEventName returns the name of a hook event in the helm.sh/hook
+// annotation, such as pre-upgrade.
+func hookEventName(e release.Hook_Event) string {
+	for name, event := range hooks.Events {
+		if event == e {
+			return name
+		}
+	}
+	return strings.ToLower(e.String())
+}
+
+// filterHooks returns the hooks that run on one of the named events, or all
+// of them when no event is given.
+func filterHooks(all []*release.Hook, events []string) ([]*release.Hook, error) {
+	if len(events) == 0 {
+		return all, nil
+	}
+	wanted := map[release.Hook_Event]bool{}
+	for _, name := range events {
+		e, ok := hooks.Events[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown hook event %q", name)
+		}
+		wanted[e] = true
+	}
+
+	var res []*release.Hook
+	for _, h := range all {
+		for _, e := range h.Events {
+			if wanted[e] {
+				res = append(res, h)
+				break
+			}
+		}
 	}
-	return nil
+	return res, nil
 }
@@ -28,6 +28,21 @@ import (
 )
 
 func TestGetHooks(t *testing.T) {
+	upgraded := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "dido"})
+	upgraded.Hooks = append(upgraded.Hooks, &release.Hook{
+		Name:     "migrate",
+		Kind:     "Job",
+		Path:     "migrate.yaml",
+		Manifest: "kind: Job",
+		Events:   []release.Hook_Event{release.Hook_PRE_INSTALL, release.Hook_PRE_UPGRADE},
+	}, &release.Hook{
+		Name:     "smoke-test",
+		Kind:     "Pod",
+		Path:     "smoke-test.yaml",
+		Manifest: "kind: Pod",
+		Events:   []release.Hook_Event{release.Hook_RELEASE_TEST_SUCCESS},
+	})
+
 	tests := []releaseCase{
 		{
 			name:     "get hooks with release",
@@ -36,6 +51,54 @@ func TestGetHooks(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:     "get hooks in json",
+			args:     []string{"aeneas"},
+			flags:    []string{"--output", "json"},
+			expected: `^\[{"name":"pre-install-hook","kind":"Job","path":"pre-install-hook.yaml","events":\["pre-install"\],"weight":0,"manifest":"apiVersion: v1\\nkind: Job\\n`,
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:     "get hooks in yaml",
+			args:     []string{"aeneas"},
+			flags:    []string{"-o", "yaml"},
+			expected: "- events:\n  - pre-install\n  kind: Job\n",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:  "get hooks with an invalid output format",
+			args:  []string{"aeneas"},
+			flags: []string{"-o", "table"},
+			resp:  helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+			err:   true,
+		},
+		{
+			name:     "get hooks of an event",
+			args:     []string{"dido"},
+			flags:    []string{"--event", "pre-upgrade"},
+			expected: "^---\n# migrate\nkind: Job\n$",
+			resp:     upgraded,
+			rels:     []*release.Release{upgraded},
+		},
+		{
+			name:     "get hooks of several events",
+			args:     []string{"dido"},
+			flags:    []string{"--event", "test-success,post-upgrade", "-o", "json"},
+			expected: `^\[{"name":"smoke-test","kind":"Pod","path":"smoke-test.yaml","events":\["test-success"\],"weight":0,"manifest":"kind: Pod"}\]\n$`,
+			resp:     upgraded,
+			rels:     []*release.Release{upgraded},
+		},
+		{
+			name:  "get hooks of an unknown event",
+			args:  []string{"dido"},
+			flags: []string{"--event", "pre-deploy"},
+			resp:  upgraded,
+			rels:  []*release.Release{upgraded},
+			err:   true,
+		},
 		{
 			name: "get hooks without args",
 			args: []string{},
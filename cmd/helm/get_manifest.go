@@ -19,10 +19,14 @@ package main
 import (
 	"fmt"
 	"io"
+	"path"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 var getManifestHelp = `
@@ -31,6 +35,12 @@ This command fetches the generated manifest for a given release.
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
 charts, those resources will also be included in the manifest.
+
+The '--filter' flag only prints the resources whose kind and name match one of
+the given KIND/NAME patterns. The kind is not case sensitive and both may use
+the wildcards of shell file name patterns:
+
+	$ helm get manifest --filter 'Deployment/web,ConfigMap/*' happy-panda
 `
 
 type getManifestCmd struct {
@@ -38,6 +48,7 @@ type getManifestCmd struct {
 	out     io.Writer
 	client  helm.Interface
 	version int32
+	filter  []string
 }
 
 func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -63,6 +74,7 @@ func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
+	f.StringSliceVar(&get.filter, "filter", []string{}, "Only print the resources matching these KIND/NAME patterns (can specify multiple or separate values with commas: Deployment/web,ConfigMap/*)")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -76,6 +88,55 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
+	if len(g.filter) == 0 {
+		fmt.Fprintln(g.out, res.Release.Manifest)
+		return nil
+	}
+	docs, err := filterManifest(res.Release.Manifest, g.filter)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return fmt.Errorf("no resources in release %q match %s", g.release, strings.Join(g.filter, ","))
+	}
+	for _, doc := range docs {
+		fmt.Fprintf(g.out, "---\n%s\n", doc)
+	}
 	return nil
 }
+
+// filterManifest returns, in order, the documents of a manifest that describe
+// a resource matching one of the KIND/NAME patterns.
+func filterManifest(manifest string, patterns []string) ([]string, error) {
+	for _, p := range patterns {
+		if strings.Count(p, "/") != 1 {
+			return nil, fmt.Errorf("invalid filter %q, expected KIND/NAME", p)
+		}
+	}
+
+	docs := releaseutil.SplitManifests(manifest)
+	var res []string
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Metadata == nil {
+			continue
+		}
+		for _, p := range patterns {
+			parts := strings.SplitN(p, "/", 2)
+			kindOK, err := path.Match(strings.ToLower(parts[0]), strings.ToLower(head.Kind))
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter %q: %s", p, err)
+			}
+			nameOK, err := path.Match(parts[1], head.Metadata.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter %q: %s", p, err)
+			}
+			if kindOK && nameOK {
+				res = append(res, doc)
+				break
+			}
+		}
+	}
+	return res, nil
+}
@@ -27,6 +27,27 @@ import (
 )
 
 func TestGetManifest(t *testing.T) {
+	multi := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "multi"})
+	multi.Manifest = `---
+# Source: multi/templates/web.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+# Source: multi/templates/config.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+---
+# Source: multi/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
 	tests := []releaseCase{
 		{
 			name:     "get manifest with release",
@@ -35,6 +56,38 @@ func TestGetManifest(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
 		},
+		{
+			name:     "get manifest filtered by kind and name",
+			args:     []string{"multi"},
+			flags:    []string{"--filter", "Deployment/web"},
+			expected: "^---\n# Source: multi/templates/web.yaml\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n$",
+			resp:     multi,
+			rels:     []*release.Release{multi},
+		},
+		{
+			name:     "get manifest filtered by patterns",
+			args:     []string{"multi"},
+			flags:    []string{"--filter", "configmap/*,Service/w?b"},
+			expected: "(?s)^---\n# Source: multi/templates/config.yaml\n.*name: web-config\n---\n# Source: multi/templates/service.yaml\n.*name: web\n$",
+			resp:     multi,
+			rels:     []*release.Release{multi},
+		},
+		{
+			name:  "get manifest filtered without matches",
+			args:  []string{"multi"},
+			flags: []string{"--filter", "Secret/*"},
+			resp:  multi,
+			rels:  []*release.Release{multi},
+			err:   true,
+		},
+		{
+			name:  "get manifest with an invalid filter",
+			args:  []string{"multi"},
+			flags: []string{"--filter", "web"},
+			resp:  multi,
+			rels:  []*release.Release{multi},
+			err:   true,
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
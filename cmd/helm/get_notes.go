@@ -27,6 +27,10 @@ import (
 
 var getNotesHelp = `
 This command shows notes provided by the chart of a named release.
+
+The notes are rendered from the NOTES.txt template of the chart when the
+release is installed or upgraded. They are printed for the latest revision,
+unless another one is given with '--revision'.
 `
 
 type getNotesCmd struct {
@@ -52,9 +56,7 @@ func newGetNotesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				return errReleaseRequired
 			}
 			get.release = args[0]
-			if get.client == nil {
-				get.client = newClient()
-			}
+			get.client = ensureHelmClient(get.client)
 			return get.run()
 		},
 	}
@@ -27,6 +27,17 @@ import (
 )
 
 func TestGetNotesCmd(t *testing.T) {
+	firstNotes := releaseMockWithStatus(&release.Status{
+		Code:  release.Status_SUPERSEDED,
+		Notes: "first notes",
+	})
+	firstNotes.Version = 1
+	secondNotes := releaseMockWithStatus(&release.Status{
+		Code:  release.Status_DEPLOYED,
+		Notes: "second notes",
+	})
+	secondNotes.Version = 2
+
 	tests := []releaseCase{
 		{
 			name:     "get notes of a deployed release",
@@ -39,6 +50,21 @@ func TestGetNotesCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get notes of a revision",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--revision", "1"},
+			expected: "^NOTES:\nfirst notes\n$",
+			rels:     []*release.Release{firstNotes, secondNotes},
+		},
+		{
+			name:     "get notes of a release without notes",
+			args:     []string{"flummoxed-chickadee"},
+			expected: "^$",
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}),
+			},
+		},
 		{
 			name: "get notes requires release name arg",
 			err:  true,
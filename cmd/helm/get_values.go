@@ -29,6 +29,15 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+Only the values supplied when the release was installed or upgraded are
+printed, unless '--all' is set. Then the values the templates were rendered
+with are printed instead: the defaults of the chart and of its subcharts
+merged with the supplied values, with the globals copied to every subchart.
+
+The values can be piped into the upgrade of another release:
+
+	$ helm get values happy-panda | helm upgrade -f - lucky-ladybug stable/mysql
 `
 
 type getValuesCmd struct {
@@ -64,7 +73,7 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
 	f.BoolVarP(&get.allValues, "all", "a", false, "Dump all (computed) values")
-	f.StringVar(&get.output, "output", "yaml", "Output the specified format (json or yaml)")
+	f.StringVarP(&get.output, "output", "o", "yaml", "Output the specified format (json or yaml)")
 
 	// set defaults from environment
 	settings.InitTLS(f)
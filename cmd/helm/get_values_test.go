@@ -36,6 +36,20 @@ func TestGetValuesCmd(t *testing.T) {
 		},
 		Config: &chart.Config{Raw: `foo: "bar"`},
 	})
+	releaseWithSubchart := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name: "umbrella",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "umbrella"},
+			Values:   &chart.Config{Raw: "global:\n  env: prod\ndb:\n  port: 5433\n"},
+			Dependencies: []*chart.Chart{
+				{
+					Metadata: &chart.Metadata{Name: "db"},
+					Values:   &chart.Config{Raw: "port: 5432\nuser: admin\n"},
+				},
+			},
+		},
+		Config: &chart.Config{Raw: `foo: "bar"`},
+	})
 
 	tests := []releaseCase{
 		{
@@ -61,6 +75,22 @@ func TestGetValuesCmd(t *testing.T) {
 			expected: "{\"foo\":\"bar\",\"foo2\":\"bar2\"}",
 			rels:     []*release.Release{releaseWithValues},
 		},
+		{
+			name:     "get all values with the json format shorthand",
+			resp:     releaseWithValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--all", "-o", "json"},
+			expected: "{\"foo\":\"bar\",\"foo2\":\"bar2\"}",
+			rels:     []*release.Release{releaseWithValues},
+		},
+		{
+			name:     "get all values with subchart defaults and globals",
+			resp:     releaseWithSubchart,
+			args:     []string{"umbrella"},
+			flags:    []string{"--all", "--output", "json"},
+			expected: `^{"db":{"global":{"env":"prod"},"port":5433,"user":"admin"},"foo":"bar","global":{"env":"prod"}}\n$`,
+			rels:     []*release.Release{releaseWithSubchart},
+		},
 		{
 			name: "get values requires release name arg",
 			err:  true,
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -36,6 +37,7 @@ import (
 	helm_env "k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/portforwarder"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/timeconv"
 	"k8s.io/helm/pkg/tlsutil"
 )
 
@@ -160,6 +162,7 @@ Environment:
 - $HELM_TLS_ENABLE:     Enable TLS connection between Helm and Tiller (default "false")
 - $HELM_TLS_VERIFY:     Enable TLS connection between Helm and Tiller and verify Tiller server certificate (default "false")
 - $HELM_TLS_HOSTNAME:   The hostname or IP address used to verify the Tiller server certificate (default "127.0.0.1")
+- $HELM_TIME_FORMAT:    Set the format of the times in the output: ansic, rfc3339, relative or unix (default "ansic")
 - $HELM_KEY_PASSPHRASE: Set HELM_KEY_PASSPHRASE to the passphrase of your PGP private key. If set, you will not be prompted for the passphrase while signing helm charts
 
 `
@@ -170,7 +173,10 @@ func newRootCmd(args []string) *cobra.Command {
 		Short:        "The Helm package manager for Kubernetes.",
 		Long:         globalUsage,
 		SilenceUsage: true,
-		PersistentPreRun: func(*cobra.Command, []string) {
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			if err := timeconv.SetDisplayFormat(settings.TimeFormat); err != nil {
+				return err
+			}
 			if settings.TLSCaCertFile == helm_env.DefaultTLSCaCert || settings.TLSCaCertFile == "" {
 				settings.TLSCaCertFile = settings.Home.TLSCaCert()
 			} else {
@@ -186,6 +192,7 @@ func newRootCmd(args []string) *cobra.Command {
 			} else {
 				settings.TLSKeyFile = os.ExpandEnv(settings.TLSKeyFile)
 			}
+			return nil
 		},
 		PersistentPostRun: func(*cobra.Command, []string) {
 			teardown()
@@ -200,6 +207,7 @@ func newRootCmd(args []string) *cobra.Command {
 
 	cmd.AddCommand(
 		// chart commands
+		newChartCmd(out),
 		newCreateCmd(out),
 		newDependencyCmd(out),
 		newFetchCmd(out),
@@ -212,7 +220,10 @@ func newRootCmd(args []string) *cobra.Command {
 		newVerifyCmd(out),
 
 		// release commands
+		newAnnotateCmd(nil, out),
+		newApplyCmd(nil, out),
 		newDeleteCmd(nil, out),
+		newDiffLiveCmd(nil, out),
 		newGetCmd(nil, out),
 		newHistoryCmd(nil, out),
 		newInstallCmd(nil, out),
@@ -260,6 +271,8 @@ func main() {
 		switch e := err.(type) {
 		case pluginError:
 			os.Exit(e.code)
+		case statusExitError:
+			os.Exit(e.code)
 		default:
 			os.Exit(1)
 		}
@@ -335,6 +348,26 @@ func configForContext(context string, kubeconfig string) (*rest.Config, error) {
 	return config, nil
 }
 
+// deployingUser returns the identity to record as the user who deployed a
+// release: setBy if given, otherwise the user of the kube context.
+func deployingUser(setBy string) string {
+	if setBy != "" {
+		return setBy
+	}
+	raw, err := kube.GetConfig(settings.KubeContext, settings.KubeConfig).RawConfig()
+	if err != nil {
+		return ""
+	}
+	name := settings.KubeContext
+	if name == "" {
+		name = raw.CurrentContext
+	}
+	if context, ok := raw.Contexts[name]; ok {
+		return context.AuthInfo
+	}
+	return ""
+}
+
 // getKubeClient creates a Kubernetes config and client for a given kubeconfig context.
 func getKubeClient(context string, kubeconfig string) (*rest.Config, kubernetes.Interface, error) {
 	config, err := configForContext(context, kubeconfig)
@@ -348,6 +381,18 @@ func getKubeClient(context string, kubeconfig string) (*rest.Config, kubernetes.
 	return config, client, nil
 }
 
+// newKubeClient returns a kube.Client for the configured kube context.
+func newKubeClient() *kube.Client {
+	flags := genericclioptions.NewConfigFlags(true)
+	if settings.KubeContext != "" {
+		flags.Context = &settings.KubeContext
+	}
+	if settings.KubeConfig != "" {
+		flags.KubeConfig = &settings.KubeConfig
+	}
+	return kube.New(flags)
+}
+
 // ensureHelmClient returns a new helm client impl. if h is not nil.
 func ensureHelmClient(h helm.Interface) helm.Interface {
 	if h != nil {
@@ -36,6 +36,7 @@ import (
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // releaseCmd is a command that works with a FakeClient
@@ -232,6 +233,124 @@ func TestRootCmd(t *testing.T) {
 	}
 }
 
+func TestTimeFormatFlag(t *testing.T) {
+	cleanup := resetEnv()
+	defer cleanup()
+	defer timeconv.SetDisplayFormat("")
+
+	tests := []struct {
+		name      string
+		args      []string
+		envars    map[string]string
+		format    string
+		expectErr bool
+	}{
+		{
+			name:   "defaults",
+			args:   []string{"home"},
+			format: "ansic",
+		},
+		{
+			name:   "with --time-format set",
+			args:   []string{"home", "--time-format", "unix"},
+			format: "unix",
+		},
+		{
+			name:   "with $HELM_TIME_FORMAT set",
+			args:   []string{"home"},
+			envars: map[string]string{"HELM_TIME_FORMAT": "relative"},
+			format: "relative",
+		},
+		{
+			name:      "with an unknown format",
+			args:      []string{"home", "--time-format", "iso"},
+			format:    "iso",
+			expectErr: true,
+		},
+	}
+
+	os.Unsetenv("HELM_TIME_FORMAT")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.envars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			cmd := newRootCmd(tt.args)
+			cmd.SetOutput(ioutil.Discard)
+			cmd.SetArgs(tt.args)
+			cmd.Run = func(*cobra.Command, []string) {}
+			err := cmd.Execute()
+			if tt.expectErr && err == nil {
+				t.Error("expected an error")
+			} else if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if settings.TimeFormat != tt.format {
+				t.Errorf("expected time format %q, got %q", tt.format, settings.TimeFormat)
+			}
+		})
+	}
+}
+
+func TestDeployingUser(t *testing.T) {
+	cleanup := resetEnv()
+	defer cleanup()
+
+	dir, err := ioutil.TempDir("", "helm-kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	kubeconfig := filepath.Join(dir, "config")
+	data := `apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: local
+    user: alice
+- name: prod
+  context:
+    cluster: local
+    user: bob
+clusters:
+- name: local
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: alice
+- name: bob
+`
+	if err := ioutil.WriteFile(kubeconfig, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	settings.KubeConfig = kubeconfig
+
+	tests := []struct {
+		name     string
+		context  string
+		setBy    string
+		expected string
+	}{
+		{"current context", "", "", "alice"},
+		{"with --kube-context", "prod", "", "bob"},
+		{"with --set-by", "prod", "ci-bot", "ci-bot"},
+		{"unknown context", "staging", "", ""},
+	}
+
+	for _, tt := range tests {
+		settings.KubeContext = tt.context
+		if got := deployingUser(tt.setBy); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
 func TestTLSFlags(t *testing.T) {
 	cleanup := resetEnv()
 	defer cleanup()
@@ -266,6 +385,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -285,6 +405,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -304,6 +425,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -323,6 +445,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -342,6 +465,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           "/foo",
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -361,6 +485,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             "/foo",
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -380,6 +505,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              "/foo",
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -400,6 +526,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -420,6 +547,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -440,6 +568,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -460,6 +589,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           "/foo",
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -480,6 +610,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             "/foo",
 				TLSKeyFile:              home.TLSKey(),
+				TimeFormat:              "ansic",
 			},
 		},
 		{
@@ -500,6 +631,7 @@ func TestTLSFlags(t *testing.T) {
 				TLSCaCertFile:           home.TLSCaCert(),
 				TLSCertFile:             home.TLSCert(),
 				TLSKeyFile:              "/foo",
+				TimeFormat:              "ansic",
 			},
 		},
 	}
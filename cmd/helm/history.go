@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
@@ -36,6 +37,8 @@ type releaseInfo struct {
 	Updated     string `json:"updated"`
 	Status      string `json:"status"`
 	Chart       string `json:"chart"`
+	AppVersion  string `json:"app_version"`
+	DeployedBy  string `json:"deployed_by,omitempty"`
 	Description string `json:"description"`
 }
 
@@ -50,11 +53,19 @@ configures the maximum length of the revision list returned.
 The historical release set is printed as a formatted table, e.g:
 
     $ helm history angry-bird --max=4
-    REVISION   UPDATED                      STATUS           CHART        DESCRIPTION
-    1           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Initial install
-    2           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Upgraded successfully
-    3           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  Rolled back to 2
-    4           Mon Oct 3 10:15:13 2016     DEPLOYED        alpine-0.1.0  Upgraded successfully
+    REVISION   UPDATED                      STATUS           CHART        APP VERSION   DEPLOYED BY   DESCRIPTION
+    1           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  3.9           alice         Initial install
+    2           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  3.9           alice         Upgraded successfully
+    3           Mon Oct 3 10:15:13 2016     SUPERSEDED      alpine-0.1.0  3.9           ci-bot        Rolled back to 2
+    4           Mon Oct 3 10:15:13 2016     DEPLOYED        alpine-0.1.0  3.9           bob           Upgraded successfully
+
+The DEPLOYED BY column shows the identity recorded for each revision: the
+common name of the TLS client certificate when Tiller verifies it, otherwise
+the '--set-by' flag or the user of the kube context.
+
+With '--output json' or '--output yaml' the revisions are printed as a list, for
+auditing tools. Their times are always in RFC 3339, in UTC, while the table
+follows the global '--time-format' flag.
 `
 
 type historyCmd struct {
@@ -108,18 +119,16 @@ func (cmd *historyCmd) run() error {
 		return nil
 	}
 
-	releaseHistory := getReleaseHistory(r.Releases)
-
 	var history []byte
 	var formattingError error
 
 	switch cmd.outputFormat {
 	case "yaml":
-		history, formattingError = yaml.Marshal(releaseHistory)
+		history, formattingError = yaml.Marshal(getReleaseHistory(r.Releases, true))
 	case "json":
-		history, formattingError = json.Marshal(releaseHistory)
+		history, formattingError = json.Marshal(getReleaseHistory(r.Releases, true))
 	case "table":
-		history = formatAsTable(releaseHistory, cmd.colWidth)
+		history = formatAsTable(getReleaseHistory(r.Releases, false), cmd.colWidth)
 	default:
 		return fmt.Errorf("unknown output format %q", cmd.outputFormat)
 	}
@@ -132,20 +141,30 @@ func (cmd *historyCmd) run() error {
 	return nil
 }
 
-func getReleaseHistory(rls []*release.Release) (history releaseHistory) {
+// getReleaseHistory returns the history of the given revisions. Their times
+// are in RFC 3339 if it is meant to be parsed, and in the display format of
+// '--time-format' otherwise.
+func getReleaseHistory(rls []*release.Release, parseable bool) (history releaseHistory) {
 	for i := len(rls) - 1; i >= 0; i-- {
 		r := rls[i]
 		c := formatChartname(r.Chart)
+		a := r.GetChart().GetMetadata().GetAppVersion()
 		t := timeconv.String(r.Info.LastDeployed)
+		if parseable {
+			t = timeconv.Time(r.Info.LastDeployed).UTC().Format(time.RFC3339)
+		}
 		s := r.Info.Status.Code.String()
 		v := r.Version
 		d := r.Info.Description
+		u := r.Info.DeployedBy
 
 		rInfo := releaseInfo{
 			Revision:    v,
 			Updated:     t,
 			Status:      s,
 			Chart:       c,
+			AppVersion:  a,
+			DeployedBy:  u,
 			Description: d,
 		}
 		history = append(history, rInfo)
@@ -158,10 +177,10 @@ func formatAsTable(releases releaseHistory, colWidth uint) []byte {
 	tbl := uitable.New()
 
 	tbl.MaxColWidth = colWidth
-	tbl.AddRow("REVISION", "UPDATED", "STATUS", "CHART", "DESCRIPTION")
+	tbl.AddRow("REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "DEPLOYED BY", "DESCRIPTION")
 	for i := 0; i <= len(releases)-1; i++ {
 		r := releases[i]
-		tbl.AddRow(r.Revision, r.Updated, r.Status, r.Chart, r.Description)
+		tbl.AddRow(r.Revision, r.Updated, r.Status, r.Chart, r.AppVersion, r.DeployedBy, r.Description)
 	}
 	return tbl.Bytes()
 }
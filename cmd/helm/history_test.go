@@ -17,13 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	rpb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 func TestHistoryCmd(t *testing.T) {
@@ -32,6 +36,9 @@ func TestHistoryCmd(t *testing.T) {
 			Name:       name,
 			Version:    vers,
 			StatusCode: code,
+			Chart: &chart.Chart{
+				Metadata: &chart.Metadata{Name: "foo", Version: "0.1.0-beta.1", AppVersion: "2.4.1"},
+			},
 		})
 	}
 
@@ -45,7 +52,7 @@ func TestHistoryCmd(t *testing.T) {
 				mk("angry-bird", 2, rpb.Status_SUPERSEDED),
 				mk("angry-bird", 1, rpb.Status_SUPERSEDED),
 			},
-			expected: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tDESCRIPTION \n1       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n2       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\tRelease mock\n",
+			expected: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tAPP VERSION\tDEPLOYED BY\tDESCRIPTION \n1       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n2       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n",
 		},
 		{
 			name:  "get history with max limit set",
@@ -55,7 +62,20 @@ func TestHistoryCmd(t *testing.T) {
 				mk("angry-bird", 4, rpb.Status_DEPLOYED),
 				mk("angry-bird", 3, rpb.Status_SUPERSEDED),
 			},
-			expected: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tDESCRIPTION \n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\tRelease mock\n",
+			expected: "REVISION\tUPDATED                 \tSTATUS    \tCHART           \tAPP VERSION\tDEPLOYED BY\tDESCRIPTION \n3       \t(.*)\tSUPERSEDED\tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n4       \t(.*)\tDEPLOYED  \tfoo-0.1.0-beta.1\t2.4.1      \t           \tRelease mock\n",
+		},
+		{
+			name:  "get history with the deploying user",
+			args:  []string{"angry-bird"},
+			flags: []string{"--max", "1"},
+			rels: []*rpb.Release{
+				func() *rpb.Release {
+					r := mk("angry-bird", 4, rpb.Status_DEPLOYED)
+					r.Info.DeployedBy = "ci-bot"
+					return r
+				}(),
+			},
+			expected: "REVISION\tUPDATED                 \tSTATUS  \tCHART           \tAPP VERSION\tDEPLOYED BY\tDESCRIPTION \n4       \t(.*)\tDEPLOYED\tfoo-0.1.0-beta.1\t2.4.1      \tci-bot     \tRelease mock\n",
 		},
 		{
 			name:  "get history with yaml output format",
@@ -65,7 +85,7 @@ func TestHistoryCmd(t *testing.T) {
 				mk("angry-bird", 4, rpb.Status_DEPLOYED),
 				mk("angry-bird", 3, rpb.Status_SUPERSEDED),
 			},
-			expected: "- chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 3\n  status: SUPERSEDED\n  updated: (.*)\n- chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 4\n  status: DEPLOYED\n  updated: (.*)\n\n",
+			expected: "- app_version: 2.4.1\n  chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 3\n  status: SUPERSEDED\n  updated: \"?1977-09-02T22:04:05Z\"?\n- app_version: 2.4.1\n  chart: foo-0.1.0-beta.1\n  description: Release mock\n  revision: 4\n  status: DEPLOYED\n  updated: \"?1977-09-02T22:04:05Z\"?\n\n",
 		},
 		{
 			name:  "get history with json output format",
@@ -75,7 +95,7 @@ func TestHistoryCmd(t *testing.T) {
 				mk("angry-bird", 4, rpb.Status_DEPLOYED),
 				mk("angry-bird", 3, rpb.Status_SUPERSEDED),
 			},
-			expected: `[{"revision":3,"updated":".*","status":"SUPERSEDED","chart":"foo\-0.1.0-beta.1","description":"Release mock"},{"revision":4,"updated":".*","status":"DEPLOYED","chart":"foo\-0.1.0-beta.1","description":"Release mock"}]\n`,
+			expected: `[{"revision":3,"updated":"1977-09-02T22:04:05Z","status":"SUPERSEDED","chart":"foo\-0.1.0-beta.1","app_version":"2.4.1","description":"Release mock"},{"revision":4,"updated":"1977-09-02T22:04:05Z","status":"DEPLOYED","chart":"foo\-0.1.0-beta.1","app_version":"2.4.1","description":"Release mock"}]\n`,
 		},
 	}
 
@@ -83,3 +103,21 @@ func TestHistoryCmd(t *testing.T) {
 		return newHistoryCmd(c, out)
 	})
 }
+
+func TestHistoryTimeFormat(t *testing.T) {
+	defer timeconv.SetDisplayFormat("")
+	if err := timeconv.SetDisplayFormat(timeconv.FormatRelative); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	c := &helm.FakeClient{Rels: []*rpb.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird"})}}
+	cmd := newHistoryCmd(c, &buf)
+	cmd.ParseFlags([]string{"--output", "json"})
+	if err := cmd.RunE(cmd, []string{"angry-bird"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"updated":"1977-09-02T22:04:05Z"`) {
+		t.Errorf("expected the time in RFC 3339 regardless of the display format, got %s", buf.String())
+	}
+}
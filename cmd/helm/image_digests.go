@@ -0,0 +1,81 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/helm/pkg/registry"
+)
+
+const resolveImageDigestsHelp = `
+To deploy exactly the images that were tested, use '--resolve-image-digests'.
+The registries are asked for the digest of every image of the rendered
+manifests, and each image is pinned to its digest, as in
+nginx:1.17@sha256:..., so that pods keep running the same image when its tag
+is moved. The credentials of the Docker CLI are used for private registries.
+`
+
+// imageLineRegexp matches the lines of a manifest setting an image, keeping
+// the indentation, the quotes and any trailing comment apart from the image.
+var imageLineRegexp = regexp.MustCompile(`^(\s*(?:-\s+)?image:\s*)(["']?)([^"'\s#]+)(["']?)(\s*(?:#.*)?)$`)
+
+// imageResolver returns the digest of an image.
+type imageResolver func(image string) (string, error)
+
+// newImageResolver returns the imageResolver used by install and upgrade. It
+// is replaced in tests.
+var newImageResolver = registryImageResolver
+
+// registryImageResolver resolves image digests by asking their registries.
+func registryImageResolver() imageResolver {
+	client := &registry.Client{Credentials: registry.DockerCredentials()}
+	return func(image string) (string, error) {
+		ref, err := registry.ParseImageReference(image)
+		if err != nil {
+			return "", err
+		}
+		return client.ImageDigest(ref)
+	}
+}
+
+// resolveImageDigests pins the images of manifests to their digests. Images
+// that already have a digest are left alone, and each image is resolved once.
+func resolveImageDigests(manifests string, resolve imageResolver) (string, error) {
+	digests := map[string]string{}
+	lines := strings.Split(manifests, "\n")
+	for i, line := range lines {
+		m := imageLineRegexp.FindStringSubmatch(line)
+		if m == nil || m[2] != m[4] || strings.Contains(m[3], "@") {
+			continue
+		}
+		image := m[3]
+		digest, ok := digests[image]
+		if !ok {
+			var err error
+			if digest, err = resolve(image); err != nil {
+				return "", fmt.Errorf("failed to resolve the digest of image %s: %s", image, err)
+			}
+			digests[image] = digest
+			debug("resolved image %s to %s\n", image, digest)
+		}
+		lines[i] = m[1] + m[2] + image + "@" + digest + m[4] + m[5]
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+func fakeImageResolver(calls *int) imageResolver {
+	return func(image string) (string, error) {
+		*calls++
+		if strings.HasPrefix(image, "missing") {
+			return "", errors.New("not found")
+		}
+		return "sha256:" + strings.Replace(image, ":", "-", -1), nil
+	}
+}
+
+func TestResolveImageDigests(t *testing.T) {
+	manifests := `---
+# Source: app/templates/deployment.yaml
+spec:
+  containers:
+  - name: web
+    image: "nginx:1.17"
+  - image: nginx:1.17 # sidecar
+    name: proxy
+  initContainers:
+  - image: 'busybox'
+    name: init
+  - image: alpine@sha256:0123
+    name: pinned
+  image:
+    repository: nginx
+`
+	expected := `---
+# Source: app/templates/deployment.yaml
+spec:
+  containers:
+  - name: web
+    image: "nginx:1.17@sha256:nginx-1.17"
+  - image: nginx:1.17@sha256:nginx-1.17 # sidecar
+    name: proxy
+  initContainers:
+  - image: 'busybox@sha256:busybox'
+    name: init
+  - image: alpine@sha256:0123
+    name: pinned
+  image:
+    repository: nginx
+`
+
+	var calls int
+	got, err := resolveImageDigests(manifests, fakeImageResolver(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+	if calls != 2 {
+		t.Errorf("expected each image to be resolved once, got %d lookups", calls)
+	}
+
+	if _, err := resolveImageDigests("image: missing:1.0\n", fakeImageResolver(&calls)); err == nil || !strings.Contains(err.Error(), "missing:1.0") {
+		t.Errorf("expected an error naming the image, got %v", err)
+	}
+}
+
+func TestInstallResolveImageDigests(t *testing.T) {
+	var calls int
+	defer func(r func() imageResolver) { newImageResolver = r }(newImageResolver)
+	newImageResolver = func() imageResolver { return fakeImageResolver(&calls) }
+
+	c := &helm.FakeClient{RenderManifests: true}
+	cmd := newInstallCmd(c, ioutil.Discard)
+	cmd.ParseFlags([]string{"--name", "virgil", "--resolve-image-digests"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Rels) != 1 || !strings.Contains(c.Rels[0].Manifest, `image: "alpine:3.3@sha256:alpine-3.3"`) {
+		t.Errorf("expected the images of the installed manifest to be pinned, got %v", c.Rels)
+	}
+}
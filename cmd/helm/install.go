@@ -32,15 +32,19 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/registry"
 	"k8s.io/helm/pkg/renderutil"
 	"k8s.io/helm/pkg/repo"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
 	"k8s.io/helm/pkg/strvals"
 )
 
@@ -54,7 +58,10 @@ To override values in a chart, use either the '--values' flag and pass in a file
 or use the '--set' flag and pass configuration from the command line.  To force string
 values in '--set', use '--set-string' instead. In case a value is large and therefore
 you want not to use neither '--values' nor '--set', use '--set-file' to read the
-single large value from file.
+single large value from file. To pass structured values with their types, such
+as lists and booleans, use '--set-json' with a JSON document as the value.
+To pass secrets from the environment, such as those of a CI system, without
+writing them to a file, use '--set-env' with the name of the variable.
 
 	$ helm install -f myvalues.yaml ./redis
 
@@ -69,12 +76,26 @@ or
 or
     $ helm install --set-file multiline_text=path/to/textfile
 
+or
+
+	$ helm install --set-json 'tolerations=[{"key":"dedicated","operator":"Exists"}]' ./redis
+
+or
+
+	$ helm install --set-env db.password=DB_PASSWORD ./redis
+
 You can specify the '--values'/'-f' flag multiple times. The priority will be given to the
 last (right-most) file specified. For example, if both myvalues.yaml and override.yaml
 contained a key called 'Test', the value set in override.yaml would take precedence:
 
 	$ helm install -f myvalues.yaml -f override.yaml ./redis
 
+A values file can also be a URL, which is downloaded with the credentials of
+the repository that serves it, if any, or '-' to read the values from stdin:
+
+	$ helm install -f https://example.com/values/prod.yaml ./redis
+	$ generate-values | helm install -f - ./redis
+
 You can specify the '--set' flag multiple times. The priority will be given to the
 last (right-most) set specified. For example, if both 'bar' and 'newbar' values are
 set for a key called 'foo', the 'newbar' value would take precedence:
@@ -86,16 +107,50 @@ To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
 round-trip to the Tiller server.
 
+Instead of '--name', the release name can be generated with '--name-template'.
+The template can use the Sprig functions, the chart metadata as '.Chart', the
+namespace as '.Namespace' and the environment variables as '.Env'. The name is
+checked before the chart is sent to Tiller: templates with random parts are
+run again while they give the name of an existing release, and the install
+fails when no free name is found:
+
+	$ helm install --name-template '{{ .Chart.Name }}-{{ .Env.USER }}-{{ randAlpha 4 | lower }}' ./redis
+
 If --verify is set, the chart MUST have a provenance file, and the provenance
 file MUST pass all verification steps.
 
-There are five different ways you can express the chart you want to install:
+To bring resources that were created with kubectl or another tool under the
+management of a release, use '--take-ownership'. Instead of failing because
+they already exist, the install patches them to match the chart. The resources
+of the release are annotated with meta.helm.sh/release-name and
+meta.helm.sh/release-namespace, and labeled app.kubernetes.io/managed-by=Tiller.
+Resources of another release are never taken, whether they carry these
+annotations or are listed in the manifest of a deployed release.
+
+To attach labels to the release itself, use '--labels'. They are stored with
+the release record, are kept by upgrades and rollbacks, and can be used to list
+releases with 'helm list --selector':
+
+	$ helm install --labels team=payments,tier=web ./redis
+	$ helm list --selector team=payments
+
+The CustomResourceDefinitions in the crds/ directory of the chart and of its
+subcharts are installed once the chart renders, before its resources, unless
+'--skip-crds' is set. They are not templates, and CRDs that already exist are
+left untouched.
+
+To consume the result of an install from a script, use '--output json' or
+'--output yaml'. The output holds the release name, revision, namespace,
+status, rendered notes and the results of the hooks that were run.
+
+There are six different ways you can express the chart you want to install:
 
 1. By chart reference: helm install stable/mariadb
 2. By path to a packaged chart: helm install ./nginx-1.2.3.tgz
 3. By path to an unpacked chart directory: helm install ./nginx
 4. By absolute URL: helm install https://example.com/charts/nginx-1.2.3.tgz
 5. By chart reference and repo url: helm install --repo https://example.com/charts/ nginx
+6. By OCI registry reference: helm install oci://registry.example.com/charts/nginx:1.2.3
 
 CHART REFERENCES
 
@@ -109,36 +164,61 @@ version of that chart unless you also supply a version number with the
 
 To see the list of chart repositories, use 'helm repo list'. To search for
 charts in a repository, use 'helm search'.
+
+REGISTRY REFERENCES
+
+Charts stored in an OCI registry are referenced as oci://<registry>/<repository>:<tag>.
+The tag is usually the chart version; if it is left out, the '--version' flag
+is used as the tag. Helm authenticates with the credentials of the Docker CLI,
+including its credential helpers, so 'docker login' gives access to private
+registries. Pulled charts are cached in $HELM_HOME/cache/registry.
 `
 
+var errValidateWithoutDryRun = errors.New("--validate can only be used with --dry-run")
+
 type installCmd struct {
-	name           string
-	namespace      string
-	valueFiles     valueFiles
-	chartPath      string
-	dryRun         bool
-	disableHooks   bool
-	disableCRDHook bool
-	replace        bool
-	verify         bool
-	keyring        string
-	out            io.Writer
-	client         helm.Interface
-	values         []string
-	stringValues   []string
-	fileValues     []string
-	nameTemplate   string
-	version        string
-	timeout        int64
-	wait           bool
-	atomic         bool
-	repoURL        string
-	username       string
-	password       string
-	devel          bool
-	depUp          bool
-	subNotes       bool
-	description    string
+	name                string
+	namespace           string
+	valueFiles          valueFiles
+	chartPath           string
+	dryRun              bool
+	validate            bool
+	strict              bool
+	missingKey          string
+	stripComments       bool
+	disableHooks        bool
+	disableCRDHook      bool
+	replace             bool
+	verify              bool
+	keyring             string
+	out                 io.Writer
+	client              helm.Interface
+	values              []string
+	stringValues        []string
+	jsonValues          []string
+	fileValues          []string
+	envValues           []string
+	nameTemplate        string
+	version             string
+	timeout             int64
+	wait                bool
+	waitForJobs         bool
+	atomic              bool
+	repoURL             string
+	username            string
+	password            string
+	devel               bool
+	depUp               bool
+	subNotes            bool
+	description         string
+	setBy               string
+	postRenderer        string
+	resolveImageDigests bool
+	takeOwnership       bool
+	skipCRDs            bool
+	labels              string
+	profile             string
+	profileDirs         []string
 
 	certFile string
 	keyFile  string
@@ -172,7 +252,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "install [CHART]",
 		Short:   "Install a chart archive",
-		Long:    installDesc,
+		Long:    installDesc + profileHelp + postRendererHelp + resolveImageDigestsHelp,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "chart name"); err != nil {
@@ -192,7 +272,13 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 			}
 			inst.chartPath = cp
 			inst.client = ensureHelmClient(inst.client)
-			inst.wait = inst.wait || inst.atomic
+			inst.wait = inst.wait || inst.atomic || inst.waitForJobs
+			if inst.validate && !inst.dryRun {
+				return errValidateWithoutDryRun
+			}
+			if err := checkPostRenderer(inst.postRenderer); err != nil {
+				return err
+			}
 
 			return inst.run()
 		},
@@ -200,22 +286,36 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
-	f.VarP(&inst.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
+	f.VarP(&inst.valueFiles, "values", "f", "Specify values in a YAML file, a URL or '-' for stdin (can specify multiple)")
+	f.StringVar(&inst.profile, "profile", "", "Layer the values-<profile>.yaml file of the chart over its default values, before the user-supplied values")
+	f.StringArrayVar(&inst.profileDirs, "profile-dir", []string{}, "Directories to look for the values-<profile>.yaml file of --profile in, after the chart (can specify multiple)")
 	f.StringVarP(&inst.name, "name", "n", "", "The release name. If unspecified, it will autogenerate one for you")
 	f.StringVar(&inst.namespace, "namespace", "", "Namespace to install the release into. Defaults to the current kube config namespace.")
 	f.BoolVar(&inst.dryRun, "dry-run", false, "Simulate an install")
+	f.BoolVar(&inst.validate, "validate", false, "With --dry-run, submit the rendered manifests to the Kubernetes API server as a server-side dry run to catch schema and admission errors")
+	f.BoolVar(&inst.strict, "strict", false, "Fail the rendering on references to values that are not defined, instead of rendering them as empty")
+	f.StringVar(&inst.missingKey, "missing-key", "", "What to render for values that are not defined: \"zero\" for nothing, \"invalid\" for \"<no value>\" or \"error\" to fail. Defaults to the setting of the chart, or \"zero\"")
+	f.BoolVar(&inst.stripComments, "strip-comments", false, "Drop comments and documents left empty from the rendered manifests, to keep large releases small")
+	f.StringVar(&inst.postRenderer, "post-renderer", "", "The path to an executable that modifies the rendered manifests before they are installed")
+	f.BoolVar(&inst.resolveImageDigests, "resolve-image-digests", false, "Pin the images of the rendered manifests to their digests, as reported by their registries, before installing")
 	f.BoolVar(&inst.disableHooks, "no-hooks", false, "Prevent hooks from running during install")
 	f.BoolVar(&inst.disableCRDHook, "no-crd-hook", false, "Prevent CRD hooks from running, but run other hooks")
+	f.BoolVar(&inst.skipCRDs, "skip-crds", false, "Do not install the CRDs of the crds/ directory of the chart")
 	f.BoolVar(&inst.replace, "replace", false, "Re-use the given name, even if that name is already used. This is unsafe in production")
+	f.BoolVar(&inst.takeOwnership, "take-ownership", false, "Adopt the resources of the chart that already exist in the cluster instead of failing, patching them to match the chart")
 	f.StringArrayVar(&inst.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&inst.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
 	f.StringArrayVar(&inst.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&inst.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringArrayVar(&inst.envValues, "set-env", []string{}, "Set values from environment variables specified via the command line (can specify multiple or separate values with commas: key1=ENV1,key2=ENV2). A variable alone, such as DB_PASSWORD, sets the value of the same name")
 	f.StringVar(&inst.nameTemplate, "name-template", "", "Specify template used to name the release")
+	f.StringVar(&inst.labels, "labels", "", "Labels to attach to the release, such as team=payments,tier=web. They can be used to select releases with 'helm list --selector'")
 	f.BoolVar(&inst.verify, "verify", false, "Verify the package before installing it")
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "Location of public keys used for verification")
 	f.StringVar(&inst.version, "version", "", "Specify the exact chart version to install. If this is not specified, the latest version is installed")
 	f.Int64Var(&inst.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&inst.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&inst.waitForJobs, "wait-for-jobs", false, "If set, will also wait until all Jobs of the release have completed, also sets --wait flag")
 	f.BoolVar(&inst.atomic, "atomic", false, "If set, installation process purges chart on fail, also sets --wait flag")
 	f.StringVar(&inst.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
 	f.StringVar(&inst.username, "username", "", "Chart repository username where to locate the requested chart")
@@ -227,6 +327,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&inst.depUp, "dep-up", false, "Run helm dependency update before installing the chart")
 	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "Render subchart notes along with the parent")
 	f.StringVar(&inst.description, "description", "", "Specify a description for the release")
+	f.StringVar(&inst.setBy, "set-by", "", "Identity to record as the user who deployed the release, instead of the user of the kube context")
 	bindOutputFlag(cmd, &inst.output)
 
 	// set defaults from environment
@@ -242,25 +343,20 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.certFile, i.keyFile, i.caFile)
+	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.jsonValues, i.envValues, i.certFile, i.keyFile, i.caFile)
 	if err != nil {
 		return err
 	}
 
-	// If template is specified, try to run the template.
-	if i.nameTemplate != "" {
-		i.name, err = generateName(i.nameTemplate)
-		if err != nil {
-			return err
-		}
-		// Print the final name so the user knows what the final name of the release is.
-		fmt.Printf("FINAL NAME: %s\n", i.name)
-	}
-
 	if msgs := validation.IsDNS1123Subdomain(i.name); i.name != "" && len(msgs) > 0 {
 		return fmt.Errorf("release name %s is invalid: %s", i.name, strings.Join(msgs, ";"))
 	}
 
+	releaseLabels, err := labels.ConvertSelectorToLabelsMap(i.labels)
+	if err != nil {
+		return fmt.Errorf("invalid release labels %q: %s", i.labels, err)
+	}
+
 	// Check chart requirements to make sure all dependencies are present in /charts
 	chartRequested, err := chartutil.Load(i.chartPath)
 	if err != nil {
@@ -299,36 +395,73 @@ func (i *installCmd) run() error {
 		return fmt.Errorf("cannot load requirements: %v", err)
 	}
 
-	res, err := i.client.InstallReleaseFromChart(
-		chartRequested,
-		i.namespace,
+	if err := applyProfile(chartRequested, i.profile, i.profileDirs); err != nil {
+		return err
+	}
+
+	// If template is specified, try to run the template.
+	if i.nameTemplate != "" {
+		i.name, err = i.nameFromTemplate(chartRequested.Metadata)
+		if err != nil {
+			return err
+		}
+		// Print the final name so the user knows what the final name of the release is.
+		fmt.Printf("FINAL NAME: %s\n", i.name)
+	}
+
+	opts := []helm.InstallOption{
 		helm.ValueOverrides(rawVals),
 		helm.ReleaseName(i.name),
 		helm.InstallDryRun(i.dryRun),
+		helm.InstallValidate(i.validate),
+		helm.InstallStrict(i.strict),
+		helm.InstallMissingKey(i.missingKey),
+		helm.InstallStripComments(i.stripComments),
 		helm.InstallReuseName(i.replace),
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallDisableCRDHook(i.disableCRDHook),
+		helm.InstallSkipCRDs(i.skipCRDs),
+		helm.InstallTakeOwnership(i.takeOwnership),
+		helm.InstallLabels(releaseLabels),
 		helm.InstallSubNotes(i.subNotes),
 		helm.InstallTimeout(i.timeout),
 		helm.InstallWait(i.wait),
-		helm.InstallDescription(i.description))
+		helm.InstallWaitForJobs(i.waitForJobs),
+		helm.InstallDescription(i.description),
+		helm.InstallDeployedBy(deployingUser(i.setBy)),
+	}
+	if i.postRenderer != "" || i.resolveImageDigests {
+		manifest, err := i.postRender(chartRequested, opts)
+		if err != nil {
+			return prettyError(err)
+		}
+		opts = append(opts, helm.ReleaseName(i.name), helm.InstallPostRenderedManifest(manifest))
+	}
+
+	// The release name is needed to find its resources, so progress can only be
+	// reported when it is not generated by Tiller.
+	stopProgress := func() {}
+	if i.wait && i.name != "" && !i.dryRun && outputFormat(i.output) == outputTable {
+		progress := &waitProgress{out: i.out, client: i.client, release: i.name}
+		stopProgress = progress.start(waitProgressInterval)
+	}
+
+	// A failed install is only purged if it stored a new revision, so the
+	// revision of a release already using the name is recorded first.
+	var lastVersion int32
+	if i.atomic && i.name != "" {
+		if status, err := i.client.ReleaseStatus(i.name); err == nil {
+			lastVersion = status.Version
+		}
+	}
+
+	res, err := i.client.InstallReleaseFromChart(chartRequested, i.namespace, opts...)
+	stopProgress()
 	if err != nil {
 		if i.atomic {
-			fmt.Fprintf(os.Stdout, "INSTALL FAILED\nPURGING CHART\nError: %v\n", prettyError(err))
-			deleteSideEffects := &deleteCmd{
-				name:         i.name,
-				disableHooks: i.disableHooks,
-				purge:        true,
-				timeout:      i.timeout,
-				description:  "",
-				dryRun:       i.dryRun,
-				out:          i.out,
-				client:       i.client,
-			}
-			if err := deleteSideEffects.run(); err != nil {
+			if err := i.purgeFailedRelease(err, lastVersion); err != nil {
 				return err
 			}
-			fmt.Fprintf(os.Stdout, "Successfully purged a chart!\n")
 		}
 		return prettyError(err)
 	}
@@ -340,6 +473,7 @@ func (i *installCmd) run() error {
 
 	if outputFormat(i.output) == outputTable {
 		i.printRelease(rel)
+		printWarnings(i.out, rel)
 	}
 
 	// If this is a dry run, we can't display status.
@@ -357,7 +491,47 @@ func (i *installCmd) run() error {
 		return prettyError(err)
 	}
 
-	return write(i.out, &statusWriter{status}, outputFormat(i.output))
+	return write(i.out, &statusWriter{status: status}, outputFormat(i.output))
+}
+
+// purgeFailedRelease purges the release left behind by a failed install with
+// --atomic. A release that was not created by this install, such as a release
+// whose name was requested again, is left alone: its latest revision is then
+// still lastVersion.
+func (i *installCmd) purgeFailedRelease(installErr error, lastVersion int32) error {
+	fmt.Fprintf(i.out, "INSTALL FAILED\nError: %v\n", prettyError(installErr))
+	if i.name == "" {
+		fmt.Fprintln(i.out, "The release name was generated by Tiller and is unknown, skipping purge")
+		return nil
+	}
+	res, err := i.client.ReleaseStatus(i.name)
+	if err != nil {
+		// The release was never stored, so there is nothing to purge.
+		return nil
+	}
+	if res.Version <= lastVersion {
+		return nil
+	}
+	if code := res.Info.Status.Code; code != release.Status_FAILED && code != release.Status_PENDING_INSTALL {
+		return nil
+	}
+
+	fmt.Fprintln(i.out, "PURGING CHART")
+	deleteSideEffects := &deleteCmd{
+		name:         i.name,
+		disableHooks: i.disableHooks,
+		purge:        true,
+		timeout:      i.timeout,
+		description:  "",
+		dryRun:       i.dryRun,
+		out:          i.out,
+		client:       i.client,
+	}
+	if err := deleteSideEffects.run(); err != nil {
+		return err
+	}
+	fmt.Fprintf(i.out, "Successfully purged a chart!\n")
+	return nil
 }
 
 // Merges source and destination map, preferring values from the source map
@@ -388,17 +562,22 @@ func mergeValues(dest map[string]interface{}, src map[string]interface{}) map[st
 }
 
 // vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
+// directly via --set-json or --set or --set-string or --set-file or --set-env, marshaling them to YAML
+func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, jsonValues []string, envValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
 	base := map[string]interface{}{}
 
 	// User specified a values files via -f/--values
+	readStdin := false
 	for _, filePath := range valueFiles {
 		currentMap := map[string]interface{}{}
 
 		var bytes []byte
 		var err error
 		if strings.TrimSpace(filePath) == "-" {
+			if readStdin {
+				return []byte{}, errors.New("values can only be read from stdin once")
+			}
+			readStdin = true
 			bytes, err = ioutil.ReadAll(os.Stdin)
 		} else {
 			bytes, err = readFile(filePath, CertFile, KeyFile, CAFile)
@@ -415,6 +594,13 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 		base = mergeValues(base, currentMap)
 	}
 
+	// User specified a value via --set-json
+	for _, value := range jsonValues {
+		if err := strvals.ParseJSON(value, base); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-json data: %s", err)
+		}
+	}
+
 	// User specified a value via --set
 	for _, value := range values {
 		if err := strvals.ParseInto(value, base); err != nil {
@@ -440,9 +626,50 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 		}
 	}
 
+	// User specified a value via --set-env
+	for _, value := range envValues {
+		if err := parseIntoEnv(value, base); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-env data: %s", err)
+		}
+	}
+
 	return yaml.Marshal(base)
 }
 
+// parseIntoEnv sets the values of a --set-env flag, key1=ENV1,key2=ENV2, to
+// the environment variables they name, so that secrets need not be written
+// to a values file. A variable alone, such as DB_PASSWORD, is short for
+// DB_PASSWORD=DB_PASSWORD. Variables that are not set are an error.
+func parseIntoEnv(s string, dest map[string]interface{}) error {
+	if !strings.Contains(s, "=") {
+		s = s + "=" + s
+	}
+	reader := func(rs []rune) (interface{}, error) {
+		name := string(rs)
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", name)
+		}
+		return v, nil
+	}
+	return strvals.ParseIntoFile(s, dest, reader)
+}
+
+// postRender renders the chart in a dry run and pipes the result through the
+// post-renderer, pinning the images to their digests if asked to. The name
+// Tiller generated for the dry run, if any, is kept so that the manifests
+// match the release that is installed.
+func (i *installCmd) postRender(ch *chart.Chart, opts []helm.InstallOption) (string, error) {
+	dryRunOpts := append([]helm.InstallOption{}, opts...)
+	dryRunOpts = append(dryRunOpts, helm.InstallDryRun(true), helm.InstallValidate(false))
+	res, err := i.client.InstallReleaseFromChart(ch, i.namespace, dryRunOpts...)
+	if err != nil {
+		return "", err
+	}
+	i.name = res.GetRelease().GetName()
+	return processManifests(releaseManifests(res.GetRelease()), i.postRenderer, i.resolveImageDigests)
+}
+
 // printRelease prints info about a release if the Debug is true.
 func (i *installCmd) printRelease(rel *release.Release) {
 	if rel == nil {
@@ -526,20 +753,118 @@ func locateChartPath(repoURL, username, password, name, version string, verify b
 		}
 		debug("Fetched %s to %s\n", name, filename)
 		return lname, nil
-	} else if settings.Debug {
+	} else if settings.Debug || strings.HasPrefix(name, registry.Scheme+"://") {
 		return filename, err
 	}
 
 	return filename, fmt.Errorf("failed to download %q (hint: running `helm repo update` may help)", name)
 }
 
-func generateName(nameTemplate string) (string, error) {
+const (
+	// maxNameTemplateAttempts is the number of names tried from a name
+	// template before giving up on finding one that is not in use.
+	maxNameTemplateAttempts = 10
+
+	// releaseNameMaxLen is the maximum length of a release name accepted by
+	// Tiller.
+	releaseNameMaxLen = 53
+)
+
+// nameTemplateData is the data available to --name-template.
+type nameTemplateData struct {
+	// Chart is the metadata of the chart being installed.
+	Chart *chart.Metadata
+	// Namespace is the namespace the release is installed into.
+	Namespace string
+	// Env holds the environment variables of the helm process.
+	Env map[string]string
+}
+
+// newNameTemplateData returns the data of --name-template for a chart. The
+// namespace of the kube config is used if namespace is empty.
+func newNameTemplateData(md *chart.Metadata, namespace string) nameTemplateData {
+	if namespace == "" {
+		namespace = defaultNamespace()
+	}
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if i := strings.Index(kv, "="); i > 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+	return nameTemplateData{Chart: md, Namespace: namespace, Env: env}
+}
+
+// nameFromTemplate generates a release name from --name-template and checks
+// that it is valid and not used by another release. Names from templates
+// that give a different name each time, like those using randAlpha, are
+// generated again until one is free.
+func (i *installCmd) nameFromTemplate(md *chart.Metadata) (string, error) {
+	data := newNameTemplateData(md, i.namespace)
+	var name string
+	for attempt := 0; attempt < maxNameTemplateAttempts; attempt++ {
+		generated, err := generateName(i.nameTemplate, data)
+		if err != nil {
+			return "", err
+		}
+		if generated == name {
+			// The template always gives the same name.
+			break
+		}
+		name = generated
+		if err := validateGeneratedName(name); err != nil {
+			return "", err
+		}
+		inUse, err := i.releaseNameInUse(name)
+		if err != nil {
+			return "", prettyError(err)
+		}
+		if !inUse {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("release name %q generated from --name-template is already in use", name)
+}
+
+// releaseNameInUse reports whether a release with the given name exists and
+// cannot be replaced by the install.
+func (i *installCmd) releaseNameInUse(name string) (bool, error) {
+	res, err := i.client.ReleaseContent(name)
+	if err != nil {
+		if strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(name).Error()) {
+			return false, nil
+		}
+		return false, err
+	}
+	switch res.GetRelease().GetInfo().GetStatus().GetCode() {
+	case release.Status_DELETED, release.Status_FAILED:
+		return !i.replace, nil
+	}
+	return true, nil
+}
+
+// validateGeneratedName checks a release name generated from a name template
+// against the rules Tiller applies to release names.
+func validateGeneratedName(name string) error {
+	if name == "" {
+		return errors.New("--name-template generated an empty release name")
+	}
+	if len(name) > releaseNameMaxLen {
+		return fmt.Errorf("release name %q exceeds max length of %d", name, releaseNameMaxLen)
+	}
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return fmt.Errorf("release name %s is invalid: %s", name, strings.Join(msgs, ";"))
+	}
+	return nil
+}
+
+func generateName(nameTemplate string, data nameTemplateData) (string, error) {
 	t, err := template.New("name-template").Funcs(sprig.TxtFuncMap()).Parse(nameTemplate)
 	if err != nil {
 		return "", err
 	}
 	var b bytes.Buffer
-	err = t.Execute(&b, nil)
+	err = t.Execute(&b, data)
 	if err != nil {
 		return "", err
 	}
@@ -554,6 +879,10 @@ func defaultNamespace() string {
 }
 
 //readFile load a file from the local directory or a remote file with a url.
+//
+// Credentials for a remote file are taken from the URL itself, or else from
+// the repository in repositories.yaml that serves it, along with the TLS
+// files of that repository when none are given.
 func readFile(filePath, CertFile, KeyFile, CAFile string) ([]byte, error) {
 	u, _ := url.Parse(filePath)
 	p := getter.All(settings)
@@ -565,10 +894,44 @@ func readFile(filePath, CertFile, KeyFile, CAFile string) ([]byte, error) {
 		return ioutil.ReadFile(filePath)
 	}
 
-	getter, err := getterConstructor(filePath, CertFile, KeyFile, CAFile)
+	var username, password string
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+		u.User = nil
+		filePath = u.String()
+	} else if rc := findRepoEntryForURL(filePath); rc != nil {
+		username, password = rc.Username, rc.Password
+		if CertFile == "" && KeyFile == "" && CAFile == "" {
+			CertFile, KeyFile, CAFile = rc.CertFile, rc.KeyFile, rc.CAFile
+		}
+	}
+
+	g, err := getterConstructor(filePath, CertFile, KeyFile, CAFile)
 	if err != nil {
 		return []byte{}, err
 	}
-	data, err := getter.Get(filePath)
-	return data.Bytes(), err
+	if t, ok := g.(*getter.HttpGetter); ok {
+		t.SetCredentials(username, password)
+	}
+	data, err := g.Get(filePath)
+	if err != nil {
+		return []byte{}, fmt.Errorf("failed to fetch %s: %s", filePath, err)
+	}
+	return data.Bytes(), nil
+}
+
+// findRepoEntryForURL returns the configured repository whose URL the given
+// URL is under, or nil if there is none.
+func findRepoEntryForURL(u string) *repo.Entry {
+	rf, err := repo.LoadRepositoriesFile(settings.Home.RepositoryFile())
+	if err != nil {
+		return nil
+	}
+	for _, rc := range rf.Repositories {
+		if strings.HasPrefix(u, strings.TrimSuffix(rc.URL, "/")+"/") {
+			return rc
+		}
+	}
+	return nil
 }
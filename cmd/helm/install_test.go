@@ -17,14 +17,28 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/repo"
 )
 
 func TestInstall(t *testing.T) {
@@ -37,6 +51,21 @@ func TestInstall(t *testing.T) {
 			expected: "aeneas",
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 		},
+		// Install, machine-readable output
+		{
+			name:     "install with json output",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name aeneas -o json", " "),
+			expected: `^\{"name":"aeneas","info":\{"status":\{"code":1\},.*\},"namespace":"default","version":1\}`,
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
+		{
+			name:     "install with yaml output",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name aeneas -o yaml", " "),
+			expected: "^info:\n(.*\n)*name: aeneas\nnamespace: default\nversion: 1\n$",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+		},
 		// Install, no hooks
 		{
 			name:     "install without hooks",
@@ -83,6 +112,20 @@ func TestInstall(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
 			expected: "virgil",
 		},
+		// Install, server-side validation
+		{
+			name:     "install with a dry run validated by the server",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name virgil --dry-run --validate", " "),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+			expected: "virgil",
+		},
+		{
+			name:  "install with server-side validation without a dry run",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: strings.Split("--name virgil --validate", " "),
+			err:   true,
+		},
 		// Install, no charts
 		{
 			name: "install with no chart specified",
@@ -113,6 +156,14 @@ func TestInstall(t *testing.T) {
 			expected: "apollo",
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "apollo"}),
 		},
+		// Install, wait for jobs
+		{
+			name:     "install with wait for jobs",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name apollo --wait-for-jobs", " "),
+			expected: "apollo",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "apollo"}),
+		},
 		// Install, with atomic
 		{
 			name:     "install with a atomic",
@@ -121,6 +172,24 @@ func TestInstall(t *testing.T) {
 			expected: "apollo",
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "apollo"}),
 		},
+		// Install, with atomic, failing on a failed release it did not create
+		{
+			name:     "install with a atomic not purging a failed release",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name apollo --atomic", " "),
+			expected: "^INSTALL FAILED\nError: cannot re-use a name that is still in use\n$",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "apollo", StatusCode: release.Status_FAILED})},
+			err:      true,
+		},
+		// Install, with atomic, failing on a release that is already deployed
+		{
+			name:     "install with a atomic not purging a deployed release",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name apollo --atomic", " "),
+			expected: "^INSTALL FAILED\nError: cannot re-use a name that is still in use\n$",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "apollo"})},
+			err:      true,
+		},
 		// Install, using the name-template
 		{
 			name:     "install with name-template",
@@ -136,6 +205,13 @@ func TestInstall(t *testing.T) {
 			expected: "virgil",
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil", Description: "foobar"}),
 		},
+		{
+			name:     "install with the deploying user",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    []string{"--name", "virgil", "--set-by", "ci-bot"},
+			expected: "virgil",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+		},
 		// Install, perform chart verification along the way.
 		{
 			name:  "install with verification, missing provenance",
@@ -191,6 +267,29 @@ func TestInstall(t *testing.T) {
 			flags: []string{"--name-template", "{{UPPER \"foobar\"}}"},
 			err:   true,
 		},
+		// Install, using a name-template with chart metadata
+		{
+			name:     "install with name-template using the chart",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    []string{"--name-template", "{{ .Chart.Name }}-{{ .Namespace }}", "--namespace", "web"},
+			expected: "NAME:   alpine-web",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "alpine-web"}),
+		},
+		// Install, using a name-template giving the name of a deployed release
+		{
+			name:  "install with name-template in use",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: []string{"--name-template", "{{ .Chart.Name }}"},
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "alpine"})},
+			err:   true,
+		},
+		// Install, using a name-template giving an invalid name
+		{
+			name:  "install with name-template too long",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: []string{"--name-template", "{{ repeat 60 \"a\" }}"},
+			err:   true,
+		},
 		// Install, using --output json
 		{
 			name:     "install using output json",
@@ -252,11 +351,25 @@ func TestNameTemplate(t *testing.T) {
 			expected:         "",
 			expectedErrorStr: "unexpected unclosed action",
 		},
+		// Chart metadata and namespace
+		{
+			tpl:      "{{ .Chart.Name }}-{{ .Chart.Version }}-{{ .Namespace }}",
+			expected: "alpine-0.1.0-web$",
+		},
+		// Environment variables
+		{
+			tpl:      "{{ .Env.HELM_NAME_TEMPLATE_TEST }}",
+			expected: "from-env$",
+		},
 	}
 
+	os.Setenv("HELM_NAME_TEMPLATE_TEST", "from-env")
+	defer os.Unsetenv("HELM_NAME_TEMPLATE_TEST")
+	data := newNameTemplateData(&chart.Metadata{Name: "alpine", Version: "0.1.0"}, "web")
+
 	for _, tc := range testCases {
 
-		n, err := generateName(tc.tpl)
+		n, err := generateName(tc.tpl, data)
 		if err != nil {
 			if tc.expectedErrorStr == "" {
 				t.Errorf("Was not expecting error, but got: %v", err)
@@ -289,6 +402,13 @@ func TestNameTemplate(t *testing.T) {
 	}
 }
 
+func TestNameTemplateDataDefaultNamespace(t *testing.T) {
+	data := newNameTemplateData(&chart.Metadata{Name: "alpine"}, "")
+	if data.Namespace == "" || data.Namespace != defaultNamespace() {
+		t.Errorf("Expected the namespace of the kube config %q, got %q", defaultNamespace(), data.Namespace)
+	}
+}
+
 func TestMergeValues(t *testing.T) {
 	nestedMap := map[string]interface{}{
 		"foo": "bar",
@@ -343,3 +463,297 @@ func TestMergeValues(t *testing.T) {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
 }
+
+func TestValsSetFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-set-file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Content that --set would mangle: commas, brackets, quotes and newlines.
+	script := "#!/bin/sh\necho \"a,b\" [c] {d: e}\n"
+	path := filepath.Join(dir, "script.sh")
+	if err := ioutil.WriteFile(path, []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := vals(nil, []string{"config.enabled=true"}, nil, []string{"config.script=" + path}, nil, nil, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"config": map[string]interface{}{
+			"enabled": true,
+			"script":  script,
+		},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+
+	if _, err := vals(nil, nil, nil, []string{"config.script=" + filepath.Join(dir, "missing")}, nil, nil, "", "", ""); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestValsSetJSON(t *testing.T) {
+	raw, err := vals(nil, []string{"resources.limits.cpu=200m"}, nil, nil, []string{`resources={"limits":{"cpu":"100m","memory":"128Mi"}},ports=[80,443]`}, nil, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		t.Fatal(err)
+	}
+	// --set is applied after --set-json, so it overrides single fields.
+	expected := map[string]interface{}{
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"cpu": "200m", "memory": "128Mi"},
+		},
+		"ports": []interface{}{float64(80), float64(443)},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+}
+
+func TestValsSetEnv(t *testing.T) {
+	os.Setenv("HELM_TEST_DB_PASSWORD", "s3cr,et")
+	defer os.Unsetenv("HELM_TEST_DB_PASSWORD")
+
+	raw, err := vals(nil, nil, nil, nil, nil, []string{"db.password=HELM_TEST_DB_PASSWORD", "HELM_TEST_DB_PASSWORD"}, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"db":                    map[string]interface{}{"password": "s3cr,et"},
+		"HELM_TEST_DB_PASSWORD": "s3cr,et",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+
+	if _, err := vals(nil, nil, nil, nil, nil, []string{"db.password=HELM_TEST_UNSET"}, "", "", ""); err == nil {
+		t.Error("expected an error for a variable that is not set")
+	}
+}
+
+func TestParseIntoEnv(t *testing.T) {
+	os.Setenv("HELM_TEST_USER", "admin")
+	defer os.Unsetenv("HELM_TEST_USER")
+	os.Setenv("HELM_TEST_PASSWORD", "s3cr=et")
+	defer os.Unsetenv("HELM_TEST_PASSWORD")
+	os.Unsetenv("HELM_TEST_UNSET")
+
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "key=VAR",
+			value:    "db.user=HELM_TEST_USER",
+			expected: map[string]interface{}{"db": map[string]interface{}{"user": "admin"}},
+		},
+		{
+			name:     "several keys",
+			value:    "db.user=HELM_TEST_USER,db.password=HELM_TEST_PASSWORD",
+			expected: map[string]interface{}{"db": map[string]interface{}{"user": "admin", "password": "s3cr=et"}},
+		},
+		{
+			name:     "bare VAR",
+			value:    "HELM_TEST_PASSWORD",
+			expected: map[string]interface{}{"HELM_TEST_PASSWORD": "s3cr=et"},
+		},
+		{
+			name:  "unset variable",
+			value: "db.user=HELM_TEST_UNSET",
+			err:   "environment variable HELM_TEST_UNSET is not set",
+		},
+		{
+			name:  "unset bare variable",
+			value: "HELM_TEST_UNSET",
+			err:   "environment variable HELM_TEST_UNSET is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := map[string]interface{}{}
+			err := parseIntoEnv(tt.value, dest)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dest, tt.expected) {
+				t.Errorf("expected values %v, got %v", tt.expected, dest)
+			}
+		})
+	}
+}
+
+func TestValsFromURL(t *testing.T) {
+	home, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := resetEnv()
+	defer func() {
+		os.RemoveAll(home.String())
+		cleanup()
+	}()
+	settings.Home = home
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "admin" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintln(w, "replicas: 3")
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/values/prod.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vals(valueFiles{u.String()}, nil, nil, nil, nil, nil, "", "", ""); err == nil {
+		t.Error("expected an error without credentials")
+	}
+
+	// Credentials in the URL.
+	u.User = url.UserPassword("admin", "secret")
+	raw, err := vals(valueFiles{u.String()}, nil, nil, nil, nil, nil, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != "replicas: 3\n" {
+		t.Errorf("expected values from the URL, got %q", raw)
+	}
+
+	// Credentials of the repository serving the file.
+	rf := repo.NewRepoFile()
+	rf.Add(&repo.Entry{Name: "values", URL: srv.URL + "/values", Username: "admin", Password: "secret"})
+	if err := rf.WriteFile(home.RepositoryFile(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vals(valueFiles{srv.URL + "/values/prod.yaml"}, nil, nil, nil, nil, nil, "", "", ""); err != nil {
+		t.Errorf("expected the repository credentials to be used: %s", err)
+	}
+}
+
+func TestValsStdin(t *testing.T) {
+	f, err := ioutil.TempFile("", "helm-values-stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("name: piped\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+
+	raw, err := vals(valueFiles{"-"}, []string{"tag=latest"}, nil, nil, nil, nil, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != "name: piped\ntag: latest\n" {
+		t.Errorf("expected values from stdin, got %q", raw)
+	}
+
+	if _, err := vals(valueFiles{"-", "-"}, nil, nil, nil, nil, nil, "", "", ""); err == nil {
+		t.Error("expected an error when reading stdin twice")
+	}
+}
+
+func TestInstallPostRenderer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the post-renderer is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "helm-post-renderer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	renderer := filepath.Join(dir, "renderer.sh")
+	if err := ioutil.WriteFile(renderer, []byte("#!/bin/sh\nsed s/fixture/patched/\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &helm.FakeClient{}
+	cmd := newInstallCmd(c, ioutil.Discard)
+	cmd.ParseFlags([]string{"--name", "virgil", "--post-renderer", renderer})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Rels) != 1 || !strings.Contains(c.Rels[0].Manifest, "name: patched") {
+		t.Errorf("expected the post-rendered manifest to be installed, got %v", c.Rels)
+	}
+
+	cmd = newInstallCmd(&helm.FakeClient{}, ioutil.Discard)
+	cmd.ParseFlags([]string{"--post-renderer", filepath.Join(dir, "missing")})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err == nil {
+		t.Error("expected an error for a missing post-renderer")
+	}
+}
+
+func TestInstallLabels(t *testing.T) {
+	c := &helm.FakeClient{}
+	cmd := newInstallCmd(c, ioutil.Discard)
+	cmd.ParseFlags([]string{"--name", "virgil", "--labels", "team=payments,tier=web"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"team": "payments", "tier": "web"}
+	if len(c.Rels) != 1 || !reflect.DeepEqual(c.Rels[0].Labels, expected) {
+		t.Errorf("expected the release to be labeled %v, got %v", expected, c.Rels)
+	}
+
+	cmd = newInstallCmd(&helm.FakeClient{}, ioutil.Discard)
+	cmd.ParseFlags([]string{"--name", "virgil", "--labels", "team"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err == nil {
+		t.Error("expected an error for a label without a value")
+	}
+}
+
+func TestInstallAtomicPurge(t *testing.T) {
+	var buf bytes.Buffer
+	c := &helm.FakeClient{InstallError: errors.New("timed out waiting for the condition")}
+	cmd := newInstallCmd(c, &buf)
+	cmd.ParseFlags([]string{"--name", "apollo", "--atomic"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err == nil {
+		t.Fatal("expected the install to fail")
+	}
+	expected := "INSTALL FAILED\nError: timed out waiting for the condition\nPURGING CHART\n"
+	if !strings.HasPrefix(buf.String(), expected) {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if len(c.Rels) != 0 {
+		t.Errorf("expected the failed release to be purged, got %v", c.Rels)
+	}
+}
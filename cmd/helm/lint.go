@@ -47,7 +47,9 @@ type lintCmd struct {
 	valueFiles valueFiles
 	values     []string
 	sValues    []string
+	jValues    []string
 	fValues    []string
+	envValues  []string
 	namespace  string
 	strict     bool
 	paths      []string
@@ -73,8 +75,10 @@ func newLintCmd(out io.Writer) *cobra.Command {
 
 	cmd.Flags().VarP(&l.valueFiles, "values", "f", "Specify values in a YAML file (can specify multiple)")
 	cmd.Flags().StringArrayVar(&l.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	cmd.Flags().StringArrayVar(&l.jValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
 	cmd.Flags().StringArrayVar(&l.sValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.Flags().StringArrayVar(&l.fValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	cmd.Flags().StringArrayVar(&l.envValues, "set-env", []string{}, "Set values from environment variables specified via the command line (can specify multiple or separate values with commas: key1=ENV1,key2=ENV2). A variable alone, such as DB_PASSWORD, sets the value of the same name")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "Namespace to put the release into")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "Fail on lint warnings")
 
@@ -175,7 +179,7 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 }
 
 // vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
+// directly via --set or --set-string or --set-file or --set-env, marshaling them to YAML
 //
 // This func is implemented intentionally and separately from the `vals` func for the `install` and `upgrade` commands.
 // Compared to the alternative func, this func lacks the parameters for tls opts - ca key, cert, and ca cert.
@@ -198,6 +202,13 @@ func (l *lintCmd) vals() ([]byte, error) {
 		base = mergeValues(base, currentMap)
 	}
 
+	// User specified a value via --set-json
+	for _, value := range l.jValues {
+		if err := strvals.ParseJSON(value, base); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-json data: %s", err)
+		}
+	}
+
 	// User specified a value via --set
 	for _, value := range l.values {
 		if err := strvals.ParseInto(value, base); err != nil {
@@ -223,5 +234,12 @@ func (l *lintCmd) vals() ([]byte, error) {
 		}
 	}
 
+	// User specified a value via --set-env
+	for _, value := range l.envValues {
+		if err := parseIntoEnv(value, base); err != nil {
+			return []byte{}, fmt.Errorf("failed parsing --set-env data: %s", err)
+		}
+	}
+
 	return yaml.Marshal(base)
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
@@ -35,12 +36,18 @@ import (
 var listHelp = `
 This command lists all of the releases.
 
-By default, it lists only releases that are deployed or failed. Flags like
-'--deleted' and '--all' will alter this behavior. Such flags can be combined:
-'--deleted --failed'.
+By default, it lists only releases that are deployed, failed or pending, that
+is still being installed, upgraded or rolled back. A release left pending by an
+interrupted operation keeps its name in use. Flags like '--deleted', '--pending'
+and '--all' will alter this behavior. Such flags can be combined to list the
+releases in any of the given statuses: '--failed --pending'.
 
-By default, items are sorted alphabetically. Use the '-d' flag to sort by
-release date.
+By default, items are sorted alphabetically. Use '--sort-by' to sort them by
+'date' of last deployment, by 'chart' name and version, or by 'revision', and
+'--reverse' to reverse the order. Sorting is done by Tiller before the list is
+cut to '--max' items, so that paging gives consistent results.
+The '-d' and '-c' flags are shorthands for '--sort-by date' and
+'--sort-by chart'.
 
 If an argument is provided, it will be treated as a filter. Filters are
 regular expressions (Perl compatible) that are applied to the list of releases.
@@ -50,6 +57,29 @@ Only items that match the filter will be returned.
 	NAME            	UPDATED                 	CHART
 	maudlin-arachnid	Mon May  9 16:07:08 2016	alpine-0.1.0
 
+Releases installed with '--labels' can be selected by their labels with
+'--selector', which takes a Kubernetes label selector. The selection is done by
+Tiller:
+
+	$ helm list --selector 'team=payments,tier!=db'
+
+To find the releases of a chart, such as those still running a vulnerable
+version of it, use '--chart' with its name and '--chart-version' with a semantic
+version constraint. Both are also applied by Tiller:
+
+	$ helm list --chart nginx-ingress --chart-version '<1.2.3'
+
+The 'annotations' of the Chart.yaml of the charts can be selected with
+'--chart-annotation', which takes the same syntax as '--selector':
+
+	$ helm list --chart-annotation 'team=payments,artifacthub.io/license'
+
+Releases can be listed from several namespaces at once by passing a
+comma-separated list of namespaces or glob patterns to '--namespace'. Quote
+patterns so that the shell does not expand them:
+
+	$ helm list --namespace 'team-a,team-b-*'
+
 If no results are found, 'helm list' will exit 0, but with no output (or in
 the case of no '-q' flag, only headers).
 
@@ -57,33 +87,69 @@ By default, up to 256 items may be returned. To limit this, use the '--max' flag
 Setting '--max' to 0 will not return all results. Rather, it will return the
 server's default, which may be much higher than 256. Pairing the '--max'
 flag with the '--offset' flag allows you to page through results.
+
+When there are more results, a continue token is printed along with the name
+of the next release. Pass it to '--continue', with the same filters and sort
+order, to get the next page. Unlike '--offset', which needs the next release to
+still exist, the token stays valid when releases are installed or deleted
+between pages.
+
+For scripts, use '--output json' or '--output yaml'. Along with the columns of
+the table, each release then has the name and version of its chart, the time it
+was last deployed in RFC 3339 format, its description and the annotations of
+its chart.
+
+To choose the columns of the table, use '--output custom-columns=' followed by
+a comma-separated list of HEADER:PATH columns. Each path is a JSONPath
+expression, as with kubectl, applied to the release with the field names of
+its protobuf definition. Status codes are shown by name and times in RFC 3339
+format:
+
+	$ helm list --output custom-columns=NAME:.name,CHART:.chart.metadata.version,STATUS:.info.status.code
+
+With '--detail', Tiller counts the resources of each release and compares them
+with the cluster. The DRIFT column is 'yes' when a resource was deleted or when
+a field set by the chart was changed outside of Helm, for instance with
+'kubectl edit'. The JSON and YAML output lists those resources. This makes a
+request to Tiller per release, so combine it with filters on large clusters.
 `
 
 type listCmd struct {
-	filter      string
-	short       bool
-	limit       int
-	offset      string
-	byDate      bool
-	sortDesc    bool
-	out         io.Writer
-	all         bool
-	deleted     bool
-	deleting    bool
-	deployed    bool
-	failed      bool
-	namespace   string
-	superseded  bool
-	pending     bool
-	client      helm.Interface
-	colWidth    uint
-	output      string
-	byChartName bool
+	filter        string
+	short         bool
+	limit         int
+	offset        string
+	continueToken string
+	byDate        bool
+	sortBy        string
+	sortDesc      bool
+	out           io.Writer
+	all           bool
+	deleted       bool
+	deleting      bool
+	deployed      bool
+	failed        bool
+	namespace     string
+	selector      string
+	chart         string
+	chartVersion  string
+	chartAnnots   string
+	superseded    bool
+	pending       bool
+	client        helm.Interface
+	colWidth      uint
+	output        string
+	byChartName   bool
+	detail        bool
 }
 
 type listResult struct {
 	Next     string
 	Releases []listRelease
+	// Continue is the token of the next page, if there is one.
+	Continue string `json:",omitempty"`
+	// detail adds the resource and drift columns to the table.
+	detail bool
 }
 
 type listRelease struct {
@@ -94,6 +160,22 @@ type listRelease struct {
 	Chart      string
 	AppVersion string
 	Namespace  string
+	// The fields below are only part of the JSON and YAML output.
+	ChartName    string
+	ChartVersion string
+	LastDeployed string
+	Description  string
+	// ChartAnnotations are the annotations of the Chart.yaml of the chart.
+	ChartAnnotations map[string]string `json:",omitempty"`
+	// Detail is only set with --detail.
+	Detail *listDetail `json:",omitempty"`
+}
+
+type listDetail struct {
+	Resources int32
+	// Drifted lists, as Kind/name, the resources that do not match the
+	// manifest of the release.
+	Drifted []string `json:",omitempty"`
 }
 
 func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -123,19 +205,26 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	settings.AddFlagsTLS(f)
 	f.BoolVarP(&list.short, "short", "q", false, "Output short (quiet) listing format")
 	f.BoolVarP(&list.byDate, "date", "d", false, "Sort by release date")
+	f.StringVar(&list.sortBy, "sort-by", "", "Sort by the given field: name, date, chart or revision")
 	f.BoolVarP(&list.sortDesc, "reverse", "r", false, "Reverse the sort order")
 	f.IntVarP(&list.limit, "max", "m", 256, "Maximum number of releases to fetch")
 	f.StringVarP(&list.offset, "offset", "o", "", "Next release name in the list, used to offset from start value")
+	f.StringVar(&list.continueToken, "continue", "", "Continue token printed by the previous page of the listing")
 	f.BoolVarP(&list.all, "all", "a", false, "Show all releases, not just the ones marked DEPLOYED")
 	f.BoolVar(&list.deleted, "deleted", false, "Show deleted releases")
 	f.BoolVar(&list.deleting, "deleting", false, "Show releases that are currently being deleted")
-	f.BoolVar(&list.deployed, "deployed", false, "Show deployed releases. If no other is specified, this will be automatically enabled")
+	f.BoolVar(&list.deployed, "deployed", false, "Show deployed releases. If no other status is specified, deployed, failed and pending releases are shown")
 	f.BoolVar(&list.failed, "failed", false, "Show failed releases")
-	f.BoolVar(&list.pending, "pending", false, "Show pending releases")
-	f.StringVar(&list.namespace, "namespace", "", "Show releases within a specific namespace")
+	f.BoolVar(&list.pending, "pending", false, "Show releases that are pending install, upgrade or rollback")
+	f.StringVar(&list.namespace, "namespace", "", "Show releases within the given namespaces, as a comma-separated list of names or glob patterns")
+	f.StringVarP(&list.selector, "selector", "l", "", "Show releases whose labels match the selector, such as team=payments")
+	f.StringVar(&list.chart, "chart", "", "Show releases of the chart with this name")
+	f.StringVar(&list.chartVersion, "chart-version", "", "Show releases whose chart version satisfies the constraint, such as \">=1.0.0 <2.0.0\"")
+	f.StringVar(&list.chartAnnots, "chart-annotation", "", "Show releases whose chart annotations match the selector, such as tier=frontend")
 	f.UintVar(&list.colWidth, "col-width", 60, "Specifies the max column width of output")
-	f.StringVar(&list.output, "output", "", "Output the specified format (json or yaml)")
+	f.StringVar(&list.output, "output", "", "Output the specified format (json, yaml or custom-columns=HEADER:PATH,...)")
 	f.BoolVarP(&list.byChartName, "chart-name", "c", false, "Sort by chart name")
+	f.BoolVar(&list.detail, "detail", false, "Show the number of resources of each release and whether they drifted from the release manifest")
 
 	// TODO: Do we want this as a feature of 'helm list'?
 	//f.BoolVar(&list.superseded, "history", true, "show historical releases")
@@ -147,12 +236,16 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (l *listCmd) run() error {
-	sortBy := services.ListSort_NAME
-	if l.byDate {
-		sortBy = services.ListSort_LAST_RELEASED
+	sortBy, err := l.sortField()
+	if err != nil {
+		return err
 	}
-	if l.byChartName {
-		sortBy = services.ListSort_CHART_NAME
+
+	var columns []customColumn
+	if isCustomColumns(l.output) {
+		if columns, err = parseCustomColumns(l.output); err != nil {
+			return err
+		}
 	}
 
 	sortOrder := services.ListSort_ASC
@@ -165,11 +258,16 @@ func (l *listCmd) run() error {
 	res, err := l.client.ListReleases(
 		helm.ReleaseListLimit(l.limit),
 		helm.ReleaseListOffset(l.offset),
+		helm.ReleaseListContinue(l.continueToken),
 		helm.ReleaseListFilter(l.filter),
 		helm.ReleaseListSort(int32(sortBy)),
 		helm.ReleaseListOrder(int32(sortOrder)),
 		helm.ReleaseListStatuses(stats),
 		helm.ReleaseListNamespace(l.namespace),
+		helm.ReleaseListSelector(l.selector),
+		helm.ReleaseListChart(l.chart),
+		helm.ReleaseListChartVersion(l.chartVersion),
+		helm.ReleaseListChartAnnotations(l.chartAnnots),
 	)
 
 	if err != nil {
@@ -180,8 +278,18 @@ func (l *listCmd) run() error {
 	}
 
 	rels := filterList(res.GetReleases())
+	if columns != nil {
+		fmt.Fprint(l.out, pageText(res.Next, res.ContinueToken))
+		return writeCustomColumns(l.out, columns, rels, l.colWidth)
+	}
 
 	result := getListResult(rels, res.Next)
+	result.Continue = res.ContinueToken
+	if l.detail && !l.short {
+		if err := l.addDetails(&result); err != nil {
+			return prettyError(err)
+		}
+	}
 
 	output, err := formatResult(l.output, l.short, result, l.colWidth)
 
@@ -193,6 +301,50 @@ func (l *listCmd) run() error {
 	return nil
 }
 
+// addDetails asks Tiller for the resources of each listed release and how
+// they compare with the cluster.
+func (l *listCmd) addDetails(result *listResult) error {
+	result.detail = true
+	for i := range result.Releases {
+		lr := &result.Releases[i]
+		res, err := l.client.ReleaseDetail(lr.Name, helm.DetailReleaseVersion(lr.Revision))
+		if err != nil {
+			return fmt.Errorf("cannot get the detail of release %s: %s", lr.Name, err)
+		}
+		lr.Detail = &listDetail{
+			Resources: res.GetResourceCount(),
+			Drifted:   res.GetDriftedResources(),
+		}
+	}
+	return nil
+}
+
+// sortField returns the field to sort the releases by. --sort-by takes
+// precedence over the -d and -c flags.
+func (l *listCmd) sortField() (services.ListSort_SortBy, error) {
+	switch l.sortBy {
+	case "name":
+		return services.ListSort_NAME, nil
+	case "date":
+		return services.ListSort_LAST_RELEASED, nil
+	case "chart":
+		return services.ListSort_CHART_NAME, nil
+	case "revision":
+		return services.ListSort_REVISION, nil
+	case "":
+	default:
+		return services.ListSort_UNKNOWN, fmt.Errorf("invalid --sort-by %q: must be one of name, date, chart or revision", l.sortBy)
+	}
+
+	if l.byChartName {
+		return services.ListSort_CHART_NAME, nil
+	}
+	if l.byDate {
+		return services.ListSort_LAST_RELEASED, nil
+	}
+	return services.ListSort_NAME, nil
+}
+
 // filterList returns a list scrubbed of old releases.
 func filterList(rels []*release.Release) []*release.Release {
 	idx := map[string]int32{}
@@ -251,9 +403,11 @@ func (l *listCmd) statusCodes() []release.Status_Code {
 		status = append(status, release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK)
 	}
 
-	// Default case.
+	// Default case. Pending releases are listed too, as their names cannot be
+	// used by another release.
 	if len(status) == 0 {
-		status = append(status, release.Status_DEPLOYED, release.Status_FAILED)
+		status = append(status, release.Status_DEPLOYED, release.Status_FAILED,
+			release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK)
 	}
 	return status
 }
@@ -262,9 +416,10 @@ func getListResult(rels []*release.Release, next string) listResult {
 	listReleases := []listRelease{}
 	for _, r := range rels {
 		md := r.GetChart().GetMetadata()
-		t := "-"
+		t, lastDeployed := "-", ""
 		if tspb := r.GetInfo().GetLastDeployed(); tspb != nil {
 			t = timeconv.String(tspb)
+			lastDeployed = timeconv.Time(tspb).UTC().Format(time.RFC3339)
 		}
 
 		lr := listRelease{
@@ -275,6 +430,12 @@ func getListResult(rels []*release.Release, next string) listResult {
 			Chart:      fmt.Sprintf("%s-%s", md.GetName(), md.GetVersion()),
 			AppVersion: md.GetAppVersion(),
 			Namespace:  r.GetNamespace(),
+
+			ChartName:        md.GetName(),
+			ChartVersion:     md.GetVersion(),
+			LastDeployed:     lastDeployed,
+			Description:      r.GetInfo().GetDescription(),
+			ChartAnnotations: md.GetAnnotations(),
 		}
 		listReleases = append(listReleases, lr)
 	}
@@ -334,22 +495,53 @@ func formatResult(format string, short bool, result listResult, colWidth uint) (
 	return output, err
 }
 
-func formatText(result listResult, colWidth uint) string {
-	nextOutput := ""
-	if result.Next != "" {
-		nextOutput = fmt.Sprintf("\tnext: %s\n", result.Next)
+// pageText returns the lines printed above a table of releases when there
+// are more of them to list.
+func pageText(next, continueToken string) string {
+	text := ""
+	if next != "" {
+		text = fmt.Sprintf("\tnext: %s\n", next)
+	}
+	if continueToken != "" {
+		text += fmt.Sprintf("\tcontinue: %s\n", continueToken)
 	}
+	return text
+}
+
+func formatText(result listResult, colWidth uint) string {
+	nextOutput := pageText(result.Next, result.Continue)
 
 	table := uitable.New()
 	table.MaxColWidth = colWidth
-	table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE")
+	if result.detail {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE", "RESOURCES", "DRIFT")
+	} else {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE")
+	}
 	for _, lr := range result.Releases {
-		table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace)
+		if lr.Detail == nil {
+			table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace)
+			continue
+		}
+		table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace, lr.Detail.Resources, driftText(lr))
 	}
 
 	return fmt.Sprintf("%s%s", nextOutput, table.String())
 }
 
+// driftText is the DRIFT column of a release. The resources of deleted
+// releases are not compared with the cluster.
+func driftText(lr listRelease) string {
+	switch {
+	case lr.Status == release.Status_DELETED.String():
+		return "-"
+	case len(lr.Detail.Drifted) > 0:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
 func formatTextShort(shortResult []string) string {
 	return strings.Join(shortResult, "\n")
 }
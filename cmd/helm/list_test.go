@@ -17,8 +17,11 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 )
 
 func TestListCmd(t *testing.T) {
@@ -81,7 +85,7 @@ func TestListCmd(t *testing.T) {
 				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
 				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide"}),
 			},
-			expected: regexp.QuoteMeta(`{"Next":"atlas-guide","Releases":[{"Name":"thomas-guide","Revision":1,"Updated":"`) + `([^"]*)` + regexp.QuoteMeta(`","Status":"DEPLOYED","Chart":"foo-0.1.0-beta.1","AppVersion":"","Namespace":"default"}]}
+			expected: regexp.QuoteMeta(`{"Next":"atlas-guide","Releases":[{"Name":"thomas-guide","Revision":1,"Updated":"`) + `([^"]*)` + regexp.QuoteMeta(`","Status":"DEPLOYED","Chart":"foo-0.1.0-beta.1","AppVersion":"","Namespace":"default","ChartName":"foo","ChartVersion":"0.1.0-beta.1","LastDeployed":"`) + `[0-9-]+T[0-9:]+Z` + regexp.QuoteMeta(`","Description":"Release mock"}]}
 `),
 		},
 		{
@@ -95,6 +99,10 @@ func TestListCmd(t *testing.T) {
 Releases:
 - AppVersion: ""
   Chart: foo-0.1.0-beta.1
+  ChartName: foo
+  ChartVersion: 0.1.0-beta.1
+  Description: Release mock
+  LastDeployed: `) + `"?[0-9-]+T[0-9:]+Z"?` + regexp.QuoteMeta(`
   Name: thomas-guide
   Namespace: default
   Revision: 1
@@ -103,6 +111,30 @@ Releases:
 
 `,
 		},
+		{
+			name:  "with custom columns",
+			flags: []string{"--output", "custom-columns=NAME:.name,CHART:.chart.metadata.version,STATUS:.info.status.code,TEAM:.labels.team"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			},
+			expected: "NAME \tCHART       \tSTATUS  \tTEAM  \natlas\t0.1.0-beta.1\tDEPLOYED\t<none>\n",
+		},
+		{
+			name:  "with custom columns and more releases to list",
+			flags: []string{"--max", "1", "--output", "custom-columns=NAME:.name"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			},
+			expected: "^\tnext: thomas-guide\nNAME \natlas\n$",
+		},
+		{
+			name:     "with invalid custom columns",
+			flags:    []string{"--output", "custom-columns=NAME:{.name"},
+			rels:     []*release.Release{},
+			err:      true,
+			expected: "",
+		},
 		{
 			name:  "with short json output",
 			flags: []string{"-q", "--output", "json"},
@@ -229,3 +261,140 @@ Releases: []
 		return newListCmd(c, out)
 	})
 }
+
+func TestListStatusCodes(t *testing.T) {
+	pending := []release.Status_Code{release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK}
+	tests := []struct {
+		name     string
+		list     listCmd
+		expected []release.Status_Code
+	}{
+		{
+			name:     "default",
+			expected: append([]release.Status_Code{release.Status_DEPLOYED, release.Status_FAILED}, pending...),
+		},
+		{
+			name:     "pending",
+			list:     listCmd{pending: true},
+			expected: pending,
+		},
+		{
+			name:     "failed and pending",
+			list:     listCmd{failed: true, pending: true},
+			expected: append([]release.Status_Code{release.Status_FAILED}, pending...),
+		},
+		{
+			name:     "deployed and deleted",
+			list:     listCmd{deployed: true, deleted: true},
+			expected: []release.Status_Code{release.Status_DEPLOYED, release.Status_DELETED},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.list.statusCodes(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: expected statuses %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestListSortField(t *testing.T) {
+	tests := []struct {
+		list     listCmd
+		expected services.ListSort_SortBy
+		err      bool
+	}{
+		{listCmd{}, services.ListSort_NAME, false},
+		{listCmd{byDate: true}, services.ListSort_LAST_RELEASED, false},
+		{listCmd{byChartName: true}, services.ListSort_CHART_NAME, false},
+		{listCmd{sortBy: "revision", byDate: true}, services.ListSort_REVISION, false},
+		{listCmd{sortBy: "chart"}, services.ListSort_CHART_NAME, false},
+		{listCmd{sortBy: "version"}, services.ListSort_UNKNOWN, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.list.sortField()
+		if (err != nil) != tt.err {
+			t.Errorf("%+v: unexpected error %v", tt.list, err)
+		}
+		if got != tt.expected {
+			t.Errorf("%+v: expected to sort by %s, got %s", tt.list, tt.expected, got)
+		}
+	}
+}
+
+func TestListContinueOutput(t *testing.T) {
+	result := listResult{Next: "atlas", Releases: []listRelease{}, Continue: "eyJuIjoiYSJ9"}
+
+	out, err := formatResult("", false, result, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "\tnext: atlas\n\tcontinue: eyJuIjoiYSJ9\n") {
+		t.Errorf("expected the continue token to be printed, got %q", out)
+	}
+
+	out, err = formatResult("json", false, result, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"Continue":"eyJuIjoiYSJ9"`) {
+		t.Errorf("expected the continue token in the JSON output, got %s", out)
+	}
+}
+
+func TestListDetail(t *testing.T) {
+	c := &helm.FakeClient{
+		Rels: []*release.Release{
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas"}),
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			helm.ReleaseMock(&helm.MockReleaseOptions{Name: "carabiner", StatusCode: release.Status_DELETED}),
+		},
+		DriftedResources: map[string][]string{"thomas-guide": {"Secret/fixture"}},
+	}
+
+	tests := []struct {
+		name     string
+		flags    []string
+		expected []string
+		absent   string
+	}{
+		{
+			name:  "table",
+			flags: []string{"--detail", "--all"},
+			expected: []string{
+				`\tNAMESPACE\tRESOURCES\tDRIFT\n`,
+				`\natlas\s.*\t1        \tno   \n`,
+				`\nthomas-guide\s.*\t1        \tyes  \n`,
+				`\ncarabiner\s.*\t1        \t-    \n`,
+			},
+		},
+		{
+			name:     "json",
+			flags:    []string{"--detail", "--output", "json"},
+			expected: []string{`"Name":"atlas",.*"Detail":\{"Resources":1\}`, regexp.QuoteMeta(`"Detail":{"Resources":1,"Drifted":["Secret/fixture"]}`)},
+		},
+		{
+			name:   "without detail",
+			flags:  []string{"--output", "json"},
+			absent: `"Detail"`,
+		},
+	}
+
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		cmd := newListCmd(c, &buf)
+		cmd.ParseFlags(tt.flags)
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		for _, e := range tt.expected {
+			if !regexp.MustCompile(e).MatchString(buf.String()) {
+				t.Errorf("%s: expected %q to match %s", tt.name, buf.String(), e)
+			}
+		}
+		if tt.absent != "" && strings.Contains(buf.String(), tt.absent) {
+			t.Errorf("%s: expected %q not to contain %s", tt.name, buf.String(), tt.absent)
+		}
+		buf.Reset()
+	}
+}
@@ -48,6 +48,12 @@ If no path is given, this will look in the present working directory for a
 Chart.yaml file, and (if found) build the current directory into a chart.
 
 Versioned chart archives are used by Helm package repositories.
+
+The '--version' and '--app-version' flags set the version and the appVersion
+of the packaged chart, leaving the Chart.yaml file in the chart directory as it
+is. This lets a build pipeline stamp the chart with the version it builds:
+
+	$ helm package --version 1.2.3 --app-version "$(git rev-parse --short HEAD)" mychart
 `
 
 type packageCmd struct {
@@ -150,6 +156,9 @@ func (p *packageCmd) run() error {
 	if filepath.Base(path) != ch.Metadata.Name {
 		return fmt.Errorf("directory name (%s) and Chart.yaml name (%s) must match", filepath.Base(path), ch.Metadata.Name)
 	}
+	if err := chartutil.ValidateChartType(ch.Metadata); err != nil {
+		return err
+	}
 
 	if reqs, err := chartutil.LoadRequirements(ch); err == nil {
 		if err := renderutil.CheckDependencies(ch, reqs); err != nil {
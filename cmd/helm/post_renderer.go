@@ -0,0 +1,92 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const postRendererHelp = `
+To modify the rendered manifests before they are used, without forking the
+chart, use '--post-renderer' with the path to an executable, such as a script
+running kustomize. The manifests, hooks included, are written to its standard
+input, and the manifests it writes to its standard output are used instead.
+Each document is preceded by a '# Source:' comment naming its template; keeping
+the comment keeps hooks and resources associated with their templates.
+`
+
+// checkPostRenderer makes sure the post-renderer can be run before anything
+// is rendered.
+func checkPostRenderer(binary string) error {
+	if binary == "" {
+		return nil
+	}
+	if _, err := exec.LookPath(binary); err != nil {
+		return fmt.Errorf("unable to find post-renderer %q: %s", binary, err)
+	}
+	return nil
+}
+
+// releaseManifests returns the manifests and the hooks of a rendered release
+// as a single YAML stream.
+func releaseManifests(rel *release.Release) string {
+	var b bytes.Buffer
+	b.WriteString(rel.Manifest)
+	for _, h := range rel.Hooks {
+		b.WriteString("\n---\n# Source: " + h.Path + "\n")
+		b.WriteString(h.Manifest)
+	}
+	return b.String()
+}
+
+// processManifests runs the rendered manifests of a release through the
+// post-renderer, if any, and then pins their images to digests if
+// resolveDigests is set.
+func processManifests(manifests, postRenderer string, resolveDigests bool) (string, error) {
+	if postRenderer != "" {
+		var err error
+		if manifests, err = postRender(postRenderer, manifests); err != nil {
+			return "", err
+		}
+	}
+	if resolveDigests {
+		return resolveImageDigests(manifests, newImageResolver())
+	}
+	return manifests, nil
+}
+
+// postRender pipes manifests through the post-renderer binary and returns
+// what it writes to its standard output.
+func postRender(binary, manifests string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(binary)
+	cmd.Stdin = strings.NewReader(manifests)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error while running post-renderer %s: %s: %s", binary, err, strings.TrimSpace(stderr.String()))
+	}
+	if strings.TrimSpace(stdout.String()) == "" {
+		return "", fmt.Errorf("post-renderer %s returned no manifests", binary)
+	}
+	return stdout.String(), nil
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"text/template"
-	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
@@ -76,11 +75,19 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	data := map[string]interface{}{
 		"Release":        rel,
 		"ComputedValues": cfgStr,
-		"ReleaseDate":    timeconv.Format(rel.Info.LastDeployed, time.ANSIC),
+		"ReleaseDate":    timeconv.String(rel.Info.LastDeployed),
 	}
 	return tpl(printReleaseTemplate, data, out)
 }
 
+// printWarnings prints the messages of the 'warn' function of the templates
+// rendered for a release.
+func printWarnings(out io.Writer, rel *release.Release) {
+	for _, w := range rel.GetInfo().GetWarnings() {
+		fmt.Fprintf(out, "WARNING: %s\n", w)
+	}
+}
+
 func tpl(t string, vals interface{}, out io.Writer) error {
 	tt, err := template.New("_").Parse(t)
 	if err != nil {
@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const profileHelp = `
+Charts can keep the values of each environment they are deployed to in files
+named values-<profile>.yaml, next to values.yaml. With '--profile staging',
+values-staging.yaml is layered over the default values of the chart, and the
+values given with '--values' and '--set' are layered over both. Files named
+after the profile in the directories given with '--profile-dir' are layered
+over the one of the chart, in order, so that environments can also be kept
+outside of the chart:
+
+	$ helm upgrade web ./mychart --profile staging --profile-dir ./environments
+`
+
+// profileValuesFile returns the name of the values file of a profile.
+func profileValuesFile(profile string) string {
+	return "values-" + profile + ".yaml"
+}
+
+// applyProfile layers the values file of the profile in the chart, and then
+// those in dirs, over the default values of the chart. It is an error if
+// none of them exist.
+func applyProfile(ch *chart.Chart, profile string, dirs []string) error {
+	if profile == "" {
+		return nil
+	}
+	if strings.ContainsAny(profile, `/\`) {
+		return fmt.Errorf("invalid profile %q", profile)
+	}
+	name := profileValuesFile(profile)
+
+	defaults, err := chartutil.ReadValues([]byte(ch.GetValues().GetRaw()))
+	if err != nil {
+		return fmt.Errorf("cannot read the values of the chart: %s", err)
+	}
+
+	var layers [][]byte
+	for _, f := range ch.GetFiles() {
+		if f.TypeUrl == name {
+			layers = append(layers, f.Value)
+		}
+	}
+	for _, dir := range dirs {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		layers = append(layers, data)
+	}
+	if len(layers) == 0 {
+		return fmt.Errorf("profile %q not found: no %s in the chart or the profile directories", profile, name)
+	}
+
+	for _, data := range layers {
+		vals, err := chartutil.ReadValues(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %s", name, err)
+		}
+		defaults = mergeValues(defaults, vals)
+	}
+	raw, err := yaml.Marshal(defaults)
+	if err != nil {
+		return err
+	}
+	debug("Layering %s over the values of %s", name, ch.GetMetadata().GetName())
+	ch.Values = &chart.Config{Raw: string(raw)}
+	return nil
+}
@@ -19,11 +19,13 @@ package main
 import (
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 const rollbackDesc = `
@@ -31,23 +33,51 @@ This command rolls back a release to a previous revision.
 
 The first argument of the rollback command is the name of a release, and the
 second is a revision (version) number. To see revision numbers, run
-'helm history RELEASE'. If you'd like to rollback to the previous release use
-'helm rollback [RELEASE] 0'.
+'helm history RELEASE'. If the revision is 0 or left out, the release is
+rolled back to its most recent revision that was deployed successfully, so
+failed upgrades are skipped:
+
+    $ helm rollback angry-bird
+
+With '--force', resources that cannot be patched are patched again from their
+live state and then replaced. Add '--force-recreate' to delete and create
+again the resources that cannot be replaced either. '--recreate-pods' restarts
+the pods of every workload of the release, while '--recreate-pods-for' only
+restarts the pods of the listed workloads, given as kind/name such as
+deployment/web.
+
+With '--wait', the command waits for the resources of the rolled back release
+to be ready, reporting the ones that are not yet, and '--wait-for-jobs' also
+waits for its Jobs to complete. If the rollback fails, '--cleanup-on-fail'
+deletes the resources that it created.
+
+The rollback deletes the resources of the current revision that the target
+revision does not define. When it skips several revisions, such as failed
+upgrades, add '--prune' to also delete the resources left by those.
+
+With '--dry-run', nothing is changed. Instead, the command prints the revision
+the rollback would create and a unified diff of its manifests against those of
+the deployed revision, which is not the latest one after a failed upgrade.
 `
 
 type rollbackCmd struct {
-	name          string
-	revision      int32
-	dryRun        bool
-	recreate      bool
-	force         bool
-	disableHooks  bool
-	out           io.Writer
-	client        helm.Interface
-	timeout       int64
-	wait          bool
-	description   string
-	cleanupOnFail bool
+	name            string
+	revision        int32
+	dryRun          bool
+	recreate        bool
+	recreatePodsFor []string
+	force           bool
+	forceRecreate   bool
+	disableHooks    bool
+	out             io.Writer
+	client          helm.Interface
+	timeout         int64
+	wait            bool
+	waitForJobs     bool
+	prune           bool
+	description     string
+	setBy           string
+	cleanupOnFail   bool
 }
 
 func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -62,18 +92,25 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		Long:    rollbackDesc,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
-				return err
+			if len(args) != 1 {
+				if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
+					return err
+				}
 			}
 
 			rollback.name = args[0]
 
-			v64, err := strconv.ParseInt(args[1], 10, 32)
-			if err != nil {
-				return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+			if len(args) == 2 {
+				v64, err := strconv.ParseInt(args[1], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+				}
+				rollback.revision = int32(v64)
 			}
-
-			rollback.revision = int32(v64)
+			if err := checkRecreatePodsFor(rollback.recreatePodsFor); err != nil {
+				return err
+			}
+			rollback.wait = rollback.wait || rollback.waitForJobs
 			rollback.client = ensureHelmClient(rollback.client)
 			return rollback.run()
 		},
@@ -81,13 +118,18 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
-	f.BoolVar(&rollback.dryRun, "dry-run", false, "Simulate a rollback")
+	f.BoolVar(&rollback.dryRun, "dry-run", false, "Simulate a rollback and print a diff of the manifests it would deploy")
 	f.BoolVar(&rollback.recreate, "recreate-pods", false, "Performs pods restart for the resource if applicable")
-	f.BoolVar(&rollback.force, "force", false, "Force resource update through delete/recreate if needed")
+	f.StringSliceVar(&rollback.recreatePodsFor, "recreate-pods-for", []string{}, "Only restart the pods of these resources, given as kind/name (can specify multiple or separate them with commas: deployment/web,statefulset/db)")
+	f.BoolVar(&rollback.force, "force", false, "Force resource update by retrying conflicting patches, then replacing the resources that still cannot be patched")
+	f.BoolVar(&rollback.forceRecreate, "force-recreate", false, "With --force, delete and recreate the resources that cannot be replaced either. This interrupts the traffic to recreated Services")
 	f.BoolVar(&rollback.disableHooks, "no-hooks", false, "Prevent hooks from running during rollback")
 	f.Int64Var(&rollback.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&rollback.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&rollback.waitForJobs, "wait-for-jobs", false, "If set, will also wait until all Jobs of the release have completed, also sets --wait flag")
+	f.BoolVar(&rollback.prune, "prune", false, "Also delete the resources left by the revisions between the target and the current one, such as the ones of failed upgrades")
 	f.StringVar(&rollback.description, "description", "", "Specify a description for the release")
+	f.StringVar(&rollback.setBy, "set-by", "", "Identity to record as the user who rolled back the release, instead of the user of the kube context")
 	f.BoolVar(&rollback.cleanupOnFail, "cleanup-on-fail", false, "Allow deletion of new resources created in this rollback when rollback failed")
 
 	// set defaults from environment
@@ -97,22 +139,80 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (r *rollbackCmd) run() error {
-	_, err := r.client.RollbackRelease(
+	stopProgress := func() {}
+	if r.wait && !r.dryRun {
+		progress := &waitProgress{out: r.out, client: r.client, release: r.name}
+		stopProgress = progress.start(waitProgressInterval)
+	}
+	res, err := r.client.RollbackRelease(
 		r.name,
 		helm.RollbackDryRun(r.dryRun),
 		helm.RollbackRecreate(r.recreate),
+		helm.RollbackRecreatePodsFor(r.recreatePodsFor),
 		helm.RollbackForce(r.force),
+		helm.RollbackForceRecreate(r.forceRecreate),
 		helm.RollbackDisableHooks(r.disableHooks),
 		helm.RollbackVersion(r.revision),
 		helm.RollbackTimeout(r.timeout),
 		helm.RollbackWait(r.wait),
+		helm.RollbackWaitForJobs(r.waitForJobs),
+		helm.RollbackPrune(r.prune),
 		helm.RollbackDescription(r.description),
+		helm.RollbackDeployedBy(deployingUser(r.setBy)),
 		helm.RollbackCleanupOnFail(r.cleanupOnFail))
+	stopProgress()
 	if err != nil {
 		return prettyError(err)
 	}
 
+	if r.dryRun {
+		return r.printDryRun(res.GetRelease())
+	}
+
 	fmt.Fprintf(r.out, "Rollback was a success.\n")
 
 	return nil
 }
+
+// printDryRun prints the revision a rollback would create and how its
+// manifests differ from the ones of the deployed revision.
+func (r *rollbackCmd) printDryRun(target *release.Release) error {
+	deployed, err := r.deployedRelease()
+	if err != nil {
+		return prettyError(err)
+	}
+
+	to := fmt.Sprintf("revision %d", r.revision)
+	if r.revision == 0 {
+		to = "its previous successful revision"
+	}
+	if deployed == nil {
+		fmt.Fprintf(r.out, "Rolling back %q to %s would create revision %d. No revision is deployed.\n\n",
+			r.name, to, target.GetVersion())
+		diffManifests(r.out, "", target.GetManifest(), isTerminal(r.out))
+		return nil
+	}
+	fmt.Fprintf(r.out, "Rolling back %q to %s would create revision %d, replacing the deployed revision %d.\n\n",
+		r.name, to, target.GetVersion(), deployed.Version)
+	diffManifests(r.out, deployed.Manifest, target.GetManifest(), isTerminal(r.out))
+	return nil
+}
+
+// deployedRelease returns the deployed revision of the release, or nil if no
+// revision is deployed, as after a failed upgrade that superseded it.
+func (r *rollbackCmd) deployedRelease() (*release.Release, error) {
+	res, err := r.client.ReleaseHistory(r.name, helm.WithMaxHistory(math.MaxInt32))
+	if err != nil {
+		return nil, err
+	}
+	var deployed *release.Release
+	for _, rel := range res.GetReleases() {
+		if rel.Name != r.name || rel.GetInfo().GetStatus().GetCode() != release.Status_DEPLOYED {
+			continue
+		}
+		if deployed == nil || rel.Version > deployed.Version {
+			deployed = rel
+		}
+	}
+	return deployed, nil
+}
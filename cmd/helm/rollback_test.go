@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 func TestRollbackCmd(t *testing.T) {
@@ -45,6 +46,18 @@ func TestRollbackCmd(t *testing.T) {
 			flags:    []string{"--wait"},
 			expected: "Rollback was a success.",
 		},
+		{
+			name:     "rollback a release with wait-for-jobs",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--wait-for-jobs"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:     "rollback a release with prune",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--prune"},
+			expected: "Rollback was a success.",
+		},
 		{
 			name:     "rollback a release with description",
 			args:     []string{"funny-honey", "1"},
@@ -52,8 +65,77 @@ func TestRollbackCmd(t *testing.T) {
 			expected: "Rollback was a success.",
 		},
 		{
-			name: "rollback a release without revision",
-			args: []string{"funny-honey"},
+			name:     "rollback a release with the deploying user",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--set-by", "ci-bot"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:     "rollback a release restarting the pods of some resources",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--recreate-pods-for", "deployment/web"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:  "rollback a release restarting the pods of a resource without a name",
+			args:  []string{"funny-honey", "1"},
+			flags: []string{"--recreate-pods-for", "deployment/"},
+			err:   true,
+		},
+		{
+			name:     "rollback a release with dry-run",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--dry-run"},
+			expected: `^Rolling back "funny-honey" to revision 1 would create revision 4, replacing the deployed revision 3.\n\n--- a/Secret/fixture\n\+\+\+ b/Secret/fixture\n(?s:.*)\nSUMMARY: 0 to add, 1 to change, 0 to remove\n$`,
+			rels: []*release.Release{
+				func() *release.Release {
+					r := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 3})
+					r.Manifest += "data:\n  key: dmFsdWU=\n"
+					return r
+				}(),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 1}),
+			},
+		},
+		{
+			name:  "rollback a release with dry-run to a missing revision",
+			args:  []string{"funny-honey", "2"},
+			flags: []string{"--dry-run"},
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 3})},
+			err:   true,
+		},
+		{
+			name:     "rollback a release without revision",
+			args:     []string{"funny-honey"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:     "rollback a release without revision with dry-run",
+			args:     []string{"funny-honey"},
+			flags:    []string{"--dry-run"},
+			expected: `^Rolling back "funny-honey" to its previous successful revision would create revision 4, replacing the deployed revision 2.\n\nNo changes to the release manifests.\n`,
+			rels: []*release.Release{
+				func() *release.Release {
+					r := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 3, StatusCode: release.Status_FAILED})
+					r.Manifest += "data:\n  key: dmFsdWU=\n"
+					return r
+				}(),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 2}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 1, StatusCode: release.Status_SUPERSEDED}),
+			},
+		},
+		{
+			name:     "rollback a release without a deployed revision with dry-run",
+			args:     []string{"funny-honey"},
+			flags:    []string{"--dry-run"},
+			expected: `^Rolling back "funny-honey" to its previous successful revision would create revision 3. No revision is deployed.\n\n(?s:.*)\nSUMMARY: 1 to add, 0 to change, 0 to remove\n$`,
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 2, StatusCode: release.Status_FAILED}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey", Version: 1, StatusCode: release.Status_SUPERSEDED}),
+			},
+		},
+		{
+			name: "rollback a release with too many arguments",
+			args: []string{"funny-honey", "1", "2"},
 			err:  true,
 		},
 	}
@@ -25,6 +25,8 @@ import (
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
+	"k8s.io/apimachinery/pkg/labels"
+
 	"k8s.io/helm/cmd/helm/search"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/repo"
@@ -35,6 +37,12 @@ Search reads through all of the repositories configured on the system, and
 looks for matches.
 
 Repositories are managed with 'helm repo' commands.
+
+Charts can be selected by the 'annotations' of their Chart.yaml with
+'--annotation', which takes a Kubernetes label selector. As with '--version',
+the latest version of each chart that matches is shown:
+
+	$ helm search --annotation 'team=payments,tier!=db'
 `
 
 // searchMaxScore suggests that any score higher than this is not considered a match.
@@ -44,11 +52,12 @@ type searchCmd struct {
 	out      io.Writer
 	helmhome helmpath.Home
 
-	versions bool
-	regexp   bool
-	version  string
-	colWidth uint
-	output   string
+	versions   bool
+	regexp     bool
+	version    string
+	annotation string
+	colWidth   uint
+	output     string
 }
 
 type chartElement struct {
@@ -75,6 +84,7 @@ func newSearchCmd(out io.Writer) *cobra.Command {
 	f.BoolVarP(&sc.regexp, "regexp", "r", false, "Use regular expressions for searching")
 	f.BoolVarP(&sc.versions, "versions", "l", false, "Show the long listing, with each version of each chart on its own line")
 	f.StringVarP(&sc.version, "version", "v", "", "Search using semantic versioning constraints")
+	f.StringVar(&sc.annotation, "annotation", "", "Search for charts whose annotations match the selector, such as tier=frontend")
 	f.UintVar(&sc.colWidth, "col-width", 60, "Specifies the max column width of output")
 	bindOutputFlag(cmd, &sc.output)
 
@@ -108,13 +118,23 @@ func (s *searchCmd) run(args []string) error {
 }
 
 func (s *searchCmd) applyConstraint(res []*search.Result) ([]*search.Result, error) {
-	if len(s.version) == 0 {
+	if len(s.version) == 0 && len(s.annotation) == 0 {
 		return res, nil
 	}
 
-	constraint, err := semver.NewConstraint(s.version)
-	if err != nil {
-		return res, fmt.Errorf("an invalid version/constraint format: %s", err)
+	var constraint *semver.Constraints
+	if len(s.version) > 0 {
+		var err error
+		if constraint, err = semver.NewConstraint(s.version); err != nil {
+			return res, fmt.Errorf("an invalid version/constraint format: %s", err)
+		}
+	}
+	selector := labels.Everything()
+	if len(s.annotation) > 0 {
+		var err error
+		if selector, err = labels.Parse(s.annotation); err != nil {
+			return res, fmt.Errorf("invalid annotation selector %q: %s", s.annotation, err)
+		}
 	}
 
 	data := res[:0]
@@ -123,8 +143,11 @@ func (s *searchCmd) applyConstraint(res []*search.Result) ([]*search.Result, err
 		if _, found := foundNames[r.Name]; found {
 			continue
 		}
+		if !selector.Matches(labels.Set(r.Chart.Annotations)) {
+			continue
+		}
 		v, err := semver.NewVersion(r.Chart.Version)
-		if err != nil || constraint.Check(v) {
+		if constraint == nil || err != nil || constraint.Check(v) {
 			data = append(data, r)
 			if !s.versions {
 				foundNames[r.Name] = true // If user hasn't requested all versions, only show the latest that matches
@@ -152,7 +175,7 @@ func (s *searchCmd) buildIndex() (*search.Index, error) {
 			continue
 		}
 
-		i.AddRepo(n, ind, s.versions || len(s.version) > 0)
+		i.AddRepo(n, ind, s.versions || len(s.version) > 0 || len(s.annotation) > 0)
 	}
 	return i, nil
 }
@@ -68,6 +68,18 @@ func TestSearchCmd(t *testing.T) {
 			flags:    []string{"--versions", "--version", ">= 0.1"},
 			expected: "NAME          \tCHART VERSION\tAPP VERSION\tDESCRIPTION                    \ntesting/alpine\t0.2.0        \t2.3.4      \tDeploy a basic Alpine Linux pod\ntesting/alpine\t0.1.0        \t1.2.3      \tDeploy a basic Alpine Linux pod",
 		},
+		{
+			name:     "search for 'alpine' with annotation selector, expect one match with version 0.1.0",
+			args:     []string{"alpine"},
+			flags:    []string{"--annotation", "tier=base"},
+			expected: "NAME          \tCHART VERSION\tAPP VERSION\tDESCRIPTION                    \ntesting/alpine\t0.1.0        \t1.2.3      \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:     "search for 'alpine' with unmatched annotation selector, expect no matches",
+			args:     []string{"alpine"},
+			flags:    []string{"--annotation", "tier=db"},
+			expected: "No results found",
+		},
 		{
 			name:     "search for 'syzygy', expect no matches",
 			args:     []string{"syzygy"},
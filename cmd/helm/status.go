@@ -17,18 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
 	"github.com/spf13/cobra"
 
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -38,17 +45,118 @@ The status consists of:
 - last deployment time
 - k8s namespace in which the release lives
 - state of the release (can be: UNKNOWN, DEPLOYED, DELETED, SUPERSEDED, FAILED or DELETING)
-- list of resources that this release consists of, sorted by kind
+- name and version of the chart the release was deployed from
+- digest of the user-supplied values, to tell whether they changed
+- list of resources that this release consists of, along with how many of
+  their replicas are desired and ready in the cluster
+- hooks run for the release, with the phase of their latest execution
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+With '--logs', the last lines of the logs of every pod belonging to a FAILED
+release or its hooks are printed after the table output.
+
+With '--resources kind=KIND[,KIND...]', only resources of the given kinds are
+listed in the RESOURCES section.
+
+With '--show-resources', the phase and conditions of every resource in the
+release manifest are shown as well. Use '--output raw' to print the resource
+list as stored by Tiller instead of querying the cluster.
+
+With '--watch', the command keeps running and prints the status again every
+time it changes, until interrupted. The ages of the resources growing does not
+count as a change.
+
+With '--exit-code', the exit code of the command reflects the state of the
+release, so that scripts can act on it without parsing the output:
+
+	0: DEPLOYED or SUPERSEDED
+	1: FAILED
+	2: PENDING_INSTALL, PENDING_UPGRADE or PENDING_ROLLBACK
+	3: DELETED or DELETING
+	4: UNKNOWN
+
+With '--compare-to', the status, chart, values digest and deployment time of
+the revision given by '--revision' (or the latest revision) and of the revision
+to compare to are shown side by side:
+
+	$ helm status --revision 3 --compare-to 5 my-release
+
+It cannot be combined with '--exit-code'.
+
+When more than one release is named, or releases are picked by their labels
+with '--selector', as with 'helm list', a summary of each release is shown in a
+single table or document. With '--exit-code', the highest exit code of all
+releases is used.
+
+With '--output custom-columns=HEADER:PATH,...', a table of the given fields of
+each release is shown instead. The paths are JSONPath expressions, as with
+kubectl, applied to the release with the field names of its protobuf
+definition:
+
+	$ helm status -o custom-columns=NAME:.name,CHART:.chart.metadata.version,STATUS:.info.status.code my-release
 `
 
+// outputRaw prints the status with the resource list stored by Tiller.
+const outputRaw outputFormat = "raw"
+
+// statusWatchInterval is how often the status is refreshed with --watch.
+const statusWatchInterval = 2 * time.Second
+
+// statusKubeClient fetches the live state of the resources in a manifest.
+type statusKubeClient interface {
+	ResourceStatuses(namespace string, reader io.Reader) ([]kube.ResourceStatus, error)
+	PodLogs(namespace string, reader io.Reader, tailLines int64) ([]kube.PodLog, error)
+}
+
 type statusCmd struct {
 	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
-	outfmt  string
+	// releases and selector pick the releases summarized when more than
+	// one release is shown.
+	releases      []string
+	selector      string
+	out           io.Writer
+	client        helm.Interface
+	kubeClient    statusKubeClient
+	version       int32
+	outfmt        string
+	showResources bool
+	watch         bool
+	exitCode      bool
+	logs          bool
+	logLines      int64
+	compareTo     int32
+	// columns are those of a custom-columns output format.
+	columns  []customColumn
+	colWidth uint
+	// kinds restricts the resources shown to the given kinds, keyed by their
+	// lower case name. All resources are shown if it is nil.
+	kinds map[string]bool
+	// now is the time the ages of the resources are computed from. The
+	// current time is used if it is zero.
+	now time.Time
+}
+
+// statusExitError is returned by 'helm status --exit-code' when the state of
+// the release maps to a non-zero exit code.
+type statusExitError struct {
+	error
+	code int
+}
+
+// statusExitCode maps a release status to the exit code of 'helm status --exit-code'.
+func statusExitCode(code release.Status_Code) int {
+	switch code {
+	case release.Status_DEPLOYED, release.Status_SUPERSEDED:
+		return 0
+	case release.Status_FAILED:
+		return 1
+	case release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+		return 2
+	case release.Status_DELETED, release.Status_DELETING:
+		return 3
+	}
+	return 4
 }
 
 func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -57,16 +165,45 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 		client: client,
 	}
 
+	var resources string
+
 	cmd := &cobra.Command{
-		Use:     "status [flags] RELEASE_NAME",
+		Use:     "status [flags] RELEASE_NAME [...]",
 		Short:   "Displays the status of the named release",
 		Long:    statusHelp,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if len(args) == 0 && status.selector == "" {
 				return errReleaseRequired
 			}
-			status.release = args[0]
+			if len(args) == 1 && status.selector == "" {
+				status.release = args[0]
+			} else {
+				if status.version != 0 || status.showResources || status.logs || status.compareTo != 0 || resources != "" {
+					return errors.New("--revision, --compare-to, --resources, --show-resources and --logs can only be used with a single release")
+				}
+				status.releases = args
+			}
+			if status.compareTo != 0 && status.exitCode {
+				return errors.New("--compare-to cannot be used with --exit-code")
+			}
+			if resources != "" {
+				kinds, err := parseResourceKinds(resources)
+				if err != nil {
+					return err
+				}
+				status.kinds = kinds
+			}
+			if isCustomColumns(status.outfmt) {
+				if status.compareTo != 0 || status.showResources {
+					return errors.New("--compare-to and --show-resources cannot be used with custom-columns output")
+				}
+				columns, err := parseCustomColumns(status.outfmt)
+				if err != nil {
+					return err
+				}
+				status.columns = columns
+			}
 			if status.client == nil {
 				status.client = newClient()
 			}
@@ -77,7 +214,16 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&status.version, "revision", 0, "If set, display the status of the named release with revision")
-	bindOutputFlag(cmd, &status.outfmt)
+	f.StringVarP(&status.selector, "selector", "l", "", "Show the status of all deployed, failed or pending releases whose labels match this selector, such as team=payments")
+	f.Int32Var(&status.compareTo, "compare-to", 0, "If set, show a summary of the named release's revision side by side with this revision")
+	f.StringVar(&resources, "resources", "", "Only list resources of the given kinds in the RESOURCES section, as kind=KIND[,KIND...]")
+	f.BoolVar(&status.showResources, "show-resources", false, "If set, also show the phase and conditions of the release's resources")
+	f.BoolVarP(&status.watch, "watch", "w", false, "After printing the status, keep watching it and print it again whenever it changes")
+	f.BoolVar(&status.exitCode, "exit-code", false, "Exit with a code reflecting the release state (0: deployed, 1: failed, 2: pending, 3: deleted, 4: unknown)")
+	f.BoolVar(&status.logs, "logs", false, "If the release has failed, print the logs of the pods belonging to the release and its hooks")
+	f.Int64Var(&status.logLines, "log-lines", 20, "Number of lines to print from the end of each container log with --logs")
+	f.StringVarP(&status.outfmt, outputFlag, "o", string(outputTable), fmt.Sprintf("Prints the output in the specified format. Allowed values: %s, %s, %s, %s or %sHEADER:PATH,...", outputTable, outputJSON, outputYAML, outputRaw, customColumnsPrefix))
+	f.UintVar(&status.colWidth, "col-width", 60, "Specifies the max column width of custom-columns output")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -86,49 +232,526 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (s *statusCmd) run() error {
+	if s.watch {
+		return s.watchStatus(statusWatchInterval, nil)
+	}
+	statuses, err := s.render(s.out)
+	if err != nil {
+		return err
+	}
+	if s.exitCode {
+		return checkExitCode(statuses)
+	}
+	return nil
+}
+
+// checkExitCode returns a statusExitError for the release with the highest
+// exit code, if any.
+func checkExitCode(statuses []*services.GetReleaseStatusResponse) error {
+	var worst *services.GetReleaseStatusResponse
+	exitCode := 0
+	for _, res := range statuses {
+		if c := statusExitCode(res.Info.Status.Code); c > exitCode {
+			worst, exitCode = res, c
+		}
+	}
+	if worst == nil {
+		return nil
+	}
+	return statusExitError{
+		error: fmt.Errorf("release %s is %s", worst.Name, worst.Info.Status.Code),
+		code:  exitCode,
+	}
+}
+
+// render fetches the current status of the releases and writes it to out.
+func (s *statusCmd) render(out io.Writer) ([]*services.GetReleaseStatusResponse, error) {
+	if s.release == "" {
+		return s.renderSummary(out)
+	}
+	if s.compareTo != 0 {
+		return nil, s.renderComparison(out)
+	}
+
 	res, err := s.client.ReleaseStatus(s.release, helm.StatusReleaseVersion(s.version))
+	if err != nil {
+		return nil, prettyError(err)
+	}
+	statuses := []*services.GetReleaseStatusResponse{res}
+	if s.columns != nil {
+		return statuses, s.writeCustomColumns(out, statuses)
+	}
+	if s.kinds != nil {
+		res.Info.Status.Resources = filterStoredResources(res.Info.Status.Resources, s.kinds)
+	}
+
+	format := outputFormat(s.outfmt)
+	if format == outputRaw {
+		PrintStatus(out, res)
+		return statuses, nil
+	}
+
+	sw := &statusWriter{status: res, wide: s.showResources, now: s.now}
+	if s.showResources || format == outputTable {
+		resources, err := s.liveResources(res.Namespace)
+		switch {
+		case err == nil:
+			sw.resources = filterResourceStatuses(resources, s.kinds)
+		case s.showResources:
+			return nil, err
+		default:
+			// The cluster may not be reachable from here even though Tiller
+			// is, so fall back to the resource list stored by Tiller.
+			debug("%s, showing stored resource list", err)
+		}
+	}
+
+	if err := write(out, sw, format); err != nil {
+		return nil, err
+	}
+	if s.logs && format == outputTable && res.Info.Status.Code == release.Status_FAILED {
+		if err := s.printLogs(out, res.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
+// printLogs prints the tail of the logs of the pods belonging to the release
+// and its hooks.
+func (s *statusCmd) printLogs(out io.Writer, namespace string) error {
+	content, err := s.client.ReleaseContent(s.release, helm.ContentReleaseVersion(s.version))
 	if err != nil {
 		return prettyError(err)
 	}
+	manifests := []string{content.Release.Manifest}
+	for _, h := range content.Release.Hooks {
+		manifests = append(manifests, h.Manifest)
+	}
+
+	if s.kubeClient == nil {
+		s.kubeClient = newKubeClient()
+	}
+	logs, err := s.kubeClient.PodLogs(namespace, strings.NewReader(strings.Join(manifests, "\n---\n")), s.logLines)
+	if err != nil {
+		return fmt.Errorf("could not get pod logs: %s", err)
+	}
+
+	fmt.Fprintln(out, "LOGS:")
+	for _, l := range logs {
+		fmt.Fprintf(out, "==> %s/%s <==\n%s\n", l.Pod, l.Container, strings.TrimRight(l.Log, "\n"))
+	}
+	return nil
+}
+
+// renderSummary writes a summary of the status of several releases to out.
+func (s *statusCmd) renderSummary(out io.Writer) ([]*services.GetReleaseStatusResponse, error) {
+	names := s.releases
+	if s.selector != "" {
+		res, err := s.client.ListReleases(
+			helm.ReleaseListSelector(s.selector),
+			helm.ReleaseListSort(int32(services.ListSort_NAME)),
+			helm.ReleaseListStatuses([]release.Status_Code{
+				release.Status_DEPLOYED,
+				release.Status_FAILED,
+				release.Status_PENDING_INSTALL,
+				release.Status_PENDING_UPGRADE,
+				release.Status_PENDING_ROLLBACK,
+			}),
+		)
+		if err != nil {
+			return nil, prettyError(err)
+		}
+		for _, r := range res.GetReleases() {
+			names = append(names, r.Name)
+		}
+	}
+
+	statuses := []*services.GetReleaseStatusResponse{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		res, err := s.client.ReleaseStatus(name)
+		if err != nil {
+			return nil, prettyError(err)
+		}
+		statuses = append(statuses, res)
+	}
+
+	if s.columns != nil {
+		return statuses, s.writeCustomColumns(out, statuses)
+	}
+	format := outputFormat(s.outfmt)
+	if format == outputRaw {
+		format = outputTable
+	}
+	return statuses, write(out, &statusSummaryWriter{statuses}, format)
+}
+
+// writeCustomColumns writes the custom columns of the releases whose status
+// was fetched to out.
+func (s *statusCmd) writeCustomColumns(out io.Writer, statuses []*services.GetReleaseStatusResponse) error {
+	rels := make([]*release.Release, 0, len(statuses))
+	for _, res := range statuses {
+		content, err := s.client.ReleaseContent(res.Name, helm.ContentReleaseVersion(res.Version))
+		if err != nil {
+			return prettyError(err)
+		}
+		rels = append(rels, content.Release)
+	}
+	return writeCustomColumns(out, s.columns, rels, s.colWidth)
+}
+
+// renderComparison writes a summary of two revisions of the release to out.
+func (s *statusCmd) renderComparison(out io.Writer) error {
+	var revisions []*revisionSummary
+	for _, version := range []int32{s.version, s.compareTo} {
+		res, err := s.client.ReleaseContent(s.release, helm.ContentReleaseVersion(version))
+		if err != nil {
+			return prettyError(err)
+		}
+		summary, err := summarizeRevision(res.Release)
+		if err != nil {
+			return err
+		}
+		revisions = append(revisions, summary)
+	}
+
+	format := outputFormat(s.outfmt)
+	if format == outputRaw {
+		format = outputTable
+	}
+	return write(out, &revisionComparisonWriter{revisions}, format)
+}
+
+// watchStatus renders the status every interval, printing it whenever it
+// differs from the last one printed. It returns once stop is closed; a nil
+// stop channel watches until the process is interrupted.
+func (s *statusCmd) watchStatus(interval time.Duration, stop <-chan struct{}) error {
+	var last string
+	printed := time.Now()
+	for {
+		// The ages of the resources are computed as of the last print, so
+		// that time passing alone does not count as a change.
+		s.now = printed
+		current, err := s.renderString()
+		if err != nil {
+			return err
+		}
+		if current != last {
+			if last != "" {
+				// Print the change with up to date ages.
+				printed = time.Now()
+				s.now = printed
+				if current, err = s.renderString(); err != nil {
+					return err
+				}
+				s.writeWatchSeparator()
+			}
+			if _, err := io.WriteString(s.out, current); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// renderString renders the status to a string.
+func (s *statusCmd) renderString() (string, error) {
+	var buf bytes.Buffer
+	if _, err := s.render(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeWatchSeparator separates consecutive renders in a way that keeps the
+// output parseable for the selected format.
+func (s *statusCmd) writeWatchSeparator() {
+	if s.columns != nil {
+		fmt.Fprintln(s.out)
+		return
+	}
+	switch outputFormat(s.outfmt) {
+	case outputYAML:
+		fmt.Fprintln(s.out, "---")
+	case outputTable:
+		fmt.Fprintln(s.out)
+	}
+}
+
+// liveResources fetches the live state of the resources in the release
+// manifest. It returns nil if the release has no manifest.
+func (s *statusCmd) liveResources(namespace string) ([]kube.ResourceStatus, error) {
+	content, err := s.client.ReleaseContent(s.release, helm.ContentReleaseVersion(s.version))
+	if err != nil {
+		return nil, prettyError(err)
+	}
+	if strings.TrimSpace(content.Release.Manifest) == "" {
+		return nil, nil
+	}
+	if s.kubeClient == nil {
+		s.kubeClient = newKubeClient()
+	}
+	statuses, err := s.kubeClient.ResourceStatuses(namespace, strings.NewReader(content.Release.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("could not get live resource status: %s", err)
+	}
+	return statuses, nil
+}
+
+// parseResourceKinds parses the value of the --resources flag.
+func parseResourceKinds(value string) (map[string]bool, error) {
+	const prefix = "kind="
+	if !strings.HasPrefix(value, prefix) || len(value) == len(prefix) {
+		return nil, fmt.Errorf("invalid --resources value %q: expected kind=KIND[,KIND...]", value)
+	}
+	kinds := map[string]bool{}
+	for _, kind := range strings.Split(value[len(prefix):], ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			kinds[strings.ToLower(kind)] = true
+		}
+	}
+	return kinds, nil
+}
+
+// filterResourceStatuses returns the statuses of the resources whose kind is
+// in kinds. A nil kinds returns all statuses.
+func filterResourceStatuses(statuses []kube.ResourceStatus, kinds map[string]bool) []kube.ResourceStatus {
+	if kinds == nil || statuses == nil {
+		return statuses
+	}
+	filtered := []kube.ResourceStatus{}
+	for _, rs := range statuses {
+		if kinds[strings.ToLower(rs.Kind)] {
+			filtered = append(filtered, rs)
+		}
+	}
+	return filtered
+}
 
-	return write(s.out, &statusWriter{res}, outputFormat(s.outfmt))
+// filterStoredResources removes the sections of the resource list stored by
+// Tiller whose kind is not in kinds. Sections start with a "==> VERSION/KIND"
+// header; the rows of the MISSING section are filtered one by one.
+func filterStoredResources(resources string, kinds map[string]bool) string {
+	var buf bytes.Buffer
+	for _, section := range strings.SplitAfter(resources, "\n\n") {
+		header := strings.SplitN(section, "\n", 2)[0]
+		if !strings.HasPrefix(header, "==> ") {
+			buf.WriteString(section)
+			continue
+		}
+		if header == "==> MISSING" {
+			var kept []string
+			for i, line := range strings.Split(section, "\n") {
+				// Keep the section and column headers as well as blank lines.
+				fields := strings.Fields(line)
+				if i < 2 || len(fields) == 0 || kinds[strings.ToLower(resourceKind(fields[0]))] {
+					kept = append(kept, line)
+				}
+			}
+			buf.WriteString(strings.Join(kept, "\n"))
+			continue
+		}
+		if kinds[strings.ToLower(resourceKind(strings.TrimPrefix(header, "==> ")))] {
+			buf.WriteString(section)
+		}
+	}
+	return buf.String()
+}
+
+// resourceKind returns the kind in a "VERSION/KIND" or "VERSION/KIND(related)"
+// resource type.
+func resourceKind(typ string) string {
+	typ = strings.TrimSuffix(typ, "(related)")
+	return typ[strings.LastIndex(typ, "/")+1:]
 }
 
 type statusWriter struct {
-	status *services.GetReleaseStatusResponse
+	status    *services.GetReleaseStatusResponse
+	resources []kube.ResourceStatus
+	// wide adds the phase and conditions of each resource to the output.
+	wide bool
+	// now is the time the ages of the resources are computed from. The
+	// current time is used if it is zero.
+	now time.Time
+}
+
+// statusWithResources is the structured output of a release status along with
+// the live state of its resources.
+type statusWithResources struct {
+	*services.GetReleaseStatusResponse
+	LiveResources []kube.ResourceStatus `json:"live_resources"`
 }
 
 func (s *statusWriter) WriteTable(out io.Writer) error {
-	PrintStatus(out, s.status)
+	printStatus(out, s.status, s.resources, s.wide, s.now)
 	// There is no error handling here due to backwards compatibility with
 	// PrintStatus
 	return nil
 }
 
 func (s *statusWriter) WriteJSON(out io.Writer) error {
-	return encodeJSON(out, s.status)
+	return encodeJSON(out, s.structured())
 }
 
 func (s *statusWriter) WriteYAML(out io.Writer) error {
-	return encodeYAML(out, s.status)
+	return encodeYAML(out, s.structured())
+}
+
+func (s *statusWriter) structured() interface{} {
+	if !s.wide {
+		return s.status
+	}
+	return &statusWithResources{s.status, s.resources}
+}
+
+type statusSummaryWriter struct {
+	statuses []*services.GetReleaseStatusResponse
+}
+
+func (s *statusSummaryWriter) WriteTable(out io.Writer) error {
+	tbl := uitable.New()
+	tbl.MaxColWidth = 60
+	tbl.AddRow("NAME", "NAMESPACE", "STATUS", "LAST DEPLOYED")
+	for _, res := range s.statuses {
+		deployed := ""
+		if res.Info.LastDeployed != nil {
+			deployed = timeconv.String(res.Info.LastDeployed)
+		}
+		tbl.AddRow(res.Name, res.Namespace, res.Info.Status.Code, deployed)
+	}
+	return encodeTable(out, tbl)
+}
+
+func (s *statusSummaryWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, s.statuses)
+}
+
+func (s *statusSummaryWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, s.statuses)
+}
+
+// revisionSummary is the part of a release revision that is compared by
+// 'helm status --compare-to'.
+type revisionSummary struct {
+	Revision     int32  `json:"revision"`
+	Status       string `json:"status"`
+	Chart        string `json:"chart"`
+	ChartVersion string `json:"chart_version"`
+	ValuesDigest string `json:"values_digest"`
+	LastDeployed string `json:"last_deployed,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+func summarizeRevision(rel *release.Release) (*revisionSummary, error) {
+	digest, err := releaseutil.ValuesDigest(rel)
+	if err != nil {
+		return nil, err
+	}
+	summary := &revisionSummary{
+		Revision:     rel.Version,
+		Status:       rel.GetInfo().GetStatus().GetCode().String(),
+		Chart:        rel.GetChart().GetMetadata().GetName(),
+		ChartVersion: rel.GetChart().GetMetadata().GetVersion(),
+		ValuesDigest: digest,
+		Description:  rel.GetInfo().GetDescription(),
+	}
+	if deployed := rel.GetInfo().GetLastDeployed(); deployed != nil {
+		summary.LastDeployed = timeconv.String(deployed)
+	}
+	return summary, nil
+}
+
+type revisionComparisonWriter struct {
+	revisions []*revisionSummary
+}
+
+func (r *revisionComparisonWriter) WriteTable(out io.Writer) error {
+	tbl := uitable.New()
+	tbl.MaxColWidth = 60
+	header := []interface{}{"REVISION"}
+	status := []interface{}{"STATUS"}
+	chart := []interface{}{"CHART"}
+	digest := []interface{}{"VALUES DIGEST"}
+	deployed := []interface{}{"LAST DEPLOYED"}
+	description := []interface{}{"DESCRIPTION"}
+	for _, rev := range r.revisions {
+		header = append(header, rev.Revision)
+		status = append(status, rev.Status)
+		chart = append(chart, fmt.Sprintf("%s-%s", rev.Chart, rev.ChartVersion))
+		// The first 12 hex digits are enough to tell the values apart.
+		digest = append(digest, rev.ValuesDigest[:len("sha256:")+12])
+		deployed = append(deployed, rev.LastDeployed)
+		description = append(description, rev.Description)
+	}
+	tbl.AddRow(header...)
+	tbl.AddRow(status...)
+	tbl.AddRow(chart...)
+	tbl.AddRow(digest...)
+	tbl.AddRow(deployed...)
+	tbl.AddRow(description...)
+	return encodeTable(out, tbl)
+}
+
+func (r *revisionComparisonWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, r.revisions)
+}
+
+func (r *revisionComparisonWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, r.revisions)
 }
 
 // PrintStatus prints out the status of a release. Shared because also used by
 // install / upgrade
 func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
+	printStatus(out, res, nil, false, time.Time{})
+}
+
+// printStatus prints the status of a release, using the live state of its
+// resources in place of the stored resource list when it is available. The
+// ages of the resources are computed from now, or from the current time if it
+// is zero.
+func printStatus(out io.Writer, res *services.GetReleaseStatusResponse, live []kube.ResourceStatus, wide bool, now time.Time) {
 	if res.Info.LastDeployed != nil {
 		fmt.Fprintf(out, "LAST DEPLOYED: %s\n", timeconv.String(res.Info.LastDeployed))
 	}
+	if res.Info.DeployedBy != "" {
+		fmt.Fprintf(out, "DEPLOYED BY: %s\n", res.Info.DeployedBy)
+	}
 	fmt.Fprintf(out, "NAMESPACE: %s\n", res.Namespace)
 	fmt.Fprintf(out, "STATUS: %s\n", res.Info.Status.Code)
+	if res.Info.Description != "" {
+		fmt.Fprintf(out, "DESCRIPTION: %s\n", res.Info.Description)
+	}
+	if res.Chart != "" {
+		fmt.Fprintf(out, "CHART: %s-%s\n", res.Chart, res.ChartVersion)
+	}
+	if res.ValuesDigest != "" {
+		fmt.Fprintf(out, "VALUES DIGEST: %s\n", res.ValuesDigest)
+	}
 	fmt.Fprintf(out, "\n")
-	if len(res.Info.Status.Resources) > 0 {
+	if live != nil {
+		fmt.Fprintf(out, "RESOURCES:\n%s\n\n", formatResourceStatuses(live, wide, now))
+	} else if len(res.Info.Status.Resources) > 0 {
 		re := regexp.MustCompile("  +")
 
 		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.TabIndent)
 		fmt.Fprintf(w, "RESOURCES:\n%s\n", re.ReplaceAllString(res.Info.Status.Resources, "\t"))
 		w.Flush()
 	}
+	if len(res.Info.Status.HookExecutions) > 0 {
+		fmt.Fprintf(out, "HOOKS:\n%s\n\n", formatHookExecutions(res.Info.Status.HookExecutions))
+	}
 	if res.Info.Status.LastTestSuiteRun != nil {
 		lastRun := res.Info.Status.LastTestSuiteRun
 		fmt.Fprintf(out, "TEST SUITE:\n%s\n%s\n\n%s\n",
@@ -157,3 +780,64 @@ func formatTestResults(results []*release.TestRun) string {
 	}
 	return tbl.String()
 }
+
+func formatHookExecutions(executions []*release.HookExecution) string {
+	tbl := uitable.New()
+	tbl.MaxColWidth = 50
+	tbl.AddRow("HOOK", "KIND", "EVENT", "PHASE", "STARTED", "COMPLETED")
+	for _, e := range executions {
+		var started, completed string
+		if e.StartedAt != nil {
+			started = timeconv.String(e.StartedAt)
+		}
+		if e.CompletedAt != nil {
+			completed = timeconv.String(e.CompletedAt)
+		}
+		tbl.AddRow(e.Name, e.Kind, e.Event, e.Phase, started, completed)
+	}
+	return tbl.String()
+}
+
+func formatResourceStatuses(statuses []kube.ResourceStatus, wide bool, now time.Time) string {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	tbl := uitable.New()
+	tbl.MaxColWidth = 60
+	if wide {
+		tbl.AddRow("KIND", "NAME", "DESIRED", "READY", "AGE", "STATUS", "CONDITIONS")
+	} else {
+		tbl.AddRow("KIND", "NAME", "DESIRED", "READY", "AGE")
+	}
+	for _, rs := range statuses {
+		desired, ready := "-", "-"
+		if rs.Scalable {
+			desired, ready = fmt.Sprint(rs.Desired), fmt.Sprint(rs.Ready)
+		}
+		age := "<unknown>"
+		switch {
+		case rs.Missing:
+			age = "<missing>"
+		case !rs.Created.IsZero():
+			age = duration.HumanDuration(now.Sub(rs.Created))
+		}
+		if !wide {
+			tbl.AddRow(rs.Kind, rs.Name, desired, ready, age)
+			continue
+		}
+
+		status := rs.Phase
+		switch {
+		case rs.Missing:
+			status = "Missing"
+		case status == "":
+			status = "-"
+		}
+		conditions := strings.Join(rs.Conditions, ",")
+		if conditions == "" {
+			conditions = "-"
+		}
+		tbl.AddRow(rs.Kind, rs.Name, desired, ready, age, status, conditions)
+	}
+	return tbl.String()
+}
@@ -17,15 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -46,6 +54,30 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get status of a release with a description",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("DEPLOYED\nDESCRIPTION: ticket-1234 canary bump\n\n"),
+			rels: []*release.Release{
+				func() *release.Release {
+					rel := releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})
+					rel.Info.Description = "ticket-1234 canary bump"
+					return rel
+				}(),
+			},
+		},
+		{
+			name:     "get status of a release with the deploying user",
+			args:     []string{"flummoxed-chickadee"},
+			expected: fmt.Sprintf("LAST DEPLOYED: %s\nDEPLOYED BY: ci-bot\nNAMESPACE: \nSTATUS: DEPLOYED\n\n", dateString),
+			rels: []*release.Release{
+				func() *release.Release {
+					rel := releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})
+					rel.Info.DeployedBy = "ci-bot"
+					return rel
+				}(),
+			},
+		},
 		{
 			name:     "get status of a deployed release with notes",
 			args:     []string{"flummoxed-chickadee"},
@@ -92,6 +124,60 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get status of a deployed release with exit code",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--exit-code"},
+			expected: outputWithStatus("DEPLOYED\n\n"),
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code: release.Status_DEPLOYED,
+				}),
+			},
+		},
+		{
+			name:     "get status of a failed release with exit code",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--exit-code"},
+			expected: outputWithStatus("FAILED\n\n"),
+			err:      true,
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code: release.Status_FAILED,
+				}),
+			},
+		},
+		{
+			name: "get status of a release with a failed hook",
+			args: []string{"flummoxed-chickadee"},
+			expected: outputWithStatus(
+				"PENDING_INSTALL\n\nHOOKS:\n" +
+					"HOOK (.*)\tKIND\tEVENT (.*)\tPHASE (.*)\tSTARTED (.*)\tCOMPLETED (.*)\n" +
+					fmt.Sprintf("db-migrate\tJob \tPRE_INSTALL\tFAILED (.*)\t%s\t%s\n", dateString, dateString) +
+					fmt.Sprintf("db-seed (.*)\tJob \tPRE_INSTALL\tRUNNING\t%s\t(.*)\n", dateString)),
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code: release.Status_PENDING_INSTALL,
+					HookExecutions: []*release.HookExecution{
+						{
+							Name:        "db-migrate",
+							Kind:        "Job",
+							Event:       release.Hook_PRE_INSTALL,
+							Phase:       release.HookExecution_FAILED,
+							StartedAt:   &date,
+							CompletedAt: &date,
+						},
+						{
+							Name:      "db-seed",
+							Kind:      "Job",
+							Event:     release.Hook_PRE_INSTALL,
+							Phase:     release.HookExecution_RUNNING,
+							StartedAt: &date,
+						},
+					},
+				}),
+			},
+		},
 		{
 			name: "get status of a deployed release with test suite",
 			args: []string{"flummoxed-chickadee"},
@@ -125,6 +211,91 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get status of multiple releases",
+			args:     []string{"flummoxed-chickadee", "giddy-gazelle"},
+			expected: "NAME (.*)\tNAMESPACE\tSTATUS  \tLAST DEPLOYED (.*)\nflummoxed-chickadee\t (.*)\tDEPLOYED\t" + dateString + "\ngiddy-gazelle (.*)\t (.*)\tFAILED (.*)\t" + dateString + "\n",
+			rels:     []*release.Release{releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}), namedReleaseMockWithStatus("giddy-gazelle", &release.Status{Code: release.Status_FAILED})},
+		},
+		{
+			name:     "get status of releases matching a selector",
+			flags:    []string{"--selector", "team=payments"},
+			expected: "NAME (.*)\tNAMESPACE\tSTATUS  \tLAST DEPLOYED (.*)\nflummoxed-chickadee\t (.*)\tDEPLOYED\t" + dateString + "\ngiddy-gazelle (.*)\t (.*)\tFAILED (.*)\t" + dateString + "\n$",
+			rels: []*release.Release{
+				labeled(releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}), "team=payments"),
+				labeled(namedReleaseMockWithStatus("giddy-gazelle", &release.Status{Code: release.Status_FAILED}), "team=payments"),
+				labeled(namedReleaseMockWithStatus("wild-wombat", &release.Status{Code: release.Status_DEPLOYED}), "team=search"),
+			},
+		},
+		{
+			name:     "get status of multiple releases with exit code",
+			args:     []string{"flummoxed-chickadee", "giddy-gazelle"},
+			flags:    []string{"--exit-code"},
+			expected: "giddy-gazelle",
+			err:      true,
+			rels:     []*release.Release{releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}), namedReleaseMockWithStatus("giddy-gazelle", &release.Status{Code: release.Status_FAILED})},
+		},
+		{
+			name:     "get status of a deployed release with resources of some kinds",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--resources", "kind=Service,configmap"},
+			expected: outputWithStatus("DEPLOYED\n\nRESOURCES:\n==> v1/ConfigMap\nNAME  DATA\nweb   1\n\n==> v1/Service\nNAME  TYPE\nweb   ClusterIP\n\n==> MISSING\nKIND            NAME\nv1/ConfigMap    extra\n\n"),
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code:      release.Status_DEPLOYED,
+					Resources: "==> v1/ConfigMap\nNAME  DATA\nweb   1\n\n==> v1/Pod(related)\nNAME   READY\nweb-1  1/1\n\n==> v1/Service\nNAME  TYPE\nweb   ClusterIP\n\n==> MISSING\nKIND\t\tNAME\nv1/ConfigMap\t\textra\napps/v1/Deployment\t\tweb\n",
+				}),
+			},
+		},
+		{
+			name:     "get status compared to a revision with exit code",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--compare-to", "1", "--exit-code"},
+			expected: "",
+			err:      true,
+		},
+		{
+			name:     "get status with an invalid resources filter",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"--resources", "Deployment"},
+			expected: `invalid --resources value "Deployment"`,
+			err:      true,
+		},
+		{
+			name:     "get status in custom columns",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"-o", "custom-columns=NAME:.name,STATUS:.info.status.code,DEPLOYED:{.info.last_deployed},CHART:.chart.metadata.version"},
+			expected: "NAME               \tSTATUS  \tDEPLOYED            \tCHART \nflummoxed-chickadee\tDEPLOYED\t1977-09-02T22:04:05Z\t<none>\n",
+			rels:     []*release.Release{releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})},
+		},
+		{
+			name:     "get status of multiple releases in custom columns",
+			args:     []string{"flummoxed-chickadee", "giddy-gazelle"},
+			flags:    []string{"-o", "custom-columns=NAME:.name,STATUS:.info.status.code"},
+			expected: "NAME               \tSTATUS  \nflummoxed-chickadee\tDEPLOYED\ngiddy-gazelle      \tFAILED  \n",
+			rels:     []*release.Release{releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}), namedReleaseMockWithStatus("giddy-gazelle", &release.Status{Code: release.Status_FAILED})},
+		},
+		{
+			name:     "get status in custom columns with a column width",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"-o", "custom-columns=NAME:.name,STATUS:.info.status.code", "--col-width", "10"},
+			expected: "NAME      \tSTATUS  \nflummox...\tDEPLOYED\n",
+			rels:     []*release.Release{releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})},
+		},
+		{
+			name:     "get status with invalid custom columns",
+			args:     []string{"flummoxed-chickadee"},
+			flags:    []string{"-o", "custom-columns=NAME"},
+			expected: "",
+			err:      true,
+		},
+		{
+			name:     "get status of multiple releases with revision",
+			args:     []string{"flummoxed-chickadee", "giddy-gazelle"},
+			flags:    []string{"--revision", "2"},
+			expected: "",
+			err:      true,
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -133,15 +304,447 @@ func TestStatusCmd(t *testing.T) {
 
 }
 
+type fakeStatusKubeClient struct {
+	manifest    string
+	statuses    []kube.ResourceStatus
+	logManifest string
+	logs        []kube.PodLog
+}
+
+func (f *fakeStatusKubeClient) ResourceStatuses(namespace string, reader io.Reader) ([]kube.ResourceStatus, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	f.manifest = string(b)
+	return f.statuses, nil
+}
+
+func (f *fakeStatusKubeClient) PodLogs(namespace string, reader io.Reader, tailLines int64) ([]kube.PodLog, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	f.logManifest = string(b)
+	return f.logs, nil
+}
+
+func TestStatusCmdResources(t *testing.T) {
+	tests := []struct {
+		name          string
+		outfmt        outputFormat
+		showResources bool
+		kinds         map[string]bool
+		queried       bool
+		expected      []string
+	}{
+		{
+			name:    "live resources",
+			outfmt:  outputTable,
+			queried: true,
+			expected: []string{
+				`RESOURCES:\nKIND\s*\tNAME\s*\tDESIRED\s*\tREADY\s*\tAGE\s*\n`,
+				`Deployment\s*\tweb\s*\t3\s*\t1\s*\t<unknown>`,
+				`Service\s*\tweb\s*\t-\s*\t-\s*\t<unknown>`,
+				`ConfigMap\s*\tweb-config\s*\t-\s*\t-\s*\t<missing>`,
+			},
+		},
+		{
+			name:          "live resources with details",
+			outfmt:        outputTable,
+			showResources: true,
+			queried:       true,
+			expected: []string{
+				`RESOURCES:\nKIND\s*\tNAME\s*\tDESIRED\s*\tREADY\s*\tAGE\s*\tSTATUS\s*\tCONDITIONS`,
+				`Deployment\s*\tweb\s*\t3\s*\t1\s*\t<unknown>\s*\t-\s*\tAvailable=False`,
+				`Service\s*\tweb\s*\t-\s*\t-\s*\t<unknown>\s*\t-\s*\t-`,
+				`ConfigMap\s*\tweb-config\s*\t-\s*\t-\s*\t<missing>\s*\tMissing\s*\t-`,
+			},
+		},
+		{
+			name:     "live resources of some kinds",
+			outfmt:   outputTable,
+			kinds:    map[string]bool{"deployment": true},
+			queried:  true,
+			expected: []string{`RESOURCES:\nKIND\s*\tNAME\s*\tDESIRED\s*\tREADY\s*\tAGE\s*\nDeployment\s*\tweb\s*\t3\s*\t1\s*\t<unknown>\n\n`},
+		},
+		{
+			name:     "raw resources",
+			outfmt:   outputRaw,
+			expected: []string{`RESOURCES:\nstored resources\n`},
+		},
+		{
+			name:     "json without live resources",
+			outfmt:   outputJSON,
+			expected: []string{`^\{"name":"flummoxed-chickadee",.*"resources":"stored resources\\n"`},
+		},
+		{
+			name:          "json with live resources",
+			outfmt:        outputJSON,
+			showResources: true,
+			queried:       true,
+			expected:      []string{`"live_resources":\[\{"kind":"Deployment","name":"web","scalable":true,"desired":3,"ready":1,"conditions":\["Available=False"\]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel := releaseMockWithStatus(&release.Status{
+				Code:      release.Status_DEPLOYED,
+				Resources: "stored resources\n",
+			})
+			rel.Manifest = "kind: Deployment\nmetadata:\n  name: web\n"
+
+			kc := &fakeStatusKubeClient{
+				statuses: []kube.ResourceStatus{
+					{Kind: "Deployment", Name: "web", Scalable: true, Desired: 3, Ready: 1, Conditions: []string{"Available=False"}},
+					{Kind: "Service", Name: "web"},
+					{Kind: "ConfigMap", Name: "web-config", Missing: true},
+				},
+			}
+
+			var buf bytes.Buffer
+			cmd := &statusCmd{
+				release:       rel.Name,
+				out:           &buf,
+				client:        &helm.FakeClient{Rels: []*release.Release{rel}},
+				kubeClient:    kc,
+				outfmt:        string(tt.outfmt),
+				showResources: tt.showResources,
+				kinds:         tt.kinds,
+			}
+			if err := cmd.run(); err != nil {
+				t.Fatal(err)
+			}
+
+			if queried := kc.manifest != ""; queried != tt.queried {
+				t.Errorf("expected cluster to be queried: %t, got %t", tt.queried, queried)
+			} else if queried && kc.manifest != rel.Manifest {
+				t.Errorf("expected the release manifest to be queried, got %q", kc.manifest)
+			}
+			for _, e := range tt.expected {
+				if !regexp.MustCompile(e).Match(buf.Bytes()) {
+					t.Errorf("expected output to match %q, got\n%s", e, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestStatusCmdLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     release.Status_Code
+		outfmt   outputFormat
+		queried  bool
+		expected string
+	}{
+		{
+			name:     "failed release",
+			code:     release.Status_FAILED,
+			outfmt:   outputTable,
+			queried:  true,
+			expected: "LOGS:\n==> web-1/app <==\nconnection refused\n==> web-test/test <==\nunable to retrieve logs: not found\n",
+		},
+		{
+			name:   "deployed release",
+			code:   release.Status_DEPLOYED,
+			outfmt: outputTable,
+		},
+		{
+			name:   "failed release as json",
+			code:   release.Status_FAILED,
+			outfmt: outputJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel := releaseMockWithStatus(&release.Status{Code: tt.code})
+			rel.Manifest = "kind: Deployment\nmetadata:\n  name: web\n"
+			rel.Hooks = []*release.Hook{{Name: "web-test", Manifest: "kind: Pod\nmetadata:\n  name: web-test\n"}}
+
+			kc := &fakeStatusKubeClient{
+				logs: []kube.PodLog{
+					{Pod: "web-1", Container: "app", Log: "connection refused\n"},
+					{Pod: "web-test", Container: "test", Log: "unable to retrieve logs: not found"},
+				},
+			}
+
+			var buf bytes.Buffer
+			cmd := &statusCmd{
+				release:    rel.Name,
+				out:        &buf,
+				client:     &helm.FakeClient{Rels: []*release.Release{rel}},
+				kubeClient: kc,
+				outfmt:     string(tt.outfmt),
+				logs:       true,
+				logLines:   5,
+			}
+			if err := cmd.run(); err != nil {
+				t.Fatal(err)
+			}
+
+			if queried := kc.logManifest != ""; queried != tt.queried {
+				t.Fatalf("expected logs to be queried: %t, got %t", tt.queried, queried)
+			}
+			if !tt.queried {
+				if strings.Contains(buf.String(), "LOGS:") {
+					t.Errorf("expected no logs, got\n%s", buf.String())
+				}
+				return
+			}
+			if expected := rel.Manifest + "\n---\n" + rel.Hooks[0].Manifest; kc.logManifest != expected {
+				t.Errorf("expected manifest %q, got %q", expected, kc.logManifest)
+			}
+			if !strings.HasSuffix(buf.String(), tt.expected) {
+				t.Errorf("expected output to end with %q, got\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestPrintStatusChart(t *testing.T) {
+	res := &rls.GetReleaseStatusResponse{
+		Name:         "flummoxed-chickadee",
+		Info:         &release.Info{LastDeployed: &date, Status: &release.Status{Code: release.Status_DEPLOYED}},
+		Chart:        "foo",
+		ChartVersion: "0.1.0",
+		ValuesDigest: "sha256:806c4e125a3c",
+	}
+
+	var buf bytes.Buffer
+	PrintStatus(&buf, res)
+	expected := outputWithStatus("DEPLOYED\nCHART: foo-0.1.0\nVALUES DIGEST: sha256:806c4e125a3c\n\n")
+	if buf.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
+
+func TestStatusExitCode(t *testing.T) {
+	tests := map[release.Status_Code]int{
+		release.Status_DEPLOYED:         0,
+		release.Status_SUPERSEDED:       0,
+		release.Status_FAILED:           1,
+		release.Status_PENDING_INSTALL:  2,
+		release.Status_PENDING_UPGRADE:  2,
+		release.Status_PENDING_ROLLBACK: 2,
+		release.Status_DELETED:          3,
+		release.Status_DELETING:         3,
+		release.Status_UNKNOWN:          4,
+	}
+	for code, expected := range tests {
+		if got := statusExitCode(code); got != expected {
+			t.Errorf("expected exit code %d for %s, got %d", expected, code, got)
+		}
+	}
+}
+
+// changingStatusClient fails the release on the second status request and
+// closes stop on the third.
+type changingStatusClient struct {
+	*helm.FakeClient
+	calls int
+	stop  chan struct{}
+}
+
+func (c *changingStatusClient) ReleaseStatus(rlsName string, opts ...helm.StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	c.calls++
+	switch c.calls {
+	case 2:
+		c.Rels[0].Info.Status.Code = release.Status_FAILED
+	case 3:
+		close(c.stop)
+	}
+	return c.FakeClient.ReleaseStatus(rlsName, opts...)
+}
+
+func TestStatusCmdWatch(t *testing.T) {
+	client := &changingStatusClient{
+		FakeClient: &helm.FakeClient{
+			Rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}),
+			},
+		},
+		stop: make(chan struct{}),
+	}
+
+	var buf bytes.Buffer
+	cmd := &statusCmd{
+		release: "flummoxed-chickadee",
+		out:     &buf,
+		client:  client,
+		outfmt:  string(outputTable),
+	}
+	if err := cmd.watchStatus(time.Millisecond, client.stop); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := outputWithStatus("DEPLOYED\n\n") + "\n" + outputWithStatus("FAILED\n\n")
+	if buf.String() != expected {
+		t.Errorf("expected\n%q\ngot\n%q", expected, buf.String())
+	}
+}
+
+// stoppingStatusClient closes stop on the given status request.
+type stoppingStatusClient struct {
+	*helm.FakeClient
+	calls     int
+	stopAfter int
+	stop      chan struct{}
+}
+
+func (c *stoppingStatusClient) ReleaseStatus(rlsName string, opts ...helm.StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	c.calls++
+	if c.calls == c.stopAfter {
+		close(c.stop)
+	}
+	return c.FakeClient.ReleaseStatus(rlsName, opts...)
+}
+
+func TestStatusCmdWatchAges(t *testing.T) {
+	client := &stoppingStatusClient{
+		FakeClient: &helm.FakeClient{
+			Rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED}),
+			},
+		},
+		stopAfter: 12,
+		stop:      make(chan struct{}),
+	}
+	kc := &fakeStatusKubeClient{
+		statuses: []kube.ResourceStatus{
+			{Kind: "Service", Name: "web", Created: time.Now().Add(-1500 * time.Millisecond)},
+		},
+	}
+
+	var buf bytes.Buffer
+	cmd := &statusCmd{
+		release:    "flummoxed-chickadee",
+		out:        &buf,
+		client:     client,
+		kubeClient: kc,
+		outfmt:     string(outputTable),
+	}
+	// The age of the service goes past 2s while the status is watched.
+	if err := cmd.watchStatus(100*time.Millisecond, client.stop); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(buf.String(), "STATUS: DEPLOYED"); n != 1 {
+		t.Errorf("expected the unchanged status to be printed once, got %d times in\n%s", n, buf.String())
+	}
+}
+
+// sequentialContentClient returns the content of its releases in the order
+// they are requested.
+type sequentialContentClient struct {
+	*helm.FakeClient
+	calls int
+}
+
+func (c *sequentialContentClient) ReleaseContent(rlsName string, opts ...helm.ContentOption) (*rls.GetReleaseContentResponse, error) {
+	rel := c.Rels[c.calls]
+	c.calls++
+	return &rls.GetReleaseContentResponse{Release: rel}, nil
+}
+
+func TestStatusCmdCompareTo(t *testing.T) {
+	older := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "flummoxed-chickadee", Version: 3, StatusCode: release.Status_SUPERSEDED})
+	newer := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name:        "flummoxed-chickadee",
+		Version:     5,
+		Chart:       &chart.Chart{Metadata: &chart.Metadata{Name: "foo", Version: "0.2.0"}},
+		Config:      &chart.Config{Raw: `name: "other"`},
+		Description: "ticket-1234 canary bump",
+	})
+
+	tests := []struct {
+		name     string
+		outfmt   outputFormat
+		expected []string
+	}{
+		{
+			name:   "table",
+			outfmt: outputTable,
+			expected: []string{
+				`REVISION\s*\t3\s*\t5\s*\n`,
+				`STATUS\s*\tSUPERSEDED\s*\tDEPLOYED\s*\n`,
+				`CHART\s*\tfoo-0.1.0-beta.1\s*\tfoo-0.2.0\s*\n`,
+				`VALUES DIGEST\s*\tsha256:[0-9a-f]{12}\s*\tsha256:[0-9a-f]{12}\s*\n`,
+				`LAST DEPLOYED\s*\t` + regexp.QuoteMeta(dateString),
+				`DESCRIPTION\s*\tRelease mock\s*\tticket-1234 canary bump\s*\n`,
+			},
+		},
+		{
+			name:   "json",
+			outfmt: outputJSON,
+			expected: []string{
+				`^\[\{"revision":3,"status":"SUPERSEDED","chart":"foo","chart_version":"0.1.0-beta.1","values_digest":"sha256:[0-9a-f]{64}",`,
+				`\{"revision":5,"status":"DEPLOYED","chart":"foo","chart_version":"0.2.0",.*"description":"ticket-1234 canary bump"\}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := &statusCmd{
+				release:   "flummoxed-chickadee",
+				out:       &buf,
+				client:    &sequentialContentClient{FakeClient: &helm.FakeClient{Rels: []*release.Release{older, newer}}},
+				outfmt:    string(tt.outfmt),
+				version:   3,
+				compareTo: 5,
+			}
+			if err := cmd.run(); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range tt.expected {
+				if !regexp.MustCompile(e).Match(buf.Bytes()) {
+					t.Errorf("expected output to match %q, got\n%s", e, buf.String())
+				}
+			}
+		})
+	}
+
+	older.Config, newer.Config = &chart.Config{Raw: "a: 1"}, &chart.Config{Raw: "a: 1"}
+	a, err := summarizeRevision(older)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := summarizeRevision(newer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ValuesDigest != b.ValuesDigest {
+		t.Errorf("expected identical values to have the same digest, got %s and %s", a.ValuesDigest, b.ValuesDigest)
+	}
+}
+
 func outputWithStatus(status string) string {
 	return fmt.Sprintf("LAST DEPLOYED: %s\nNAMESPACE: \nSTATUS: %s",
 		dateString,
 		status)
 }
 
+// labeled sets the labels of a release to those of a selector like a=b,c=d.
+func labeled(rel *release.Release, labels string) *release.Release {
+	rel.Labels = map[string]string{}
+	for _, l := range strings.Split(labels, ",") {
+		kv := strings.SplitN(l, "=", 2)
+		rel.Labels[kv[0]] = kv[1]
+	}
+	return rel
+}
+
 func releaseMockWithStatus(status *release.Status) *release.Release {
+	return namedReleaseMockWithStatus("flummoxed-chickadee", status)
+}
+
+func namedReleaseMockWithStatus(name string, status *release.Status) *release.Release {
 	return &release.Release{
-		Name: "flummoxed-chickadee",
+		Name: name,
 		Info: &release.Info{
 			FirstDeployed: &date,
 			LastDeployed:  &date,
@@ -17,23 +17,27 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/manifest"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/renderutil"
 	"k8s.io/helm/pkg/tiller"
 	"k8s.io/helm/pkg/timeconv"
@@ -42,8 +46,6 @@ import (
 const defaultDirectoryPermission = 0755
 
 var (
-	whitespaceRegex = regexp.MustCompile(`^\s*$`)
-
 	// defaultKubeVersion is the default value of --kube-version flag
 	defaultKubeVersion = fmt.Sprintf("%s.%s", chartutil.DefaultKubeVersion.Major, chartutil.DefaultKubeVersion.Minor)
 )
@@ -56,9 +58,43 @@ looked up or retrieved in-cluster will be faked locally. Additionally, none
 of the server-side testing of chart validity (e.g. whether an API is supported)
 is done.
 
+The resources are printed one document at a time, in the order in which Tiller
+installs them: namespaces first, then configuration, services and workloads.
+Resources of the same kind are ordered by the path of their template, so the
+output can be applied with 'kubectl apply' and compared between runs.
+
+The CustomResourceDefinitions of the crds/ directories of the chart and its
+subcharts are printed first, so that the whole stream can be applied. Use
+'--skip-crds' to leave them out. When '-x' or '--show-only' select files, only
+the CRDs they select by path, such as crds/crontab.yaml, are printed.
+
 To render just one template in a chart, use '-x':
 
 	$ helm template mychart -x templates/deployment.yaml
+
+Charts that check the capabilities of the cluster see the API versions built
+into Kubernetes and the Kubernetes version of '--kube-version'. Add the API
+versions of custom resources with '--api-versions':
+
+	$ helm template mychart --kube-version 1.14 --api-versions monitoring.coreos.com/v1
+
+To see how values reach the subcharts of an umbrella chart, '--debug-values'
+prints the values that each chart is rendered with, after globals, imported
+values and aliases are applied, before the manifests.
+
+To only show some of the rendered templates, use '--show-only' with their paths
+in the chart. The paths may be glob patterns, and the templates of subcharts
+are under charts/, for example:
+
+	$ helm template mychart --show-only templates/deployment.yaml --show-only 'charts/*/templates/*.yaml'
+
+To keep the rendered manifests in a repository, such as for GitOps, write them
+to a directory with '--output-dir'. With '--output-dir-layout by-kind', each
+resource gets a file of its own, such as deployment/web.yaml, and
+'--output-dir-name-prefix' prefixes the file names with the release name so
+that several releases can share the directory:
+
+	$ helm template mychart --name web --output-dir ./manifests --output-dir-layout by-kind --output-dir-name-prefix
 `
 
 type templateCmd struct {
@@ -68,14 +104,31 @@ type templateCmd struct {
 	out              io.Writer
 	values           []string
 	stringValues     []string
+	jsonValues       []string
 	fileValues       []string
+	envValues        []string
+	allowedEnv       []string
 	nameTemplate     string
 	showNotes        bool
 	releaseName      string
 	releaseIsUpgrade bool
+	releaseRevision  int
 	renderFiles      []string
+	showOnly         []string
 	kubeVersion      string
+	apiVersions      []string
+	strict           bool
+	debugValues      bool
+	stripComments    bool
+	missingKey       string
+	maxIncludeDepth  int
 	outputDir        string
+	skipCRDs         bool
+	outputDirLayout  string
+	outputNamePrefix bool
+	postRenderer     string
+	profile          string
+	profileDirs      []string
 }
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
@@ -87,7 +140,7 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "template [flags] CHART",
 		Short: "Locally render templates",
-		Long:  templateDesc,
+		Long:  templateDesc + profileHelp + postRendererHelp,
 		RunE:  t.run,
 	}
 
@@ -95,15 +148,34 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&t.showNotes, "notes", false, "Show the computed NOTES.txt file as well")
 	f.StringVarP(&t.releaseName, "name", "n", "release-name", "Release name")
 	f.BoolVar(&t.releaseIsUpgrade, "is-upgrade", false, "Set .Release.IsUpgrade instead of .Release.IsInstall")
+	f.IntVar(&t.releaseRevision, "revision", 0, "Set .Release.Revision. If not set, it is 1, or 2 with --is-upgrade")
 	f.StringArrayVarP(&t.renderFiles, "execute", "x", []string{}, "Only execute the given templates")
-	f.VarP(&t.valueFiles, "values", "f", "Specify values in a YAML file (can specify multiple)")
+	f.StringArrayVarP(&t.showOnly, "show-only", "s", []string{}, "Only show the templates whose paths in the chart match these glob patterns, such as templates/deployment.yaml (can specify multiple)")
+	f.StringVar(&t.profile, "profile", "", "Layer the values-<profile>.yaml file of the chart over its default values, before the user-supplied values")
+	f.StringArrayVar(&t.profileDirs, "profile-dir", []string{}, "Directories to look for the values-<profile>.yaml file of --profile in, after the chart (can specify multiple)")
+	f.VarP(&t.valueFiles, "values", "f", "Specify values in a YAML file, a URL or '-' for stdin (can specify multiple)")
 	f.StringVar(&t.namespace, "namespace", "", "Namespace to install the release into")
 	f.StringArrayVar(&t.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&t.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
 	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringArrayVar(&t.envValues, "set-env", []string{}, "Set values from environment variables specified via the command line (can specify multiple or separate values with commas: key1=ENV1,key2=ENV2). A variable alone, such as DB_PASSWORD, sets the value of the same name")
+	f.StringArrayVar(&t.allowedEnv, "allow-env", []string{}, "Environment variables that the 'env' template function may read (can specify multiple)")
 	f.StringVar(&t.nameTemplate, "name-template", "", "Specify template used to name the release")
-	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor")
+	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor, such as 1.14 or v1.14.2")
+	f.StringArrayVarP(&t.apiVersions, "api-versions", "a", []string{}, "Kubernetes API versions used for Capabilities.APIVersions, in addition to the default ones (can specify multiple)")
+	f.BoolVar(&t.debugValues, "debug-values", false, "Print the values that each chart and subchart is rendered with before the manifests")
+	f.BoolVar(&t.strict, "strict", false, "Fail the rendering on references to values that are not defined, instead of rendering them as empty")
+	f.BoolVar(&t.stripComments, "strip-comments", false, "Drop comments and documents left empty from the rendered manifests, to keep large releases small")
+	f.StringVar(&t.missingKey, "missing-key", "", "What to render for values that are not defined: \"zero\" for nothing, \"invalid\" for \"<no value>\" or \"error\" to fail. Defaults to the setting of the chart, or \"zero\"")
+	f.IntVar(&t.maxIncludeDepth, "max-include-depth", engine.DefaultMaxIncludeDepth, "Maximum number of nested 'include' and 'tpl' calls")
+	f.Bool("include-crds", true, "Print the CRDs of the crds/ directories of the chart and its subcharts before the templates")
+	f.MarkDeprecated("include-crds", "CRDs are printed by default; use --skip-crds to leave them out")
+	f.BoolVar(&t.skipCRDs, "skip-crds", false, "Do not print the CRDs of the crds/ directories")
 	f.StringVar(&t.outputDir, "output-dir", "", "Writes the executed templates to files in output-dir instead of stdout")
+	f.StringVar(&t.outputDirLayout, "output-dir-layout", layoutByChart, "How the files of --output-dir are laid out: \"by-chart\" mirrors the paths of the templates, \"flat\" writes them all to output-dir and \"by-kind\" writes each resource to <kind>/<name>.yaml")
+	f.BoolVar(&t.outputNamePrefix, "output-dir-name-prefix", false, "Prefix the names of the files written to --output-dir with the release name")
+	f.StringVar(&t.postRenderer, "post-renderer", "", "The path to an executable that modifies the rendered manifests before they are displayed")
 
 	return cmd
 }
@@ -127,26 +199,32 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("output-dir '%s' does not exist", t.outputDir)
 		}
+		if t.postRenderer != "" {
+			return errors.New("--post-renderer cannot be used with --output-dir")
+		}
+	}
+	switch t.outputDirLayout {
+	case layoutByChart, layoutFlat, layoutByKind:
+	default:
+		return fmt.Errorf("output-dir layout %q is not valid. Valid options are %q, %q and %q", t.outputDirLayout, layoutByChart, layoutFlat, layoutByKind)
+	}
+	if err := checkPostRenderer(t.postRenderer); err != nil {
+		return err
+	}
+	if len(t.showOnly) > 0 && len(t.renderFiles) > 0 {
+		return errors.New("--show-only cannot be used with --execute")
 	}
 
 	if t.namespace == "" {
 		t.namespace = defaultNamespace()
 	}
 	// get combined values and create config
-	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, "", "", "")
+	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, t.jsonValues, t.envValues, "", "", "")
 	if err != nil {
 		return err
 	}
 	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
 
-	// If template is specified, try to run the template.
-	if t.nameTemplate != "" {
-		t.releaseName, err = generateName(t.nameTemplate)
-		if err != nil {
-			return err
-		}
-	}
-
 	if msgs := validation.IsDNS1123Subdomain(t.releaseName); t.releaseName != "" && len(msgs) > 0 {
 		return fmt.Errorf("release name %s is invalid: %s", t.releaseName, strings.Join(msgs, ";"))
 	}
@@ -156,22 +234,73 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return prettyError(err)
 	}
+	if err := applyProfile(c, t.profile, t.profileDirs); err != nil {
+		return err
+	}
+
+	// If template is specified, try to run the template. There are no
+	// releases to check the name against without Tiller.
+	if t.nameTemplate != "" {
+		t.releaseName, err = generateName(t.nameTemplate, newNameTemplateData(c.Metadata, t.namespace))
+		if err != nil {
+			return err
+		}
+		if err := validateGeneratedName(t.releaseName); err != nil {
+			return err
+		}
+	}
+
+	revision := t.releaseRevision
+	if revision <= 0 {
+		revision = 1
+		if t.releaseIsUpgrade {
+			revision = 2
+		}
+	}
 
 	renderOpts := renderutil.Options{
 		ReleaseOptions: chartutil.ReleaseOptions{
 			Name:      t.releaseName,
 			IsInstall: !t.releaseIsUpgrade,
 			IsUpgrade: t.releaseIsUpgrade,
+			Revision:  revision,
 			Time:      timeconv.Now(),
 			Namespace: t.namespace,
 		},
-		KubeVersion: t.kubeVersion,
+		KubeVersion:     t.kubeVersion,
+		APIVersions:     t.apiVersions,
+		Strict:          t.strict,
+		MissingKey:      t.missingKey,
+		MaxIncludeDepth: t.maxIncludeDepth,
+		AllowedEnv:      t.allowedEnv,
+	}
+	warnings := &engine.Warnings{}
+	renderOpts.WarnFunc = warnings.Add
+	var chartValues map[string]chartutil.Values
+	if t.debugValues {
+		renderOpts.ValuesFunc = func(vals chartutil.Values) {
+			chartValues = engine.ChartValues(c, vals)
+		}
 	}
 
 	renderedTemplates, err := renderutil.Render(c, config, renderOpts)
 	if err != nil {
 		return err
 	}
+	if err := manifest.CheckDuplicates(renderedTemplates, t.namespace); err != nil {
+		return err
+	}
+	if t.stripComments {
+		for name, content := range renderedTemplates {
+			if filepath.Base(name) != "NOTES.txt" {
+				renderedTemplates[name] = releaseutil.StripComments(content)
+			}
+		}
+	}
+	// The warnings go to stderr, so that the output remains valid YAML.
+	for _, w := range warnings.List() {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", w)
+	}
 
 	if settings.Debug {
 		rel := &release.Release{
@@ -184,10 +313,25 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		}
 		printRelease(os.Stdout, rel)
 	}
+	if t.debugValues {
+		if err := printChartValues(os.Stdout, chartValues); err != nil {
+			return err
+		}
+	}
 
 	listManifests := manifest.SplitManifests(renderedTemplates)
 	var manifestsToRender []manifest.Manifest
 
+	// The CRDs can be selected along with the templates.
+	crdNames := map[string]bool{}
+	if !t.skipCRDs {
+		crds := crdManifests(c, "")
+		for _, m := range crds {
+			crdNames[m.Name] = true
+		}
+		listManifests = append(listManifests, crds...)
+	}
+
 	// if we have a list of files to render, then check that each of the
 	// provided files exists in the chart.
 	if len(t.renderFiles) > 0 {
@@ -221,61 +365,255 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("could not find template %s in chart", f)
 			}
 		}
+	} else if len(t.showOnly) > 0 {
+		if manifestsToRender, err = showOnlyManifests(listManifests, t.showOnly); err != nil {
+			return err
+		}
 	} else {
 		// no renderFiles provided, render all manifests in the chart
 		manifestsToRender = listManifests
 	}
 
-	for _, m := range tiller.SortByKind(manifestsToRender) {
-		data := m.Content
-		b := filepath.Base(m.Name)
-		if !t.showNotes && b == "NOTES.txt" {
+	var crds, docs, notes []manifest.Manifest
+	for _, m := range manifestsToRender {
+		// Like Tiller, the CRDs come first, so that the resources of their
+		// kinds can be created.
+		if crdNames[m.Name] {
+			crds = append(crds, m)
 			continue
 		}
+		b := filepath.Base(m.Name)
 		if strings.HasPrefix(b, "_") {
 			continue
 		}
+		// The notes are not a manifest, so they are printed after it.
+		if b == "NOTES.txt" {
+			if t.showNotes {
+				// Nothing is deployed, so live values are pending.
+				m.Content = engine.ResolveDeployed(m.Content, func(string, string, string, string) string { return "" })
+				notes = append(notes, m)
+			}
+			continue
+		}
+		// As in Tiller, 'deployed' is only resolved in the notes.
+		if engine.HasDeployed(m.Content) {
+			return fmt.Errorf("the 'deployed' function can only be used in NOTES.txt, but is used in %s", m.Name)
+		}
+		docs = append(docs, splitDocuments(m)...)
+	}
 
-		if t.outputDir != "" {
-			// blank template after execution
-			if whitespaceRegex.MatchString(data) {
-				continue
+	var rendered bytes.Buffer
+	written := map[string]bool{}
+	for _, m := range append(crds, tiller.SortByKind(docs)...) {
+		// Tiller leaves out the resources annotated with helm.sh/skip.
+		if releaseutil.IsSkipped(m.Head) {
+			continue
+		}
+
+		switch {
+		case t.postRenderer != "":
+			fmt.Fprintf(&rendered, "---\n# Source: %s\n%s\n", m.Name, m.Content)
+		case t.outputDir != "":
+			file, err := t.outputFile(m)
+			if err != nil {
+				return err
+			}
+			if err := writeToFile(t.outputDir, file, m.Name, m.Content, written[file]); err != nil {
+				return err
 			}
-			err = writeToFile(t.outputDir, m.Name, data)
+			written[file] = true
+		default:
+			fmt.Printf("---\n# Source: %s\n", m.Name)
+			fmt.Println(m.Content)
+		}
+	}
+
+	if t.postRenderer != "" {
+		out, err := postRender(t.postRenderer, rendered.String())
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+	}
+	for _, m := range notes {
+		if t.outputDir != "" && t.postRenderer == "" {
+			file, err := t.outputFile(m)
 			if err != nil {
 				return err
 			}
+			if err := writeToFile(t.outputDir, file, m.Name, m.Content, false); err != nil {
+				return err
+			}
 			continue
 		}
 		fmt.Printf("---\n# Source: %s\n", m.Name)
-		fmt.Println(data)
+		fmt.Println(m.Content)
+	}
+	return nil
+}
+
+// printChartValues prints the values of each chart, in the order of their
+// paths, as YAML documents headed by a "# Values:" comment.
+func printChartValues(out io.Writer, chartValues map[string]chartutil.Values) error {
+	paths := make([]string, 0, len(chartValues))
+	for p := range chartValues {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		y, err := chartValues[p].YAML()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "---\n# Values: %s\n%s", p, y)
 	}
 	return nil
 }
 
-// write the <data> to <output-dir>/<name>
-func writeToFile(outputDir string, name string, data string) error {
-	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
+// crdManifests returns the documents of the crds/ directories of a chart and
+// of the dependencies it renders, named after their paths like templates.
+func crdManifests(c *chart.Chart, dir string) []manifest.Manifest {
+	dir = path.Join(dir, c.Metadata.Name)
+	var crds []manifest.Manifest
+	// The dependencies are walked here, to know their paths.
+	for _, f := range chartutil.CRDs(&chart.Chart{Files: c.Files}) {
+		crds = append(crds, splitDocuments(manifest.Manifest{Name: path.Join(dir, f.TypeUrl), Content: string(f.Value)})...)
+	}
+	for _, dep := range c.Dependencies {
+		crds = append(crds, crdManifests(dep, path.Join(dir, "charts"))...)
+	}
+	return crds
+}
+
+// splitDocuments splits a rendered template into its YAML documents, so that
+// they can be sorted in the order in which Tiller installs them: by kind, then
+// by the path of their template. Blank documents are dropped.
+func splitDocuments(m manifest.Manifest) []manifest.Manifest {
+	split := releaseutil.SplitManifests(m.Content)
+	docs := make([]manifest.Manifest, 0, len(split))
+	for i := 0; i < len(split); i++ {
+		content := split[fmt.Sprintf("manifest-%d", i)]
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil {
+			// Documents that are not valid YAML have no kind, so they are
+			// printed after the resources of known kinds.
+			head = releaseutil.SimpleHead{}
+		}
+		docs = append(docs, manifest.Manifest{Name: m.Name, Content: content, Head: &head})
+	}
+	return docs
+}
+
+// showOnlyManifests returns the manifests whose paths in the chart, such as
+// templates/deployment.yaml or charts/mysql/templates/secrets.yaml, match one
+// of the glob patterns. Every pattern has to match a manifest.
+func showOnlyManifests(manifests []manifest.Manifest, patterns []string) ([]manifest.Manifest, error) {
+	var shown []manifest.Manifest
+	matched := make([]bool, len(patterns))
+	for _, m := range manifests {
+		// manifest.Name starts with the name of the chart and always uses
+		// forward slashes.
+		parts := strings.SplitN(m.Name, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		show := false
+		for i, p := range patterns {
+			ok, err := path.Match(filepath.ToSlash(p), parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q for --show-only: %s", p, err)
+			}
+			if ok {
+				matched[i] = true
+				show = true
+			}
+		}
+		if show {
+			shown = append(shown, m)
+		}
+	}
+	for i, p := range patterns {
+		if !matched[i] {
+			return nil, fmt.Errorf("could not find template %s in chart", p)
+		}
+	}
+	return shown, nil
+}
+
+// The layouts of the files written to --output-dir.
+const (
+	layoutByChart = "by-chart"
+	layoutFlat    = "flat"
+	layoutByKind  = "by-kind"
+)
+
+// outputFile returns the path, relative to --output-dir, of the file a
+// rendered template or document is written to:
+//
+//	by-chart: mychart/charts/mysql/templates/svc.yaml
+//	flat:     mychart-mysql-svc.yaml
+//	by-kind:  service/mysql.yaml
+//
+// With the by-kind layout, documents that are not resources, such as the
+// notes, are named as with the flat layout. The kind and name of a resource
+// come from the chart, so they are rejected if they could lead out of the
+// output directory.
+func (t *templateCmd) outputFile(m manifest.Manifest) (string, error) {
+	dir, file := path.Split(m.Name)
+	switch t.outputDirLayout {
+	case layoutFlat, layoutByKind:
+		dir = ""
+		file = strings.NewReplacer("/templates/", "-", "/charts/", "-", "/", "-").Replace(m.Name)
+		if t.outputDirLayout == layoutByKind && m.Head != nil && m.Head.Kind != "" && m.Head.Metadata != nil && m.Head.Metadata.Name != "" {
+			if !isFileName(m.Head.Kind) || !isFileName(m.Head.Metadata.Name) {
+				return "", fmt.Errorf("cannot write %s %q of %s to a file named after it", m.Head.Kind, m.Head.Metadata.Name, m.Name)
+			}
+			dir = strings.ToLower(m.Head.Kind)
+			file = m.Head.Metadata.Name + ".yaml"
+		}
+	}
+	if t.outputNamePrefix {
+		file = t.releaseName + "-" + file
+	}
+	return path.Join(dir, file), nil
+}
+
+// isFileName reports whether s can name a file without a path separator or
+// "..", which would let it lead to another directory.
+func isFileName(s string) bool {
+	return !strings.ContainsAny(s, `/\`) && !strings.Contains(s, "..")
+}
+
+// write the <data> of the template <name> to <output-dir>/<file>, after the
+// documents already written to it if appendData is set
+func writeToFile(outputDir, file, name, data string, appendData bool) error {
+	outfileName := strings.Join([]string{outputDir, file}, string(filepath.Separator))
 
 	err := ensureDirectoryForFile(outfileName)
 	if err != nil {
 		return err
 	}
 
-	f, err := os.Create(outfileName)
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendData {
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(outfileName, flags, 0644)
 	if err != nil {
 		return err
 	}
 
 	defer f.Close()
 
-	_, err = f.WriteString(fmt.Sprintf("---\n# Source: %s\n%s", name, data))
+	_, err = f.WriteString(fmt.Sprintf("---\n# Source: %s\n%s\n", name, data))
 
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("wrote %s\n", outfileName)
+	if !appendData {
+		fmt.Printf("wrote %s\n", outfileName)
+	}
 	return nil
 }
 
@@ -23,13 +23,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/manifest"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 var (
 	subchart1ChartPath = "./../../pkg/chartutil/testdata/subpop/charts/subchart1"
 	frobnitzChartPath  = "./../../pkg/chartutil/testdata/frobnitz"
+	profilesChartPath  = "testdata/testcharts/profiles"
 )
 
 func TestTemplateCmd(t *testing.T) {
@@ -93,6 +98,38 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "frobnitz/charts/mariner/templates/placeholder.tpl",
 			expectValue: "Goodbye moon",
 		},
+		{
+			name:        "check_show_only",
+			desc:        "verify --show-only shows the given template",
+			args:        []string{subchart1ChartPath, "--show-only", "templates/service.yaml", "--set", "service.name=apache"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "protocol: TCP\n    name: apache",
+		},
+		{
+			name:        "check_show_only_subchart_glob",
+			desc:        "verify --show-only matches the templates of subcharts with a glob",
+			args:        []string{subchart1ChartPath, "-s", "charts/*/templates/*.yaml", "--set", "subcharta.service.name=foobar"},
+			expectKey:   "subchart1/charts/subcharta/templates/service.yaml",
+			expectValue: "protocol: TCP\n    name: foobar",
+		},
+		{
+			name:        "check_show_only_non_existent",
+			desc:        "verify --show-only fails on a pattern that matches no template",
+			args:        []string{subchart1ChartPath, "--show-only", "templates/*.json"},
+			expectError: "could not find template",
+		},
+		{
+			name:        "check_show_only_with_execute",
+			desc:        "verify --show-only cannot be combined with --execute",
+			args:        []string{subchart1ChartPath, "--show-only", "templates/service.yaml", "-x", "templates/service.yaml"},
+			expectError: "cannot be used with --execute",
+		},
+		{
+			name:        "check_duplicates",
+			desc:        "verify resources defined twice are reported with their templates",
+			args:        []string{"testdata/testcharts/duplicates", "--name", "test", "--namespace", "ocean"},
+			expectError: `ConfigMap "test-settings" in namespace "ocean" is defined more than once, by duplicates/templates/overrides.yaml, duplicates/templates/settings.yaml`,
+		},
 		{
 			name:        "check_namespace",
 			desc:        "verify --namespace",
@@ -142,6 +179,27 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "release-is-upgrade: \"true\"",
 		},
+		{
+			name:        "check_release_revision_install",
+			desc:        "verify .Release.Revision is 1 by default",
+			args:        []string{subchart1ChartPath},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-revision: \"1\"",
+		},
+		{
+			name:        "check_release_revision_upgrade",
+			desc:        "verify .Release.Revision is 2 by default with --is-upgrade",
+			args:        []string{subchart1ChartPath, "--is-upgrade"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-revision: \"2\"",
+		},
+		{
+			name:        "check_release_revision",
+			desc:        "verify --revision sets .Release.Revision",
+			args:        []string{subchart1ChartPath, "--is-upgrade", "--revision", "7"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-revision: \"7\"",
+		},
 		{
 			name:        "check_notes",
 			desc:        "verify --notes shows notes",
@@ -169,6 +227,13 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "release-name: \"foobar-abc-baz\"",
 		},
+		{
+			name:        "check_name_template_chart",
+			desc:        "verify --name-template can use the chart metadata and namespace",
+			args:        []string{subchart1ChartPath, "--name-template", "{{ .Chart.Name }}-{{ .Namespace }}", "--namespace", "test"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "release-name: \"subchart1-test\"",
+		},
 		{
 			name:        "check_kube_version",
 			desc:        "verify --kube-version overrides the kubernetes version",
@@ -176,6 +241,54 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "kube-version/major: \"1\"\n    kube-version/minor: \"6\"\n    kube-version/gitversion: \"v1.6.0\"",
 		},
+		{
+			name:        "check_api_versions",
+			desc:        "verify --api-versions adds to the API versions of the capabilities",
+			args:        []string{subchart1ChartPath, "--api-versions", "monitoring.coreos.com/v1", "--kube-version", "v1.14.2"},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "kube-version/minor: \"14\"\n    kube-version/gitversion: \"v1.14.0\"\n    api-versions/monitoring: \"true\"",
+		},
+		{
+			name:        "check_default_api_versions",
+			desc:        "verify the API versions of the capabilities default to the built-in ones",
+			args:        []string{subchart1ChartPath},
+			expectKey:   "subchart1/templates/service.yaml",
+			expectValue: "api-versions/monitoring: \"false\"",
+		},
+		{
+			name:        "check_debug_values",
+			desc:        "verify --debug-values prints the values a subchart is rendered with",
+			args:        []string{subchart1ChartPath, "--debug-values", "--set", "subcharta.service.name=httpd"},
+			expectKey:   "subchart1/charts/subcharta",
+			expectValue: "name: httpd",
+		},
+		{
+			name:        "check_profile",
+			desc:        "verify --profile layers the values file of the profile over the defaults",
+			args:        []string{profilesChartPath, "--profile", "staging"},
+			expectKey:   "profiles/templates/configmap.yaml",
+			expectValue: "environment: \"staging\"\n  replicas: \"1\"\n  database: \"db.staging.example.com:5432\"",
+		},
+		{
+			name:        "check_profile_set",
+			desc:        "verify --set overrides the values of the profile",
+			args:        []string{profilesChartPath, "--profile", "staging", "--set", "environment=qa"},
+			expectKey:   "profiles/templates/configmap.yaml",
+			expectValue: "environment: \"qa\"",
+		},
+		{
+			name:        "check_profile_dir",
+			desc:        "verify --profile-dir layers the values file of the profile over that of the chart",
+			args:        []string{profilesChartPath, "--profile", "staging", "--profile-dir", "testdata/profiles"},
+			expectKey:   "profiles/templates/configmap.yaml",
+			expectValue: "environment: \"staging\"\n  replicas: \"3\"",
+		},
+		{
+			name:        "check_profile_not_found",
+			desc:        "verify a profile without a values file fails",
+			args:        []string{profilesChartPath, "--profile", "production"},
+			expectError: "profile \"production\" not found",
+		},
 	}
 
 	var buf bytes.Buffer
@@ -243,3 +356,194 @@ func TestTemplateCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestShowOnlyManifests(t *testing.T) {
+	manifests := []manifest.Manifest{
+		{Name: "mychart/templates/deployment.yaml"},
+		{Name: "mychart/templates/service.yaml"},
+		{Name: "mychart/charts/mysql/templates/secrets.yaml"},
+		{Name: "mychart/charts/mysql/templates/svc.yaml"},
+	}
+	tests := []struct {
+		patterns []string
+		expected []string
+		err      bool
+	}{
+		{[]string{"templates/service.yaml"}, []string{"mychart/templates/service.yaml"}, false},
+		{[]string{"templates/*"}, []string{"mychart/templates/deployment.yaml", "mychart/templates/service.yaml"}, false},
+		{[]string{"charts/mysql/templates/secrets.yaml", "templates/deployment.yaml"}, []string{"mychart/templates/deployment.yaml", "mychart/charts/mysql/templates/secrets.yaml"}, false},
+		{[]string{"templates/service.yaml", "templates/ingress.yaml"}, nil, true},
+		{[]string{"templates/["}, nil, true},
+	}
+	for _, tt := range tests {
+		shown, err := showOnlyManifests(manifests, tt.patterns)
+		if (err != nil) != tt.err {
+			t.Errorf("%v: expected error %t, got %v", tt.patterns, tt.err, err)
+			continue
+		}
+		var names []string
+		for _, m := range shown {
+			names = append(names, m.Name)
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("%v: expected %v, got %v", tt.patterns, tt.expected, names)
+		}
+	}
+}
+
+func TestTemplateOutputFile(t *testing.T) {
+	svc := manifest.Manifest{
+		Name: "mychart/charts/mysql/templates/svc.yaml",
+		Head: &releaseutil.SimpleHead{Kind: "Service", Metadata: &struct {
+			Name        string            `json:"name"`
+			Namespace   string            `json:"namespace,omitempty"`
+			Annotations map[string]string `json:"annotations"`
+		}{Name: "mysql"}},
+	}
+	notes := manifest.Manifest{Name: "mychart/templates/NOTES.txt"}
+	named := func(kind, name string) manifest.Manifest {
+		m := svc
+		m.Head = &releaseutil.SimpleHead{Kind: kind, Metadata: &struct {
+			Name        string            `json:"name"`
+			Namespace   string            `json:"namespace,omitempty"`
+			Annotations map[string]string `json:"annotations"`
+		}{Name: name}}
+		return m
+	}
+
+	tests := []struct {
+		layout string
+		prefix bool
+		m      manifest.Manifest
+		expect string
+	}{
+		{layoutByChart, false, svc, "mychart/charts/mysql/templates/svc.yaml"},
+		{layoutByChart, true, svc, "mychart/charts/mysql/templates/nautilus-svc.yaml"},
+		{layoutFlat, false, svc, "mychart-mysql-svc.yaml"},
+		{layoutByKind, false, svc, "service/mysql.yaml"},
+		{layoutByKind, true, svc, "service/nautilus-mysql.yaml"},
+		{layoutByKind, false, notes, "mychart-NOTES.txt"},
+		{layoutByKind, false, named("Role", "system:reader"), "role/system:reader.yaml"},
+		{layoutFlat, false, named("Service", "../../escape"), "mychart-mysql-svc.yaml"},
+	}
+	for _, tt := range tests {
+		tc := &templateCmd{releaseName: "nautilus", outputDirLayout: tt.layout, outputNamePrefix: tt.prefix}
+		got, err := tc.outputFile(tt.m)
+		if err != nil {
+			t.Errorf("%s (prefix %t): %s", tt.layout, tt.prefix, err)
+		}
+		if got != tt.expect {
+			t.Errorf("%s (prefix %t): expected %s, got %s", tt.layout, tt.prefix, tt.expect, got)
+		}
+	}
+
+	for _, m := range []manifest.Manifest{
+		named("Service", "../../escape"),
+		named("Service", ".."),
+		named("Service", "a/b"),
+		named(`..\Service`, "mysql"),
+	} {
+		tc := &templateCmd{releaseName: "nautilus", outputDirLayout: layoutByKind}
+		if got, err := tc.outputFile(m); err == nil {
+			t.Errorf("expected %s %q to be rejected, got %s", m.Head.Kind, m.Head.Metadata.Name, got)
+		}
+	}
+}
+
+func TestTemplateInstallOrder(t *testing.T) {
+	// capture stdout
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	cmd := newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs([]string{"testdata/testcharts/multidoc"})
+	err := cmd.Execute()
+	w.Close()
+	os.Stdout = old
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	io.Copy(&b, r)
+	r.Close()
+
+	var order []string
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "kind: ") || strings.HasPrefix(line, "  name: ") {
+			order = append(order, strings.TrimSpace(line))
+		}
+	}
+	expected := []string{
+		"kind: Namespace", "name: apps",
+		"kind: ConfigMap", "name: app-b",
+		"kind: ConfigMap", "name: app-a",
+		"kind: Service", "name: app",
+		"kind: Deployment", "name: app",
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected the resources in install order %v, got %v", expected, order)
+	}
+}
+
+func TestTemplateCRDs(t *testing.T) {
+	render := func(args ...string) (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		cmd := newTemplateCmd(bytes.NewBuffer(nil))
+		cmd.SetArgs(append([]string{"testdata/testcharts/crds"}, args...))
+		err := cmd.Execute()
+		w.Close()
+		os.Stdout = old
+		var b bytes.Buffer
+		io.Copy(&b, r)
+		r.Close()
+		return b.String(), err
+	}
+
+	hasCRD := func(out string) bool {
+		return strings.Contains(out, "kind: CustomResourceDefinition")
+	}
+
+	out, err := render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd := strings.Index(out, "# Source: crds/crds/crontab.yaml\napiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition")
+	crontab := strings.Index(out, "kind: CronTab")
+	if crd < 0 || crontab < crd {
+		t.Errorf("Expected the CRD to come before the templates, got\n%s", out)
+	}
+
+	for _, args := range [][]string{
+		{"--skip-crds"},
+		{"-x", "templates/crontab.yaml"},
+		{"--show-only", "templates/*"},
+	} {
+		out, err := render(args...)
+		if err != nil {
+			t.Fatalf("%v: %s", args, err)
+		}
+		if hasCRD(out) {
+			t.Errorf("%v: expected the CRDs to be left out, got\n%s", args, out)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"-x", "crds/crontab.yaml"},
+		{"--show-only", "crds/*"},
+	} {
+		out, err := render(args...)
+		if err != nil {
+			t.Fatalf("%v: %s", args, err)
+		}
+		if !hasCRD(out) || strings.Contains(out, "\nkind: CronTab") {
+			t.Errorf("%v: expected only the CRDs to be printed, got\n%s", args, out)
+		}
+	}
+
+	if _, err := render("--skip-crds", "--show-only", "crds/*"); err == nil {
+		t.Error("Expected --show-only to find no skipped CRDs")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -25,6 +26,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/renderutil"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
@@ -36,16 +38,23 @@ Required arguments are release and chart. The chart argument can be one of:
  - a chart reference('stable/mariadb'); use '--version' and '--devel' flags for versions other than latest,
  - a path to a chart directory,
  - a packaged chart,
- - a fully qualified URL.
+ - a fully qualified URL,
+ - an OCI registry reference('oci://registry.example.com/charts/mariadb:1.2.3').
 
 To customize the chart values, use any of
  - '--values'/'-f' to pass in a yaml file holding settings,
  - '--set' to provide one or more key=val pairs directly,
  - '--set-string' to provide key=val forcing val to be stored as a string,
- - '--set-file' to provide key=path to read a single large value from a file at path.
+ - '--set-file' to provide key=path to read a single large value from a file at path,
+ - '--set-json' to provide key=jsonval, where jsonval is a JSON document keeping its types,
+ - '--set-env' to provide key=NAME to read a value from the environment variable NAME.
 
 To edit or append to the existing customized values, add the
  '--reuse-values' flag, otherwise any existing customized values are ignored.
+'--reuse-values' also keeps the defaults of the chart the release was
+installed with, so defaults that changed in the new chart are not picked up.
+To use the defaults of the new chart and still keep the existing customized
+values, use '--reset-then-reuse-values' instead.
 
 If no chart value arguments are provided on the command line, any existing customized values are carried
 forward. If you want to revert to just the values provided in the chart, use the '--reset-values' flag.
@@ -81,38 +90,90 @@ your shell convention for escaping it; for example in bash:
 	$ helm upgrade --set pwd='3jk$o2z=f\\30with'\''quote'
 
 which results in "pwd: 3jk$o2z=f\30with'quote".
+
+Use '--output json' or '--output yaml' to print the name, revision, namespace,
+status, notes and hook results of the upgraded release in a machine-readable
+form.
+
+To preview an upgrade, add the '--diff' flag. It prints a unified diff of the
+rendered manifests against those of the current revision, along with the number
+of resources to add, change and remove. Combine it with '--dry-run' to only
+print the diff.
+
+Resources that cannot be patched, for example because they were changed in the
+meantime, fail the upgrade. With '--force', conflicting patches are computed
+again from the live resources and retried, then the resources are replaced.
+Nothing is deleted, so Services keep their cluster IP and keep serving traffic.
+Add '--force-recreate' to also delete and create again the resources that
+cannot be replaced either.
+
+'--recreate-pods' restarts the pods of every workload of the release. To only
+restart the pods of some workloads, list them with '--recreate-pods-for':
+
+    $ helm upgrade --recreate-pods-for deployment/web,statefulset/db angry-bird ./chart
+
+Add '--check-recreated' to a dry run to also list the resources that the API
+server refuses to update in place, for example because the selector of a
+Deployment or the clusterIP of a Service changed. The upgrade fails on them,
+unless both '--force' and '--force-recreate' are set, in which case they are
+deleted and created again.
+
+CustomResourceDefinitions in the crds/ directory of the chart that do not exist
+yet are installed once the chart renders, before its resources. Existing ones
+are never upgraded. Use '--skip-crds' to leave them out.
 `
 
+var errCheckRecreatedWithoutDryRun = errors.New("--check-recreated can only be used with --dry-run")
+
 type upgradeCmd struct {
-	release       string
-	chart         string
-	out           io.Writer
-	client        helm.Interface
-	dryRun        bool
-	recreate      bool
-	force         bool
-	disableHooks  bool
-	valueFiles    valueFiles
-	values        []string
-	stringValues  []string
-	fileValues    []string
-	verify        bool
-	keyring       string
-	install       bool
-	namespace     string
-	version       string
-	timeout       int64
-	resetValues   bool
-	reuseValues   bool
-	wait          bool
-	atomic        bool
-	repoURL       string
-	username      string
-	password      string
-	devel         bool
-	subNotes      bool
-	description   string
-	cleanupOnFail bool
+	release              string
+	chart                string
+	out                  io.Writer
+	client               helm.Interface
+	dryRun               bool
+	validate             bool
+	checkRecreated       bool
+	strict               bool
+	missingKey           string
+	stripComments        bool
+	diff                 bool
+	recreate             bool
+	recreatePodsFor      []string
+	force                bool
+	forceRecreate        bool
+	disableHooks         bool
+	valueFiles           valueFiles
+	values               []string
+	stringValues         []string
+	jsonValues           []string
+	fileValues           []string
+	envValues            []string
+	verify               bool
+	keyring              string
+	install              bool
+	namespace            string
+	version              string
+	timeout              int64
+	resetValues          bool
+	reuseValues          bool
+	resetThenReuseValues bool
+	wait                 bool
+	waitForJobs          bool
+	atomic               bool
+	repoURL              string
+	username             string
+	password             string
+	devel                bool
+	subNotes             bool
+	description          string
+	setBy                string
+	cleanupOnFail        bool
+	maxHistory           int32
+	postRenderer         string
+	resolveImageDigests  bool
+	skipCRDs             bool
+	profile              string
+	profileDirs          []string
 
 	certFile string
 	keyFile  string
@@ -130,7 +191,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "upgrade [RELEASE] [CHART]",
 		Short:   "Upgrade a release",
-		Long:    upgradeDesc,
+		Long:    upgradeDesc + profileHelp + postRendererHelp + resolveImageDigestsHelp,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
@@ -145,7 +206,22 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			upgrade.release = args[0]
 			upgrade.chart = args[1]
 			upgrade.client = ensureHelmClient(upgrade.client)
-			upgrade.wait = upgrade.wait || upgrade.atomic
+			upgrade.wait = upgrade.wait || upgrade.atomic || upgrade.waitForJobs
+			if upgrade.validate && !upgrade.dryRun {
+				return errValidateWithoutDryRun
+			}
+			if upgrade.checkRecreated && !upgrade.dryRun {
+				return errCheckRecreatedWithoutDryRun
+			}
+			if upgrade.diff && outputFormat(upgrade.output) != outputTable {
+				return fmt.Errorf("--diff cannot be used with the %s output format", upgrade.output)
+			}
+			if err := checkPostRenderer(upgrade.postRenderer); err != nil {
+				return err
+			}
+			if err := checkRecreatePodsFor(upgrade.recreatePodsFor); err != nil {
+				return err
+			}
 
 			return upgrade.run()
 		},
@@ -153,15 +229,30 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
-	f.VarP(&upgrade.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
+	f.VarP(&upgrade.valueFiles, "values", "f", "Specify values in a YAML file, a URL or '-' for stdin (can specify multiple)")
+	f.StringVar(&upgrade.profile, "profile", "", "Layer the values-<profile>.yaml file of the chart over its default values, before the user-supplied values")
+	f.StringArrayVar(&upgrade.profileDirs, "profile-dir", []string{}, "Directories to look for the values-<profile>.yaml file of --profile in, after the chart (can specify multiple)")
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "Simulate an upgrade")
+	f.BoolVar(&upgrade.diff, "diff", false, "Print a diff of the rendered manifests against the current revision before upgrading")
+	f.BoolVar(&upgrade.validate, "validate", false, "With --dry-run, submit the rendered manifests to the Kubernetes API server as a server-side dry run to catch schema and admission errors")
+	f.BoolVar(&upgrade.checkRecreated, "check-recreated", false, "With --dry-run, list the resources that the Kubernetes API server refuses to update in place")
+	f.BoolVar(&upgrade.strict, "strict", false, "Fail the rendering on references to values that are not defined, instead of rendering them as empty")
+	f.StringVar(&upgrade.missingKey, "missing-key", "", "What to render for values that are not defined: \"zero\" for nothing, \"invalid\" for \"<no value>\" or \"error\" to fail. Defaults to the setting of the chart, or \"zero\"")
+	f.BoolVar(&upgrade.stripComments, "strip-comments", false, "Drop comments and documents left empty from the rendered manifests, to keep large releases small")
+	f.StringVar(&upgrade.postRenderer, "post-renderer", "", "The path to an executable that modifies the rendered manifests before they are deployed")
+	f.BoolVar(&upgrade.resolveImageDigests, "resolve-image-digests", false, "Pin the images of the rendered manifests to their digests, as reported by their registries, before upgrading")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "Performs pods restart for the resource if applicable")
-	f.BoolVar(&upgrade.force, "force", false, "Force resource update through delete/recreate if needed")
+	f.StringSliceVar(&upgrade.recreatePodsFor, "recreate-pods-for", []string{}, "Only restart the pods of these resources, given as kind/name (can specify multiple or separate them with commas: deployment/web,statefulset/db)")
+	f.BoolVar(&upgrade.force, "force", false, "Force resource update by retrying conflicting patches, then replacing the resources that still cannot be patched")
+	f.BoolVar(&upgrade.forceRecreate, "force-recreate", false, "With --force, delete and recreate the resources that cannot be replaced either. This interrupts the traffic to recreated Services")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&upgrade.jsonValues, "set-json", []string{}, "Set JSON values on the command line (can specify multiple or separate values with commas: key1=jsonval1,key2=jsonval2)")
 	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringArrayVar(&upgrade.envValues, "set-env", []string{}, "Set values from environment variables specified via the command line (can specify multiple or separate values with commas: key1=ENV1,key2=ENV2). A variable alone, such as DB_PASSWORD, sets the value of the same name")
 	f.BoolVar(&upgrade.disableHooks, "disable-hooks", false, "Disable pre/post upgrade hooks. DEPRECATED. Use no-hooks")
 	f.BoolVar(&upgrade.disableHooks, "no-hooks", false, "Disable pre/post upgrade hooks")
+	f.BoolVar(&upgrade.skipCRDs, "skip-crds", false, "Do not install the new CRDs of the crds/ directory of the chart")
 	f.BoolVar(&upgrade.verify, "verify", false, "Verify the provenance of the chart before upgrading")
 	f.StringVar(&upgrade.keyring, "keyring", defaultKeyring(), "Path to the keyring that contains public signing keys")
 	f.BoolVarP(&upgrade.install, "install", "i", false, "If a release by this name doesn't already exist, run an install")
@@ -170,7 +261,9 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.Int64Var(&upgrade.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "When upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "When upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored.")
+	f.BoolVar(&upgrade.resetThenReuseValues, "reset-then-reuse-values", false, "When upgrading, reset the values to the ones built into the chart, apply the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' or '--reuse-values' is specified, this is ignored.")
 	f.BoolVar(&upgrade.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&upgrade.waitForJobs, "wait-for-jobs", false, "If set, will also wait until all Jobs of the release have completed, also sets --wait flag")
 	f.BoolVar(&upgrade.atomic, "atomic", false, "If set, upgrade process rolls back changes made in case of failed upgrade, also sets --wait flag")
 	f.StringVar(&upgrade.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.username, "username", "", "Chart repository username where to locate the requested chart")
@@ -181,7 +274,9 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
 	f.BoolVar(&upgrade.subNotes, "render-subchart-notes", false, "Render subchart notes along with parent")
 	f.StringVar(&upgrade.description, "description", "", "Specify the description to use for the upgrade, rather than the default")
+	f.StringVar(&upgrade.setBy, "set-by", "", "Identity to record as the user who deployed the release, instead of the user of the kube context")
 	f.BoolVar(&upgrade.cleanupOnFail, "cleanup-on-fail", false, "Allow deletion of new resources created in this upgrade when upgrade failed")
+	f.Int32Var(&upgrade.maxHistory, "history-max", 0, "Limit the maximum number of revisions saved for this release, pruning the oldest superseded ones first. Use 0 for the Tiller default")
 	bindOutputFlag(cmd, &upgrade.output)
 
 	f.MarkDeprecated("disable-hooks", "Use --no-hooks instead")
@@ -224,29 +319,42 @@ func (u *upgradeCmd) run() error {
 		if err != nil && strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(u.release).Error()) {
 			fmt.Fprintf(u.out, "Release %q does not exist. Installing it now.\n", u.release)
 			ic := &installCmd{
-				chartPath:    chartPath,
-				client:       u.client,
-				out:          u.out,
-				name:         u.release,
-				valueFiles:   u.valueFiles,
-				dryRun:       u.dryRun,
-				verify:       u.verify,
-				disableHooks: u.disableHooks,
-				keyring:      u.keyring,
-				values:       u.values,
-				stringValues: u.stringValues,
-				fileValues:   u.fileValues,
-				namespace:    u.namespace,
-				timeout:      u.timeout,
-				wait:         u.wait,
-				description:  u.description,
-				atomic:       u.atomic,
+				chartPath:           chartPath,
+				client:              u.client,
+				out:                 u.out,
+				name:                u.release,
+				valueFiles:          u.valueFiles,
+				dryRun:              u.dryRun,
+				validate:            u.validate,
+				strict:              u.strict,
+				missingKey:          u.missingKey,
+				stripComments:       u.stripComments,
+				verify:              u.verify,
+				disableHooks:        u.disableHooks,
+				keyring:             u.keyring,
+				values:              u.values,
+				stringValues:        u.stringValues,
+				jsonValues:          u.jsonValues,
+				fileValues:          u.fileValues,
+				envValues:           u.envValues,
+				namespace:           u.namespace,
+				timeout:             u.timeout,
+				wait:                u.wait,
+				waitForJobs:         u.waitForJobs,
+				description:         u.description,
+				setBy:               u.setBy,
+				atomic:              u.atomic,
+				postRenderer:        u.postRenderer,
+				resolveImageDigests: u.resolveImageDigests,
+				skipCRDs:            u.skipCRDs,
+				profile:             u.profile,
+				profileDirs:         u.profileDirs,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.certFile, u.keyFile, u.caFile)
+	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.jsonValues, u.envValues, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
 	}
@@ -265,38 +373,81 @@ func (u *upgradeCmd) run() error {
 		return prettyError(err)
 	}
 
-	resp, err := u.client.UpdateReleaseFromChart(
-		u.release,
-		ch,
+	if err := applyProfile(ch, u.profile, u.profileDirs); err != nil {
+		return err
+	}
+
+	opts := []helm.UpdateOption{
 		helm.UpdateValueOverrides(rawVals),
 		helm.UpgradeDryRun(u.dryRun),
+		helm.UpgradeValidate(u.validate),
+		helm.UpgradeCheckRecreated(u.checkRecreated),
+		helm.UpgradeStrict(u.strict),
+		helm.UpgradeMissingKey(u.missingKey),
+		helm.UpgradeStripComments(u.stripComments),
 		helm.UpgradeRecreate(u.recreate),
+		helm.UpgradeRecreatePodsFor(u.recreatePodsFor),
 		helm.UpgradeForce(u.force),
+		helm.UpgradeForceRecreate(u.forceRecreate),
 		helm.UpgradeDisableHooks(u.disableHooks),
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
+		helm.ResetThenReuseValues(u.resetThenReuseValues),
 		helm.UpgradeSubNotes(u.subNotes),
 		helm.UpgradeWait(u.wait),
+		helm.UpgradeWaitForJobs(u.waitForJobs),
 		helm.UpgradeDescription(u.description),
-		helm.UpgradeCleanupOnFail(u.cleanupOnFail))
+		helm.UpgradeDeployedBy(deployingUser(u.setBy)),
+		helm.UpgradeCleanupOnFail(u.cleanupOnFail),
+		helm.UpgradeMaxHistory(u.maxHistory),
+		helm.UpgradeSkipCRDs(u.skipCRDs),
+	}
+	if u.postRenderer != "" || u.resolveImageDigests {
+		manifest, err := u.postRender(ch, opts)
+		if err != nil {
+			return prettyError(err)
+		}
+		opts = append(opts, helm.UpgradePostRenderedManifest(manifest))
+	}
+
+	if u.diff {
+		if err := u.printDiff(ch, opts); err != nil {
+			return prettyError(err)
+		}
+		if u.dryRun {
+			return nil
+		}
+	}
+
+	stopProgress := func() {}
+	if u.wait && !u.dryRun && outputFormat(u.output) == outputTable {
+		progress := &waitProgress{out: u.out, client: u.client, release: u.release}
+		stopProgress = progress.start(waitProgressInterval)
+	}
+	resp, err := u.client.UpdateReleaseFromChart(u.release, ch, opts...)
+	stopProgress()
 	if err != nil {
 		fmt.Fprintf(u.out, "UPGRADE FAILED\nError: %v\n", prettyError(err))
-		if u.atomic {
-			fmt.Fprint(u.out, "ROLLING BACK")
+		if u.atomic && releaseHistory != nil && len(releaseHistory.Releases) > 0 {
+			fmt.Fprintln(u.out, "ROLLING BACK")
 			rollback := &rollbackCmd{
-				out:           u.out,
-				client:        u.client,
-				name:          u.release,
-				dryRun:        u.dryRun,
-				recreate:      u.recreate,
-				force:         u.force,
-				timeout:       u.timeout,
-				wait:          u.wait,
-				description:   "",
-				revision:      releaseHistory.Releases[0].Version,
-				disableHooks:  u.disableHooks,
-				cleanupOnFail: u.cleanupOnFail,
+				out:             u.out,
+				client:          u.client,
+				name:            u.release,
+				dryRun:          u.dryRun,
+				recreate:        u.recreate,
+				recreatePodsFor: u.recreatePodsFor,
+				force:           u.force,
+				forceRecreate:   u.forceRecreate,
+				timeout:         u.timeout,
+				wait:            u.wait,
+				waitForJobs:     u.waitForJobs,
+				description:     "",
+				setBy:           u.setBy,
+				revision:        releaseHistory.Releases[0].Version,
+				disableHooks:    u.disableHooks,
+				cleanupOnFail:   u.cleanupOnFail,
 			}
 			if err := rollback.run(); err != nil {
 				return err
@@ -311,6 +462,8 @@ func (u *upgradeCmd) run() error {
 
 	if outputFormat(u.output) == outputTable {
 		fmt.Fprintf(u.out, "Release %q has been upgraded.\n", u.release)
+		printWarnings(u.out, resp.Release)
+		u.printRecreatedResources(resp.GetRecreatedResources())
 	}
 	// Print the status like status command does
 	status, err := u.client.ReleaseStatus(u.release)
@@ -318,5 +471,60 @@ func (u *upgradeCmd) run() error {
 		return prettyError(err)
 	}
 
-	return write(u.out, &statusWriter{status}, outputFormat(u.output))
+	return write(u.out, &statusWriter{status: status}, outputFormat(u.output))
+}
+
+// printRecreatedResources lists the resources that a dry run found cannot be
+// updated in place.
+func (u *upgradeCmd) printRecreatedResources(resources []string) {
+	if len(resources) == 0 {
+		return
+	}
+	if u.force && u.forceRecreate {
+		fmt.Fprintln(u.out, "The following resources cannot be updated in place and would be deleted and recreated:")
+	} else {
+		fmt.Fprintln(u.out, "The following resources cannot be updated in place, so the upgrade would fail unless --force and --force-recreate are used to recreate them:")
+	}
+	for _, r := range resources {
+		fmt.Fprintf(u.out, "  %s\n", r)
+	}
+}
+
+// postRender renders the upgrade as a dry run and pipes the result through the
+// post-renderer, pinning the images to their digests if asked to.
+func (u *upgradeCmd) postRender(ch *chart.Chart, opts []helm.UpdateOption) (string, error) {
+	dryRunOpts := append([]helm.UpdateOption{}, opts...)
+	dryRunOpts = append(dryRunOpts, helm.UpgradeDryRun(true), helm.UpgradeValidate(false), helm.UpgradeCheckRecreated(false))
+	res, err := u.client.UpdateReleaseFromChart(u.release, ch, dryRunOpts...)
+	if err != nil {
+		return "", err
+	}
+	return processManifests(releaseManifests(res.GetRelease()), u.postRenderer, u.resolveImageDigests)
+}
+
+// printDiff renders the upgrade as a dry run and prints how its manifests
+// differ from the ones of the current revision.
+func (u *upgradeCmd) printDiff(ch *chart.Chart, opts []helm.UpdateOption) error {
+	current, err := u.client.ReleaseContent(u.release)
+	if err != nil {
+		return err
+	}
+	target, err := u.client.UpdateReleaseFromChart(u.release, ch, append(opts, helm.UpgradeDryRun(true), helm.UpgradeCheckRecreated(false))...)
+	if err != nil {
+		return err
+	}
+	diffManifests(u.out, current.Release.Manifest, target.Release.Manifest, isTerminal(u.out))
+	return nil
+}
+
+// checkRecreatePodsFor checks that the resources whose pods are restarted are
+// given as kind/name.
+func checkRecreatePodsFor(resources []string) error {
+	for _, r := range resources {
+		parts := strings.SplitN(r, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid resource %q for --recreate-pods-for, expected kind/name such as deployment/web", r)
+		}
+	}
+	return nil
 }
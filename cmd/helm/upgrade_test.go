@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -99,6 +101,14 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"funny-bunny\" has been upgraded.\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
 		},
+		{
+			name:     "upgrade a release with json output",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"-o", "json"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch}),
+			expected: `^\{"name":"funny-bunny","info":\{.*\},"namespace":"default","version":2\}`,
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+		},
 		{
 			name:     "upgrade a release with timeout",
 			args:     []string{"funny-bunny", chartPath},
@@ -155,6 +165,44 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"crazy-bunny\" has been upgraded.\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 1, Chart: ch, Description: "foo"})},
 		},
+		{
+			name:     "upgrade a release with diff",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--diff"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch}),
+			expected: "No changes to the release manifests.\nSUMMARY: 0 to add, 0 to change, 0 to remove\nRelease \"funny-bunny\" has been upgraded.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+		},
+		{
+			name:     "only print the diff of a release with diff and dry-run",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--diff", "--dry-run"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch}),
+			expected: "^No changes to the release manifests.\nSUMMARY: 0 to add, 0 to change, 0 to remove\n$",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+		},
+		{
+			name:  "upgrade a release with check-recreated and without dry-run",
+			args:  []string{"funny-bunny", chartPath},
+			flags: []string{"--check-recreated"},
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+			err:   true,
+		},
+		{
+			name:  "upgrade a release with diff and json output",
+			args:  []string{"funny-bunny", chartPath},
+			flags: []string{"--diff", "-o", "json"},
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+			err:   true,
+		},
+		{
+			name:     "upgrade a release with history max",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--history-max", "5"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch}),
+			expected: "Release \"funny-bunny\" has been upgraded.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 2, Chart: ch})},
+		},
 		{
 			name:     "upgrade a release with wait",
 			args:     []string{"crazy-bunny", chartPath},
@@ -171,6 +219,21 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"crazy-bunny\" has been upgraded.\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 2, Chart: ch2, Description: "foo"})},
 		},
+		{
+			name:     "upgrade a release restarting the pods of some resources",
+			args:     []string{"crazy-bunny", chartPath},
+			flags:    []string{"--recreate-pods-for", "deployment/web,statefulset/db"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 3, Chart: ch2}),
+			expected: "Release \"crazy-bunny\" has been upgraded.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 3, Chart: ch2})},
+		},
+		{
+			name:  "upgrade a release restarting the pods of a resource without a kind",
+			args:  []string{"crazy-bunny", chartPath},
+			flags: []string{"--recreate-pods-for", "web"},
+			resp:  helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny", Version: 3, Chart: ch2}),
+			err:   true,
+		},
 		{
 			name: "upgrade a release with missing dependencies",
 			args: []string{"bonkers-bunny", missingDepsPath},
@@ -192,3 +255,52 @@ func TestUpgradeCmd(t *testing.T) {
 	runReleaseCases(t, tests, cmd)
 
 }
+
+func TestUpgradeDryRunRecreatedResources(t *testing.T) {
+	tests := []struct {
+		flags    []string
+		expected string
+	}{
+		{
+			flags:    []string{"--dry-run", "--check-recreated"},
+			expected: "would fail unless --force and --force-recreate are used to recreate them:\n  Service/funny-bunny\n",
+		},
+		{
+			flags:    []string{"--dry-run", "--check-recreated", "--force"},
+			expected: "would fail unless --force and --force-recreate are used to recreate them:\n  Service/funny-bunny\n",
+		},
+		{
+			flags:    []string{"--dry-run", "--check-recreated", "--force", "--force-recreate"},
+			expected: "would be deleted and recreated:\n  Service/funny-bunny\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		c := &helm.FakeClient{
+			Rels:               []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny"})},
+			RecreatedResources: []string{"Service/funny-bunny"},
+		}
+		cmd := newUpgradeCmd(c, &buf)
+		cmd.ParseFlags(tt.flags)
+		if err := cmd.RunE(cmd, []string{"funny-bunny", "testdata/testcharts/alpine"}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), tt.expected) {
+			t.Errorf("%v: expected %q in\n%s", tt.flags, tt.expected, buf.String())
+		}
+	}
+	var buf bytes.Buffer
+	c := &helm.FakeClient{
+		Rels:               []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny"})},
+		RecreatedResources: []string{"Service/funny-bunny"},
+	}
+	cmd := newUpgradeCmd(c, &buf)
+	cmd.ParseFlags([]string{"--dry-run"})
+	if err := cmd.RunE(cmd, []string{"funny-bunny", "testdata/testcharts/alpine"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "cannot be updated in place") {
+		t.Errorf("expected no recreated resources without --check-recreated in\n%s", buf.String())
+	}
+}
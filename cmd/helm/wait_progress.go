@@ -0,0 +1,122 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+)
+
+// waitProgressInterval is how often the resources of a release are checked
+// while Tiller waits for them to become ready.
+const waitProgressInterval = 10 * time.Second
+
+// waitProgress reports the resources of a release that are not ready yet
+// while an install, upgrade or rollback is blocked on --wait.
+//
+// Tiller does not stream the state of the wait, so the resources are read
+// from the manifest of the latest revision, which is stored before they are
+// created, and their live state is fetched from the cluster.
+type waitProgress struct {
+	out        io.Writer
+	client     helm.Interface
+	kubeClient statusKubeClient
+	release    string
+	started    time.Time
+}
+
+// start reports progress every interval until the returned function is called.
+func (p *waitProgress) start(interval time.Duration) (stop func()) {
+	p.started = time.Now()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := p.report(); err != nil {
+					debug("could not report wait progress: %s", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// report prints a line for each resource of the release that is not ready.
+func (p *waitProgress) report() error {
+	res, err := p.client.ReleaseContent(p.release)
+	if err != nil {
+		return err
+	}
+	rel := res.GetRelease()
+	if p.kubeClient == nil {
+		p.kubeClient = newKubeClient()
+	}
+	statuses, err := p.kubeClient.ResourceStatuses(rel.Namespace, strings.NewReader(rel.Manifest))
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, rs := range statuses {
+		if line, ready := progressLine(rs); !ready {
+			pending = append(pending, line)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	elapsed := time.Since(p.started).Round(time.Second)
+	fmt.Fprintf(p.out, "Waiting for %d resource(s) to be ready (%s elapsed):\n", len(pending), elapsed)
+	for _, line := range pending {
+		fmt.Fprintf(p.out, "  %s\n", line)
+	}
+	return nil
+}
+
+// progressLine describes the readiness of a resource, such as
+// "deployment/web 2/3 ready" or "persistentvolumeclaim/data Pending".
+func progressLine(rs kube.ResourceStatus) (string, bool) {
+	name := strings.ToLower(rs.Kind) + "/" + rs.Name
+	switch {
+	case rs.Missing:
+		return name + " not found", false
+	case rs.Phase == "Succeeded":
+		return name, true
+	case rs.Scalable && rs.Ready < rs.Desired:
+		return fmt.Sprintf("%s %d/%d ready", name, rs.Ready, rs.Desired), false
+	case rs.Phase == "Pending":
+		return name + " " + rs.Phase, false
+	}
+	return name, true
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestWaitProgressReport(t *testing.T) {
+	var buf bytes.Buffer
+	kc := &fakeStatusKubeClient{
+		statuses: []kube.ResourceStatus{
+			{Kind: "Deployment", Name: "web", Scalable: true, Desired: 3, Ready: 2},
+			{Kind: "PersistentVolumeClaim", Name: "data", Phase: "Pending"},
+			{Kind: "Service", Name: "web"},
+			{Kind: "Pod", Name: "migrate", Scalable: true, Desired: 1, Phase: "Succeeded"},
+			{Kind: "ConfigMap", Name: "settings", Missing: true},
+		},
+	}
+	p := &waitProgress{
+		out: &buf,
+		client: &helm.FakeClient{
+			Rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web"})},
+		},
+		kubeClient: kc,
+		release:    "web",
+		started:    time.Now(),
+	}
+
+	if err := p.report(); err != nil {
+		t.Fatal(err)
+	}
+	if kc.manifest != helm.MockManifest {
+		t.Errorf("expected the release manifest to be checked, got %q", kc.manifest)
+	}
+	expected := `^Waiting for 3 resource\(s\) to be ready \(\d+s elapsed\):
+  deployment/web 2/3 ready
+  persistentvolumeclaim/data Pending
+  configmap/settings not found
+$`
+	if !regexp.MustCompile(expected).MatchString(buf.String()) {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	kc.statuses = []kube.ResourceStatus{{Kind: "Deployment", Name: "web", Scalable: true, Desired: 3, Ready: 3}}
+	if err := p.report(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output once every resource is ready, got %q", buf.String())
+	}
+}
@@ -41,6 +41,7 @@ import (
 	// Import to initialize client auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/storage"
@@ -93,6 +94,13 @@ var (
 	maxHistory   = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
 	printVersion = flag.Bool("version", false, "print the version number")
 
+	releaseNameWords  = flag.String("release-name-words", "", "path to a YAML file listing the 'adjectives' and 'nouns' used to generate release names")
+	releaseNamePrefix = flag.String("release-name-prefix", "", "prefix of generated release names, such as the name of a team")
+
+	maxIncludeDepth = flag.Int("max-include-depth", engine.DefaultMaxIncludeDepth, "maximum number of nested 'include' and 'tpl' calls of a chart")
+	allowedEnv      = flag.String("template-env", "", "comma-separated list of the environment variables of Tiller that the 'env' template function may read")
+	chartFunctions  = flag.Bool("chart-functions", false, "enable the Starlark functions of the functions/ directory of charts, whose memory use is not bounded")
+
 	// rootServer is the root gRPC server.
 	//
 	// Each gRPC service registers itself to this server during start().
@@ -167,6 +175,19 @@ func start() {
 		env.Releases.MaxHistory = *maxHistory
 	}
 
+	namer, err := tiller.NewNamer(*releaseNameWords, *releaseNamePrefix)
+	if err != nil {
+		logger.Fatalf("Cannot configure release names: %s", err)
+	}
+
+	if e, ok := env.EngineYard[environment.GoTplEngine].(*engine.Engine); ok {
+		e.MaxIncludeDepth = *maxIncludeDepth
+		e.DisableChartFunctions = !*chartFunctions
+		if *allowedEnv != "" {
+			e.AllowedEnv = strings.Split(*allowedEnv, ",")
+		}
+	}
+
 	kubeClient := kube.New(nil)
 	kubeClient.Log = newLogger("kube").Printf
 	env.KubeClient = kubeClient
@@ -218,6 +239,7 @@ func start() {
 	go func() {
 		svc := tiller.NewReleaseServer(env, clientset, *remoteReleaseModules)
 		svc.Log = newLogger("tiller").Printf
+		svc.Namer = namer
 		services.RegisterReleaseServiceServer(rootServer, svc)
 		if err := rootServer.Serve(lstn); err != nil {
 			srvErrCh <- err
@@ -50,6 +50,12 @@ type Capabilities struct {
 	//
 	// This always comes from pkg/version.GetVersionProto().
 	TillerVersion *tversion.Version
+	// HelmVersion is the version of Helm rendering the chart. Tiller only
+	// serves clients of its own minor version, so when Tiller renders, this
+	// is the Tiller version.
+	//
+	// This always comes from pkg/version.GetVersionProto().
+	HelmVersion *tversion.Version
 }
 
 // VersionSet is a set of Kubernetes API versions.
@@ -23,7 +23,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/Masterminds/semver"
 	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes/any"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
@@ -33,6 +35,29 @@ import (
 // This is ApiVersionV1 instead of APIVersionV1 to match the protobuf-generated name.
 const ApiVersionV1 = "v1" // nolint
 
+// ApiVersionV2 is the API version number for version 2.
+//
+// A version 2 chart lists its dependencies in the 'dependencies' field of
+// Chart.yaml, instead of in requirements.yaml, and locks them in Chart.lock.
+const ApiVersionV2 = "v2" // nolint
+
+// chartfileV2 is the Chart.yaml of a version 2 chart.
+type chartfileV2 struct {
+	*chart.Metadata
+	Dependencies []*Dependency `json:"dependencies,omitempty"`
+}
+
+// The types of chart, set by the 'type' field of Chart.yaml.
+const (
+	// ApplicationChart is a chart that can be installed. A chart without a
+	// type is an application chart.
+	ApplicationChart = "application"
+	// LibraryChart is a chart that only provides named templates to the
+	// charts that depend on it. Its other templates are not rendered, and it
+	// cannot be installed on its own.
+	LibraryChart = "library"
+)
+
 // UnmarshalChartfile takes raw Chart.yaml data and unmarshals it.
 func UnmarshalChartfile(data []byte) (*chart.Metadata, error) {
 	y := &chart.Metadata{}
@@ -63,6 +88,160 @@ func SaveChartfile(filename string, cf *chart.Metadata) error {
 	return ioutil.WriteFile(filename, out, 0644)
 }
 
+// ValidateChartfileV2 checks the Chart.yaml of a version 2 chart, which must
+// have a name, a semantic version and a known type, and whose dependencies must
+// each have a name and a version, and a unique name or alias.
+func ValidateChartfileV2(cf *chart.Metadata, deps []*Dependency) error {
+	if cf.Name == "" {
+		return errors.New("name is required")
+	}
+	if cf.Version == "" {
+		return errors.New("version is required")
+	}
+	if _, err := semver.NewVersion(cf.Version); err != nil {
+		return fmt.Errorf("version '%s' is not a valid SemVer", cf.Version)
+	}
+	if err := ValidateChartType(cf); err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for i, dep := range deps {
+		if dep.Name == "" {
+			return fmt.Errorf("dependency %d has no name", i+1)
+		}
+		if dep.Version == "" {
+			return fmt.Errorf("dependency %q has no version", dep.Name)
+		}
+		name := dep.Name
+		if dep.Alias != "" {
+			name = dep.Alias
+		}
+		if names[name] {
+			return fmt.Errorf("more than one dependency is named %q; give them different aliases", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// loadChartfileV2 reads the dependencies of a version 2 chart from its
+// Chart.yaml, validates it and keeps the dependencies as the requirements of
+// the chart, where LoadRequirements and Tiller find them as for a version 1
+// chart.
+func loadChartfileV2(c *chart.Chart, data []byte) error {
+	cf := &chartfileV2{Metadata: &chart.Metadata{}}
+	if err := yaml.Unmarshal(data, cf); err != nil {
+		return err
+	}
+	if err := ValidateChartfileV2(c.Metadata, cf.Dependencies); err != nil {
+		return fmt.Errorf("invalid chart (Chart.yaml): %s", err)
+	}
+	for _, f := range c.Files {
+		if f.TypeUrl == requirementsName || f.TypeUrl == lockfileName {
+			return fmt.Errorf("%s is not allowed in an apiVersion %s chart: the dependencies are listed in Chart.yaml and locked in %s", f.TypeUrl, ApiVersionV2, chartLockfileName)
+		}
+	}
+	if len(cf.Dependencies) == 0 {
+		return nil
+	}
+	reqs, err := yaml.Marshal(&Requirements{Dependencies: cf.Dependencies})
+	if err != nil {
+		return err
+	}
+	c.Files = append(c.Files, &any.Any{TypeUrl: requirementsName, Value: reqs})
+	return nil
+}
+
+// marshalChartfile returns the Chart.yaml of a chart. The requirements of a
+// version 2 chart are written back to its dependencies.
+func marshalChartfile(c *chart.Chart) ([]byte, error) {
+	if c.Metadata.ApiVersion != ApiVersionV2 {
+		return yaml.Marshal(c.Metadata)
+	}
+	cf := &chartfileV2{Metadata: c.Metadata}
+	reqs, err := LoadRequirements(c)
+	if err == nil {
+		cf.Dependencies = reqs.Dependencies
+	} else if err != ErrRequirementsNotFound {
+		return nil, err
+	}
+	return yaml.Marshal(cf)
+}
+
+// ConvertChartfileToV2 converts the chart in dirname to a version 2 chart.
+// The dependencies of requirements.yaml move to Chart.yaml, and
+// requirements.lock is renamed Chart.lock. Charts that are already version 2
+// are left as they are.
+func ConvertChartfileToV2(dirname string) error {
+	c, err := LoadDir(dirname)
+	if err != nil {
+		return err
+	}
+	if c.Metadata.ApiVersion == ApiVersionV2 {
+		return nil
+	}
+
+	c.Metadata.ApiVersion = ApiVersionV2
+	cf := &chartfileV2{Metadata: c.Metadata}
+	reqs, err := LoadRequirements(c)
+	if err == nil {
+		cf.Dependencies = reqs.Dependencies
+	} else if err != ErrRequirementsNotFound {
+		return err
+	}
+	if err := ValidateChartfileV2(cf.Metadata, cf.Dependencies); err != nil {
+		return fmt.Errorf("cannot convert %s: %s", dirname, err)
+	}
+	data, err := yaml.Marshal(cf)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dirname, ChartfileName), data, 0644); err != nil {
+		return err
+	}
+
+	lock := filepath.Join(dirname, lockfileName)
+	if _, err := os.Stat(lock); err == nil {
+		if err := os.Rename(lock, filepath.Join(dirname, chartLockfileName)); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(filepath.Join(dirname, requirementsName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ValidateChartType fails if the type of a chart is not one of the known
+// types.
+func ValidateChartType(cf *chart.Metadata) error {
+	switch cf.Type {
+	case "", ApplicationChart, LibraryChart:
+		return nil
+	}
+	return fmt.Errorf("chart type %q is not valid. Valid types are %q and %q", cf.Type, ApplicationChart, LibraryChart)
+}
+
+// IsLibraryChart reports whether a chart is a library chart.
+func IsLibraryChart(c *chart.Chart) bool {
+	return c.Metadata != nil && c.Metadata.Type == LibraryChart
+}
+
+// CheckInstallable fails for charts that cannot be installed: library charts,
+// and charts of an unknown type.
+func CheckInstallable(c *chart.Chart) error {
+	if c.Metadata != nil {
+		if err := ValidateChartType(c.Metadata); err != nil {
+			return err
+		}
+	}
+	if IsLibraryChart(c) {
+		return fmt.Errorf("%s is a library chart, which cannot be installed; it can only be a dependency of other charts", c.Metadata.Name)
+	}
+	return nil
+}
+
 // IsChartDir validate a chart directory.
 //
 // Checks for a valid Chart.yaml.
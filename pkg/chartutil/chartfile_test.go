@@ -17,6 +17,10 @@ limitations under the License.
 package chartutil
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -120,3 +124,64 @@ func TestIsChartDir(t *testing.T) {
 		return
 	}
 }
+
+func TestCheckInstallable(t *testing.T) {
+	tests := []struct {
+		typ     string
+		wantErr string
+	}{
+		{"", ""},
+		{ApplicationChart, ""},
+		{LibraryChart, "library chart"},
+		{"plugin", `chart type "plugin" is not valid`},
+	}
+	for _, tt := range tests {
+		c := &chart.Chart{Metadata: &chart.Metadata{Name: "common", Type: tt.typ}}
+		err := CheckInstallable(c)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error: %s", tt.typ, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: expected an error containing %q, got %v", tt.typ, tt.wantErr, err)
+		}
+	}
+}
+
+func TestConvertChartfileToV2(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c, err := Load("testdata/frobnitz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveDir(c, tmp); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(tmp, "frobnitz")
+
+	if err := ConvertChartfileToV2(dir); err != nil {
+		t.Fatalf("Failed to convert: %s", err)
+	}
+	for _, name := range []string{"requirements.yaml", "requirements.lock"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", name, err)
+		}
+	}
+
+	c, err = Load(dir)
+	if err != nil {
+		t.Fatalf("Failed to load the converted chart: %s", err)
+	}
+	if c.Metadata.ApiVersion != ApiVersionV2 {
+		t.Errorf("Expected apiVersion %s, got %q", ApiVersionV2, c.Metadata.ApiVersion)
+	}
+	verifyRequirements(t, c)
+	verifyRequirementsLock(t, c)
+}
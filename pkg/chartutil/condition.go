@@ -0,0 +1,342 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// conditionValues is the name under which condition expressions refer to the
+// values of the chart.
+const conditionValues = "values"
+
+// isConditionExpression reports whether the condition of a requirement is an
+// expression, such as
+//
+//	values.global.env == "prod" && values.metrics.enabled
+//
+// rather than a comma-separated list of value paths. Expressions use an
+// operator, parentheses or a quoted string, so a lone path such as
+// 'values.enabled' remains a path condition; '(values.enabled)' is the
+// expression.
+func isConditionExpression(cond string) bool {
+	return strings.ContainsAny(cond, "=!<>&|()\"'")
+}
+
+// evalCondition evaluates a condition expression on the values of a chart.
+//
+// Expressions are made of literals (strings in double or single quotes,
+// numbers, true, false and null), value paths, the comparisons ==, !=, <, <=,
+// > and >=, the boolean operators !, && and ||, and parentheses. A path that
+// is not set is null, and null is false for the boolean operators. There are
+// no functions or loops, so expressions are safe to evaluate on any chart.
+func evalCondition(cond string, vals Values) (interface{}, error) {
+	tokens, err := lexCondition(cond)
+	if err != nil {
+		return nil, err
+	}
+	p := &conditionParser{tokens: tokens, values: vals}
+	v, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos])
+	}
+	return v, nil
+}
+
+// lexCondition splits a condition expression into operators, quoted strings
+// and words, which are names, paths and numbers.
+func lexCondition(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case i+1 < len(s) && isConditionOperator(s[i:i+2]):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case strings.IndexByte("!<>()", c) >= 0:
+			tokens = append(tokens, s[i:i+1])
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && c == '"' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string %s", s[i:])
+			}
+			tokens = append(tokens, s[i:j+1])
+			i = j + 1
+		case isConditionWordByte(c):
+			j := i
+			for j < len(s) && isConditionWordByte(s[j]) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isConditionOperator(s string) bool {
+	switch s {
+	case "&&", "||", "==", "!=", "<=", ">=":
+		return true
+	}
+	return false
+}
+
+func isConditionWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
+
+// conditionParser evaluates the tokens of a condition expression as it parses
+// them, by recursive descent.
+type conditionParser struct {
+	tokens []string
+	pos    int
+	values Values
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *conditionParser) or() (interface{}, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l, err := conditionTruth(left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := conditionTruth(right)
+		if err != nil {
+			return nil, err
+		}
+		left = l || r
+	}
+	return left, nil
+}
+
+func (p *conditionParser) and() (interface{}, error) {
+	left, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		l, err := conditionTruth(left)
+		if err != nil {
+			return nil, err
+		}
+		r, err := conditionTruth(right)
+		if err != nil {
+			return nil, err
+		}
+		left = l && r
+	}
+	return left, nil
+}
+
+func (p *conditionParser) comparison() (interface{}, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return compareCondition(op, left, right)
+	}
+	return left, nil
+}
+
+func (p *conditionParser) unary() (interface{}, error) {
+	if p.peek() == "!" {
+		p.next()
+		v, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		b, err := conditionTruth(v)
+		if err != nil {
+			return nil, err
+		}
+		return !b, nil
+	}
+	return p.primary()
+}
+
+func (p *conditionParser) primary() (interface{}, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, errors.New("unexpected end of the expression")
+	case t == "(":
+		v, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("missing )")
+		}
+		return v, nil
+	case t[0] == '"':
+		return strconv.Unquote(t)
+	case t[0] == '\'':
+		return t[1 : len(t)-1], nil
+	case t == "true":
+		return true, nil
+	case t == "false":
+		return false, nil
+	case t == "null":
+		return nil, nil
+	case t == conditionValues || strings.HasPrefix(t, conditionValues+"."):
+		return p.lookup(t), nil
+	case isConditionWordByte(t[0]):
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("unknown name %s; values are referred to as %s.<path>", t, conditionValues)
+	}
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+// lookup returns the value at a path starting with 'values', or nil if it is
+// not set.
+func (p *conditionParser) lookup(path string) interface{} {
+	var v interface{} = map[string]interface{}(p.values)
+	for _, key := range strings.Split(path, ".")[1:] {
+		switch m := v.(type) {
+		case map[string]interface{}:
+			v = m[key]
+		case Values:
+			v = m[key]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// conditionTruth returns the boolean value of an operand of !, && or ||.
+func conditionTruth(v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	}
+	return false, fmt.Errorf("%v is not a boolean", v)
+}
+
+// compareCondition applies a comparison operator. Numbers compare with
+// numbers and strings with strings; equality also applies to booleans and
+// null.
+func compareCondition(op string, a, b interface{}) (interface{}, error) {
+	if fa, ok := conditionNumber(a); ok {
+		if fb, ok := conditionNumber(b); ok {
+			return compareOrdered(op, fa < fb, fa == fb), nil
+		}
+	}
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return compareOrdered(op, sa < sb, sa == sb), nil
+		}
+	}
+	if op != "==" && op != "!=" {
+		return nil, fmt.Errorf("cannot compare %v %s %v", a, op, b)
+	}
+	switch a.(type) {
+	case nil, bool, string, float64, int, int64:
+	default:
+		return nil, fmt.Errorf("cannot compare %v %s %v", a, op, b)
+	}
+	switch b.(type) {
+	case nil, bool, string, float64, int, int64:
+	default:
+		return nil, fmt.Errorf("cannot compare %v %s %v", a, op, b)
+	}
+	return (a == b) == (op == "=="), nil
+}
+
+func compareOrdered(op string, less, equal bool) bool {
+	switch op {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	}
+	return !less
+}
+
+// conditionNumber returns a number of the values, which are float64 when read
+// from YAML and may be integers when set programmatically, as a float64.
+func conditionNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
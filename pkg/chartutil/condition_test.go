@@ -0,0 +1,121 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvalCondition(t *testing.T) {
+	vals := Values{
+		"global":   map[string]interface{}{"env": "prod"},
+		"metrics":  map[string]interface{}{"enabled": true},
+		"replicas": float64(3),
+		"debug":    false,
+		"count":    int64(2),
+	}
+
+	tests := []struct {
+		cond   string
+		expect interface{}
+	}{
+		{`values.global.env == "prod" && values.metrics.enabled`, true},
+		{`values.global.env == 'dev' || !values.metrics.enabled`, false},
+		{`values.replicas >= 3 && values.replicas < 4`, true},
+		{`values.count == 2 && values.count != values.replicas`, true},
+		{`!(values.debug || values.missing.enabled)`, true},
+		{`values.missing.enabled`, nil},
+		{`values.missing == null`, true},
+		{`values.global.env > "dev"`, true},
+		{`values.debug`, false},
+	}
+	for _, tt := range tests {
+		v, err := evalCondition(tt.cond, vals)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.cond, err)
+			continue
+		}
+		if v != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.cond, tt.expect, v)
+		}
+	}
+
+	failures := []struct {
+		cond   string
+		expect string
+	}{
+		{`values.replicas && true`, "3 is not a boolean"},
+		{`values.global < 3`, "cannot compare"},
+		{`global.env == "prod"`, "unknown name global.env"},
+		{`values.global.env == "prod`, "unterminated string"},
+		{`(values.debug`, "missing )"},
+		{`values.debug values.debug`, "unexpected values.debug"},
+		{`values.debug ==`, "unexpected end"},
+	}
+	for _, tt := range failures {
+		_, err := evalCondition(tt.cond, vals)
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.cond, tt.expect, err)
+		}
+	}
+}
+
+func TestProcessRequirementsConditionExpressions(t *testing.T) {
+	reqs := &Requirements{Dependencies: []*Dependency{
+		{Name: "prometheus", Condition: `values.global.env == "prod" && values.metrics.enabled`, Enabled: true},
+		{Name: "grafana", Condition: `values.global.env != "prod"`, Enabled: true},
+		{Name: "mysql", Condition: "mysql.enabled", Enabled: true},
+		{Name: "broken", Condition: `values.global.env ==`, Enabled: true},
+	}}
+	vals := Values{
+		"global":  map[string]interface{}{"env": "prod"},
+		"metrics": map[string]interface{}{"enabled": true},
+		"mysql":   map[string]interface{}{"enabled": false},
+	}
+
+	ProcessRequirementsConditions(reqs, vals)
+
+	expect := map[string]bool{"prometheus": true, "grafana": false, "mysql": false, "broken": true}
+	for _, r := range reqs.Dependencies {
+		if r.Enabled != expect[r.Name] {
+			t.Errorf("Expected %s to be enabled: %t, got %t", r.Name, expect[r.Name], r.Enabled)
+		}
+	}
+
+	err := processRequirementsConditions(reqs, vals)
+	if err == nil || !strings.Contains(err.Error(), "condition 'values.global.env ==' for chart broken cannot be evaluated") {
+		t.Errorf("Expected the broken condition to fail, got %v", err)
+	}
+}
+
+func TestIsConditionExpression(t *testing.T) {
+	tests := map[string]bool{
+		"mysql.enabled":                        false,
+		"mysql.enabled,global.mysql.enabled":   false,
+		"values.enabled":                       false,
+		"(values.enabled)":                     true,
+		"!values.enabled":                      true,
+		`values.global.env == "prod"`:          true,
+		"values.a.enabled || values.b.enabled": true,
+	}
+	for cond, expect := range tests {
+		if got := isConditionExpression(cond); got != expect {
+			t.Errorf("%s: expected an expression: %t, got %t", cond, expect, got)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The Helm Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// CRDsDir is the directory of a chart that holds CustomResourceDefinitions.
+//
+// The files in it are not templates: they are installed as they are, before
+// the chart is rendered.
+const CRDsDir = "crds"
+
+// CRDs returns the YAML and JSON files in the crds/ directory of a chart and
+// of its dependencies, the chart's own files first.
+func CRDs(c *chart.Chart) []*any.Any {
+	if c == nil {
+		return nil
+	}
+	var crds []*any.Any
+	for _, f := range c.Files {
+		if !strings.HasPrefix(f.TypeUrl, CRDsDir+"/") {
+			continue
+		}
+		switch path.Ext(f.TypeUrl) {
+		case ".yaml", ".yml", ".json":
+			crds = append(crds, f)
+		}
+	}
+	for _, dep := range c.Dependencies {
+		crds = append(crds, CRDs(dep)...)
+	}
+	return crds
+}
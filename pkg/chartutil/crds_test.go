@@ -0,0 +1,56 @@
+/*
+Copyright The Helm Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestCRDs(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent"},
+		Files: []*any.Any{
+			{TypeUrl: "README.md"},
+			{TypeUrl: "crds/crontab.yaml"},
+			{TypeUrl: "crds/README.md"},
+			{TypeUrl: "crds/nested/widget.json"},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "child"},
+				Files:    []*any.Any{{TypeUrl: "crds/gadget.yml"}},
+			},
+		},
+	}
+
+	var paths []string
+	for _, f := range CRDs(c) {
+		paths = append(paths, f.TypeUrl)
+	}
+	expect := []string{"crds/crontab.yaml", "crds/nested/widget.json", "crds/gadget.yml"}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v", expect, paths)
+	}
+
+	if crds := CRDs(nil); crds != nil {
+		t.Errorf("expected no CRDs for a nil chart, got %v", crds)
+	}
+}
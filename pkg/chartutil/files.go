@@ -17,10 +17,13 @@ package chartutil
 
 import (
 	"bytes"
+	"container/list"
 	"encoding/base64"
 	"encoding/json"
 	"path"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/ghodss/yaml"
 
@@ -49,6 +52,9 @@ func NewFiles(from []*any.Any) Files {
 //
 // This is intended to be accessed from within a template, so a missed key returns
 // an empty []byte.
+//
+// The contents are not copied: the returned slice is the one held by the chart,
+// and must not be modified.
 func (f Files) GetBytes(name string) []byte {
 	v, ok := f[name]
 	if !ok {
@@ -68,18 +74,17 @@ func (f Files) Get(name string) string {
 }
 
 // Glob takes a glob pattern and returns another files object only containing
-// matched  files.
+// matched  files. The matched files share their contents with f, so globbing
+// a chart full of large files does not copy them. Patterns are compiled once
+// and reused, as templates often glob in loops.
 //
 // This is designed to be called from a template.
 //
-// {{ range $name, $content := .Files.Glob("foo/**") }}
+// {{ range $name, $content := .Files.Glob "foo/**" }}
 // {{ $name }}: |
-// {{ .Files.Get($name) | indent 4 }}{{ end }}
+// {{ $.Files.Get $name | indent 4 }}{{ end }}
 func (f Files) Glob(pattern string) Files {
-	g, err := glob.Compile(pattern, '/')
-	if err != nil {
-		g, _ = glob.Compile("**")
-	}
+	g := compileGlob(pattern)
 
 	nf := NewFiles(nil)
 	for name, contents := range f {
@@ -91,6 +96,85 @@ func (f Files) Glob(pattern string) Files {
 	return nf
 }
 
+// File is a file of a chart, as iterated over with Files.Iter. Its contents
+// are only looked up when Get or GetBytes is called.
+type File struct {
+	// Name is the path of the file in the chart.
+	Name  string
+	files Files
+}
+
+// GetBytes returns the contents of the file, without copying them.
+func (f File) GetBytes() []byte {
+	return f.files.GetBytes(f.Name)
+}
+
+// Get returns the contents of the file as a string.
+func (f File) Get() string {
+	return f.files.Get(f.Name)
+}
+
+// Iter returns the files matching a glob pattern, sorted by name. Unlike
+// Glob, it builds no Files map, and the contents of a file are only read when
+// its Get or GetBytes method is called, so templates that only need the names
+// of many large files do not touch their contents.
+//
+// This is designed to be called from a template.
+//
+// {{ range .Files.Iter "dashboards/*.json" }}
+// {{ base .Name }}: {{ .Get | quote }}{{ end }}
+func (f Files) Iter(pattern string) []File {
+	g := compileGlob(pattern)
+
+	var matched []File
+	for name := range f {
+		if g.Match(name) {
+			matched = append(matched, File{Name: name, files: f})
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// maxGlobs is the number of compiled patterns of Files.Glob that are kept.
+const maxGlobs = 256
+
+// globs holds the most recently used compiled patterns of Files.Glob, so that
+// a pattern built from values cannot grow it without bound. Templates are
+// rendered concurrently, so it is guarded by a lock.
+var globs = struct {
+	sync.Mutex
+	order    *list.List
+	compiled map[string]*list.Element
+}{order: list.New(), compiled: map[string]*list.Element{}}
+
+// compiledGlob is a compiled pattern in the globs cache.
+type compiledGlob struct {
+	pattern string
+	glob    glob.Glob
+}
+
+// compileGlob compiles a pattern of Files.Glob and Files.Iter, falling back
+// to matching every file if it is invalid.
+func compileGlob(pattern string) glob.Glob {
+	globs.Lock()
+	defer globs.Unlock()
+	if e, ok := globs.compiled[pattern]; ok {
+		globs.order.MoveToFront(e)
+		return e.Value.(*compiledGlob).glob
+	}
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		g, _ = glob.Compile("**")
+	}
+	globs.compiled[pattern] = globs.order.PushFront(&compiledGlob{pattern: pattern, glob: g})
+	if globs.order.Len() > maxGlobs {
+		oldest := globs.order.Remove(globs.order.Back()).(*compiledGlob)
+		delete(globs.compiled, oldest.pattern)
+	}
+	return g
+}
+
 // AsConfig turns a Files group and flattens it to a YAML map suitable for
 // including in the 'data' section of a Kubernetes ConfigMap definition.
 // Duplicate keys will be overwritten, so be aware that your file names
@@ -104,7 +188,10 @@ func (f Files) Glob(pattern string) Files {
 // 'indent' template function.
 //
 //   data:
-// {{ .Files.Glob("config/**").AsConfig() | indent 4 }}
+// {{ (.Files.Glob "config/**").AsConfig | indent 4 }}
+//
+// Files that are not UTF-8 text, such as images or DER certificates, cannot
+// be in 'data'; use AsBinaryConfig for them.
 func (f Files) AsConfig() string {
 	if f == nil {
 		return ""
@@ -133,8 +220,27 @@ func (f Files) AsConfig() string {
 // 'indent' template function.
 //
 //   data:
-// {{ .Files.Glob("secrets/*").AsSecrets() }}
+// {{ (.Files.Glob "secrets/*").AsSecrets | indent 4 }}
 func (f Files) AsSecrets() string {
+	return f.asBase64()
+}
+
+// AsBinaryConfig returns the base64-encoded value of a Files object suitable
+// for including in the 'binaryData' section of a Kubernetes ConfigMap
+// definition, which holds files that are not UTF-8 text. As with AsConfig,
+// the keys are the file names, regardless of path.
+//
+// This is designed to be called from a template, and will return empty string
+// (via ToYaml function) if it cannot be serialized to YAML, or if the Files
+// object is nil.
+//
+//   binaryData:
+// {{ (.Files.Glob "certs/*.der").AsBinaryConfig | indent 4 }}
+func (f Files) AsBinaryConfig() string {
+	return f.asBase64()
+}
+
+func (f Files) asBase64() string {
 	if f == nil {
 		return ""
 	}
@@ -168,12 +274,19 @@ func (f Files) Lines(path string) []string {
 //
 // This is designed to be called from a template.
 func ToYaml(v interface{}) string {
-	data, err := yaml.Marshal(v)
+	data, err := MustToYaml(v)
 	if err != nil {
 		// Swallow errors inside of a template.
 		return ""
 	}
-	return string(data)
+	return data
+}
+
+// MustToYaml is like ToYaml, but returns the marshaling error, so that a
+// template calling it fails.
+func MustToYaml(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	return string(data), err
 }
 
 // FromYaml converts a YAML document into a map[string]interface{}.
@@ -183,14 +296,21 @@ func ToYaml(v interface{}) string {
 // it tolerates errors. It will insert the returned error message string into
 // m["Error"] in the returned map.
 func FromYaml(str string) map[string]interface{} {
-	m := map[string]interface{}{}
-
-	if err := yaml.Unmarshal([]byte(str), &m); err != nil {
+	m, err := MustFromYaml(str)
+	if err != nil {
 		m["Error"] = err.Error()
 	}
 	return m
 }
 
+// MustFromYaml is like FromYaml, but returns the parse error instead of
+// setting m["Error"].
+func MustFromYaml(str string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(str), &m)
+	return m, err
+}
+
 // ToToml takes an interface, marshals it to toml, and returns a string. It will
 // always return a string, even on marshal error (empty string).
 //
@@ -205,18 +325,68 @@ func ToToml(v interface{}) string {
 	return b.String()
 }
 
+// FromYamlArray converts a YAML document holding a list into a
+// []interface{}. Like FromYaml, it tolerates errors: the error message is
+// returned as the only element of the list.
+func FromYamlArray(str string) []interface{} {
+	a := []interface{}{}
+	if err := yaml.Unmarshal([]byte(str), &a); err != nil {
+		a = []interface{}{err.Error()}
+	}
+	return a
+}
+
+// MustToToml is like ToToml, but returns the marshaling error instead of
+// rendering it.
+func MustToToml(v interface{}) (string, error) {
+	b := bytes.NewBuffer(nil)
+	if err := toml.NewEncoder(b).Encode(v); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 // ToJson takes an interface, marshals it to json, and returns a string. It will
 // always return a string, even on marshal error (empty string).
 //
 // This is designed to be called from a template.
 // TODO: change the function signature in Helm 3
 func ToJson(v interface{}) string { // nolint
+	data, err := MustToJson(v)
+	if err != nil {
+		// Swallow errors inside of a template.
+		return ""
+	}
+	return data
+}
+
+// MustToJson is like ToJson, but returns the marshaling error.
+func MustToJson(v interface{}) (string, error) { // nolint
 	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// ToRawJson is like ToJson, but does not escape the characters that are
+// special in HTML, such as '<', '>' and '&', which are common in
+// configuration that is not meant for a web page.
+func ToRawJson(v interface{}) string { // nolint
+	data, err := MustToRawJson(v)
 	if err != nil {
 		// Swallow errors inside of a template.
 		return ""
 	}
-	return string(data)
+	return data
+}
+
+// MustToRawJson is like ToRawJson, but returns the marshaling error.
+func MustToRawJson(v interface{}) (string, error) { // nolint
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
 }
 
 // FromJson converts a JSON document into a map[string]interface{}.
@@ -227,10 +397,28 @@ func ToJson(v interface{}) string { // nolint
 // m["Error"] in the returned map.
 // TODO: change the function signature in Helm 3
 func FromJson(str string) map[string]interface{} { // nolint
-	m := map[string]interface{}{}
-
-	if err := json.Unmarshal([]byte(str), &m); err != nil {
+	m, err := MustFromJson(str)
+	if err != nil {
 		m["Error"] = err.Error()
 	}
 	return m
 }
+
+// MustFromJson is like FromJson, but returns the parse error instead of
+// setting m["Error"].
+func MustFromJson(str string) (map[string]interface{}, error) { // nolint
+	m := map[string]interface{}{}
+	err := json.Unmarshal([]byte(str), &m)
+	return m, err
+}
+
+// FromJsonArray converts a JSON document holding a list into a
+// []interface{}. Like FromJson, it tolerates errors: the error message is
+// returned as the only element of the list.
+func FromJsonArray(str string) []interface{} { // nolint
+	a := []interface{}{}
+	if err := json.Unmarshal([]byte(str), &a); err != nil {
+		a = []interface{}{err.Error()}
+	}
+	return a
+}
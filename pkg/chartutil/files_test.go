@@ -16,6 +16,8 @@ limitations under the License.
 package chartutil
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/any"
@@ -65,6 +67,42 @@ func TestFileGlob(t *testing.T) {
 
 	as.Len(matched, 2, "Should be two files in glob story/**")
 	as.Equal("Joseph Conrad", matched.Get("story/author.txt"))
+
+	// The compiled pattern is reused, and the contents are shared.
+	again := f.Glob("story/**")
+	as.Len(again, 2)
+	as.True(&again.GetBytes("story/author.txt")[0] == &f.GetBytes("story/author.txt")[0])
+}
+
+func TestFileIter(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+
+	matched := f.Iter("story/**")
+	as.Len(matched, 2, "Should be two files in iter story/**")
+	as.Equal("story/author.txt", matched[0].Name)
+	as.Equal("story/name.txt", matched[1].Name)
+	as.Equal("Joseph Conrad", matched[0].Get())
+	as.True(&matched[0].GetBytes()[0] == &f.GetBytes("story/author.txt")[0])
+
+	as.Empty(f.Iter("nothing/**"))
+}
+
+func TestFileGlobCacheBound(t *testing.T) {
+	f := NewFiles(getTestFiles())
+	for i := 0; i < 2*maxGlobs; i++ {
+		f.Glob(fmt.Sprintf("story/%d/**", i))
+	}
+	if n := len(globs.compiled); n > maxGlobs {
+		t.Errorf("Expected at most %d compiled patterns, got %d", maxGlobs, n)
+	}
+	if _, ok := globs.compiled[fmt.Sprintf("story/%d/**", 2*maxGlobs-1)]; !ok {
+		t.Error("Expected the last pattern to be kept")
+	}
+	if _, ok := globs.compiled["story/0/**"]; ok {
+		t.Error("Expected the first pattern to be dropped")
+	}
 }
 
 func TestToConfig(t *testing.T) {
@@ -87,6 +125,16 @@ func TestToSecret(t *testing.T) {
 	as.Equal("captain.txt: VGhlIENhcHRhaW4=\nstowaway.txt: TGVnYXR0\n", out)
 }
 
+func TestToBinaryConfig(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+
+	out := f.Glob("ship/**").AsBinaryConfig()
+	as.Equal("captain.txt: VGhlIENhcHRhaW4=\nstowaway.txt: TGVnYXR0\n", out)
+	as.Equal("", Files(nil).AsBinaryConfig())
+}
+
 func TestLines(t *testing.T) {
 	as := assert.New(t)
 
@@ -215,3 +263,40 @@ func TestFromJson(t *testing.T) {
 		t.Fatal("Expected parser error")
 	}
 }
+
+func TestMustFunctions(t *testing.T) {
+	if _, err := MustFromYaml("- one\n- two\n"); err == nil {
+		t.Error("Expected MustFromYaml to fail on a list")
+	}
+	if _, err := MustFromJson("[1, 2]"); err == nil {
+		t.Error("Expected MustFromJson to fail on a list")
+	}
+	if _, err := MustToJson(map[string]interface{}{"f": func() {}}); err == nil {
+		t.Error("Expected MustToJson to fail on a function")
+	}
+	if _, err := MustToYaml(map[string]interface{}{"f": func() {}}); err == nil {
+		t.Error("Expected MustToYaml to fail on a function")
+	}
+	if got, err := MustToToml(map[string]interface{}{"foo": "bar"}); err != nil || got != "foo = \"bar\"\n" {
+		t.Errorf("Expected TOML, got %q, %v", got, err)
+	}
+}
+
+func TestToRawJson(t *testing.T) {
+	expect := `{"url":"http://example.com/?a=1&b=<2>"}`
+	if got := ToRawJson(map[string]interface{}{"url": "http://example.com/?a=1&b=<2>"}); got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+}
+
+func TestFromArrays(t *testing.T) {
+	if got := FromYamlArray("- one\n- two\n"); !reflect.DeepEqual(got, []interface{}{"one", "two"}) {
+		t.Errorf("Expected the list, got %v", got)
+	}
+	if got := FromJsonArray(`["one", 2]`); !reflect.DeepEqual(got, []interface{}{"one", float64(2)}) {
+		t.Errorf("Expected the list, got %v", got)
+	}
+	if got := FromJsonArray(`{"one": 2}`); len(got) != 1 {
+		t.Errorf("Expected the error as the only element, got %v", got)
+	}
+}
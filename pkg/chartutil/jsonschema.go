@@ -0,0 +1,93 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// SchemaFile is the optional file of a chart holding a JSON Schema that its
+// values must satisfy.
+const SchemaFile = "values.schema.json"
+
+// Schema returns the JSON Schema of the values of a chart, or nil if the chart
+// has none.
+func Schema(c *chart.Chart) []byte {
+	for _, f := range c.Files {
+		if f.TypeUrl == SchemaFile {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// ValidateAgainstSchema checks the coalesced values of a chart against its
+// schema, and the values of each dependency against the schema of that
+// dependency. All the violations are reported, grouped by chart.
+func ValidateAgainstSchema(c *chart.Chart, values map[string]interface{}) error {
+	var sb bytes.Buffer
+	if schema := Schema(c); schema != nil {
+		if err := ValidateAgainstSingleSchema(values, schema); err != nil {
+			fmt.Fprintf(&sb, "%s:\n%s", c.Metadata.Name, err)
+		}
+	}
+
+	for _, dep := range c.Dependencies {
+		depValues, _ := values[dep.Metadata.Name].(map[string]interface{})
+		if err := ValidateAgainstSchema(dep, depValues); err != nil {
+			sb.WriteString(err.Error())
+		}
+	}
+
+	if sb.Len() > 0 {
+		return errors.New(sb.String())
+	}
+	return nil
+}
+
+// ValidateAgainstSingleSchema checks values against a JSON Schema, listing
+// each violation on its own line.
+func ValidateAgainstSingleSchema(values map[string]interface{}, schemaJSON []byte) error {
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(valuesJSON, []byte("null")) {
+		valuesJSON = []byte("{}")
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaJSON), gojsonschema.NewBytesLoader(valuesJSON))
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %s", SchemaFile, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	var sb bytes.Buffer
+	for _, desc := range result.Errors() {
+		fmt.Fprintf(&sb, "- %s\n", desc)
+	}
+	return errors.New(sb.String())
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const testSchema = `{
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string"},
+    "replicas": {"type": "integer"}
+  }
+}`
+
+func schemaChart(name string, deps ...*chart.Chart) *chart.Chart {
+	return &chart.Chart{
+		Metadata:     &chart.Metadata{Name: name},
+		Files:        []*any.Any{{TypeUrl: SchemaFile, Value: []byte(testSchema)}},
+		Dependencies: deps,
+	}
+}
+
+func TestValidateAgainstSingleSchema(t *testing.T) {
+	if err := ValidateAgainstSingleSchema(map[string]interface{}{"name": "nautilus", "replicas": 2}, []byte(testSchema)); err != nil {
+		t.Errorf("Expected the values to be valid, got %s", err)
+	}
+
+	err := ValidateAgainstSingleSchema(map[string]interface{}{"replicas": "two"}, []byte(testSchema))
+	if err == nil {
+		t.Fatal("Expected the values to be invalid")
+	}
+	expected := "- (root): name is required\n- replicas: Invalid type. Expected: integer, given: string\n"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err)
+	}
+
+	// No values at all are checked as an empty object.
+	if err := ValidateAgainstSingleSchema(nil, []byte(testSchema)); err == nil {
+		t.Error("Expected the missing name to be reported")
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	c := schemaChart("parent", schemaChart("child"), &chart.Chart{Metadata: &chart.Metadata{Name: "noschema"}})
+
+	values := map[string]interface{}{
+		"name":     "parent",
+		"child":    map[string]interface{}{"name": "child"},
+		"noschema": map[string]interface{}{"anything": true},
+	}
+	if err := ValidateAgainstSchema(c, values); err != nil {
+		t.Errorf("Expected the values to be valid, got %s", err)
+	}
+
+	values["child"] = map[string]interface{}{"replicas": 1}
+	err := ValidateAgainstSchema(c, values)
+	if err == nil {
+		t.Fatal("Expected the values of the child to be invalid")
+	}
+	if !strings.HasPrefix(err.Error(), "child:\n- (root): name is required") {
+		t.Errorf("Expected the error to name the child chart, got %q", err)
+	}
+}
+
+func TestToRenderValuesSchema(t *testing.T) {
+	c := schemaChart("parent")
+	c.Values = &chart.Config{Raw: "name: parent"}
+
+	if _, err := ToRenderValuesCaps(c, &chart.Config{Raw: "replicas: 3"}, ReleaseOptions{}, nil); err != nil {
+		t.Errorf("Expected the values to be valid, got %s", err)
+	}
+
+	_, err := ToRenderValuesCaps(c, &chart.Config{Raw: "replicas: three"}, ReleaseOptions{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "replicas: Invalid type") {
+		t.Errorf("Expected the overridden replicas to be rejected, got %v", err)
+	}
+}
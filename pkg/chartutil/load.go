@@ -163,6 +163,7 @@ func LoadArchive(in io.Reader) (*chart.Chart, error) {
 func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 	c := &chart.Chart{}
 	subcharts := map[string][]*BufferedFile{}
+	var chartfile []byte
 
 	for _, f := range files {
 		if f.Name == "Chart.yaml" {
@@ -171,9 +172,10 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 				return c, err
 			}
 			c.Metadata = m
+			chartfile = f.Data
 			var apiVersion = c.Metadata.ApiVersion
-			if apiVersion != "" && apiVersion != ApiVersionV1 {
-				return c, fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\"", apiVersion)
+			if apiVersion != "" && apiVersion != ApiVersionV1 && apiVersion != ApiVersionV2 {
+				return c, fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\" or \"v2\"", apiVersion)
 			}
 		} else if f.Name == "values.toml" {
 			return c, errors.New("values.toml is illegal as of 2.0.0-alpha.2")
@@ -206,6 +208,11 @@ func LoadFiles(files []*BufferedFile) (*chart.Chart, error) {
 	if c.Metadata.Name == "" {
 		return c, errors.New("invalid chart (Chart.yaml): name must not be empty")
 	}
+	if c.Metadata.ApiVersion == ApiVersionV2 {
+		if err := loadChartfileV2(c, chartfile); err != nil {
+			return c, err
+		}
+	}
 
 	for n, files := range subcharts {
 		var sc *chart.Chart
@@ -23,7 +23,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"strings"
 	"testing"
 	"time"
 
@@ -40,15 +39,73 @@ func TestLoadDir(t *testing.T) {
 	verifyRequirements(t, c)
 }
 
-func TestLoadNonV1Chart(t *testing.T) {
-	_, err := Load("testdata/frobnitz.v2")
+func TestLoadV2Chart(t *testing.T) {
+	c, err := Load("testdata/frobnitz.v2")
 	if err != nil {
-		if strings.Compare(err.Error(), "apiVersion 'v2' is not valid. The value must be \"v1\"") != 0 {
-			t.Errorf("Unexpected message: %s", err)
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	verifyFrobnitz(t, c)
+	verifyChart(t, c)
+	verifyRequirements(t, c)
+	verifyRequirementsLock(t, c)
+}
+
+func TestLoadV2ChartInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []*BufferedFile
+		expect string
+	}{
+		{
+			name:   "unknown apiVersion",
+			files:  []*BufferedFile{{Name: "Chart.yaml", Data: []byte("apiVersion: v3\nname: frobnitz\nversion: 1.2.3\n")}},
+			expect: "apiVersion 'v3' is not valid. The value must be \"v1\" or \"v2\"",
+		},
+		{
+			name:   "version that is not SemVer",
+			files:  []*BufferedFile{{Name: "Chart.yaml", Data: []byte("apiVersion: v2\nname: frobnitz\nversion: latest\n")}},
+			expect: "invalid chart (Chart.yaml): version 'latest' is not a valid SemVer",
+		},
+		{
+			name: "dependency without a version",
+			files: []*BufferedFile{{Name: "Chart.yaml", Data: []byte(`apiVersion: v2
+name: frobnitz
+version: 1.2.3
+dependencies:
+- name: alpine
+  repository: https://example.com/charts
+`)}},
+			expect: "invalid chart (Chart.yaml): dependency \"alpine\" has no version",
+		},
+		{
+			name: "dependencies with the same name",
+			files: []*BufferedFile{{Name: "Chart.yaml", Data: []byte(`apiVersion: v2
+name: frobnitz
+version: 1.2.3
+dependencies:
+- name: alpine
+  version: 0.1.0
+- name: alpine
+  version: 0.2.0
+`)}},
+			expect: "invalid chart (Chart.yaml): more than one dependency is named \"alpine\"; give them different aliases",
+		},
+		{
+			name: "requirements.yaml",
+			files: []*BufferedFile{
+				{Name: "Chart.yaml", Data: []byte("apiVersion: v2\nname: frobnitz\nversion: 1.2.3\n")},
+				{Name: "requirements.yaml", Data: []byte("dependencies: []\n")},
+			},
+			expect: "requirements.yaml is not allowed in an apiVersion v2 chart: the dependencies are listed in Chart.yaml and locked in Chart.lock",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadFiles(tt.files)
+		if err == nil || err.Error() != tt.expect {
+			t.Errorf("%s: expected error %q, got %v", tt.name, tt.expect, err)
 		}
-		return
 	}
-	t.Fatalf("chart with v2 apiVersion should not load")
 }
 
 func TestLoadFile(t *testing.T) {
@@ -17,6 +17,7 @@ package chartutil
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -27,8 +28,9 @@ import (
 )
 
 const (
-	requirementsName = "requirements.yaml"
-	lockfileName     = "requirements.lock"
+	requirementsName  = "requirements.yaml"
+	lockfileName      = "requirements.lock"
+	chartLockfileName = "Chart.lock"
 )
 
 var (
@@ -108,11 +110,31 @@ func LoadRequirements(c *chart.Chart) (*Requirements, error) {
 	return r, yaml.Unmarshal(data, r)
 }
 
+// RequirementsFileName returns the name of the file that lists the
+// dependencies of a chart: Chart.yaml for a version 2 chart, and
+// requirements.yaml otherwise.
+func RequirementsFileName(c *chart.Chart) string {
+	if c.Metadata != nil && c.Metadata.ApiVersion == ApiVersionV2 {
+		return ChartfileName
+	}
+	return requirementsName
+}
+
+// LockfileName returns the name of the lock file of the dependencies of a
+// chart: Chart.lock for a version 2 chart, and requirements.lock otherwise.
+func LockfileName(c *chart.Chart) string {
+	if c.Metadata != nil && c.Metadata.ApiVersion == ApiVersionV2 {
+		return chartLockfileName
+	}
+	return lockfileName
+}
+
 // LoadRequirementsLock loads a requirements lock file.
 func LoadRequirementsLock(c *chart.Chart) (*RequirementsLock, error) {
 	var data []byte
+	name := LockfileName(c)
 	for _, f := range c.Files {
-		if f.TypeUrl == lockfileName {
+		if f.TypeUrl == name {
 			data = f.Value
 		}
 	}
@@ -124,15 +146,42 @@ func LoadRequirementsLock(c *chart.Chart) (*RequirementsLock, error) {
 }
 
 // ProcessRequirementsConditions disables charts based on condition path value in values
+//
+// A condition may also be an expression over the values, which enables the
+// chart if it is true and disables it if it is false. An expression that
+// cannot be evaluated is logged and has no effect.
 func ProcessRequirementsConditions(reqs *Requirements, cvals Values) {
+	if err := processRequirementsConditions(reqs, cvals); err != nil {
+		log.Printf("Warning: %s", err)
+	}
+}
+
+// processRequirementsConditions is ProcessRequirementsConditions, but fails on
+// the first condition expression that cannot be evaluated.
+func processRequirementsConditions(reqs *Requirements, cvals Values) error {
 	var cond string
 	var conds []string
 	if reqs == nil || len(reqs.Dependencies) == 0 {
-		return
+		return nil
 	}
+	var exprErr error
 	for _, r := range reqs.Dependencies {
 		var hasTrue, hasFalse bool
 		cond = string(r.Condition)
+		if isConditionExpression(cond) {
+			v, err := evalCondition(cond, cvals)
+			if err != nil {
+				err = fmt.Errorf("condition '%s' for chart %s cannot be evaluated: %s", cond, r.Name, err)
+			} else if bv, ok := v.(bool); ok {
+				r.Enabled = bv
+			} else if v != nil {
+				err = fmt.Errorf("condition '%s' for chart %s returned non-bool value", cond, r.Name)
+			}
+			if err != nil && exprErr == nil {
+				exprErr = err
+			}
+			continue
+		}
 		// check for list
 		if len(cond) > 0 {
 			if strings.Contains(cond, ",") {
@@ -175,7 +224,7 @@ func ProcessRequirementsConditions(reqs *Requirements, cvals Values) {
 		}
 
 	}
-
+	return exprErr
 }
 
 // ProcessRequirementsTags disables charts based on tags in values
@@ -303,7 +352,9 @@ func ProcessRequirementsEnabled(c *chart.Chart, v *chart.Config) error {
 	cc := chart.Config{Raw: yvals}
 	// flag dependencies as enabled/disabled
 	ProcessRequirementsTags(reqs, cvals)
-	ProcessRequirementsConditions(reqs, cvals)
+	if err := processRequirementsConditions(reqs, cvals); err != nil {
+		return err
+	}
 	// make a map of charts to remove
 	rm := map[string]bool{}
 	for _, r := range reqs.Dependencies {
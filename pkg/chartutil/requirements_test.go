@@ -15,10 +15,12 @@ limitations under the License.
 package chartutil
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"strconv"
@@ -177,6 +179,22 @@ func TestRequirementsCombinedDisabledL1(t *testing.T) {
 	verifyRequirementsEnabled(t, c, v, e)
 }
 
+func TestRequirementsConditionsExpressionError(t *testing.T) {
+	c, err := Load("testdata/subpop")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	for _, f := range c.Files {
+		if f.TypeUrl == "requirements.yaml" {
+			f.Value = bytes.Replace(f.Value, []byte("condition: subchart1.enabled"), []byte("condition: values.subchart1.enabled =="), 1)
+		}
+	}
+	err = ProcessRequirementsEnabled(c, &chart.Config{Raw: ""})
+	if err == nil || !strings.Contains(err.Error(), "condition 'values.subchart1.enabled ==' for chart subchart1 cannot be evaluated") {
+		t.Errorf("Expected the condition expression to fail, got %v", err)
+	}
+}
+
 func verifyRequirementsEnabled(t *testing.T, c *chart.Chart, v *chart.Config, e []string) {
 	out := []*chart.Chart{}
 	err := ProcessRequirementsEnabled(c, v)
@@ -26,8 +26,6 @@ import (
 	"path/filepath"
 	"time"
 
-	"github.com/ghodss/yaml"
-
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
@@ -42,7 +40,11 @@ func SaveDir(c *chart.Chart, dest string) error {
 	}
 
 	// Save the chart file.
-	if err := SaveChartfile(filepath.Join(outdir, ChartfileName), c.Metadata); err != nil {
+	cdata, err := marshalChartfile(c)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outdir, ChartfileName), cdata, 0644); err != nil {
 		return err
 	}
 
@@ -76,6 +78,9 @@ func SaveDir(c *chart.Chart, dest string) error {
 
 	// Save files
 	for _, f := range c.Files {
+		if isChartfileRequirements(c, f.TypeUrl) {
+			continue
+		}
 		n := filepath.Join(outdir, f.TypeUrl)
 
 		d := filepath.Dir(n)
@@ -168,7 +173,7 @@ func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
 	base := filepath.Join(prefix, c.Metadata.Name)
 
 	// Save Chart.yaml
-	cdata, err := yaml.Marshal(c.Metadata)
+	cdata, err := marshalChartfile(c)
 	if err != nil {
 		return err
 	}
@@ -193,6 +198,9 @@ func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
 
 	// Save files
 	for _, f := range c.Files {
+		if isChartfileRequirements(c, f.TypeUrl) {
+			continue
+		}
 		n := filepath.Join(base, f.TypeUrl)
 		if err := writeToTar(out, n, f.Value); err != nil {
 			return err
@@ -208,6 +216,13 @@ func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
 	return nil
 }
 
+// isChartfileRequirements reports whether a file of a chart holds the
+// requirements read from the Chart.yaml of a version 2 chart, which are saved
+// back to Chart.yaml instead.
+func isChartfileRequirements(c *chart.Chart, name string) bool {
+	return name == requirementsName && c.Metadata.ApiVersion == ApiVersionV2
+}
+
 // writeToTar writes a single file to a tar archive.
 func writeToTar(out *tar.Writer, name string, body []byte) error {
 	// TODO: Do we need to create dummy parent directory names if none exist?
@@ -207,3 +207,45 @@ func TestSaveDir(t *testing.T) {
 		t.Fatal("Templates data did not match")
 	}
 }
+
+func TestSaveV2Chart(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c, err := Load("testdata/frobnitz.v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, err := Save(c, tmp)
+	if err != nil {
+		t.Fatalf("Failed to save: %s", err)
+	}
+	if err := SaveDir(c, tmp); err != nil {
+		t.Fatalf("Failed to save: %s", err)
+	}
+
+	for _, name := range []string{where, tmp + "/frobnitz"} {
+		c2, err := Load(name)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %s", name, err)
+		}
+		verifyRequirements(t, c2)
+		verifyRequirementsLock(t, c2)
+	}
+
+	// The dependencies are only in Chart.yaml.
+	if _, err := os.Stat(tmp + "/frobnitz/requirements.yaml"); !os.IsNotExist(err) {
+		t.Errorf("Expected no requirements.yaml, got %v", err)
+	}
+	cf, err := ioutil.ReadFile(tmp + "/frobnitz/Chart.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cf), "dependencies:") {
+		t.Errorf("Expected the dependencies in Chart.yaml, got %s", cf)
+	}
+}
@@ -39,6 +39,13 @@ type ErrNoValue error
 // GlobalKey is the name of the Values key that is used for storing global vars.
 const GlobalKey = "global"
 
+// ScopedGlobalKey is the name of the key of the globals that holds globals
+// scoped to a single subchart, by its name or alias. The globals under
+// global.scoped.<name> override the other globals for that subchart and its
+// own subcharts, and are not seen by the other subcharts, so that two
+// instances of the same chart can be given different globals.
+const ScopedGlobalKey = "scoped"
+
 // Values represents a collection of chart values.
 type Values map[string]interface{}
 
@@ -161,6 +168,8 @@ func ReadValuesFile(filename string) (Values, error) {
 //	- Scalar values and arrays are replaced, maps are merged
 //	- A chart has access to all of the variables for it, as well as all of
 //		the values destined for its dependencies.
+//	- A null value removes the key, along with the defaults of the charts
+//		below it for that key
 func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 	cvals := Values{}
 	// Parse values if not nil. We merge these at the top level because
@@ -170,10 +179,27 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 		if err != nil {
 			return cvals, err
 		}
-		return coalesce(chrt, evals)
+		v, err := coalesce(chrt, evals)
+		return removeNulls(v), err
 	}
 
-	return coalesceDeps(chrt, cvals)
+	v, err := coalesceDeps(chrt, cvals)
+	return removeNulls(v), err
+}
+
+// removeNulls deletes the keys set to null from a coalesced table and its
+// nested tables. The nulls are kept while coalescing, so that a null for a
+// key of a subchart still removes the default of the subchart once its values
+// are coalesced.
+func removeNulls(v map[string]interface{}) map[string]interface{} {
+	for key, val := range v {
+		if val == nil {
+			delete(v, key)
+		} else if table, ok := val.(map[string]interface{}); ok {
+			removeNulls(table)
+		}
+	}
+	return v
 }
 
 // coalesce coalesces the dest values and the chart values, giving priority to the dest values.
@@ -191,8 +217,10 @@ func coalesce(ch *chart.Chart, dest map[string]interface{}) (map[string]interfac
 // coalesceDeps coalesces the dependencies of the given chart.
 func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
 	for _, subchart := range chrt.Dependencies {
-		if c, ok := dest[subchart.Metadata.Name]; !ok {
-			// If dest doesn't already have the key, create it.
+		if c, ok := dest[subchart.Metadata.Name]; !ok || c == nil {
+			// If dest doesn't already have the key, create it. A null drops
+			// the values the parent gives to the subchart, which is left
+			// with its own defaults.
 			dest[subchart.Metadata.Name] = map[string]interface{}{}
 		} else if !istable(c) {
 			return dest, fmt.Errorf("type mismatch on %s: %t", subchart.Metadata.Name, c)
@@ -201,7 +229,7 @@ func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]in
 			dvmap := dv.(map[string]interface{})
 
 			// Get globals out of dest and merge them into dvmap.
-			dvmap = coalesceGlobals(dvmap, dest, chrt.Metadata.Name)
+			dvmap = coalesceGlobals(dvmap, scopeGlobals(dest, subchart.Metadata.Name), chrt.Metadata.Name)
 
 			var err error
 			// Now coalesce the rest of the values.
@@ -250,6 +278,33 @@ func coalesceGlobals(dest, src map[string]interface{}, chartName string) map[str
 	return rv
 }
 
+// scopeGlobals returns values holding the globals of src as seen by the
+// subchart called name: the globals that are not scoped, overridden by those
+// scoped to the subchart.
+func scopeGlobals(src map[string]interface{}, name string) map[string]interface{} {
+	sg, ok := src[GlobalKey].(map[string]interface{})
+	if !ok {
+		return src
+	}
+	scoped, ok := sg[ScopedGlobalKey]
+	if !ok {
+		return src
+	}
+
+	globals := make(map[string]interface{}, len(sg))
+	for k, v := range sg {
+		if k != ScopedGlobalKey {
+			globals[k] = v
+		}
+	}
+	if scopes, ok := scoped.(map[string]interface{}); !ok {
+		log.Printf("Warning: Skipping scoped globals for chart '%s' because '%s.%s' is not a table.", name, GlobalKey, ScopedGlobalKey)
+	} else if scope, ok := scopes[name].(map[string]interface{}); ok {
+		globals = coalesceTables(scope, globals, name)
+	}
+	return map[string]interface{}{GlobalKey: globals}
+}
+
 // coalesceValues builds up a values map for a particular chart.
 //
 // Values in v will override the values in the chart.
@@ -288,6 +343,9 @@ func coalesceTables(dst, src map[string]interface{}, chartName string) map[strin
 			// When the YAML value is null, we skip the value's key.
 			// This allows Helm's various sources of values (value files or --set) to
 			// remove incompatible keys from any previous chart, file, or set values.
+			// The null itself is kept until CoalesceValues is done, as the key
+			// may also have a default in a subchart.
+			rv[key] = nil
 			continue
 		}
 
@@ -309,9 +367,6 @@ func coalesceTables(dst, src map[string]interface{}, chartName string) map[strin
 
 	// do we have anything in dst that wasn't processed already that we need to copy across?
 	for key, val := range dst {
-		if val == nil {
-			continue
-		}
 		_, ok := rv[key]
 		if !ok {
 			rv[key] = val
@@ -368,6 +423,10 @@ func ToRenderValuesCaps(chrt *chart.Chart, chrtVals *chart.Config, options Relea
 		return top, err
 	}
 
+	if err := ValidateAgainstSchema(chrt, vals); err != nil {
+		return top, fmt.Errorf("values don't meet the specifications of the schema(s) in the following chart(s):\n%s", err)
+	}
+
 	top["Values"] = vals
 	return top, nil
 }
@@ -675,3 +675,94 @@ func TestSubchartCoaleseWithNullValue(t *testing.T) {
 		t.Errorf("got %+v, expected %+v", result, expected)
 	}
 }
+
+func TestCoalesceNullRemovesSubchartDefault(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "demo"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "logstash"},
+				Values: &chart.Config{
+					Raw: `tolerations: {master: {effect: NoSchedule}, spot: {effect: NoExecute}}`,
+				},
+			},
+			{
+				Metadata: &chart.Metadata{Name: "kibana"},
+				Values:   &chart.Config{Raw: `replicas: 1`},
+			},
+		},
+		Values: &chart.Config{
+			Raw: `{logstash: {tolerations: {spot: {effect: NoSchedule}}}, kibana: {replicas: 2}}`,
+		},
+	}
+
+	// The parent chart has a default for the table holding the null, which
+	// must not swallow it.
+	v, err := CoalesceValues(c, &chart.Config{Raw: `{logstash: {tolerations: {master: null}}, kibana: null}`})
+	if err != nil {
+		t.Fatalf("Failed with %s", err)
+	}
+	expected := map[string]interface{}{
+		"logstash": map[string]interface{}{
+			"global": map[string]interface{}{},
+			"tolerations": map[string]interface{}{
+				"spot": map[string]interface{}{"effect": "NoSchedule"},
+			},
+		},
+		"kibana": map[string]interface{}{
+			"global":   map[string]interface{}{},
+			"replicas": float64(1),
+		},
+	}
+	if result := v.AsMap(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("got %+v, expected %+v", result, expected)
+	}
+}
+
+func TestCoalesceScopedGlobals(t *testing.T) {
+	db := func(name string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: name},
+			Values:   &chart.Config{Raw: `global: {storageClass: standard}`},
+			Dependencies: []*chart.Chart{
+				{Metadata: &chart.Metadata{Name: "backup"}},
+			},
+		}
+	}
+	c := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "umbrella"},
+		Dependencies: []*chart.Chart{db("db-primary"), db("db-replica")},
+	}
+
+	v, err := CoalesceValues(c, &chart.Config{Raw: `
+global:
+  registry: registry.example.com
+  scoped:
+    db-primary:
+      storageClass: fast
+      registry: primary.example.com
+`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tpl    string
+		expect string
+	}{
+		{"{{.global.registry}}", "registry.example.com"},
+		{"{{index .global.scoped \"db-primary\" \"storageClass\"}}", "fast"},
+		{"{{index . \"db-primary\" \"global\" \"storageClass\"}}", "fast"},
+		{"{{index . \"db-primary\" \"global\" \"registry\"}}", "primary.example.com"},
+		{"{{index . \"db-primary\" \"global\" \"scoped\"}}", "<no value>"},
+		{"{{index . \"db-primary\" \"backup\" \"global\" \"storageClass\"}}", "fast"},
+		{"{{index . \"db-replica\" \"global\" \"storageClass\"}}", "standard"},
+		{"{{index . \"db-replica\" \"global\" \"registry\"}}", "registry.example.com"},
+		{"{{index . \"db-replica\" \"backup\" \"global\" \"storageClass\"}}", "standard"},
+	}
+	for _, tt := range tests {
+		if o, err := ttpl(tt.tpl, v); err != nil || o != tt.expect {
+			t.Errorf("Expected %q to expand to %q, got %q (%v)", tt.tpl, tt.expect, o, err)
+		}
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/registry"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/urlutil"
 )
@@ -96,6 +97,10 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 	}
 
 	name := filepath.Base(u.Path)
+	if u.Scheme == registry.Scheme {
+		// Tags are not part of chart file names: nginx:1.2.3 is saved as nginx-1.2.3.tgz.
+		name = strings.Replace(name, ":", "-", 1) + ".tgz"
+	}
 	destfile := filepath.Join(dest, name)
 	if err := ioutil.WriteFile(destfile, data.Bytes(), 0644); err != nil {
 		return destfile, nil, err
@@ -134,11 +139,13 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 // It returns the URL as well as a preconfigured repo.Getter that can fetch
 // the URL.
 //
-// A reference may be an HTTP URL, a 'reponame/chartname' reference, or a local path.
+// A reference may be an HTTP URL, an oci:// registry reference, a
+// 'reponame/chartname' reference, or a local path.
 //
 // A version is a SemVer string (1.2.3-beta.1+f334a6789).
 //
 //	- For fully qualified URLs, the version will be ignored (since URLs aren't versioned)
+//	- For registry references, the version is used as the tag if the reference has none
 //	- For a chart reference
 //		* If version is non-empty, this will return the URL for that version
 //		* If version is empty, this will return the URL for the latest version
@@ -149,6 +156,10 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 		return nil, nil, fmt.Errorf("invalid chart URL format: %s", ref)
 	}
 
+	if u.Scheme == registry.Scheme {
+		return c.resolveRegistryReference(ref, version)
+	}
+
 	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
 	if err != nil {
 		return u, nil, err
@@ -248,6 +259,32 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 	return u, r.Client, nil
 }
 
+// resolveRegistryReference resolves an oci:// reference to the URL of a
+// tagged chart, using the version as the tag if the reference has none.
+func (c *ChartDownloader) resolveRegistryReference(ref, version string) (*url.URL, getter.Getter, error) {
+	r, err := registry.ParseReference(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	if r.Tag == "" {
+		if version == "" {
+			return nil, nil, fmt.Errorf("%s: a tag or a version is required for registry references", ref)
+		}
+		// Tags cannot contain '+', so build metadata is separated by '_'.
+		r.Tag = strings.Replace(version, "+", "_", -1)
+	}
+	u, err := url.Parse(r.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	getterConstructor, err := c.Getters.ByScheme(registry.Scheme)
+	if err != nil {
+		return u, nil, err
+	}
+	g, err := getterConstructor(u.String(), "", "", "")
+	return u, g, err
+}
+
 // setCredentials if HttpGetter is used, this method sets the configured repository credentials on the HttpGetter.
 func (c *ChartDownloader) setCredentials(r *repo.ChartRepository) {
 	if t, ok := r.Client.(*getter.HttpGetter); ok {
@@ -50,6 +50,9 @@ func TestResolveChartRef(t *testing.T) {
 		{name: "reference, testing-relative-trailing-slash repo", ref: "testing-relative-trailing-slash/bar", expect: "http://example.com/helm/bar-1.2.3.tgz"},
 		{name: "full URL, HTTPS, irrelevant version", ref: "https://example.com/foo-1.2.3.tgz", version: "0.1.0", expect: "https://example.com/foo-1.2.3.tgz", fail: true},
 		{name: "full URL, file", ref: "file:///foo-1.2.3.tgz", fail: true},
+		{name: "registry reference", ref: "oci://registry.example.com/charts/foo:1.2.3", expect: "oci://registry.example.com/charts/foo:1.2.3"},
+		{name: "registry reference, version", ref: "oci://registry.example.com/charts/foo", version: "1.2.3+build", expect: "oci://registry.example.com/charts/foo:1.2.3_build"},
+		{name: "registry reference, no tag", ref: "oci://registry.example.com/charts/foo", fail: true},
 		{name: "invalid", ref: "invalid-1.2.3", fail: true},
 		{name: "not found", ref: "nosuchthing/invalid-1.2.3", fail: true},
 	}
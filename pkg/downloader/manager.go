@@ -80,10 +80,10 @@ func (m *Manager) Build() error {
 	// A lock must accompany a requirements.yaml file.
 	req, err := chartutil.LoadRequirements(c)
 	if err != nil {
-		return fmt.Errorf("requirements.yaml cannot be opened: %s", err)
+		return fmt.Errorf("%s cannot be opened: %s", chartutil.RequirementsFileName(c), err)
 	}
 	if sum, err := resolver.HashReq(req); err != nil || sum != lock.Digest {
-		return fmt.Errorf("requirements.lock is out of sync with requirements.yaml")
+		return fmt.Errorf("%s is out of sync with %s", chartutil.LockfileName(c), chartutil.RequirementsFileName(c))
 	}
 
 	// Check that all of the repos we're dependent on actually exist.
@@ -163,7 +163,7 @@ func (m *Manager) Update() error {
 	}
 
 	// Finally, we need to write the lockfile.
-	return writeLock(m.ChartPath, lock)
+	return writeLock(m.ChartPath, chartutil.LockfileName(c), lock)
 }
 
 func (m *Manager) loadChartDir() (*chart.Chart, error) {
@@ -606,12 +606,12 @@ func (m *Manager) loadChartRepositories() (map[string]*repo.ChartRepository, err
 }
 
 // writeLock writes a lockfile to disk
-func writeLock(chartpath string, lock *chartutil.RequirementsLock) error {
+func writeLock(chartpath, name string, lock *chartutil.RequirementsLock) error {
 	data, err := yaml.Marshal(lock)
 	if err != nil {
 		return err
 	}
-	dest := filepath.Join(chartpath, "requirements.lock")
+	dest := filepath.Join(chartpath, name)
 	return ioutil.WriteFile(dest, data, 0644)
 }
 
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+)
+
+// PendingValue is what ResolveDeployed puts in place of the live data that
+// is not known, such as the IP address of a load balancer that is still
+// being assigned.
+const PendingValue = "<pending>"
+
+// deployedPattern matches the placeholders of the 'deployed' template
+// function. The arguments are encoded so that the placeholder is left
+// untouched by the functions it may be piped through, such as quote.
+var deployedPattern = regexp.MustCompile(`__HELM_DEPLOYED\(([A-Za-z0-9+/]*)\)__`)
+
+// deployed backs the 'deployed' template function, which stands for a field of
+// a resource of the release, given as a JSONPath expression such as
+// "{.status.loadBalancer.ingress[0].ip}". The notes cannot know it when they
+// are rendered, before the resources exist, so it renders a placeholder that
+// Tiller replaces, with ResolveDeployed, whenever the notes are shown.
+func deployed(apiVersion, kind, name, jsonPath string) (string, error) {
+	args, err := json.Marshal([]string{apiVersion, kind, name, jsonPath})
+	if err != nil {
+		return "", err
+	}
+	return "__HELM_DEPLOYED(" + base64.RawStdEncoding.EncodeToString(args) + ")__", nil
+}
+
+// HasDeployed reports whether text holds a placeholder of the 'deployed'
+// template function.
+func HasDeployed(text string) bool {
+	return deployedPattern.MatchString(text)
+}
+
+// ResolveDeployed replaces the placeholders of the 'deployed' template
+// function in text with the values that resolve returns for them. Values that
+// resolve cannot find, for which it returns an empty string, are replaced with
+// PendingValue.
+func ResolveDeployed(text string, resolve func(apiVersion, kind, name, jsonPath string) string) string {
+	return deployedPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		encoded := deployedPattern.FindStringSubmatch(placeholder)[1]
+		var args []string
+		if b, err := base64.RawStdEncoding.DecodeString(encoded); err != nil || json.Unmarshal(b, &args) != nil || len(args) != 4 {
+			return PendingValue
+		}
+		if v := resolve(args[0], args[1], args[2], args[3]); v != "" {
+			return v
+		}
+		return PendingValue
+	})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestResolveDeployed(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/NOTES.txt", Data: []byte(`IP: {{ deployed "v1" "Service" "web" "{.status.loadBalancer.ingress[0].ip}" | quote }}, port: {{ deployed "v1" "Service" "db" ".spec.ports[0].nodePort" }}`)},
+		},
+	}
+	v := chartutil.Values{"Values": map[string]interface{}{}, "Chart": c.Metadata}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes := out["moby/templates/NOTES.txt"]
+
+	type lookup struct{ apiVersion, kind, name, path string }
+	var lookups []lookup
+	got := ResolveDeployed(notes, func(apiVersion, kind, name, path string) string {
+		lookups = append(lookups, lookup{apiVersion, kind, name, path})
+		if name == "web" {
+			return "10.0.0.1"
+		}
+		return ""
+	})
+
+	if expect := `IP: "10.0.0.1", port: ` + PendingValue; got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+	expect := []lookup{
+		{"v1", "Service", "web", "{.status.loadBalancer.ingress[0].ip}"},
+		{"v1", "Service", "db", ".spec.ports[0].nodePort"},
+	}
+	if len(lookups) != len(expect) {
+		t.Fatalf("Expected %d lookups, got %v", len(expect), lookups)
+	}
+	for i := range expect {
+		if lookups[i] != expect[i] {
+			t.Errorf("Expected lookup %v, got %v", expect[i], lookups[i])
+		}
+	}
+}
+
+func TestHasDeployed(t *testing.T) {
+	placeholder, err := deployed("v1", "Service", "web", ".spec.clusterIP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !HasDeployed("ip: " + placeholder) {
+		t.Errorf("Expected a placeholder in %q", placeholder)
+	}
+	if HasDeployed("ip: 10.0.0.1") {
+		t.Error("Expected no placeholder")
+	}
+}
@@ -20,10 +20,15 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"os"
 	"path"
+	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/Masterminds/sprig"
 
@@ -35,21 +40,106 @@ import (
 type Engine struct {
 	// FuncMap contains the template functions that will be passed to each
 	// render call. This may only be modified before the first call to Render.
+	// As templates are rendered concurrently, the functions must be safe for
+	// concurrent use.
 	FuncMap template.FuncMap
 	// If strict is enabled, template rendering will fail if a template references
 	// a value that was not passed in.
 	Strict bool
+	// MissingKey is what a template renders for a value that was not passed
+	// in: nothing with MissingKeyZero, the default, "<no value>" with
+	// MissingKeyInvalid, and an error with MissingKeyError. Strict implies
+	// MissingKeyError.
+	MissingKey string
 	// In LintMode, some 'required' template values may be missing, so don't fail
 	LintMode bool
+	// LookupFunc backs the 'lookup' template function. If it is nil, lookup
+	// finds nothing, as when rendering without a cluster.
+	LookupFunc LookupFunc
+	// WarnFunc receives the messages of the 'warn' template function. It may
+	// be called by several templates at once. If it is nil, warnings are
+	// dropped.
+	WarnFunc func(msg string)
+	// MaxIncludeDepth is the number of nested 'include' and 'tpl' calls at
+	// which rendering fails. If it is zero, DefaultMaxIncludeDepth is used.
+	MaxIncludeDepth int
+	// AllowedEnv lists the environment variables that the 'env' template
+	// function may read. Reading any other variable fails the rendering.
+	AllowedEnv []string
+	// DisableChartFunctions fails the rendering of charts that define
+	// Starlark functions. Their steps and time are bounded, but not the memory
+	// a script allocates while it runs, so a server rendering the charts of
+	// others may not want to run them.
+	DisableChartFunctions bool
 }
 
+// DefaultMaxIncludeDepth is the default limit of nested 'include' and 'tpl'
+// calls. It is far above what helpers need, but keeps a template that
+// includes itself from exhausting the stack.
+const DefaultMaxIncludeDepth = 1000
+
+// The settings of Engine.MissingKey, named after the 'missingkey' option of Go
+// templates.
+const (
+	MissingKeyZero    = "zero"
+	MissingKeyInvalid = "invalid"
+	MissingKeyError   = "error"
+)
+
+// ValidateMissingKey fails if s is not a setting of Engine.MissingKey. The
+// empty string stands for the default.
+func ValidateMissingKey(s string) error {
+	switch s {
+	case "", MissingKeyZero, MissingKeyInvalid, MissingKeyError:
+		return nil
+	}
+	return fmt.Errorf("missing key behavior %q is not valid. Valid options are %q, %q and %q", s, MissingKeyZero, MissingKeyInvalid, MissingKeyError)
+}
+
+// Warnings collects the messages of the 'warn' template function, once each,
+// for Engine.WarnFunc. It is safe for concurrent use.
+type Warnings struct {
+	mu   sync.Mutex
+	msgs map[string]bool
+}
+
+// Add records a warning.
+func (w *Warnings) Add(msg string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.msgs == nil {
+		w.msgs = map[string]bool{}
+	}
+	w.msgs[msg] = true
+}
+
+// List returns the warnings in alphabetical order, so that it does not depend
+// on the order in which templates were rendered.
+func (w *Warnings) List() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var list []string
+	for msg := range w.msgs {
+		list = append(list, msg)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// LookupFunc returns the resource of the cluster with the given API version,
+// kind, namespace and name, or an empty map if it does not exist. With an
+// empty name, it returns the list of the resources of the namespace instead.
+// It may be called by several templates at once.
+type LookupFunc func(apiVersion, kind, namespace, name string) (map[string]interface{}, error)
+
 // New creates a new Go template Engine instance.
 //
 // The FuncMap is initialized here. You may modify the FuncMap _prior to_ the
 // first invocation of Render.
 //
 // The FuncMap sets all of the Sprig functions except for those that provide
-// access to the underlying OS (env, expandenv).
+// access to the underlying OS (env, expandenv). Render adds an 'env' function
+// of its own that only reads the variables of Engine.AllowedEnv.
 func New() *Engine {
 	f := FuncMap()
 	return &Engine{
@@ -71,6 +161,10 @@ func New() *Engine {
 //	   included in the FuncMap is a placeholder.
 //      - "tpl": This is late-bound in Engine.Render(). The version
 //	   included in the FuncMap is a placeholder.
+//      - "lookup": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap always finds nothing.
+//      - "warn": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap drops the warning.
 func FuncMap() template.FuncMap {
 	f := sprig.TxtFuncMap()
 	delete(f, "env")
@@ -84,17 +178,43 @@ func FuncMap() template.FuncMap {
 		"toJson":   chartutil.ToJson,
 		"fromJson": chartutil.FromJson,
 
+		"toRawJson":     chartutil.ToRawJson,
+		"fromYamlArray": chartutil.FromYamlArray,
+		"fromJsonArray": chartutil.FromJsonArray,
+
+		// The "must" variants fail the rendering instead of swallowing
+		// errors.
+		"mustToToml":    chartutil.MustToToml,
+		"mustToYaml":    chartutil.MustToYaml,
+		"mustFromYaml":  chartutil.MustFromYaml,
+		"mustToJson":    chartutil.MustToJson,
+		"mustFromJson":  chartutil.MustFromJson,
+		"mustToRawJson": chartutil.MustToRawJson,
+
+		"deepCopy":     deepCopy,
+		"mustDeepCopy": mustDeepCopy,
+
+		// Resolved by Tiller in the notes of a deployed release.
+		"deployed": deployed,
+
 		// This is a placeholder for the "include" function, which is
 		// late-bound to a template. By declaring it here, we preserve the
 		// integrity of the linter.
 		"include":  func(string, interface{}) string { return "not implemented" },
 		"required": func(string, interface{}) interface{} { return "not implemented" },
 		"tpl":      func(string, interface{}) interface{} { return "not implemented" },
+		"lookup": func(string, string, string, string) (map[string]interface{}, error) {
+			return map[string]interface{}{}, nil
+		},
+		"warn": func(string) string { return "" },
 	}
 
 	for k, v := range extra {
 		f[k] = v
 	}
+	for k, v := range quantityFuncs() {
+		f[k] = v
+	}
 
 	return f
 }
@@ -112,13 +232,38 @@ func FuncMap() template.FuncMap {
 //
 // Values should be prepared with something like `chartutils.ReadValues`.
 //
+// The template files are rendered concurrently, but the result does not
+// depend on the order in which they finish: a failing render reports the
+// error of the first failing file in the usual order.
+//
 // Values are passed through the templates according to scope. If the top layer
 // chart includes the chart foo, which includes the chart bar, the values map
 // will be examined for a table called "foo". If "foo" is found in vals,
 // that section of the values will be passed into the "foo" chart. And if that
 // section contains a value named "bar", that value will be passed on to the
 // bar chart during render time.
+//
+// The public functions of the Starlark scripts in the functions/ directories
+// of the charts can be called by all of the templates, like the functions of
+// the FuncMap, unless DisableChartFunctions is set.
 func (e *Engine) Render(chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
+	fns, err := chartFunctions(chrt, e.FuncMap, e.DisableChartFunctions)
+	if err != nil {
+		return nil, err
+	}
+	if len(fns) > 0 {
+		// The functions of the chart are only added for this call.
+		withFns := *e
+		withFns.FuncMap = template.FuncMap{}
+		for k, v := range e.FuncMap {
+			withFns.FuncMap[k] = v
+		}
+		for k, v := range fns {
+			withFns.FuncMap[k] = v
+		}
+		e = &withFns
+	}
+
 	// Render the charts
 	tmap := allTemplates(chrt, values)
 	return e.render(tmap)
@@ -137,7 +282,7 @@ type renderable struct {
 // alterFuncMap takes the Engine's FuncMap and adds context-specific functions.
 //
 // The resulting FuncMap is only valid for the passed-in template.
-func (e *Engine) alterFuncMap(t *template.Template, referenceTpls map[string]renderable) template.FuncMap {
+func (e *Engine) alterFuncMap(t *template.Template, chain *includeChain) template.FuncMap {
 	// Clone the func map because we are adding context-specific functions.
 	var funcMap template.FuncMap = map[string]interface{}{}
 	for k, v := range e.FuncMap {
@@ -146,8 +291,18 @@ func (e *Engine) alterFuncMap(t *template.Template, referenceTpls map[string]ren
 
 	// Add the 'include' function here so we can close over t.
 	funcMap["include"] = func(name string, data interface{}) (string, error) {
+		if err := chain.push(name); err != nil {
+			return "", err
+		}
+		defer chain.pop()
+
 		buf := bytes.NewBuffer(nil)
 		if err := t.ExecuteTemplate(buf, name, data); err != nil {
+			// A chain that went too deep is reported once, not wrapped by
+			// each of its calls.
+			if chain.err != nil {
+				return "", chain.err
+			}
 			return "", err
 		}
 		return buf.String(), nil
@@ -176,45 +331,116 @@ func (e *Engine) alterFuncMap(t *template.Template, referenceTpls map[string]ren
 		return val, nil
 	}
 
-	// Add the 'tpl' function here
+	// Add the 'tpl' function here. Rather than parsing all the templates of
+	// the chart again for every call, it parses the string alone and adds the
+	// already parsed templates of t next to it. The result is kept for later
+	// calls with the same string.
+	compiled := map[string]*template.Template{}
 	funcMap["tpl"] = func(tpl string, vals chartutil.Values) (string, error) {
-		basePath, err := vals.PathValue("Template.BasePath")
-		if err != nil {
-			return "", fmt.Errorf("Cannot retrieve Template.Basepath from values inside tpl function: %s (%s)", tpl, err.Error())
+		if err := chain.push("tpl"); err != nil {
+			return "", err
 		}
+		defer chain.pop()
 
-		r := renderable{
-			tpl:      tpl,
-			vals:     vals,
-			basePath: basePath.(string),
+		if _, err := vals.PathValue("Template.BasePath"); err != nil {
+			return "", fmt.Errorf("Cannot retrieve Template.Basepath from values inside tpl function: %s (%s)", tpl, err.Error())
 		}
-
-		templates := map[string]renderable{}
 		templateName, err := vals.PathValue("Template.Name")
 		if err != nil {
 			return "", fmt.Errorf("Cannot retrieve Template.Name from values inside tpl function: %s (%s)", tpl, err.Error())
 		}
+		name := templateName.(string)
+
+		key := name + "\x00" + tpl
+		tt, ok := compiled[key]
+		if !ok {
+			// The string may define templates of its own for its includes.
+			tt = e.newTemplate(name)
+			tt.Funcs(e.alterFuncMap(tt, chain))
+			if _, err := tt.Parse(tpl); err != nil {
+				return "", fmt.Errorf("Error during tpl function execution for %q: parse error in %q: %s", tpl, name, err)
+			}
+			for _, ref := range t.Templates() {
+				if ref.Tree == nil || tt.Lookup(ref.Name()) != nil {
+					continue
+				}
+				if _, err := tt.AddParseTree(ref.Name(), ref.Tree); err != nil {
+					return "", err
+				}
+			}
+			compiled[key] = tt
+		}
 
-		templates[templateName.(string)] = r
-
-		result, err := e.renderWithReferences(templates, referenceTpls)
-		if err != nil {
+		var buf bytes.Buffer
+		if err := tt.ExecuteTemplate(&buf, name, vals); err != nil {
+			if chain.err != nil {
+				return "", chain.err
+			}
 			return "", fmt.Errorf("Error during tpl function execution for %q: %s", tpl, err.Error())
 		}
-		return result[templateName.(string)], nil
+		return e.clean(buf.String()), nil
+	}
+
+	// Add the 'warn' function here, which renders nothing
+	funcMap["warn"] = func(msg string) string {
+		if e.WarnFunc != nil {
+			e.WarnFunc(msg)
+		}
+		return ""
+	}
+
+	// Add the 'env' function here, limited to the allowed variables
+	funcMap["env"] = func(name string) (string, error) {
+		for _, allowed := range e.AllowedEnv {
+			if name == allowed {
+				return os.Getenv(name), nil
+			}
+		}
+		if e.LintMode {
+			log.Printf("[INFO] Environment variable not allowed: %s", name)
+			return "", nil
+		}
+		return "", fmt.Errorf("environment variable %s is not allowed to be read by templates", name)
+	}
+
+	// Add the 'lookup' function here, if the engine can query a cluster
+	if e.LookupFunc != nil {
+		funcMap["lookup"] = e.LookupFunc
 	}
 
 	return funcMap
 }
 
-// render takes a map of templates/values and renders them.
-func (e *Engine) render(tpls map[string]renderable) (rendered map[string]string, err error) {
-	return e.renderWithReferences(tpls, tpls)
+// missingKey returns the 'missingkey' option of the templates.
+func (e *Engine) missingKey() string {
+	if e.Strict {
+		return MissingKeyError
+	}
+	if e.MissingKey == "" {
+		return MissingKeyZero
+	}
+	return e.MissingKey
 }
 
-// renderWithReferences takes a map of templates/values to render, and a map of
-// templates which can be referenced within them.
-func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls map[string]renderable) (rendered map[string]string, err error) {
+// newTemplate creates an empty template with the options of the engine.
+func (e *Engine) newTemplate(name string) *template.Template {
+	// Note that zero will attempt to add default values for types it knows,
+	// but will still emit <no value> for others. We mitigate that in clean.
+	return template.New(name).Option("missingkey=" + e.missingKey())
+}
+
+// clean works around the issue where Go will emit "<no value>" even if
+// Options(missing=zero) is set. With MissingKeyInvalid, "<no value>" is what
+// was asked for, and missing=error never gets here.
+func (e *Engine) clean(out string) string {
+	if e.missingKey() != MissingKeyZero {
+		return out
+	}
+	return strings.Replace(out, "<no value>", "", -1)
+}
+
+// render takes a map of templates/values and renders them.
+func (e *Engine) render(tpls map[string]renderable) (rendered map[string]string, err error) {
 	// Basically, what we do here is start with an empty parent template and then
 	// build up a list of templates -- one for each file. Once all of the templates
 	// have been parsed, we loop through again and execute every template.
@@ -227,16 +453,17 @@ func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls
 			err = fmt.Errorf("rendering template failed: %v", r)
 		}
 	}()
-	t := template.New("gotpl")
-	if e.Strict {
-		t.Option("missingkey=error")
-	} else {
-		// Not that zero will attempt to add default values for types it knows,
-		// but will still emit <no value> for others. We mitigate that later.
-		t.Option("missingkey=zero")
+	if err := ValidateMissingKey(e.MissingKey); err != nil {
+		return map[string]string{}, err
 	}
+	t := e.newTemplate("gotpl")
 
-	funcMap := e.alterFuncMap(t, referenceTpls)
+	max := e.MaxIncludeDepth
+	if max <= 0 {
+		max = DefaultMaxIncludeDepth
+	}
+	chain := &includeChain{max: max}
+	funcMap := e.alterFuncMap(t, chain)
 
 	// We want to parse the templates in a predictable order. The order favors
 	// higher-level (in file system) templates over deeply nested templates.
@@ -253,42 +480,309 @@ func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls
 		files = append(files, fname)
 	}
 
-	// Adding the reference templates to the template context
-	// so they can be referenced in the tpl function
-	for fname, r := range referenceTpls {
-		if t.Lookup(fname) == nil {
-			t = t.New(fname).Funcs(funcMap)
-			if _, err := t.Parse(r.tpl); err != nil {
-				return map[string]string{}, fmt.Errorf("parse error in %q: %s", fname, err)
-			}
-		}
+	results, err := e.executeAll(t, files, tpls, chain)
+	if err != nil {
+		return map[string]string{}, err
 	}
 
 	rendered = make(map[string]string, len(files))
-	var buf bytes.Buffer
-	for _, file := range files {
-		// Don't render partials. We don't care out the direct output of partials.
-		// They are only included from other templates.
-		if strings.HasPrefix(path.Base(file), "_") {
+	var failures []string
+	for i, file := range files {
+		res := results[i]
+		if res.skipped {
 			continue
 		}
-		// At render time, add information about the template that is being rendered.
-		vals := tpls[file].vals
-		vals["Template"] = map[string]interface{}{"Name": file, "BasePath": tpls[file].basePath}
-		if err := t.ExecuteTemplate(&buf, file, vals); err != nil {
-			return map[string]string{}, fmt.Errorf("render error in %q: %s", file, err)
+		if res.err != nil {
+			// Failures asked for by the chart are collected, so that all of
+			// them can be fixed before rendering again.
+			if failure, ok := chartFailure(file, res.err); ok {
+				failures = append(failures, failure)
+				continue
+			}
+			return map[string]string{}, fmt.Errorf("render error in %q: %s", file, res.err)
 		}
 
-		// Work around the issue where Go will emit "<no value>" even if Options(missing=zero)
-		// is set. Since missing=error will never get here, we do not need to handle
-		// the Strict case.
-		rendered[file] = strings.Replace(buf.String(), "<no value>", "", -1)
-		buf.Reset()
+		rendered[file] = e.clean(res.out)
 	}
 
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return map[string]string{}, fmt.Errorf("render error in %d template(s):\n%s", len(failures), strings.Join(failures, "\n"))
+	}
 	return rendered, nil
 }
 
+// execResult is the outcome of executing one template file.
+type execResult struct {
+	out     string
+	err     error
+	skipped bool
+}
+
+// executeAll executes the given files of t. If a template can change its
+// values, each file gets its own copy of them, so that changes made by one
+// template are not seen by the others. Otherwise the values are shared, as
+// they are only read. Files are shared out between up to GOMAXPROCS workers, as the templates of
+// a large chart are independent of each other. The results are in the order
+// of files, whatever the order in which they finished.
+func (e *Engine) executeAll(t *template.Template, files []string, tpls map[string]renderable, chain *includeChain) ([]execResult, error) {
+	results := make([]execResult, len(files))
+	copyVals := mutatesValues(t)
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(files) {
+		workers = len(files)
+	}
+	if workers <= 1 {
+		for i, file := range files {
+			chain.err = nil
+			results[i] = executeFile(t, file, tpls[file], copyVals)
+		}
+		return results, nil
+	}
+
+	// Each worker has its own include chain, and so its own copy of the
+	// templates with functions that use that chain.
+	clones := make([]*template.Template, workers)
+	chains := make([]*includeChain, workers)
+	for w := range clones {
+		clone, err := t.Clone()
+		if err != nil {
+			return nil, err
+		}
+		chains[w] = &includeChain{names: append([]string(nil), chain.names...), max: chain.max}
+		clones[w] = clone.Funcs(e.alterFuncMap(clone, chains[w]))
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := range clones {
+		wg.Add(1)
+		go func(t *template.Template, chain *includeChain) {
+			defer wg.Done()
+			for i := range next {
+				chain.err = nil
+				results[i] = executeFile(t, files[i], tpls[files[i]], copyVals)
+			}
+		}(clones[w], chains[w])
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, nil
+}
+
+// executeFile executes a single template file. Partials are skipped, as
+// they are only included from other templates. If copyVals is set, the
+// template is executed on a copy of its values.
+func executeFile(t *template.Template, file string, r renderable, copyVals bool) (res execResult) {
+	if strings.HasPrefix(path.Base(file), "_") {
+		return execResult{skipped: true}
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			res = execResult{err: fmt.Errorf("rendering template failed: %v", p)}
+		}
+	}()
+
+	// At render time, add information about the template that is being
+	// rendered. The values of a chart are shared by its templates, which may
+	// be executed at the same time, so they are copied first if a template
+	// may change them with 'set'.
+	vals := make(chartutil.Values, len(r.vals)+1)
+	for k, v := range r.vals {
+		if copyVals {
+			v = copyValues(v)
+		}
+		vals[k] = v
+	}
+	vals["Template"] = map[string]interface{}{"Name": file, "BasePath": r.basePath}
+
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, file, vals); err != nil {
+		return execResult{err: err}
+	}
+	return execResult{out: buf.String()}
+}
+
+// copyValues returns a copy of the tables and lists of v, so that a template
+// changing them does not change what other templates see. Other values cannot
+// be changed by templates and are shared.
+func copyValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case chartutil.Values:
+		return chartutil.Values(copyValues(map[string]interface{}(v)).(map[string]interface{}))
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			c[k] = copyValues(e)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = copyValues(e)
+		}
+		return c
+	}
+	return v
+}
+
+// mutatingFuncs are the template functions that can change the tables and
+// lists they are given. 'tpl' is one of them, as the templates it executes
+// are only known at render time.
+var mutatingFuncs = map[string]bool{
+	"set":                true,
+	"unset":              true,
+	"merge":              true,
+	"mergeOverwrite":     true,
+	"mustMerge":          true,
+	"mustMergeOverwrite": true,
+	"append":             true,
+	"push":               true,
+	"mustAppend":         true,
+	"mustPush":           true,
+	"tpl":                true,
+}
+
+// mutatesValues reports whether a template of t, including the partials,
+// calls one of mutatingFuncs.
+func mutatesValues(t *template.Template) bool {
+	for _, tt := range t.Templates() {
+		if tt.Tree != nil && nodeMutatesValues(tt.Tree.Root) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeMutatesValues(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, c := range n.Nodes {
+			if nodeMutatesValues(c) {
+				return true
+			}
+		}
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, c := range n.Cmds {
+			if nodeMutatesValues(c) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			if nodeMutatesValues(a) {
+				return true
+			}
+		}
+	case *parse.IdentifierNode:
+		return mutatingFuncs[n.Ident]
+	case *parse.ChainNode:
+		return nodeMutatesValues(n.Node)
+	case *parse.ActionNode:
+		return nodeMutatesValues(n.Pipe)
+	case *parse.TemplateNode:
+		return nodeMutatesValues(n.Pipe)
+	case *parse.IfNode:
+		return branchMutatesValues(&n.BranchNode)
+	case *parse.RangeNode:
+		return branchMutatesValues(&n.BranchNode)
+	case *parse.WithNode:
+		return branchMutatesValues(&n.BranchNode)
+	}
+	return false
+}
+
+func branchMutatesValues(b *parse.BranchNode) bool {
+	return nodeMutatesValues(b.Pipe) || nodeMutatesValues(b.List) || nodeMutatesValues(b.ElseList)
+}
+
+// failurePattern matches the error of a template that stopped on the
+// 'required' or 'fail' function, capturing the position of the call and the
+// message of the chart.
+var failurePattern = regexp.MustCompile(`(?s)template: ([^\s:]+:\d+:\d+): executing "[^"]*" at <[^>]*>: error calling (?:required|fail): (.*)$`)
+
+// chartFailure returns the position and message of a failure asked for by the
+// chart itself while rendering file, as opposed to a broken template. For a
+// call made from an included template, the position is the one in that
+// template.
+func chartFailure(file string, err error) (string, bool) {
+	m := failurePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	if !strings.HasPrefix(m[1], file+":") {
+		return fmt.Sprintf("%s (included from %s): %s", m[1], file, m[2]), true
+	}
+	return m[1] + ": " + m[2], true
+}
+
+// includeChain holds the names of the nested 'include' and 'tpl' calls of a
+// rendering, outermost first.
+type includeChain struct {
+	names []string
+	max   int
+	// err is set once the chain goes deeper than max.
+	err error
+}
+
+// push records a call to name, failing if it is one too many. When the
+// last calls repeat, the error shows the cycle, such as "a -> b -> a".
+func (c *includeChain) push(name string) error {
+	if len(c.names) < c.max {
+		c.names = append(c.names, name)
+		return nil
+	}
+
+	calls := append(c.names, name)
+	if cycle := repeatedCycle(calls); cycle != nil {
+		c.err = fmt.Errorf("include depth of %d exceeded in the cycle %s", c.max, strings.Join(cycle, " -> "))
+		return c.err
+	}
+	if len(calls) > 10 {
+		calls = append(append(calls[:3:3], "..."), calls[len(calls)-6:]...)
+	}
+	c.err = fmt.Errorf("include depth of %d exceeded: %s", c.max, strings.Join(calls, " -> "))
+	return c.err
+}
+
+func (c *includeChain) pop() {
+	c.names = c.names[:len(c.names)-1]
+}
+
+// repeatedCycle returns the shortest cycle that the calls end with, such as
+// [a b a] for [x a b a b a], if it comes at least twice in a row.
+func repeatedCycle(calls []string) []string {
+	last := len(calls) - 1
+	for start := last - 1; start >= 0; start-- {
+		n := last - start
+		if start < n {
+			return nil
+		}
+		if calls[start] == calls[last] && repeats(calls[start-n:start], calls[start:last]) {
+			return calls[start:]
+		}
+	}
+	return nil
+}
+
+func repeats(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func sortTemplates(tpls map[string]renderable) []string {
 	keys := make([]string, len(tpls))
 	i := 0
@@ -365,6 +859,10 @@ func recAllTpls(c *chart.Chart, templates map[string]renderable, parentVals char
 		recAllTpls(child, templates, cvals, false, newParentID)
 	}
 	for _, t := range c.Templates {
+		// Library charts only provide named templates to the others.
+		if chartutil.IsLibraryChart(c) && !strings.HasPrefix(path.Base(t.Name), "_") {
+			continue
+		}
 		templates[path.Join(newParentID, t.Name)] = renderable{
 			tpl:      string(t.Data),
 			vals:     cvals,
@@ -372,3 +870,79 @@ func recAllTpls(c *chart.Chart, templates map[string]renderable, parentVals char
 		}
 	}
 }
+
+// ChartValues returns the .Values that Render gives the templates of each
+// chart of c for the same values, keyed by the path of the chart, such as
+// "mychart/charts/mysql". It shows how the values of a parent chart reach its
+// subcharts.
+func ChartValues(c *chart.Chart, vals chartutil.Values) map[string]chartutil.Values {
+	scoped := map[string]chartutil.Values{}
+	top, err := vals.Table("Values")
+	if err != nil {
+		top = chartutil.Values{}
+	}
+	recChartValues(c, top, c.Metadata.Name, scoped)
+	return scoped
+}
+
+func recChartValues(c *chart.Chart, vals chartutil.Values, id string, scoped map[string]chartutil.Values) {
+	scoped[id] = vals
+	for _, child := range c.Dependencies {
+		if child.Metadata == nil || child.Metadata.Name == "" {
+			continue
+		}
+		childVals, err := vals.Table(child.Metadata.Name)
+		if err != nil {
+			childVals = chartutil.Values{}
+		}
+		recChartValues(child, childVals, path.Join(id, "charts", child.Metadata.Name), scoped)
+	}
+}
+
+// deepCopy returns a copy of a value, such as a table of the values, that can
+// be modified without changing the original. Values it cannot copy are
+// returned as they are.
+func deepCopy(v interface{}) interface{} {
+	c, err := mustDeepCopy(v)
+	if err != nil {
+		return v
+	}
+	return c
+}
+
+// mustDeepCopy is like deepCopy, but fails for values it cannot copy.
+func mustDeepCopy(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, string, int, int64, float64:
+		return v, nil
+	case chartutil.Values:
+		c, err := mustDeepCopy(map[string]interface{}(v))
+		if err != nil {
+			return nil, err
+		}
+		return chartutil.Values(c.(map[string]interface{})), nil
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ce, err := mustDeepCopy(e)
+			if err != nil {
+				return nil, err
+			}
+			c[k] = ce
+		}
+		return c, nil
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			ce, err := mustDeepCopy(e)
+			if err != nil {
+				return nil, err
+			}
+			c[i] = ce
+		}
+		return c, nil
+	case []string:
+		return append([]string(nil), v...), nil
+	}
+	return nil, fmt.Errorf("cannot copy a %T", v)
+}
@@ -18,8 +18,12 @@ package engine
 
 import (
 	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
+	"text/template"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -80,7 +84,7 @@ func TestFuncMap(t *testing.T) {
 	}
 
 	// Test for Engine-specific template functions.
-	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson"}
+	expect := []string{"include", "required", "tpl", "warn", "toYaml", "fromYaml", "toToml", "toJson", "fromJson", "mustToYaml", "mustFromYaml", "mustToToml", "mustToJson", "mustFromJson", "deepCopy", "mustDeepCopy", "semver", "semverCompare", "toRawJson", "mustToRawJson", "fromYamlArray", "fromJsonArray", "quantityValue", "quantityMul", "deployed"}
 	for _, f := range expect {
 		if _, ok := fns[f]; !ok {
 			t.Errorf("Expected add-on function %q", f)
@@ -589,3 +593,513 @@ func TestAlterFuncMap(t *testing.T) {
 	}
 
 }
+
+func TestLookup(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "vault"},
+		Templates: []*chart.Template{
+			{Name: "templates/secret", Data: []byte(`{{ $s := lookup "v1" "Secret" "default" "db" }}{{ if $s }}{{ $s.data.password }}{{ else }}generated{{ end }}`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+	v := chartutil.Values{
+		"Values":  &chart.Config{Raw: ""},
+		"Chart":   c.Metadata,
+		"Release": chartutil.Values{"Name": "vault"},
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["vault/templates/secret"]; got != "generated" {
+		t.Errorf("Expected lookup to find nothing without a cluster, got %q", got)
+	}
+
+	e := New()
+	e.LookupFunc = func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+		if apiVersion != "v1" || kind != "Secret" || namespace != "default" || name != "db" {
+			t.Errorf("unexpected lookup of %s %s %s/%s", apiVersion, kind, namespace, name)
+		}
+		return map[string]interface{}{"data": map[string]interface{}{"password": "c2VjcmV0"}}, nil
+	}
+	out, err = e.Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["vault/templates/secret"]; got != "c2VjcmV0" {
+		t.Errorf("Expected the password of the existing secret, got %q", got)
+	}
+}
+
+func TestRenderCollectsFailures(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "conan"},
+		Templates: []*chart.Template{
+			{Name: "templates/quote", Data: []byte("kind: Quote\nwho: {{ required \"A valid 'who' is required\" .Values.who }}")},
+			{Name: "templates/bases", Data: []byte(`All {{ required "A valid 'bases' is required" .Values.bases }} of them!`)},
+			{Name: "templates/sword", Data: []byte(`{{ include "conan.sword" . }}`)},
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "conan.sword" }}{{ fail "The sword is lost" }}{{ end }}`)},
+			{Name: "templates/fine", Data: []byte(`fine`)},
+		},
+		Values:       &chart.Config{Raw: ``},
+		Dependencies: []*chart.Chart{},
+	}
+	v := chartutil.Values{
+		"Values":  chartutil.Values{},
+		"Chart":   c.Metadata,
+		"Release": chartutil.Values{"Name": "That 90s meme"},
+	}
+
+	_, err := New().Render(c, v)
+	if err == nil {
+		t.Fatal("Expected the missing values to fail the rendering")
+	}
+	expected := `render error in 3 template(s):
+conan/templates/_helpers.tpl:1:29 (included from conan/templates/sword): The sword is lost
+conan/templates/bases:1:7: A valid 'bases' is required
+conan/templates/quote:2:8: A valid 'who' is required`
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err)
+	}
+
+	// Other errors still stop the rendering at once.
+	c.Templates = append(c.Templates, &chart.Template{Name: "templates/broken", Data: []byte(`{{ .Values.who.name }}`)})
+	v["Values"] = chartutil.Values{"who": "us", "bases": 2}
+	_, err = New().Render(c, v)
+	if err == nil || !strings.HasPrefix(err.Error(), `render error in "conan/templates/`) {
+		t.Errorf("Expected the broken template to fail the rendering, got %v", err)
+	}
+}
+
+func TestRenderIncludeDepth(t *testing.T) {
+	render := func(e *Engine, templates ...*chart.Template) error {
+		c := &chart.Chart{
+			Metadata:     &chart.Metadata{Name: "loop"},
+			Templates:    templates,
+			Values:       &chart.Config{Raw: ``},
+			Dependencies: []*chart.Chart{},
+		}
+		v := chartutil.Values{"Values": chartutil.Values{}, "Chart": c.Metadata}
+		_, err := e.Render(c, v)
+		return err
+	}
+
+	err := render(New(),
+		&chart.Template{Name: "templates/main", Data: []byte(`{{ include "x" . }}`)},
+		&chart.Template{Name: "templates/_helpers", Data: []byte(`{{ define "x" }}{{ include "a" . }}{{ end }}{{ define "a" }}{{ include "b" . }}{{ end }}{{ define "b" }}{{ include "a" . }}{{ end }}`)},
+	)
+	if err == nil || !strings.HasSuffix(err.Error(), "include depth of 1000 exceeded in the cycle b -> a -> b") {
+		t.Errorf("Expected the cycle to be reported, got %v", err)
+	}
+	if err != nil && strings.Count(err.Error(), "error calling include") != 1 {
+		t.Errorf("Expected the error to be wrapped once, got %v", err)
+	}
+
+	// A helper that nests deeply but ends renders within a higher limit.
+	countdown := &chart.Template{Name: "templates/_countdown", Data: []byte(`{{ define "countdown" }}{{ if gt . 0 }}{{ include "countdown" (sub . 1) }}{{ end }}{{ end }}`)}
+	deep := &chart.Template{Name: "templates/deep", Data: []byte(`{{ include "countdown" 20 }}`)}
+	e := New()
+	e.MaxIncludeDepth = 10
+	if err := render(e, countdown, deep); err == nil || !strings.Contains(err.Error(), "include depth of 10 exceeded") {
+		t.Errorf("Expected the limit of 10 to be exceeded, got %v", err)
+	}
+	e.MaxIncludeDepth = 30
+	if err := render(e, countdown, deep); err != nil {
+		t.Errorf("Expected the countdown to render, got %v", err)
+	}
+}
+
+func TestRepeatedCycle(t *testing.T) {
+	tests := []struct {
+		calls    string
+		expected string
+	}{
+		{"x a b a b a", "a b a"},
+		{"a a a", "a a"},
+		{"a b a c a b a c a", "a b a c a"},
+		{"x y z a b a", ""},
+		{"a b c", ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(repeatedCycle(strings.Fields(tt.calls)), " ")
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.calls, tt.expected, got)
+		}
+	}
+}
+
+func TestRenderConcurrently(t *testing.T) {
+	templates := []*chart.Template{
+		{Name: "templates/_helpers", Data: []byte(`{{ define "name" }}{{ .Template.Name }}{{ end }}`)},
+	}
+	for i := 0; i < 50; i++ {
+		templates = append(templates, &chart.Template{
+			Name: fmt.Sprintf("templates/t%02d", i),
+			Data: []byte(`{{ include "name" . }}/{{ .Values.who }}`),
+		})
+	}
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "many"},
+		Templates: templates,
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{"who": "octopus"}}
+
+	out, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 50 {
+		t.Fatalf("Expected 50 templates, got %d", len(out))
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("many/templates/t%02d", i)
+		if expected := name + "/octopus"; out[name] != expected {
+			t.Errorf("Expected %q, got %q", expected, out[name])
+		}
+	}
+
+	// The broken template that comes first in the render order, which is
+	// t39, is reported whichever one fails first.
+	c.Templates[10].Data = []byte(`{{ .Values.who.nope.nope }}`)
+	c.Templates[40].Data = []byte(`{{ .Values.who.nope.nope }}`)
+	for i := 0; i < 10; i++ {
+		_, err := New().Render(c, vals)
+		if err == nil || !strings.HasPrefix(err.Error(), `render error in "many/templates/t39"`) {
+			t.Fatalf("Expected the error of t39, got %v", err)
+		}
+	}
+}
+
+func TestRenderConcurrentlySet(t *testing.T) {
+	var templates []*chart.Template
+	for i := 0; i < 50; i++ {
+		templates = append(templates, &chart.Template{
+			Name: fmt.Sprintf("templates/t%02d", i),
+			Data: []byte(`{{ $_ := set .Values "who" .Template.Name }}{{ $_ := set .Values.sea "name" .Template.Name }}{{ .Values.who }}/{{ .Values.sea.name }}`),
+		})
+	}
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "many"},
+		Templates: templates,
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{
+		"who": "octopus",
+		"sea": map[string]interface{}{"name": "atlantic"},
+	}}
+
+	out, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("many/templates/t%02d", i)
+		if expected := name + "/" + name; out[name] != expected {
+			t.Errorf("Expected %q, got %q", expected, out[name])
+		}
+	}
+	v := vals["Values"].(map[string]interface{})
+	if v["who"] != "octopus" || v["sea"].(map[string]interface{})["name"] != "atlantic" {
+		t.Errorf("Expected the values to be left as they were, got %v", v)
+	}
+}
+
+func TestMutatesValues(t *testing.T) {
+	tests := []struct {
+		tpl    string
+		expect bool
+	}{
+		{`{{ .Values.who }}`, false},
+		{`{{ range .Values.list }}{{ . | quote }}{{ end }}`, false},
+		{`{{ template "_helper" . }}`, false},
+		{`{{ $_ := set .Values "who" "octopus" }}`, true},
+		{`{{ if .Values.enabled }}{{ else }}{{ $_ := unset .Values "who" }}{{ end }}`, true},
+		{`{{ with .Values.sea }}{{ merge . (dict "name" "atlantic") }}{{ end }}`, true},
+		{`{{ (append .Values.list "x") | toYaml }}`, true},
+		{`{{ tpl .Values.text . }}`, true},
+	}
+
+	e := New()
+	for _, tt := range tests {
+		tmpl := template.New("test").Funcs(e.FuncMap)
+		if _, err := tmpl.Parse(tt.tpl); err != nil {
+			t.Fatalf("%q: %s", tt.tpl, err)
+		}
+		if got := mutatesValues(tmpl); got != tt.expect {
+			t.Errorf("%q: expected %t, got %t", tt.tpl, tt.expect, got)
+		}
+	}
+
+	// The partials are looked at as well.
+	tmpl := template.Must(template.New("test").Funcs(e.FuncMap).Parse(`{{ include "_helper" . }}`))
+	template.Must(tmpl.New("_helper").Parse(`{{ $_ := set .Values "who" "octopus" }}`))
+	if !mutatesValues(tmpl) {
+		t.Error("Expected a partial calling set to mutate the values")
+	}
+}
+
+func TestRenderTplCache(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "tpls"},
+		Templates: []*chart.Template{
+			{Name: "templates/loop", Data: []byte(`{{ range .Values.names }}{{ tpl "[{{ .Values.prefix }}]" $ }}{{ end }}`)},
+			{Name: "templates/empty", Data: []byte(`before{{ tpl .Values.empty . }}after`)},
+			{Name: "templates/define", Data: []byte(`{{ tpl "{{ define \"own\" }}own{{ end }}{{ include \"own\" . }}/{{ include \"shared\" . }}" . }}`)},
+			{Name: "templates/_helpers", Data: []byte(`{{ define "shared" }}shared{{ end }}`)},
+		},
+	}
+	v := chartutil.Values{
+		"Values": chartutil.Values{
+			"names":  []interface{}{"a", "b", "c"},
+			"prefix": "p",
+			"empty":  "",
+		},
+		"Chart": c.Metadata,
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expects := map[string]string{
+		"tpls/templates/loop":   "[p][p][p]",
+		"tpls/templates/empty":  "beforeafter",
+		"tpls/templates/define": "own/shared",
+	}
+	for file, expect := range expects {
+		if out[file] != expect {
+			t.Errorf("Expected %q for %s, got %q", expect, file, out[file])
+		}
+	}
+}
+
+func BenchmarkRenderTpl(b *testing.B) {
+	templates := []*chart.Template{
+		{Name: "templates/loop", Data: []byte(`{{ range $i, $n := .Values.names }}{{ tpl "{{ include \"helper\" . }}-{{ .Values.prefix }}" $ }}{{ end }}`)},
+	}
+	for i := 0; i < 100; i++ {
+		templates = append(templates, &chart.Template{
+			Name: fmt.Sprintf("templates/_helpers%02d.tpl", i),
+			Data: []byte(fmt.Sprintf(`{{ define "helper%02d" }}{{ .Values.prefix | upper | quote }}{{ end }}`, i)),
+		})
+	}
+	templates = append(templates, &chart.Template{Name: "templates/_helper.tpl", Data: []byte(`{{ define "helper" }}helper{{ end }}`)})
+	names := make([]interface{}, 100)
+	for i := range names {
+		names[i] = fmt.Sprint(i)
+	}
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "bench"},
+		Templates: templates,
+	}
+	v := chartutil.Values{
+		"Values": chartutil.Values{"names": names, "prefix": "p"},
+		"Chart":  c.Metadata,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := New().Render(c, v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRenderLibraryDependency(t *testing.T) {
+	library := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "common", Type: chartutil.LibraryChart},
+		Templates: []*chart.Template{
+			{Name: "templates/_labels.tpl", Data: []byte(`{{ define "common.labels" }}app: {{ .Chart.Name }}{{ end }}`)},
+			{Name: "templates/configmap.yaml", Data: []byte(`kind: ConfigMap`)},
+		},
+	}
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "app"},
+		Templates: []*chart.Template{
+			{Name: "templates/service.yaml", Data: []byte(`{{ include "common.labels" . }}`)},
+		},
+		Dependencies: []*chart.Chart{library},
+	}
+	v := chartutil.Values{"Values": chartutil.Values{}, "Chart": c.Metadata}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["app/templates/service.yaml"]; got != "app: app" {
+		t.Errorf("Expected the labels of the library, got %q", got)
+	}
+	if _, ok := out["app/charts/common/templates/configmap.yaml"]; ok {
+		t.Error("Expected the templates of the library not to be rendered")
+	}
+}
+
+func TestRenderMissingKey(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/whale", Data: []byte(`whale: {{ .Values.whale }}`)},
+		},
+	}
+	v := chartutil.Values{"Values": chartutil.Values{}, "Chart": c.Metadata}
+
+	tests := []struct {
+		missingKey string
+		strict     bool
+		expect     string
+		wantErr    string
+	}{
+		{"", false, "whale: ", ""},
+		{MissingKeyZero, false, "whale: ", ""},
+		{MissingKeyInvalid, false, "whale: <no value>", ""},
+		{MissingKeyError, false, "", `map has no entry for key "whale"`},
+		{MissingKeyInvalid, true, "", `map has no entry for key "whale"`},
+		{"ignore", false, "", `missing key behavior "ignore" is not valid`},
+	}
+	for _, tt := range tests {
+		e := New()
+		e.MissingKey = tt.missingKey
+		e.Strict = tt.strict
+		out, err := e.Render(c, v)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%q: expected an error containing %q, got %v", tt.missingKey, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.missingKey, err)
+			continue
+		}
+		if got := out["moby/templates/whale"]; got != tt.expect {
+			t.Errorf("%q: expected %q, got %q", tt.missingKey, tt.expect, got)
+		}
+	}
+}
+
+func TestRenderWarnings(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers", Data: []byte(`{{ define "moby.deprecated" }}{{ if .Values.whale }}{{ warn "whale is deprecated, use cetacean" }}{{ end }}{{ end }}`)},
+			{Name: "templates/a", Data: []byte(`a{{ include "moby.deprecated" . }}`)},
+			{Name: "templates/b", Data: []byte(`b{{ include "moby.deprecated" . }}{{ warn "boat is unused" }}`)},
+		},
+	}
+	v := chartutil.Values{"Values": chartutil.Values{"whale": "white"}, "Chart": c.Metadata}
+
+	warnings := &Warnings{}
+	e := New()
+	e.WarnFunc = warnings.Add
+	out, err := e.Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["moby/templates/a"] != "a" || out["moby/templates/b"] != "b" {
+		t.Errorf("Expected warn to render nothing, got %v", out)
+	}
+	expect := []string{"boat is unused", "whale is deprecated, use cetacean"}
+	if got := warnings.List(); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected %v, got %v", expect, got)
+	}
+
+	// Without a WarnFunc, warnings are dropped.
+	if _, err := New().Render(c, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderEnv(t *testing.T) {
+	os.Setenv("HELM_TEST_REGION", "atlantic")
+	defer os.Unsetenv("HELM_TEST_REGION")
+
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/region", Data: []byte(`region: {{ env "HELM_TEST_REGION" }}`)},
+		},
+	}
+	v := chartutil.Values{"Values": chartutil.Values{}, "Chart": c.Metadata}
+
+	e := New()
+	if _, err := e.Render(c, v); err == nil || !strings.Contains(err.Error(), "HELM_TEST_REGION is not allowed") {
+		t.Errorf("Expected a variable that is not allowed to fail, got %v", err)
+	}
+
+	e.AllowedEnv = []string{"HELM_TEST_REGION"}
+	out, err := e.Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/region"]; got != "region: atlantic" {
+		t.Errorf("Expected the allowed variable to be read, got %q", got)
+	}
+}
+
+func TestChartValues(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "umbrella"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata:     &chart.Metadata{Name: "web"},
+				Dependencies: []*chart.Chart{{Metadata: &chart.Metadata{Name: "cache"}}},
+			},
+			{Metadata: &chart.Metadata{Name: "db"}},
+		},
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{
+		"replicas": 2,
+		"web": map[string]interface{}{
+			"port":  80,
+			"cache": map[string]interface{}{"size": "1Gi"},
+		},
+	}}
+
+	scoped := ChartValues(c, vals)
+	if len(scoped) != 4 {
+		t.Fatalf("Expected the values of 4 charts, got %v", scoped)
+	}
+	if v := scoped["umbrella"]["replicas"]; v != 2 {
+		t.Errorf("Expected the values of the top chart, got %v", scoped["umbrella"])
+	}
+	if v := scoped["umbrella/charts/web"]["port"]; v != 80 {
+		t.Errorf("Expected the values of web, got %v", scoped["umbrella/charts/web"])
+	}
+	if v := scoped["umbrella/charts/web/charts/cache"]["size"]; v != "1Gi" {
+		t.Errorf("Expected the values of cache, got %v", scoped["umbrella/charts/web/charts/cache"])
+	}
+	if len(scoped["umbrella/charts/db"]) != 0 {
+		t.Errorf("Expected no values for db, got %v", scoped["umbrella/charts/db"])
+	}
+}
+
+func TestRenderMustFunctions(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/config", Data: []byte(`{{ $c := deepCopy .Values.config }}{{ $_ := set $c "size" 2 }}{{ .Values.config.size }} {{ $c.size }} {{ (mustFromJson .Values.json).whale }}{{ if semverCompare ">=1.2" .Values.version }} new{{ end }}`)},
+		},
+	}
+	v := chartutil.Values{
+		"Values": map[string]interface{}{
+			"config":  map[string]interface{}{"size": 1},
+			"json":    `{"whale": "white"}`,
+			"version": "1.3.0",
+		},
+		"Chart": c.Metadata,
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expect := out["moby/templates/config"], "1 2 white new"; got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+
+	v["Values"].(map[string]interface{})["json"] = "not json"
+	if _, err := New().Render(c, v); err == nil {
+		t.Error("Expected mustFromJson to fail on invalid JSON")
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.starlark.net/starlark"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// FunctionsDir is the directory of a chart holding Starlark scripts, with the
+// ".star" extension, whose public functions can be called from templates.
+const FunctionsDir = "functions"
+
+// The limits of a Starlark script, applied to its loading and to each call of
+// one of its functions. Starlark has no access to files, the network or the
+// clock, and does not allow recursion, so the functions are pure and only
+// the computation itself needs to be bounded. The arguments and the result of
+// a call may hold at most functionMaxSize bytes of strings and elements of
+// lists and dicts. Starlark cannot limit the memory a script allocates while
+// it runs, which is why Engine.DisableChartFunctions exists.
+const (
+	functionMaxSteps = 1000000
+	functionTimeout  = time.Second
+	functionMaxSize  = 1 << 20
+)
+
+// chartFunctions loads the scripts of the functions/ directories of a chart
+// and of its dependencies, and returns their public functions, those whose
+// names do not start with '_', as template functions. A name may only be
+// defined once, and may not hide a function of the engine. If disabled is
+// set, any script fails the loading.
+func chartFunctions(c *chart.Chart, reserved template.FuncMap, disabled bool) (template.FuncMap, error) {
+	funcs := template.FuncMap{}
+	sources := map[string]string{}
+	if err := recChartFunctions(c, "", reserved, disabled, funcs, sources); err != nil {
+		return nil, err
+	}
+	return funcs, nil
+}
+
+func recChartFunctions(c *chart.Chart, parentID string, reserved template.FuncMap, disabled bool, funcs template.FuncMap, sources map[string]string) error {
+	id := c.Metadata.Name
+	if parentID != "" {
+		id = path.Join(parentID, "charts", id)
+	}
+
+	for _, f := range c.Files {
+		if !strings.HasPrefix(f.TypeUrl, FunctionsDir+"/") || path.Ext(f.TypeUrl) != ".star" {
+			continue
+		}
+		script := path.Join(id, f.TypeUrl)
+		if disabled {
+			return fmt.Errorf("cannot load %s: chart functions are disabled", script)
+		}
+		thread := newThread(script)
+		var globals starlark.StringDict
+		err := withTimeout(thread, func() (err error) {
+			globals, err = starlark.ExecFile(thread, script, f.Value, nil)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("cannot load %s: %s", script, err)
+		}
+		// Frozen globals can be shared by the templates rendered at once.
+		globals.Freeze()
+
+		names := make([]string, 0, len(globals))
+		for name := range globals {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fn, ok := globals[name].(*starlark.Function)
+			if !ok || strings.HasPrefix(name, "_") {
+				continue
+			}
+			if _, ok := reserved[name]; ok || name == "env" {
+				return fmt.Errorf("function %q of %s hides the template function of the same name", name, script)
+			}
+			if other, ok := sources[name]; ok {
+				return fmt.Errorf("function %q of %s is already defined by %s", name, script, other)
+			}
+			sources[name] = script
+			funcs[name] = templateFunction(fn)
+		}
+	}
+
+	for _, child := range c.Dependencies {
+		if err := recChartFunctions(child, id, reserved, disabled, funcs, sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateFunction calls a Starlark function with the arguments of a template,
+// within the limits of a script.
+func templateFunction(fn *starlark.Function) func(args ...interface{}) (interface{}, error) {
+	return func(args ...interface{}) (interface{}, error) {
+		size := functionMaxSize
+		sargs := make(starlark.Tuple, len(args))
+		for i, arg := range args {
+			v, err := toStarlark(arg, &size)
+			if err != nil {
+				return nil, fmt.Errorf("%s: argument %d: %s", fn.Name(), i+1, err)
+			}
+			sargs[i] = v
+		}
+
+		thread := newThread(fn.Name())
+		var res starlark.Value
+		err := withTimeout(thread, func() (err error) {
+			res, err = starlark.Call(thread, fn, sargs, nil)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", fn.Name(), err)
+		}
+		size = functionMaxSize
+		out, err := fromStarlark(res, &size)
+		if err != nil {
+			return nil, fmt.Errorf("%s: result: %s", fn.Name(), err)
+		}
+		return out, nil
+	}
+}
+
+// newThread returns a Starlark thread that cannot load other modules, prints
+// nothing and stops after functionMaxSteps steps.
+func newThread(name string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name:  name,
+		Print: func(*starlark.Thread, string) {},
+	}
+	thread.SetMaxExecutionSteps(functionMaxSteps)
+	return thread
+}
+
+// withTimeout runs f, cancelling thread if it takes longer than
+// functionTimeout.
+func withTimeout(thread *starlark.Thread, f func() error) error {
+	timer := time.AfterFunc(functionTimeout, func() {
+		thread.Cancel(fmt.Sprintf("took longer than %s", functionTimeout))
+	})
+	defer timer.Stop()
+	return f()
+}
+
+// spend takes n from the size left for the arguments or the result of a call,
+// and fails once it is exhausted.
+func spend(size *int, n int) error {
+	if n > *size {
+		return fmt.Errorf("larger than the limit of %d bytes and elements", functionMaxSize)
+	}
+	*size -= n
+	return nil
+}
+
+// toStarlark converts a value of a template, such as a value of the chart, to
+// Starlark, taking its size from size.
+func toStarlark(v interface{}, size *int) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		if err := spend(size, len(v)); err != nil {
+			return nil, err
+		}
+		return starlark.String(v), nil
+	case int:
+		return starlark.MakeInt(v), nil
+	case int64:
+		return starlark.MakeInt64(v), nil
+	case float64:
+		return starlark.Float(v), nil
+	case []string:
+		if err := spend(size, len(v)); err != nil {
+			return nil, err
+		}
+		list := make([]starlark.Value, len(v))
+		for i, s := range v {
+			if err := spend(size, len(s)); err != nil {
+				return nil, err
+			}
+			list[i] = starlark.String(s)
+		}
+		return starlark.NewList(list), nil
+	case []interface{}:
+		if err := spend(size, len(v)); err != nil {
+			return nil, err
+		}
+		list := make([]starlark.Value, len(v))
+		for i, e := range v {
+			sv, err := toStarlark(e, size)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = sv
+		}
+		return starlark.NewList(list), nil
+	case chartutil.Values:
+		return toStarlark(map[string]interface{}(v), size)
+	case map[string]interface{}:
+		if err := spend(size, len(v)); err != nil {
+			return nil, err
+		}
+		dict := starlark.NewDict(len(v))
+		for k, e := range v {
+			if err := spend(size, len(k)); err != nil {
+				return nil, err
+			}
+			sv, err := toStarlark(e, size)
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(k), sv); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	}
+	return nil, fmt.Errorf("cannot pass a %T to a function", v)
+}
+
+// fromStarlark converts the result of a Starlark function to the types of the
+// values of a chart, taking its size from size.
+func fromStarlark(v starlark.Value, size *int) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.String:
+		if err := spend(size, len(v)); err != nil {
+			return nil, err
+		}
+		return string(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, fmt.Errorf("%s does not fit in 64 bits", v)
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.Indexable:
+		// The length of a range is not backed by memory, so it is checked
+		// before the list is made.
+		if err := spend(size, v.Len()); err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			e, err := fromStarlark(v.Index(i), size)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = e
+		}
+		return list, nil
+	case *starlark.Dict:
+		if err := spend(size, v.Len()); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, v.Len())
+		for _, item := range v.Items() {
+			k, ok := starlark.AsString(item[0])
+			if !ok {
+				return nil, fmt.Errorf("cannot return a dict with the key %s, which is not a string", item[0])
+			}
+			if err := spend(size, len(k)); err != nil {
+				return nil, err
+			}
+			e, err := fromStarlark(item[1], size)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = e
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("cannot return a %s from a function", v.Type())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const portsScript = `
+def ports(services):
+    return ",".join([_port(s) for s in services])
+
+def _port(s):
+    return "%s:%d" % (s["name"], int(s["port"]))
+
+def spin():
+    for i in range(100000000):
+        pass
+`
+
+func functionsChart(script string, deps ...*chart.Chart) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/ports", Data: []byte(`{{ ports .Values.services }}`)},
+		},
+		Files:        []*any.Any{{TypeUrl: "functions/ports.star", Value: []byte(script)}},
+		Dependencies: deps,
+	}
+}
+
+func TestRenderChartFunctions(t *testing.T) {
+	c := functionsChart(portsScript)
+	v := chartutil.Values{
+		"Values": map[string]interface{}{
+			"services": []interface{}{
+				map[string]interface{}{"name": "http", "port": float64(80)},
+				map[string]interface{}{"name": "https", "port": float64(443)},
+			},
+		},
+		"Chart": c.Metadata,
+	}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/ports"]; got != "http:80,https:443" {
+		t.Errorf("Expected the ports to be joined, got %q", got)
+	}
+}
+
+func TestRenderChartFunctionsLimits(t *testing.T) {
+	c := functionsChart(portsScript)
+	c.Templates = []*chart.Template{{Name: "templates/spin", Data: []byte(`{{ spin }}`)}}
+	v := chartutil.Values{"Values": map[string]interface{}{}, "Chart": c.Metadata}
+
+	if _, err := New().Render(c, v); err == nil || !strings.Contains(err.Error(), "too many steps") {
+		t.Errorf("Expected a function that runs too long to be stopped, got %v", err)
+	}
+
+	// Arguments and results are bounded.
+	c = functionsChart("def big(n):\n    return range(n)\n")
+	c.Templates = []*chart.Template{{Name: "templates/big", Data: []byte(`{{ big 100000000 }}`)}}
+	if _, err := New().Render(c, v); err == nil || !strings.Contains(err.Error(), "big: result: larger than the limit") {
+		t.Errorf("Expected an oversized result to fail, got %v", err)
+	}
+	c = functionsChart("def size(s):\n    return len(s)\n")
+	c.Templates = []*chart.Template{{Name: "templates/size", Data: []byte(`{{ size .Values.s }}`)}}
+	v["Values"] = map[string]interface{}{"s": strings.Repeat("x", functionMaxSize+1)}
+	if _, err := New().Render(c, v); err == nil || !strings.Contains(err.Error(), "size: argument 1: larger than the limit") {
+		t.Errorf("Expected an oversized argument to fail, got %v", err)
+	}
+	v["Values"] = map[string]interface{}{}
+
+	// Scripts cannot load anything.
+	c = functionsChart(`load("os.star", "getenv")`)
+	if _, err := New().Render(c, v); err == nil || !strings.Contains(err.Error(), "cannot load moby/functions/ports.star") {
+		t.Errorf("Expected load to fail, got %v", err)
+	}
+}
+
+func TestRenderChartFunctionsDisabled(t *testing.T) {
+	c := functionsChart(portsScript)
+	v := chartutil.Values{"Values": map[string]interface{}{}, "Chart": c.Metadata}
+
+	e := New()
+	e.DisableChartFunctions = true
+	if _, err := e.Render(c, v); err == nil || !strings.Contains(err.Error(), "cannot load moby/functions/ports.star: chart functions are disabled") {
+		t.Errorf("Expected disabled chart functions to fail the rendering, got %v", err)
+	}
+}
+
+func TestRenderChartFunctionsConflicts(t *testing.T) {
+	v := chartutil.Values{"Values": map[string]interface{}{}}
+
+	c := functionsChart("def include(name):\n    return name\n")
+	if _, err := New().Render(c, v); err == nil || !strings.Contains(err.Error(), "hides the template function") {
+		t.Errorf("Expected a function of the engine not to be hidden, got %v", err)
+	}
+
+	dep := functionsChart(portsScript)
+	dep.Metadata = &chart.Metadata{Name: "dep"}
+	c = functionsChart(portsScript, dep)
+	_, err := New().Render(c, v)
+	if err == nil || !strings.Contains(err.Error(), `"ports" of moby/charts/dep/functions/ports.star is already defined by moby/functions/ports.star`) {
+		t.Errorf("Expected a function defined twice to fail, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// quantityFuncs are the template functions for Kubernetes quantities, such as
+// the memory limit of a container, to derive settings from them:
+//
+//	-Xmx{{ div (quantityMul .Values.resources.limits.memory 0.75 | quantityValue) 1048576 }}m
+//
+// Quantities are given as strings, such as "512Mi" or "250m", or as numbers.
+// Results that are quantities are strings in the format of the first
+// argument.
+func quantityFuncs() template.FuncMap {
+	return template.FuncMap{
+		"quantityValue":      quantityValue,
+		"quantityMilliValue": quantityMilliValue,
+		"quantityAdd":        quantityAdd,
+		"quantitySub":        quantitySub,
+		"quantityMul":        quantityMul,
+		"quantityCmp":        quantityCmp,
+	}
+}
+
+// parseQuantity reads a quantity of the values of a chart.
+func parseQuantity(v interface{}) (resource.Quantity, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case int:
+		s = strconv.Itoa(v)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return resource.Quantity{}, fmt.Errorf("cannot read a quantity from a %T", v)
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("cannot read quantity %q: %s", s, err)
+	}
+	return q, nil
+}
+
+// quantityValue returns a quantity as an integer, rounded up, such as the
+// number of bytes of "1Gi".
+func quantityValue(v interface{}) (int64, error) {
+	q, err := parseQuantity(v)
+	if err != nil {
+		return 0, err
+	}
+	return q.Value(), nil
+}
+
+// quantityMilliValue returns a quantity in thousandths, rounded up, such as
+// 250 for "250m" of CPU.
+func quantityMilliValue(v interface{}) (int64, error) {
+	q, err := parseQuantity(v)
+	if err != nil {
+		return 0, err
+	}
+	return q.MilliValue(), nil
+}
+
+// quantityAdd returns the sum of two quantities.
+func quantityAdd(a, b interface{}) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	qa.Add(qb)
+	return qa.String(), nil
+}
+
+// quantitySub returns the difference of two quantities.
+func quantitySub(a, b interface{}) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	qa.Sub(qb)
+	return qa.String(), nil
+}
+
+// quantityMul returns a quantity multiplied by a factor, such as 0.75 for
+// three quarters of it, rounded up to the thousandth.
+func quantityMul(v interface{}, factor float64) (string, error) {
+	q, err := parseQuantity(v)
+	if err != nil {
+		return "", err
+	}
+	milli := math.Ceil(float64(q.MilliValue()) * factor)
+	if math.Abs(milli) >= math.MaxInt64 {
+		return "", fmt.Errorf("%s times %v is too large", q.String(), factor)
+	}
+	return resource.NewMilliQuantity(int64(milli), q.Format).String(), nil
+}
+
+// quantityCmp returns -1, 0 or 1 if the first quantity is less than, equal to
+// or greater than the second one.
+func quantityCmp(a, b interface{}) (int, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return 0, err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return 0, err
+	}
+	return qa.Cmp(qb), nil
+}
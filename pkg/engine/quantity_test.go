@@ -0,0 +1,72 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestQuantityFuncs(t *testing.T) {
+	if v, err := quantityValue("1Gi"); err != nil || v != 1073741824 {
+		t.Errorf("Expected 1Gi to be 1073741824 bytes, got %d, %v", v, err)
+	}
+	if v, err := quantityMilliValue("250m"); err != nil || v != 250 {
+		t.Errorf("Expected 250m to be 250 thousandths, got %d, %v", v, err)
+	}
+	if v, err := quantityMilliValue(float64(2)); err != nil || v != 2000 {
+		t.Errorf("Expected a number to be read as a quantity, got %d, %v", v, err)
+	}
+	if v, err := quantityMul("1Gi", 0.75); err != nil || v != "768Mi" {
+		t.Errorf("Expected three quarters of 1Gi to be 768Mi, got %s, %v", v, err)
+	}
+	if v, err := quantityAdd("500m", "1"); err != nil || v != "1500m" {
+		t.Errorf("Expected 500m and 1 to be 1500m, got %s, %v", v, err)
+	}
+	if v, err := quantitySub("1Gi", "256Mi"); err != nil || v != "768Mi" {
+		t.Errorf("Expected 1Gi minus 256Mi to be 768Mi, got %s, %v", v, err)
+	}
+	if v, err := quantityCmp("1Gi", "1000Mi"); err != nil || v != 1 {
+		t.Errorf("Expected 1Gi to be more than 1000Mi, got %d, %v", v, err)
+	}
+	if _, err := quantityValue("lots"); err == nil {
+		t.Error("Expected an invalid quantity to fail")
+	}
+	if _, err := quantityValue(true); err == nil {
+		t.Error("Expected a boolean to fail")
+	}
+}
+
+func TestRenderQuantityFuncs(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/heap", Data: []byte(`-Xmx{{ div (quantityMul .Values.memory 0.75 | quantityValue) 1048576 }}m`)},
+		},
+	}
+	v := chartutil.Values{"Values": map[string]interface{}{"memory": "2Gi"}, "Chart": c.Metadata}
+
+	out, err := New().Render(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/heap"]; got != "-Xmx1536m" {
+		t.Errorf("Expected the heap to be derived from the memory, got %q", got)
+	}
+}
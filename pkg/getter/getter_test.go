@@ -57,8 +57,8 @@ func TestAll(t *testing.T) {
 	env := hh(false)
 
 	all := All(env)
-	if len(all) != 3 {
-		t.Errorf("expected 3 providers (default plus two plugins), got %d", len(all))
+	if len(all) != 4 {
+		t.Errorf("expected 4 providers (http, oci plus two plugins), got %d", len(all))
 	}
 
 	if _, err := all.ByScheme("test2"); err != nil {
@@ -78,4 +78,7 @@ func TestByScheme(t *testing.T) {
 	if _, err := ByScheme("https", env); err != nil {
 		t.Error(err)
 	}
+	if _, err := ByScheme("oci", env); err != nil {
+		t.Error(err)
+	}
 }
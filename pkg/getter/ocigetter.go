@@ -0,0 +1,82 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/tlsutil"
+)
+
+// OCIGetter pulls charts from OCI registries, given oci:// references.
+type OCIGetter struct {
+	client *registry.Client
+}
+
+// Get pulls the chart archive that the oci:// reference href points to.
+//
+// Registries do not serve provenance files, so requests for them fail.
+func (g *OCIGetter) Get(href string) (*bytes.Buffer, error) {
+	if strings.HasSuffix(href, ".prov") {
+		return nil, fmt.Errorf("provenance files are not supported for %s references", registry.Scheme)
+	}
+	ref, err := registry.ParseReference(href)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.client.Pull(ref)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(data), nil
+}
+
+// newOCIGetterConstructor returns a Constructor of OCI getters that cache the
+// charts they pull in the Helm home of settings.
+func newOCIGetterConstructor(settings environment.EnvSettings) Constructor {
+	return func(URL, CertFile, KeyFile, CAFile string) (Getter, error) {
+		return NewOCIGetter(URL, CertFile, KeyFile, CAFile, settings.Home.Registry())
+	}
+}
+
+// NewOCIGetter constructs an OCIGetter that authenticates with the Docker
+// credentials of the user and caches pulled charts in cacheDir.
+func NewOCIGetter(URL, CertFile, KeyFile, CAFile, cacheDir string) (*OCIGetter, error) {
+	tr := &http.Transport{
+		DisableCompression: true,
+		Proxy:              http.ProxyFromEnvironment,
+	}
+	if (CertFile != "" && KeyFile != "") || CAFile != "" {
+		tlsConf, err := tlsutil.NewTLSConfig(strings.Replace(URL, registry.Scheme+"://", "https://", 1), CertFile, KeyFile, CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't create TLS config: %s", err.Error())
+		}
+		tr.TLSClientConfig = tlsConf
+	}
+	return &OCIGetter{
+		client: &registry.Client{
+			HTTPClient:  &http.Client{Transport: tr},
+			Credentials: registry.DockerCredentials(),
+			CacheDir:    cacheDir,
+		},
+	}, nil
+}
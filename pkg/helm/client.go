@@ -148,6 +148,35 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 	return h.delete(ctx, req)
 }
 
+// DeleteReleases uninstalls the releases matching the selector and the filter
+// given with the DeleteSelector and DeleteFilter options.
+func (h *Client) DeleteReleases(opts ...DeleteOption) (*rls.UninstallReleasesResponse, error) {
+	// apply the uninstall options
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &reqOpts.uninstallManyReq
+	req.DisableHooks = reqOpts.disableHooks
+	req.DryRun = reqOpts.dryRun
+	req.Purge = reqOpts.uninstallReq.Purge
+	req.Timeout = reqOpts.uninstallReq.Timeout
+	req.Description = reqOpts.uninstallReq.Description
+	req.Wait = reqOpts.uninstallReq.Wait
+	req.Cascade = reqOpts.uninstallReq.Cascade
+	req.ForceProtected = reqOpts.uninstallReq.ForceProtected
+	req.IgnoreResourcePolicy = reqOpts.uninstallReq.IgnoreResourcePolicy
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.deleteMany(ctx, req)
+}
+
 // UpdateRelease loads a chart from chstr and updates a release to a new/different chart.
 func (h *Client) UpdateRelease(rlsName string, chstr string, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
 	// load the chart to update
@@ -173,8 +202,10 @@ func (h *Client) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts
 	req.DisableHooks = reqOpts.disableHooks
 	req.Recreate = reqOpts.recreate
 	req.Force = reqOpts.force
+	req.ForceRecreate = reqOpts.forceRecreate
 	req.ResetValues = reqOpts.resetValues
 	req.ReuseValues = reqOpts.reuseValues
+	req.ResetThenReuseValues = reqOpts.resetThenReuseValues
 	ctx := NewContext()
 
 	if reqOpts.before != nil {
@@ -220,6 +251,7 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 	req := &reqOpts.rollbackReq
 	req.Recreate = reqOpts.recreate
 	req.Force = reqOpts.force
+	req.ForceRecreate = reqOpts.forceRecreate
 	req.DisableHooks = reqOpts.disableHooks
 	req.DryRun = reqOpts.dryRun
 	req.Name = rlsName
@@ -288,6 +320,39 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 	return h.history(ctx, req)
 }
 
+// ReleaseDetail returns the number of resources of a release and those
+// that drifted from its manifest.
+func (h *Client) ReleaseDetail(rlsName string, opts ...DetailOption) (*rls.GetReleaseDetailResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &reqOpts.detailReq
+	req.Name = rlsName
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.detail(ctx, req)
+}
+
+// ProtectRelease protects a release from deletion, or lifts the protection.
+func (h *Client) ProtectRelease(rlsName string, protect bool) (*rls.ProtectReleaseResponse, error) {
+	req := &rls.ProtectReleaseRequest{Name: rlsName, Protect: protect}
+	ctx := NewContext()
+
+	if h.opts.before != nil {
+		if err := h.opts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.protect(ctx, req)
+}
+
 // RunReleaseTest executes a pre-defined test on a release.
 func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	reqOpts := h.opts
@@ -389,6 +454,18 @@ func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (
 	return rlc.UninstallRelease(ctx, req)
 }
 
+// deleteMany executes tiller.UninstallReleases RPC.
+func (h *Client) deleteMany(ctx context.Context, req *rls.UninstallReleasesRequest) (*rls.UninstallReleasesResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.UninstallReleases(ctx, req)
+}
+
 // update executes tiller.UpdateRelease RPC.
 func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rls.UpdateReleaseResponse, error) {
 	c, err := h.connect(ctx)
@@ -461,6 +538,30 @@ func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.
 	return rlc.GetHistory(ctx, req)
 }
 
+// detail executes tiller.GetReleaseDetail RPC.
+func (h *Client) detail(ctx context.Context, req *rls.GetReleaseDetailRequest) (*rls.GetReleaseDetailResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.GetReleaseDetail(ctx, req)
+}
+
+// protect executes tiller.ProtectRelease RPC.
+func (h *Client) protect(ctx context.Context, req *rls.ProtectReleaseRequest) (*rls.ProtectReleaseResponse, error) {
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	rlc := rls.NewReleaseServiceClient(c)
+	return rlc.ProtectRelease(ctx, req)
+}
+
 // test executes tiller.TestRelease RPC.
 func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	errc := make(chan error, 1)
@@ -76,6 +76,8 @@ type EnvSettings struct {
 	TLSCertFile string
 	// TLSKeyFile is the path to a TLS key file
 	TLSKeyFile string
+	// TimeFormat is the name of the format of the times in the output.
+	TimeFormat string
 }
 
 // AddFlags binds flags to the given flagset.
@@ -87,6 +89,7 @@ func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.Debug, "debug", false, "Enable verbose output")
 	fs.StringVar(&s.TillerNamespace, "tiller-namespace", "kube-system", "Namespace of Tiller")
 	fs.Int64Var(&s.TillerConnectionTimeout, "tiller-connection-timeout", int64(300), "The duration (in seconds) Helm will wait to establish a connection to Tiller")
+	fs.StringVar(&s.TimeFormat, "time-format", "ansic", "Format of the times in the output: ansic, rfc3339, relative or unix. Overrides $HELM_TIME_FORMAT")
 }
 
 // AddFlagsTLS adds the flags for supporting client side TLS to the given flagset.
@@ -119,6 +122,7 @@ var envMap = map[string]string{
 	"home":             "HELM_HOME",
 	"host":             "HELM_HOST",
 	"tiller-namespace": "TILLER_NAMESPACE",
+	"time-format":      "HELM_TIME_FORMAT",
 }
 
 var tlsEnvMap = map[string]string{
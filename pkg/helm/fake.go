@@ -19,17 +19,21 @@ package helm // import "k8s.io/helm/pkg/helm"
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/rand"
+	"regexp"
 	"strings"
 	"sync"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/manifest"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/proto/hapi/version"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/renderutil"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
@@ -40,6 +44,18 @@ type FakeClient struct {
 	Responses       map[string]release.TestRun_Status
 	Opts            options
 	RenderManifests bool
+	// RecreatedResources is returned by dry-run upgrades that check them.
+	RecreatedResources []string
+	// DriftedResources are the drifted resources of each release, by name.
+	DriftedResources map[string][]string
+	// Drifts are returned along with DriftedResources for a diff, by release name.
+	Drifts map[string][]*rls.ResourceDrift
+	// KeptResources are the resources that deleting each release keeps, by
+	// release name, unless the resource policy is ignored.
+	KeptResources map[string][]*rls.KeptResource
+	// InstallError, if set, is returned by installs, which store the release
+	// as failed.
+	InstallError error
 }
 
 // Option returns the fake release client
@@ -61,14 +77,26 @@ func (c *FakeClient) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesR
 	}
 	req := &reqOpts.listReq
 	rels := c.Rels
-	count := int64(len(c.Rels))
+	if req.Selector != "" {
+		selector, err := labels.Parse(req.Selector)
+		if err != nil {
+			return nil, err
+		}
+		rels = nil
+		for _, rel := range c.Rels {
+			if selector.Matches(labels.Set(rel.Labels)) {
+				rels = append(rels, rel)
+			}
+		}
+	}
+	count := int64(len(rels))
 	var next string
 	limit := req.GetLimit()
 	// TODO: Handle all other options.
 	if limit != 0 && limit < count {
+		next = rels[limit].GetName()
 		rels = rels[:limit]
 		count = limit
-		next = c.Rels[limit].GetName()
 	}
 
 	resp := &rls.ListReleasesResponse{
@@ -109,18 +137,29 @@ func (c *FakeClient) InstallReleaseFromChart(chart *chart.Chart, ns string, opts
 		Namespace:   ns,
 		Description: releaseDescription,
 	}
+	if c.InstallError != nil {
+		mockOpts.StatusCode = release.Status_FAILED
+	}
 
 	release := ReleaseMock(mockOpts)
+	release.Labels = c.Opts.instReq.Labels
+	release.Info.DeployedBy = c.Opts.instReq.DeployedBy
 
 	if c.RenderManifests {
 		if err := RenderReleaseMock(release, false); err != nil {
 			return nil, err
 		}
 	}
+	if m := c.Opts.instReq.PostRenderedManifest; m != "" {
+		release.Manifest = m
+	}
 
 	if !c.Opts.dryRun {
 		c.Rels = append(c.Rels, release)
 	}
+	if c.InstallError != nil {
+		return nil, c.InstallError
+	}
 
 	return &rls.InstallReleaseResponse{
 		Release: release,
@@ -129,18 +168,73 @@ func (c *FakeClient) InstallReleaseFromChart(chart *chart.Chart, ns string, opts
 
 // DeleteRelease deletes a release from the FakeClient
 func (c *FakeClient) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.UninstallReleaseResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
 	for i, rel := range c.Rels {
 		if rel.Name == rlsName {
+			if rel.Protected && !reqOpts.uninstallReq.ForceProtected {
+				return nil, fmt.Errorf("release %q is protected from deletion, use --force-protected to delete it", rlsName)
+			}
 			c.Rels = append(c.Rels[:i], c.Rels[i+1:]...)
-			return &rls.UninstallReleaseResponse{
+			res := &rls.UninstallReleaseResponse{
 				Release: rel,
-			}, nil
+			}
+			if !reqOpts.uninstallReq.IgnoreResourcePolicy {
+				res.Kept = c.KeptResources[rlsName]
+			}
+			return res, nil
 		}
 	}
 
 	return nil, storageerrors.ErrReleaseNotFound(rlsName)
 }
 
+// DeleteReleases deletes the releases whose names match the filter and whose
+// labels match the selector from the ReleaseMocks, unless it is a dry run.
+func (c *FakeClient) DeleteReleases(opts ...DeleteOption) (*rls.UninstallReleasesResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	filter, err := regexp.Compile(reqOpts.uninstallManyReq.Filter)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(reqOpts.uninstallManyReq.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &rls.UninstallReleasesResponse{}
+	var kept []*release.Release
+	for _, rel := range c.Rels {
+		if filter.MatchString(rel.Name) && selector.Matches(labels.Set(rel.Labels)) {
+			if rel.Protected && !reqOpts.uninstallReq.ForceProtected {
+				res.Failed = append(res.Failed, &rls.FailedUninstall{
+					Name:  rel.Name,
+					Error: fmt.Sprintf("release %q is protected from deletion, use --force-protected to delete it", rel.Name),
+				})
+				kept = append(kept, rel)
+				continue
+			}
+			res.Releases = append(res.Releases, rel)
+			if !reqOpts.dryRun {
+				if !reqOpts.uninstallReq.IgnoreResourcePolicy {
+					res.Kept = append(res.Kept, c.KeptResources[rel.Name]...)
+				}
+				continue
+			}
+		}
+		kept = append(kept, rel)
+	}
+	c.Rels = kept
+	return res, nil
+}
+
 // GetVersion returns a fake version
 func (c *FakeClient) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error) {
 	return &rls.GetVersionResponse{
@@ -176,6 +270,7 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 	}
 
 	newRelease := ReleaseMock(mockOpts)
+	newRelease.Info.DeployedBy = c.Opts.updateReq.DeployedBy
 
 	if c.Opts.updateReq.ResetValues {
 		newRelease.Config = &chart.Config{Raw: "{}"}
@@ -188,27 +283,86 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 			return nil, err
 		}
 	}
+	if m := c.Opts.updateReq.PostRenderedManifest; m != "" {
+		newRelease.Manifest = m
+	}
 
 	if !c.Opts.dryRun {
 		*rel.Release = *newRelease
+		return &rls.UpdateReleaseResponse{Release: newRelease}, nil
 	}
 
-	return &rls.UpdateReleaseResponse{Release: newRelease}, nil
+	res := &rls.UpdateReleaseResponse{Release: newRelease}
+	if c.Opts.updateReq.CheckRecreated {
+		res.RecreatedResources = c.RecreatedResources
+	}
+	return res, nil
 }
 
-// RollbackRelease returns nil, nil
+// RollbackRelease returns the revision that rolling back to the requested one
+// would create, or nil, nil if the client knows no release by that name.
 func (c *FakeClient) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
-	return nil, nil
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	var current *release.Release
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName && (current == nil || rel.Version > current.Version) {
+			current = rel
+		}
+	}
+	if current == nil {
+		return nil, nil
+	}
+
+	version := reqOpts.rollbackReq.Version
+	if version == 0 {
+		for _, rel := range c.Rels {
+			code := rel.GetInfo().GetStatus().GetCode()
+			if rel.Name == rlsName && rel.Version < current.Version && rel.Version > version &&
+				(code == release.Status_DEPLOYED || code == release.Status_SUPERSEDED) {
+				version = rel.Version
+			}
+		}
+	}
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName && version != 0 && rel.Version == version {
+			return &rls.RollbackReleaseResponse{Release: &release.Release{
+				Name:      rlsName,
+				Namespace: current.Namespace,
+				Chart:     rel.Chart,
+				Config:    rel.Config,
+				Info: &release.Info{
+					Status:     &release.Status{Code: release.Status_PENDING_ROLLBACK},
+					DeployedBy: reqOpts.rollbackReq.DeployedBy,
+				},
+				Version:  current.Version + 1,
+				Manifest: rel.Manifest,
+				Hooks:    rel.Hooks,
+			}}, nil
+		}
+	}
+	return nil, storageerrors.ErrReleaseNotFound(rlsName)
 }
 
 // ReleaseStatus returns a release status response with info from the matching release name.
 func (c *FakeClient) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&reqOpts)
+		}
+	}
+	version := reqOpts.statusReq.Version
 	for _, rel := range c.Rels {
-		if rel.Name == rlsName {
+		if rel.Name == rlsName && (version == 0 || rel.Version == version) {
 			return &rls.GetReleaseStatusResponse{
 				Name:      rel.Name,
 				Info:      rel.Info,
 				Namespace: rel.Namespace,
+				Version:   rel.Version,
 			}, nil
 		}
 	}
@@ -232,6 +386,40 @@ func (c *FakeClient) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls
 	return &rls.GetHistoryResponse{Releases: c.Rels}, nil
 }
 
+// ReleaseDetail counts the documents in the manifest of the matching release
+// and returns its DriftedResources, with its Drifts for a diff.
+func (c *FakeClient) ReleaseDetail(rlsName string, opts ...DetailOption) (*rls.GetReleaseDetailResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName {
+			res := &rls.GetReleaseDetailResponse{
+				ResourceCount:    int32(len(releaseutil.SplitManifests(rel.Manifest))),
+				DriftedResources: c.DriftedResources[rlsName],
+			}
+			if reqOpts.detailReq.Diff {
+				res.Drifts = c.Drifts[rlsName]
+			}
+			return res, nil
+		}
+	}
+	return nil, storageerrors.ErrReleaseNotFound(rlsName)
+}
+
+// ProtectRelease sets the protection of the matching release from the ReleaseMocks
+func (c *FakeClient) ProtectRelease(rlsName string, protect bool) (*rls.ProtectReleaseResponse, error) {
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName {
+			rel.Protected = protect
+			return &rls.ProtectReleaseResponse{Release: rel}, nil
+		}
+	}
+	return nil, storageerrors.ErrReleaseNotFound(rlsName)
+}
+
 // RunReleaseTest executes a pre-defined tests on a release
 func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 
@@ -87,6 +87,11 @@ func (h Home) Archive() string {
 	return h.Path("cache", "archive")
 }
 
+// Registry returns the path to the cache of charts pulled from registries.
+func (h Home) Registry() string {
+	return h.Path("cache", "registry")
+}
+
 // TLSCaCert returns the path to fetch the CA certificate.
 func (h Home) TLSCaCert() string {
 	return h.Path("ca.pem")
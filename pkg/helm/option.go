@@ -47,8 +47,10 @@ type options struct {
 	reuseName bool
 	// if set, performs pod restart during upgrade/rollback
 	recreate bool
-	// if set, force resource update through delete/recreate if needed
+	// if set, force resource update by retrying conflicting patches, then replacing the resources
 	force bool
+	// if set with force, delete and recreate the resources that cannot be replaced
+	forceRecreate bool
 	// if set, skip running hooks
 	disableHooks bool
 	// if set, skip CRD hook only
@@ -65,6 +67,8 @@ type options struct {
 	updateReq rls.UpdateReleaseRequest
 	// release uninstall options are applied directly to the uninstall release request
 	uninstallReq rls.UninstallReleaseRequest
+	// bulk uninstall options are applied to the uninstall releases request, along with the uninstall options
+	uninstallManyReq rls.UninstallReleasesRequest
 	// release get status options are applied directly to the get release status request
 	statusReq rls.GetReleaseStatusRequest
 	// release get content options are applied directly to the get release content request
@@ -75,10 +79,15 @@ type options struct {
 	before func(context.Context, proto.Message) error
 	// release history options are applied directly to the get release history request
 	histReq rls.GetHistoryRequest
+	// release detail options are applied directly to the get release detail request
+	detailReq rls.GetReleaseDetailRequest
 	// resetValues instructs Tiller to reset values to their defaults.
 	resetValues bool
 	// reuseValues instructs Tiller to reuse the values from the last release.
 	reuseValues bool
+	// resetThenReuseValues instructs Tiller to reset values to the chart's
+	// defaults, then reuse the values supplied to the last release.
+	resetThenReuseValues bool
 	// release test options are applied directly to the test release history request
 	testReq rls.TestReleaseRequest
 	// connectTimeout specifies the time duration Helm will wait to establish a connection to tiller
@@ -159,13 +168,49 @@ func ReleaseListStatuses(statuses []release.Status_Code) ReleaseListOption {
 	}
 }
 
-// ReleaseListNamespace specifies the namespace to list releases from
+// ReleaseListNamespace specifies the namespaces to list releases from, as a
+// comma-separated list of namespaces or glob patterns
 func ReleaseListNamespace(namespace string) ReleaseListOption {
 	return func(opts *options) {
 		opts.listReq.Namespace = namespace
 	}
 }
 
+// ReleaseListContinue specifies the continue token of the previous page of releases
+func ReleaseListContinue(token string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ContinueToken = token
+	}
+}
+
+// ReleaseListSelector specifies the label selector the listed releases must match
+func ReleaseListSelector(selector string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.Selector = selector
+	}
+}
+
+// ReleaseListChart specifies the name of the chart of the listed releases
+func ReleaseListChart(name string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ChartName = name
+	}
+}
+
+// ReleaseListChartVersion specifies the semantic version constraint the chart of the listed releases must satisfy
+func ReleaseListChartVersion(constraint string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ChartVersion = constraint
+	}
+}
+
+// ReleaseListChartAnnotations specifies the selector the annotations of the chart of the listed releases must match
+func ReleaseListChartAnnotations(selector string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ChartAnnotations = selector
+	}
+}
+
 // InstallOption allows specifying various settings
 // configurable by the helm client user for overriding
 // the defaults used when running the `helm install` command.
@@ -213,6 +258,49 @@ func DeleteTimeout(timeout int64) DeleteOption {
 	}
 }
 
+// DeleteWait specifies whether or not to wait for the resources of the release to be removed
+func DeleteWait(wait bool) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.Wait = wait
+	}
+}
+
+// DeleteCascade specifies how the deletion propagates to the dependents of the resources
+func DeleteCascade(cascade string) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.Cascade = cascade
+	}
+}
+
+// DeleteForceProtected specifies whether or not to delete the release even if it is protected
+func DeleteForceProtected(force bool) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.ForceProtected = force
+	}
+}
+
+// DeleteIgnoreResourcePolicy specifies whether or not to also delete the
+// resources annotated with "helm.sh/resource-policy: keep"
+func DeleteIgnoreResourcePolicy(ignore bool) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.IgnoreResourcePolicy = ignore
+	}
+}
+
+// DeleteSelector specifies the label selector of the releases to uninstall with DeleteReleases
+func DeleteSelector(selector string) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallManyReq.Selector = selector
+	}
+}
+
+// DeleteFilter specifies the regular expression matching the names of the releases to uninstall with DeleteReleases
+func DeleteFilter(filter string) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallManyReq.Filter = filter
+	}
+}
+
 // ReleaseTestTimeout specifies the number of seconds before kubernetes calls timeout
 func ReleaseTestTimeout(timeout int64) ReleaseTestOption {
 	return func(opts *options) {
@@ -255,6 +343,139 @@ func UpgradeWait(wait bool) UpdateOption {
 	}
 }
 
+// InstallWaitForJobs specifies whether or not to also wait for all Jobs to complete
+func InstallWaitForJobs(waitForJobs bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.WaitForJobs = waitForJobs
+	}
+}
+
+// UpgradeWaitForJobs specifies whether or not to also wait for all Jobs to complete
+func UpgradeWaitForJobs(waitForJobs bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.WaitForJobs = waitForJobs
+	}
+}
+
+// RollbackWaitForJobs specifies whether or not to also wait for all Jobs to complete
+func RollbackWaitForJobs(waitForJobs bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.WaitForJobs = waitForJobs
+	}
+}
+
+// InstallValidate specifies whether or not to validate the manifests of a dry run on the server
+func InstallValidate(validate bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Validate = validate
+	}
+}
+
+// UpgradeValidate specifies whether or not to validate the manifests of a dry run on the server
+func UpgradeValidate(validate bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Validate = validate
+	}
+}
+
+// InstallStrict specifies whether or not to fail the rendering on references to undefined values
+func InstallStrict(strict bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Strict = strict
+	}
+}
+
+// UpgradeStrict specifies whether or not to fail the rendering on references to undefined values
+func UpgradeStrict(strict bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Strict = strict
+	}
+}
+
+// InstallMissingKey specifies what the templates render for values that are not defined
+func InstallMissingKey(missingKey string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.MissingKey = missingKey
+	}
+}
+
+// UpgradeMissingKey specifies what the templates render for values that are not defined
+func UpgradeMissingKey(missingKey string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.MissingKey = missingKey
+	}
+}
+
+// InstallStripComments specifies whether or not to drop comments and empty documents from the rendered manifests
+func InstallStripComments(strip bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.StripComments = strip
+	}
+}
+
+// UpgradeStripComments specifies whether or not to drop comments and empty documents from the rendered manifests
+func UpgradeStripComments(strip bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.StripComments = strip
+	}
+}
+
+// UpgradeCheckRecreated specifies whether or not a dry run lists the resources that cannot be updated in place
+func UpgradeCheckRecreated(check bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.CheckRecreated = check
+	}
+}
+
+// InstallPostRenderedManifest specifies the manifest to install in place of the rendered chart
+func InstallPostRenderedManifest(manifest string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.PostRenderedManifest = manifest
+	}
+}
+
+// UpgradePostRenderedManifest specifies the manifest to deploy in place of the rendered chart
+func UpgradePostRenderedManifest(manifest string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.PostRenderedManifest = manifest
+	}
+}
+
+// InstallSkipCRDs specifies whether or not to skip installing the CRDs of the crds/ directory
+func InstallSkipCRDs(skip bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.SkipCrds = skip
+	}
+}
+
+// InstallTakeOwnership specifies whether or not to adopt the resources that already exist
+func InstallTakeOwnership(take bool) InstallOption {
+	return func(opts *options) {
+		opts.instReq.TakeOwnership = take
+	}
+}
+
+// InstallLabels specifies the labels to attach to the release
+func InstallLabels(labels map[string]string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Labels = labels
+	}
+}
+
+// UpgradeSkipCRDs specifies whether or not to skip installing the new CRDs of the crds/ directory
+func UpgradeSkipCRDs(skip bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.SkipCrds = skip
+	}
+}
+
+// UpgradeMaxHistory limits the number of revisions kept for the release
+func UpgradeMaxHistory(max int32) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.MaxHistory = max
+	}
+}
+
 // RollbackWait specifies whether or not to wait for all resources to be ready
 func RollbackWait(wait bool) RollbackOption {
 	return func(opts *options) {
@@ -290,6 +511,27 @@ func RollbackDescription(description string) RollbackOption {
 	}
 }
 
+// InstallDeployedBy specifies the identity of the user installing the release
+func InstallDeployedBy(identity string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.DeployedBy = identity
+	}
+}
+
+// UpgradeDeployedBy specifies the identity of the user upgrading the release
+func UpgradeDeployedBy(identity string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.DeployedBy = identity
+	}
+}
+
+// RollbackDeployedBy specifies the identity of the user rolling back the release
+func RollbackDeployedBy(identity string) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.DeployedBy = identity
+	}
+}
+
 // DeleteDescription specifies the description for the release
 func DeleteDescription(description string) DeleteOption {
 	return func(opts *options) {
@@ -311,6 +553,13 @@ func RollbackCleanupOnFail(cleanupOnFail bool) RollbackOption {
 	}
 }
 
+// RollbackPrune allows deletion of the resources left by the versions between the target and the current one
+func RollbackPrune(prune bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.Prune = prune
+	}
+}
+
 // DeleteDisableHooks will disable hooks for a deletion operation.
 func DeleteDisableHooks(disable bool) DeleteOption {
 	return func(opts *options) {
@@ -395,13 +644,30 @@ func RollbackRecreate(recreate bool) RollbackOption {
 	}
 }
 
-// RollbackForce will (if true) force resource update through delete/recreate if needed
+// RollbackRecreatePodsFor restarts the pods of the given resources only after
+// rollback. Resources are given as kind/name, such as deployment/web.
+func RollbackRecreatePodsFor(resources []string) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.RecreatePodsFor = resources
+	}
+}
+
+// RollbackForce will (if true) force resource update by retrying conflicting
+// patches, then replacing the resources that still cannot be patched.
 func RollbackForce(force bool) RollbackOption {
 	return func(opts *options) {
 		opts.force = force
 	}
 }
 
+// RollbackForceRecreate will (if true) delete and recreate the resources that
+// cannot be replaced when RollbackForce is set.
+func RollbackForceRecreate(recreate bool) RollbackOption {
+	return func(opts *options) {
+		opts.forceRecreate = recreate
+	}
+}
+
 // RollbackVersion sets the version of the release to deploy.
 func RollbackVersion(ver int32) RollbackOption {
 	return func(opts *options) {
@@ -438,6 +704,15 @@ func ReuseValues(reuse bool) UpdateOption {
 	}
 }
 
+// ResetThenReuseValues will cause Tiller to reset the values to the defaults
+// of the new chart, then apply the values supplied to the last release.
+// This is ignored if ResetValues or ReuseValues is true.
+func ResetThenReuseValues(resetThenReuse bool) UpdateOption {
+	return func(opts *options) {
+		opts.resetThenReuseValues = resetThenReuse
+	}
+}
+
 // UpgradeRecreate will (if true) recreate pods after upgrade.
 func UpgradeRecreate(recreate bool) UpdateOption {
 	return func(opts *options) {
@@ -445,13 +720,30 @@ func UpgradeRecreate(recreate bool) UpdateOption {
 	}
 }
 
-// UpgradeForce will (if true) force resource update through delete/recreate if needed
+// UpgradeRecreatePodsFor restarts the pods of the given resources only after
+// upgrade. Resources are given as kind/name, such as deployment/web.
+func UpgradeRecreatePodsFor(resources []string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.RecreatePodsFor = resources
+	}
+}
+
+// UpgradeForce will (if true) force resource update by retrying conflicting
+// patches, then replacing the resources that still cannot be patched.
 func UpgradeForce(force bool) UpdateOption {
 	return func(opts *options) {
 		opts.force = force
 	}
 }
 
+// UpgradeForceRecreate will (if true) delete and recreate the resources that
+// cannot be replaced when UpgradeForce is set.
+func UpgradeForceRecreate(recreate bool) UpdateOption {
+	return func(opts *options) {
+		opts.forceRecreate = recreate
+	}
+}
+
 // ContentOption allows setting optional attributes when
 // performing a GetReleaseContent tiller rpc.
 type ContentOption func(*options)
@@ -505,6 +797,26 @@ func WithMaxHistory(max int32) HistoryOption {
 	}
 }
 
+// DetailOption allows configuring optional request data for
+// issuing a GetReleaseDetail rpc.
+type DetailOption func(*options)
+
+// DetailReleaseVersion will instruct Tiller to compare a particular
+// version of a release with the cluster.
+func DetailReleaseVersion(version int32) DetailOption {
+	return func(opts *options) {
+		opts.detailReq.Version = version
+	}
+}
+
+// DetailDiff will instruct Tiller to also return the manifest and the live
+// state of the drifted resources.
+func DetailDiff(diff bool) DetailOption {
+	return func(opts *options) {
+		opts.detailReq.Diff = diff
+	}
+}
+
 // NewContext creates a versioned context.
 func NewContext() context.Context {
 	md := metadata.Pairs("x-helm-api-client", version.GetVersion())
@@ -29,6 +29,8 @@ const (
 	HookDeleteAnno = "helm.sh/hook-delete-policy"
 	// HookDeleteTimeoutAnno is the label name for the timeout value for delete policies
 	HookDeleteTimeoutAnno = "helm.sh/hook-delete-timeout"
+	// HookTimeoutAnno is the label name for the timeout value of a hook, overriding the release timeout
+	HookTimeoutAnno = "helm.sh/hook-timeout"
 )
 
 // Types of hooks
@@ -46,6 +48,21 @@ const (
 	CRDInstall         = "crd-install"
 )
 
+// Events maps the types of hooks to the events they run on.
+var Events = map[string]release.Hook_Event{
+	PreInstall:         release.Hook_PRE_INSTALL,
+	PostInstall:        release.Hook_POST_INSTALL,
+	PreDelete:          release.Hook_PRE_DELETE,
+	PostDelete:         release.Hook_POST_DELETE,
+	PreUpgrade:         release.Hook_PRE_UPGRADE,
+	PostUpgrade:        release.Hook_POST_UPGRADE,
+	PreRollback:        release.Hook_PRE_ROLLBACK,
+	PostRollback:       release.Hook_POST_ROLLBACK,
+	ReleaseTestSuccess: release.Hook_RELEASE_TEST_SUCCESS,
+	ReleaseTestFailure: release.Hook_RELEASE_TEST_FAILURE,
+	CRDInstall:         release.Hook_CRD_INSTALL,
+}
+
 // Type of policy for deleting the hook
 const (
 	HookSucceeded      = "hook-succeeded"
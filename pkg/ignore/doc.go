@@ -30,12 +30,17 @@ The formatting rules are as follows:
 	- Inline comments are NOT supported ('foo* # Any foo' does not contain a comment)
 	- There is no support for multi-line patterns
 	- Shell glob patterns are supported. See Go's "path/filepath".Match
-	- If a pattern begins with a leading !, the match will be negated.
-	- If a pattern begins with a leading /, only paths relatively rooted will match.
+	- Patterns are evaluated in order, and the last pattern that matches a path
+	  decides whether it is ignored
+	- If a pattern begins with a leading !, the match will be negated: a path
+	  ignored by an earlier pattern is included again
+	- A path inside an ignored directory is always ignored
+	- If a pattern begins with a leading /, or contains a slash that is not
+	  trailing, only paths relatively rooted will match
 	- If the pattern ends with a trailing /, only directories will match
 	- If a pattern contains no slashes, file basenames are tested (not paths)
-	- The pattern sequence "**", while legal in a glob, will cause an error here
-	  (to indicate incompatibility with .gitignore).
+	- A "**" path element matches any number of directories. A trailing "/**"
+	  matches everything inside a directory.
 
 Example:
 
@@ -57,8 +62,11 @@ Example:
 	# Match any file named ab.txt, ac.txt, or ad.txt
 	a[b-d].txt
 
+	# Match everything inside the top-level docs directory, except its README.txt
+	/docs/**
+	!/docs/README.txt
+
 Notable differences from .gitignore:
-	- The '**' syntax is not supported.
 	- The globbing library is Go's 'filepath.Match', not fnmatch(3)
 	- Trailing spaces are always ignored (there is no supported escape sequence)
 	- The evaluation of escape sequences has not been tested for compatibility
@@ -18,7 +18,7 @@ package ignore
 
 import (
 	"bufio"
-	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -35,6 +35,9 @@ const HelmIgnore = ".helmignore"
 // Empty() will create an immutable empty ruleset.
 type Rules struct {
 	patterns []*pattern
+	// onlyNegated is set when the parsed rules are all negated, which keeps
+	// their former meaning: the paths they do not match are ignored.
+	onlyNegated bool
 }
 
 // Empty builds an empty ruleset.
@@ -45,8 +48,14 @@ func Empty() *Rules {
 // AddDefaults adds default ignore patterns.
 //
 // Ignore all dotfiles in "templates/"
+//
+// The defaults come before the rules already parsed, so that the rules of a
+// helmignore file can override them.
 func (r *Rules) AddDefaults() {
+	rules := r.patterns
+	r.patterns = nil
 	r.parseRule(`templates/.?*`)
+	r.patterns = append(r.patterns, rules...)
 }
 
 // ParseFile parses a helmignore file and returns the *Rules.
@@ -56,7 +65,11 @@ func ParseFile(file string) (*Rules, error) {
 		return nil, err
 	}
 	defer f.Close()
-	return Parse(f)
+	r, err := Parse(f)
+	if err == nil && r.onlyNegated {
+		log.Printf("Warning: %s only has negated rules, so every file they do not match is ignored. This is deprecated: as in a .gitignore file, they will not ignore anything in a future release. Add a '*' rule before them to keep ignoring the other files.", file)
+	}
+	return r, err
 }
 
 // Parse parses a rules file
@@ -69,6 +82,10 @@ func Parse(file io.Reader) (*Rules, error) {
 			return r, err
 		}
 	}
+	r.onlyNegated = len(r.patterns) > 0
+	for _, p := range r.patterns {
+		r.onlyNegated = r.onlyNegated && p.negate
+	}
 	return r, s.Err()
 }
 
@@ -79,8 +96,14 @@ func (r *Rules) Len() int {
 
 // Ignore evaluates the file at the given path, and returns true if it should be ignored.
 //
-// Ignore evaluates path against the rules in order. Evaluation stops when a match
-// is found. Matching a negative rule will stop evaluation.
+// Ignore evaluates path against all of the rules, in order, and the last rule
+// that matches decides: the path is ignored unless that rule is negative. As
+// with git, a path is also ignored when one of the directories containing it
+// is, whatever the rules for the path itself.
+//
+// If the rules of the file are all negated, a path that does not match each
+// of them is ignored as well, as it was before the rules followed git. This
+// is deprecated.
 func (r *Rules) Ignore(path string, fi os.FileInfo) bool {
 	// Don't match on empty dirs.
 	if path == "" {
@@ -93,34 +116,55 @@ func (r *Rules) Ignore(path string, fi os.FileInfo) bool {
 	if path == "." || path == "./" {
 		return false
 	}
+
+	path = strings.TrimSuffix(filepath.ToSlash(path), "/")
+	for i, c := range path {
+		if c == '/' && r.ignored(path[:i], true) {
+			return true
+		}
+	}
+	return r.ignored(path, fi.IsDir())
+}
+
+// ignored applies the rules to a single path, without its parent directories.
+func (r *Rules) ignored(path string, isDir bool) bool {
+	if r.onlyNegated && !r.matchesNegated(path, isDir) {
+		return true
+	}
+	ignored := false
 	for _, p := range r.patterns {
 		if p.match == nil {
 			log.Printf("ignore: no matcher supplied for %q", p.raw)
 			return false
 		}
 
-		// For negative rules, we need to capture and return non-matches,
-		// and continue for matches.
-		if p.negate {
-			if p.mustDir && !fi.IsDir() {
-				return true
-			}
-			if !p.match(path, fi) {
-				return true
-			}
+		// If the rule is looking for directories, and this is not a directory,
+		// skip it.
+		if p.mustDir && !isDir {
 			continue
 		}
+		if p.match(path) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
 
-		// If the rule is looking for directories, and this is not a directory,
-		// skip it.
-		if p.mustDir && !fi.IsDir() {
+// matchesNegated reports whether path matches every negated rule, as the
+// rules of a file that only has negated ones used to require to keep it.
+//
+// Deprecated: such files will follow the rules of .gitignore files, where
+// negated rules alone do not ignore anything.
+func (r *Rules) matchesNegated(path string, isDir bool) bool {
+	for _, p := range r.patterns {
+		if !p.negate {
 			continue
 		}
-		if p.match(path, fi) {
-			return true
+		if p.mustDir && !isDir || !p.match(path) {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
 // parseRule parses a rule string and creates a pattern, which is then stored in the Rules object.
@@ -136,17 +180,6 @@ func (r *Rules) parseRule(rule string) error {
 		return nil
 	}
 
-	// Fail any rules that contain **
-	if strings.Contains(rule, "**") {
-		return errors.New("double-star (**) syntax is not supported")
-	}
-
-	// Fail any patterns that can't compile. A non-empty string must be
-	// given to Match() to avoid optimization that skips rule evaluation.
-	if _, err := filepath.Match(rule, "abc"); err != nil {
-		return err
-	}
-
 	p := &pattern{raw: rule}
 
 	// Negation is handled at a higher level, so strip the leading ! from the
@@ -164,49 +197,67 @@ func (r *Rules) parseRule(rule string) error {
 		rule = strings.TrimSuffix(rule, "/")
 	}
 
-	if strings.HasPrefix(rule, "/") {
-		// Require path matches the root path.
-		p.match = func(n string, fi os.FileInfo) bool {
-			rule = strings.TrimPrefix(rule, "/")
-			ok, err := filepath.Match(rule, n)
-			if err != nil {
-				log.Printf("Failed to compile %q: %s", rule, err)
-				return false
-			}
-			return ok
-		}
-	} else if strings.Contains(rule, "/") {
-		// require structural match.
-		p.match = func(n string, fi os.FileInfo) bool {
-			ok, err := filepath.Match(rule, n)
-			if err != nil {
-				log.Printf("Failed to compile %q: %s", rule, err)
-				return false
-			}
-			return ok
+	// A rule with a slash, other than a trailing one, matches paths from the
+	// root of the chart. Others match at any depth, as if they started with
+	// "**/".
+	segments := strings.Split(strings.TrimPrefix(rule, "/"), "/")
+	if !strings.Contains(rule, "/") {
+		segments = append([]string{"**"}, segments...)
+	}
+
+	// Fail any patterns that can't compile. A non-empty string must be
+	// given to Match() to avoid optimization that skips rule evaluation.
+	for _, s := range segments {
+		if s == "" {
+			return fmt.Errorf("invalid rule %q: empty path element", p.raw)
 		}
-	} else {
-		p.match = func(n string, fi os.FileInfo) bool {
-			// When there is no slash in the pattern, we evaluate ONLY the
-			// filename.
-			n = filepath.Base(n)
-			ok, err := filepath.Match(rule, n)
-			if err != nil {
-				log.Printf("Failed to compile %q: %s", rule, err)
-				return false
-			}
-			return ok
+		if _, err := filepath.Match(s, "abc"); err != nil {
+			return err
 		}
 	}
 
+	p.match = func(n string) bool {
+		return matchSegments(segments, strings.Split(n, "/"))
+	}
+
 	r.patterns = append(r.patterns, p)
 	return nil
 }
 
+// matchSegments matches the elements of a path with those of a rule. An
+// element "**" matches any number of directories. At the end of a rule, it
+// matches everything inside the directory, but not the directory itself.
+func matchSegments(rule, path []string) bool {
+	if len(rule) == 0 {
+		return len(path) == 0
+	}
+	if rule[0] == "**" {
+		min := 0
+		if len(rule) == 1 {
+			min = 1
+		}
+		for i := min; i <= len(path); i++ {
+			if matchSegments(rule[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, err := filepath.Match(rule[0], path[0])
+	if err != nil {
+		log.Printf("Failed to compile %q: %s", rule[0], err)
+		return false
+	}
+	return ok && matchSegments(rule[1:], path[1:])
+}
+
 // matcher is a function capable of computing a match.
 //
-// It returns true if the rule matches.
-type matcher func(name string, fi os.FileInfo) bool
+// It returns true if the rule matches the slash-separated path.
+type matcher func(name string) bool
 
 // pattern describes a pattern to be matched in a rule set.
 type pattern struct {
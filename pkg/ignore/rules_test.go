@@ -56,7 +56,7 @@ one/more
 }
 
 func TestParseFail(t *testing.T) {
-	shouldFail := []string{"foo/**/bar", "[z-"}
+	shouldFail := []string{"foo//bar", "[z-", "cargo/[z-/a.txt"}
 	for _, fail := range shouldFail {
 		_, err := parseString(fail)
 		if err == nil {
@@ -122,6 +122,19 @@ func TestIgnore(t *testing.T) {
 		{`/a.txt`, "a.txt", true},
 		{`/a.txt`, "cargo/a.txt", false},
 		{`/cargo/a.txt`, "cargo/a.txt", true},
+		{`a.txt`, "cargo/a.txt", true},
+		{`/cargo`, "cargo/a.txt", true},
+		{`/mast`, "cargo/a.txt", false},
+
+		// Double-star tests
+		{`**/a.txt`, "a.txt", true},
+		{`**/a.txt`, "cargo/a.txt", true},
+		{`**/cargo/*.txt`, "cargo/b.txt", true},
+		{`cargo/**`, "cargo/b.txt", true},
+		{`cargo/**`, "cargo", false},
+		{`**/*.txt`, "mast", false},
+		{`cargo/**/a.txt`, "cargo/a.txt", true},
+		{`cargo/**/a.txt`, "mast/a.txt", false},
 	}
 
 	for _, test := range tests {
@@ -140,6 +153,93 @@ func TestIgnore(t *testing.T) {
 	}
 }
 
+func TestIgnoreRules(t *testing.T) {
+	// Test table: Given a rules file, Ignore should return expect for each name.
+	tests := []struct {
+		rules  string
+		expect map[string]bool
+	}{
+		{
+			rules: "*.txt\n!helm.txt",
+			expect: map[string]bool{
+				"helm.txt":    false,
+				"tiller.txt":  true,
+				"cargo/a.txt": true,
+			},
+		},
+		{
+			// Negated rules alone ignore everything else, which is
+			// deprecated.
+			rules: "!*.txt",
+			expect: map[string]bool{
+				"helm.txt":    false,
+				"cargo":       true,
+				"cargo/a.txt": true,
+			},
+		},
+		{
+			// The way to keep doing so once negated rules alone ignore
+			// nothing.
+			rules: "*\n!*.txt",
+			expect: map[string]bool{
+				"helm.txt":    false,
+				"cargo":       true,
+				"cargo/a.txt": true,
+			},
+		},
+		{
+			// The last rule that matches wins.
+			rules: "!helm.txt\n*.txt",
+			expect: map[string]bool{
+				"helm.txt":   true,
+				"tiller.txt": true,
+			},
+		},
+		{
+			rules: "cargo/*\n!cargo/b.txt",
+			expect: map[string]bool{
+				"cargo":       false,
+				"cargo/a.txt": true,
+				"cargo/b.txt": false,
+				"mast/b.txt":  false,
+			},
+		},
+		{
+			// Files of an ignored directory cannot be re-included.
+			rules: "cargo/\n!cargo/b.txt",
+			expect: map[string]bool{
+				"cargo":       true,
+				"cargo/b.txt": true,
+				"mast/b.txt":  false,
+			},
+		},
+		{
+			rules: "/*.txt\n!/a.txt",
+			expect: map[string]bool{
+				"a.txt":       false,
+				"helm.txt":    true,
+				"cargo/b.txt": false,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		r, err := parseString(test.rules)
+		if err != nil {
+			t.Fatalf("Failed to parse: %s", err)
+		}
+		for name, expect := range test.expect {
+			fi, err := os.Stat(filepath.Join(testdata, name))
+			if err != nil {
+				t.Fatalf("Fixture missing: %s", err)
+			}
+			if r.Ignore(name, fi) != expect {
+				t.Errorf("Expected %q to be %v for rules %q", name, expect, test.rules)
+			}
+		}
+	}
+}
+
 func TestAddDefaults(t *testing.T) {
 	r := Rules{}
 	r.AddDefaults()
@@ -147,6 +247,20 @@ func TestAddDefaults(t *testing.T) {
 	if len(r.patterns) != 1 {
 		t.Errorf("Expected 1 default patterns, got %d", len(r.patterns))
 	}
+
+	// The rules of a helmignore file override the defaults.
+	r2, err := parseString("*.tmp\n!templates/.dotfile")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+	r2.AddDefaults()
+	fi, err := os.Stat(filepath.Join(testdata, "templates/.dotfile"))
+	if err != nil {
+		t.Fatalf("Fixture missing: %s", err)
+	}
+	if r2.Ignore("templates/.dotfile", fi) {
+		t.Error("Expected templates/.dotfile not to be ignored")
+	}
 }
 
 func parseString(str string) (*Rules, error) {
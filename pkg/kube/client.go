@@ -30,7 +30,6 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 
-	"github.com/evanphx/json-patch"
 	appsv1 "k8s.io/api/apps/v1"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
@@ -38,7 +37,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -46,6 +44,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
@@ -101,6 +100,34 @@ type ResourceActorFunc func(*resource.Info) error
 //
 // Namespace will set the namespace.
 func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, CreateOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// CreateOptions provides options to control create behavior
+type CreateOptions struct {
+	Timeout    int64
+	ShouldWait bool
+	// Also wait for Jobs to complete when ShouldWait is set
+	WaitForJobs bool
+	// Adopt the resources that already exist, instead of failing, by
+	// patching them to match the manifest. All the resources are marked as
+	// owned by ReleaseName.
+	TakeOwnership bool
+	// ReleaseName is the release the resources belong to
+	ReleaseName string
+	// ReleaseOf finds the resources of other releases that are not annotated
+	// as theirs, so that TakeOwnership leaves them alone
+	ReleaseOf ReleaseOfFunc
+}
+
+// CreateWithOptions creates Kubernetes resources from an io.reader.
+//
+// Namespace will set the namespace. CreateOptions provides additional parameters to control
+// create behavior.
+func (c *Client) CreateWithOptions(namespace string, reader io.Reader, opts CreateOptions) error {
 	client, err := c.KubernetesClientSet()
 	if err != nil {
 		return err
@@ -114,11 +141,15 @@ func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shoul
 		return buildErr
 	}
 	c.Log("creating %d resource(s)", len(infos))
-	if err := perform(infos, createResource); err != nil {
+	create := createResource
+	if opts.TakeOwnership {
+		create = c.takeOwnership(opts.ReleaseName, namespace, opts.ReleaseOf)
+	}
+	if err := perform(infos, create); err != nil {
 		return err
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, infos)
+	if opts.ShouldWait {
+		return c.waitForResources(time.Duration(opts.Timeout)*time.Second, infos, opts.WaitForJobs)
 	}
 	return nil
 }
@@ -335,12 +366,22 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 // UpdateOptions provides options to control update behavior
 type UpdateOptions struct {
-	Force      bool
-	Recreate   bool
-	Timeout    int64
-	ShouldWait bool
+	// Force resources that cannot be patched to be updated, by retrying
+	// conflicting patches and then replacing them
+	Force bool
+	// With Force, delete and recreate the resources that cannot be replaced
+	// either
+	ForceRecreate bool
+	Recreate      bool
+	// Restart the pods of these resources only, given as kind/name, where
+	// the kind is matched regardless of case
+	RecreatePodsFor []string
+	Timeout         int64
+	ShouldWait      bool
 	// Allow deletion of new resources created in this update when update failed
 	CleanupOnFail bool
+	// Also wait for Jobs to complete when ShouldWait is set
+	WaitForJobs bool
 }
 
 // UpdateWithOptions reads the current configuration and a target configuration from io.reader
@@ -372,7 +413,8 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		currentObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
@@ -403,7 +445,8 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 			)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, opts.Force, opts.Recreate); err != nil {
+		recreate := opts.Recreate || recreatesPods(info, opts.RecreatePodsFor)
+		if err := updateResource(c, info, originalInfo.Object, currentObj, opts.Force, opts.ForceRecreate, recreate); err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -446,7 +489,7 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 		}
 	}
 	if opts.ShouldWait {
-		err := c.waitForResources(time.Duration(opts.Timeout)*time.Second, target)
+		err := c.waitForResources(time.Duration(opts.Timeout)*time.Second, target, opts.WaitForJobs)
 
 		if opts.CleanupOnFail && err != nil {
 			c.Log("Cleanup on fail enabled: cleaning up newly created resources due to wait failure during update")
@@ -484,22 +527,46 @@ func (c *Client) Delete(namespace string, reader io.Reader) error {
 //
 // Namespace will set the namespace.
 func (c *Client) DeleteWithTimeout(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.DeleteWithOptions(namespace, reader, DeleteOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// DeleteOptions provides options to control delete behavior
+type DeleteOptions struct {
+	Timeout    int64
+	ShouldWait bool
+	// PropagationPolicy decides how the dependents of the resources, such as
+	// the pods of a Deployment, are deleted. It defaults to background.
+	PropagationPolicy metav1.DeletionPropagation
+}
+
+// DeleteWithOptions deletes Kubernetes resources from an io.reader.
+//
+// Namespace will set the namespace. DeleteOptions provides additional parameters to control
+// delete behavior.
+func (c *Client) DeleteWithOptions(namespace string, reader io.Reader, opts DeleteOptions) error {
+	policy := opts.PropagationPolicy
+	if policy == "" {
+		policy = metav1.DeletePropagationBackground
+	}
 	infos, err := c.BuildUnstructured(namespace, reader)
 	if err != nil {
 		return err
 	}
 	err = perform(infos, func(info *resource.Info) error {
 		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
-		err := deleteResource(info)
+		err := deleteResourceWithPolicy(info, policy)
 		return c.skipIfNotFound(err)
 	})
 	if err != nil {
 		return err
 	}
 
-	if shouldWait {
-		c.Log("Waiting for %d seconds for delete to be completed", timeout)
-		return waitUntilAllResourceDeleted(infos, time.Duration(timeout)*time.Second)
+	if opts.ShouldWait {
+		c.Log("Waiting for %d seconds for delete to be completed", opts.Timeout)
+		return waitUntilAllResourceDeleted(infos, time.Duration(opts.Timeout)*time.Second)
 	}
 
 	return nil
@@ -633,28 +700,32 @@ func createResource(info *resource.Info) error {
 }
 
 func deleteResource(info *resource.Info) error {
-	policy := metav1.DeletePropagationBackground
+	return deleteResourceWithPolicy(info, metav1.DeletePropagationBackground)
+}
+
+func deleteResourceWithPolicy(info *resource.Info, policy metav1.DeletionPropagation) error {
 	opts := &metav1.DeleteOptions{PropagationPolicy: &policy}
 	_, err := resource.NewHelper(info.Client, info.Mapping).DeleteWithOptions(info.Namespace, info.Name, opts)
 	return err
 }
 
-func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.PatchType, error) {
-	oldData, err := json.Marshal(current)
+// createPatch computes a three-way merge patch from the manifest of the
+// previous release (original), the manifest of the new release (target) and
+// the object as it is in the cluster (current). Fields that were changed in
+// the cluster but are not managed by the chart are left alone, while fields
+// removed from the chart are removed from the live object.
+func createPatch(target *resource.Info, original, current runtime.Object) ([]byte, types.PatchType, error) {
+	oldData, err := json.Marshal(original)
 	if err != nil {
-		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing current configuration: %s", err)
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing original configuration: %s", err)
 	}
 	newData, err := json.Marshal(target.Object)
 	if err != nil {
 		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing target configuration: %s", err)
 	}
-
-	// While different objects need different merge types, the parent function
-	// that calls this does not try to create a patch when the data (first
-	// returned object) is nil. We can skip calculating the merge type as
-	// the returned merge type is ignored.
-	if apiequality.Semantic.DeepEqual(oldData, newData) {
-		return nil, types.StrategicMergePatchType, nil
+	currentData, err := json.Marshal(current)
+	if err != nil {
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing live configuration: %s", err)
 	}
 
 	// Get a versioned object
@@ -662,7 +733,7 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 
 	// Unstructured objects, such as CRDs, may not have a not registered error
 	// returned from ConvertToVersion. Anything that's unstructured should
-	// use the jsonpatch.CreateMergePatch. Strategic Merge Patch is not supported
+	// use a JSON merge patch. Strategic Merge Patch is not supported
 	// on objects like CRDs.
 	_, isUnstructured := versionedObject.(runtime.Unstructured)
 
@@ -672,7 +743,7 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 	switch {
 	case runtime.IsNotRegisteredError(err), isUnstructured, isCRD:
 		// fall back to generic JSON merge patch
-		patch, err := jsonpatch.CreateMergePatch(oldData, newData)
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(oldData, newData, currentData)
 		if err != nil {
 			return nil, types.MergePatchType, fmt.Errorf("failed to create merge patch: %v", err)
 		}
@@ -680,20 +751,24 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 	case err != nil:
 		return nil, types.StrategicMergePatchType, fmt.Errorf("failed to get versionedObject: %s", err)
 	default:
-		patch, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, versionedObject)
+		patchMeta, err := strategicpatch.NewPatchMetaFromStruct(versionedObject)
+		if err != nil {
+			return nil, types.StrategicMergePatchType, fmt.Errorf("failed to get patch metadata: %v", err)
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch(oldData, newData, currentData, patchMeta, true)
 		if err != nil {
-			return nil, types.StrategicMergePatchType, fmt.Errorf("failed to create two-way merge patch: %v", err)
+			return nil, types.StrategicMergePatchType, fmt.Errorf("failed to create three-way merge patch: %v", err)
 		}
 		return patch, types.StrategicMergePatchType, nil
 	}
 }
 
-func updateResource(c *Client, target *resource.Info, currentObj runtime.Object, force bool, recreate bool) error {
-	patch, patchType, err := createPatch(target, currentObj)
+func updateResource(c *Client, target *resource.Info, originalObj, currentObj runtime.Object, force, forceRecreate, recreate bool) error {
+	patch, patchType, err := createPatch(target, originalObj, currentObj)
 	if err != nil {
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
-	if patch == nil {
+	if patch == nil || string(patch) == "{}" {
 		c.Log("Looks like there are no changes for %s %q", target.Mapping.GroupVersionKind.Kind, target.Name)
 		// This needs to happen to make sure that tiller has the latest info from the API
 		// Otherwise there will be no labels and other functions that use labels will panic
@@ -709,28 +784,18 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 			kind := target.Mapping.GroupVersionKind.Kind
 			log.Printf("Cannot patch %s: %q (%v)", kind, target.Name, err)
 
-			if force {
-				// Attempt to delete...
-				if err := deleteResource(target); err != nil {
-					return err
-				}
-				log.Printf("Deleted %s: %q", kind, target.Name)
-
-				// ... and recreate
-				if err := createResource(target); err != nil {
-					return fmt.Errorf("Failed to recreate resource: %s", err)
-				}
-				log.Printf("Created a new %s called %q\n", kind, target.Name)
-
-				// No need to refresh the target, as we recreated the resource based
-				// on it. In addition, it might not exist yet and a call to `Refresh`
-				// may fail.
-			} else {
-				log.Print("Use --force to force recreation of the resource")
+			if !force {
+				log.Print("Use --force to force the update of the resource")
+				return err
+			}
+			if obj, err = forceUpdate(target, originalObj, err, forceRecreate); err != nil {
 				return err
 			}
-		} else {
-			// When patch succeeds without needing to recreate, refresh target.
+		}
+		// When the resource was recreated, there is no need to refresh the
+		// target, as the resource was created from it. In addition, it might
+		// not exist yet and a call to `Refresh` may fail.
+		if obj != nil {
 			target.Refresh(obj, true)
 		}
 	}
@@ -769,6 +834,18 @@ func updateResource(c *Client, target *resource.Info, currentObj runtime.Object,
 	return nil
 }
 
+// recreatesPods reports whether info is one of the resources, given as
+// kind/name, whose pods are restarted.
+func recreatesPods(info *resource.Info, resources []string) bool {
+	for _, r := range resources {
+		parts := strings.SplitN(r, "/", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], info.Mapping.GroupVersionKind.Kind) && parts[1] == info.Name {
+			return true
+		}
+	}
+	return false
+}
+
 func getSelectorFromObject(obj runtime.Object) (map[string]string, bool) {
 	switch typed := obj.(type) {
 
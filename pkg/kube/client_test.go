@@ -18,9 +18,11 @@ package kube
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -28,9 +30,11 @@ import (
 
 	"k8s.io/api/core/v1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest/fake"
@@ -153,6 +157,10 @@ func TestUpdate(t *testing.T) {
 	listB.Items[0].Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "https", ContainerPort: 443}}
 	listC.Items[0].Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "https", ContainerPort: 443}}
 
+	// The live starfish carries a label that was added outside of Helm.
+	live := listA.Items[0].DeepCopy()
+	live.Labels = map[string]string{"manual": "true"}
+
 	var actions []string
 
 	tf := cmdtesting.NewTestFactory()
@@ -166,7 +174,7 @@ func TestUpdate(t *testing.T) {
 			t.Logf("got request %s %s", p, m)
 			switch {
 			case p == "/namespaces/default/pods/starfish" && m == "GET":
-				return newResponse(200, &listA.Items[0])
+				return newResponse(200, live)
 			case p == "/namespaces/default/pods/otter" && m == "GET":
 				return newResponse(200, &listA.Items[1])
 			case p == "/namespaces/default/pods/dolphin" && m == "GET":
@@ -177,11 +185,25 @@ func TestUpdate(t *testing.T) {
 					t.Fatalf("could not dump request: %s", err)
 				}
 				req.Body.Close()
-				expected := `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"$setElementOrder/ports":[{"containerPort":443}],"name":"app:v4","ports":[{"containerPort":443,"name":"https"},{"$patch":"delete","containerPort":80}]}]}}`
-				if string(data) != expected {
-					t.Errorf("expected patch\n%s\ngot\n%s", expected, string(data))
+				liveData, err := json.Marshal(live)
+				if err != nil {
+					t.Fatal(err)
+				}
+				patched, err := strategicpatch.StrategicMergePatch(liveData, data, v1.Pod{})
+				if err != nil {
+					t.Fatalf("could not apply patch %s: %s", data, err)
+				}
+				var pod v1.Pod
+				if err := json.Unmarshal(patched, &pod); err != nil {
+					t.Fatal(err)
 				}
-				return newResponse(200, &listB.Items[0])
+				if ports := pod.Spec.Containers[0].Ports; !reflect.DeepEqual(ports, listB.Items[0].Spec.Containers[0].Ports) {
+					t.Errorf("expected ports %v, got %v", listB.Items[0].Spec.Containers[0].Ports, ports)
+				}
+				if pod.Labels["manual"] != "true" {
+					t.Errorf("expected the patch %s to keep the live label, got %v", data, pod.Labels)
+				}
+				return newResponse(200, &pod)
 			case p == "/namespaces/default/pods" && m == "POST":
 				return newResponse(200, &listB.Items[1])
 			case p == "/namespaces/default/pods/squid" && m == "DELETE":
@@ -331,6 +353,49 @@ func TestDeleteWithTimeout(t *testing.T) {
 	}
 }
 
+func TestDeleteWithOptions(t *testing.T) {
+	tests := []struct {
+		policy   metav1.DeletionPropagation
+		expected metav1.DeletionPropagation
+	}{
+		{"", metav1.DeletePropagationBackground},
+		{metav1.DeletePropagationForeground, metav1.DeletePropagationForeground},
+		{metav1.DeletePropagationOrphan, metav1.DeletePropagationOrphan},
+	}
+
+	for _, tt := range tests {
+		c := newTestClient()
+		defer c.Cleanup()
+
+		var sent metav1.DeletionPropagation
+		service := newService("my-service")
+		c.TestFactory.UnstructuredClient = &fake.RESTClient{
+			NegotiatedSerializer: unstructuredSerializer,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				if req.Method != "DELETE" {
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				}
+				var opts metav1.DeleteOptions
+				if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+					t.Fatal(err)
+				}
+				if opts.PropagationPolicy != nil {
+					sent = *opts.PropagationPolicy
+				}
+				return newResponse(200, &service)
+			}),
+		}
+
+		err := c.DeleteWithOptions(metav1.NamespaceDefault, strings.NewReader(testServiceManifest), DeleteOptions{PropagationPolicy: tt.policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent != tt.expected {
+			t.Errorf("expected propagation policy %q, got %q", tt.expected, sent)
+		}
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -574,6 +639,29 @@ func TestWaitUntilCRDEstablished(t *testing.T) {
 	}
 }
 
+func TestRecreatesPods(t *testing.T) {
+	info := &resource.Info{
+		Name:    "web",
+		Mapping: &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}},
+	}
+	tests := []struct {
+		resources []string
+		expected  bool
+	}{
+		{nil, false},
+		{[]string{"deployment/web"}, true},
+		{[]string{"statefulset/db", "Deployment/web"}, true},
+		{[]string{"deployment/Web"}, false},
+		{[]string{"service/web"}, false},
+		{[]string{"web"}, false},
+	}
+	for _, tt := range tests {
+		if got := recreatesPods(info, tt.resources); got != tt.expected {
+			t.Errorf("%v: expected %t, got %t", tt.resources, tt.expected, got)
+		}
+	}
+}
+
 func newCrdWithStatus(name string, status apiextv1beta1.CustomResourceDefinitionStatus) apiextv1beta1.CustomResourceDefinition {
 	crd := apiextv1beta1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
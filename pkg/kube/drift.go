@@ -0,0 +1,145 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// ResourceDrift is a resource whose live state no longer matches its manifest.
+type ResourceDrift struct {
+	// Resource is the kind and name of the resource, as Kind/name.
+	Resource string
+	// Manifest is the manifest of the resource, as YAML with sorted keys.
+	Manifest string
+	// Live holds the live values of the fields set by the manifest, in the
+	// same form as Manifest, or is empty if the resource is missing.
+	Live string
+}
+
+// DriftedResources returns the resources in reader that are missing from the
+// cluster or whose live state no longer matches the manifest. Only the fields
+// set by the manifest are compared, so defaults filled in by the API server
+// and fields added by controllers, such as the status, are not drift.
+//
+// Namespace will set the namespace.
+func (c *Client) DriftedResources(namespace string, reader io.Reader) ([]ResourceDrift, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding reader into objects: %s", err)
+	}
+
+	var drifted []ResourceDrift
+	for _, info := range infos {
+		kind := info.Mapping.GroupVersionKind.Kind
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		manifest, err := toUnstructured(info.Object)
+		if err != nil {
+			return nil, err
+		}
+		drift := ResourceDrift{Resource: kind + "/" + info.Name}
+		if drift.Manifest, err = marshalDrift(manifest); err != nil {
+			return nil, err
+		}
+
+		currentObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				drifted = append(drifted, drift)
+				continue
+			}
+			return nil, err
+		}
+		// The patch from the manifest to itself, merged with the live object,
+		// only holds the fields that the live object changed.
+		patch, _, err := createPatch(info, info.Object, currentObj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create patch: %s", err)
+		}
+		if patch == nil || string(patch) == "{}" {
+			continue
+		}
+		c.Log("%s %q drifted from its manifest: %s", kind, info.Name, patch)
+
+		live, err := toUnstructured(currentObj)
+		if err != nil {
+			return nil, err
+		}
+		if drift.Live, err = marshalDrift(projectFields(manifest, live)); err != nil {
+			return nil, err
+		}
+		drifted = append(drifted, drift)
+	}
+	return drifted, nil
+}
+
+// projectFields returns the values of live at the fields set in manifest.
+// Fields missing from live are left out, and list elements beyond the ones
+// of the manifest are kept whole, so that they show up as added.
+func projectFields(manifest, live interface{}) interface{} {
+	switch m := manifest.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		res := map[string]interface{}{}
+		for k, v := range m {
+			if lv, ok := l[k]; ok {
+				res[k] = projectFields(v, lv)
+			}
+		}
+		return res
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		res := make([]interface{}, len(l))
+		for i, lv := range l {
+			if i < len(m) {
+				res[i] = projectFields(m[i], lv)
+			} else {
+				res[i] = lv
+			}
+		}
+		return res
+	}
+	return live
+}
+
+func toUnstructured(obj runtime.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.Object, nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+func marshalDrift(obj interface{}) (string, error) {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource: %s", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestDriftedResources(t *testing.T) {
+	pods := newPodList("starfish", "otter", "squid")
+
+	// The live starfish only has fields the manifest leaves out, the otter
+	// was deleted and the image of the squid was changed with kubectl.
+	starfish := newPodWithStatus("starfish", v1.PodStatus{Phase: v1.PodRunning}, "")
+	starfish.Annotations = map[string]string{ReleaseNameAnno: "ocean"}
+	starfish.Spec.RestartPolicy = v1.RestartPolicyAlways
+	squid := newPod("squid")
+	squid.Spec.Containers[0].Image = "abc/app:v5"
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &starfish)
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &squid)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+	drifted, err := c.DriftedResources(v1.NamespaceDefault, objBody(&pods))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, d := range drifted {
+		names = append(names, d.Resource)
+	}
+	expected := []string{"Pod/otter", "Pod/squid"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected drifted resources %v, got %v", expected, names)
+	}
+	if drifted[0].Live != "" {
+		t.Errorf("expected no live state for the deleted otter, got %q", drifted[0].Live)
+	}
+	if !strings.Contains(drifted[1].Manifest, "image: abc/app:v4") || !strings.Contains(drifted[1].Live, "image: abc/app:v5") {
+		t.Errorf("expected the image of the squid to change from v4 to v5, got manifest\n%s\nand live state\n%s", drifted[1].Manifest, drifted[1].Live)
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	manifest := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"ports":    []interface{}{map[string]interface{}{"port": int64(80)}},
+			"selector": map[string]interface{}{"app": "web"},
+		},
+	}
+	live := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas":        int64(5),
+			"ports":           []interface{}{map[string]interface{}{"port": int64(80), "protocol": "TCP"}, map[string]interface{}{"port": int64(443)}},
+			"sessionAffinity": "None",
+		},
+		"status": map[string]interface{}{},
+	}
+	expected := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(5),
+			"ports":    []interface{}{map[string]interface{}{"port": int64(80)}, map[string]interface{}{"port": int64(443)}},
+		},
+	}
+	if got := projectFields(manifest, live); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
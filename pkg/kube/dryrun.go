@@ -0,0 +1,131 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// ServerDryRun submits the resources in reader to the API server as a
+// server-side dry run, so that schema validation and admission control are
+// applied to them without persisting anything. Resources that do not exist
+// yet are dry-run created, the others are dry-run patched with the content of
+// the manifest.
+//
+// All the resources are submitted; the returned error lists every rejection.
+//
+// Namespace will set the namespace.
+func (c *Client) ServerDryRun(namespace string, reader io.Reader) error {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, info := range infos {
+		if err := serverDryRun(info); err != nil {
+			kind := info.Mapping.GroupVersionKind.Kind
+			failures = append(failures, fmt.Sprintf("%s %q: %s", kind, info.Name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("server-side validation failed:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+func serverDryRun(info *resource.Info) error {
+	dryRun := []string{metav1.DryRunAll}
+	helper := resource.NewHelper(info.Client, info.Mapping)
+
+	if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		_, err := helper.Create(info.Namespace, true, info.Object, &metav1.CreateOptions{DryRun: dryRun})
+		return err
+	}
+
+	data, err := json.Marshal(info.Object)
+	if err != nil {
+		return err
+	}
+	_, err = helper.Patch(info.Namespace, info.Name, types.MergePatchType, data, &metav1.PatchOptions{DryRun: dryRun})
+	return err
+}
+
+// RecreatedResources returns, as Kind/name, the resources of the target
+// manifests that exist and that the API server refuses to patch from the
+// original manifests, as it does when an immutable field such as the
+// selector of a Deployment or the clusterIP of a Service changes. An update
+// with force deletes and creates those resources again, and an update without
+// it fails on them. The patches are only submitted as server-side dry runs.
+//
+// Namespace will set the namespace.
+func (c *Client) RecreatedResources(namespace string, originalReader, targetReader io.Reader) ([]string, error) {
+	original, err := c.BuildUnstructured(namespace, originalReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding reader into objects: %s", err)
+	}
+	target, err := c.BuildUnstructured(namespace, targetReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding reader into objects: %s", err)
+	}
+
+	var recreated []string
+	for _, info := range target {
+		originalInfo := original.Get(info)
+		if originalInfo == nil {
+			continue
+		}
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		currentObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		patch, patchType, err := createPatch(info, originalInfo.Object, currentObj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create patch: %s", err)
+		}
+		if patch == nil || string(patch) == "{}" {
+			continue
+		}
+
+		kind := info.Mapping.GroupVersionKind.Kind
+		_, err = helper.Patch(info.Namespace, info.Name, patchType, patch, &metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}})
+		switch {
+		case errors.IsInvalid(err):
+			recreated = append(recreated, kind+"/"+info.Name)
+		case err != nil:
+			// Other failures, such as a server without dry-run support, say
+			// nothing about the fields of the resource.
+			c.Log("dry run patch of %s %q failed: %s", kind, info.Name, err)
+		}
+	}
+	return recreated, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestServerDryRun(t *testing.T) {
+	list := newPodList("starfish", "otter", "squid")
+
+	var actions []string
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			t.Logf("got request %s %s", p, m)
+			if m != "GET" && req.URL.Query().Get("dryRun") != metav1.DryRunAll {
+				t.Errorf("expected %s %s to be a dry run", m, p)
+			}
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &list.Items[0])
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				return newResponse(200, &list.Items[0])
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods" && m == "POST":
+				return newResponse(200, &list.Items[1])
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &list.Items[2])
+			case p == "/namespaces/default/pods/squid" && m == "PATCH":
+				return newResponse(403, statusBody(403, metav1.StatusReasonForbidden))
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+
+	err := c.ServerDryRun(v1.NamespaceDefault, objBody(&list))
+	if err == nil {
+		t.Fatal("expected the dry run of the squid to fail")
+	}
+	if !strings.Contains(err.Error(), `Pod "squid"`) || !strings.Contains(err.Error(), string(metav1.StatusReasonForbidden)) {
+		t.Errorf("expected the error to name the rejected squid, got %q", err)
+	}
+	if strings.Contains(err.Error(), "starfish") || strings.Contains(err.Error(), "otter") {
+		t.Errorf("expected the error to only name the squid, got %q", err)
+	}
+
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods/otter:GET",
+		"/namespaces/default/pods:POST",
+		"/namespaces/default/pods/squid:GET",
+		"/namespaces/default/pods/squid:PATCH",
+	}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestRecreatedResources(t *testing.T) {
+	listA := newPodList("starfish", "otter", "squid", "octopus")
+	listB := newPodList("starfish", "otter", "squid", "octopus", "dolphin")
+	for _, i := range []int{0, 2, 3} {
+		listB.Items[i].Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "https", ContainerPort: 443}}
+	}
+
+	var actions []string
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			t.Logf("got request %s %s", p, m)
+			if m == "PATCH" && req.URL.Query().Get("dryRun") != metav1.DryRunAll {
+				t.Errorf("expected %s %s to be a dry run", m, p)
+			}
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &listA.Items[0])
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				return newResponse(422, statusBody(422, metav1.StatusReasonInvalid))
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &listA.Items[1])
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &listA.Items[2])
+			case p == "/namespaces/default/pods/squid" && m == "PATCH":
+				return newResponse(200, &listB.Items[2])
+			case p == "/namespaces/default/pods/octopus" && m == "GET":
+				return newResponse(404, notFoundBody())
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+
+	recreated, err := c.RecreatedResources(v1.NamespaceDefault, objBody(&listA), objBody(&listB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(recreated, []string{"Pod/starfish"}) {
+		t.Errorf("expected only the starfish to be recreated, got %v", recreated)
+	}
+
+	// Neither the unchanged otter nor the missing octopus are patched, and
+	// the new dolphin is not looked up.
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods/otter:GET",
+		"/namespaces/default/pods/squid:GET",
+		"/namespaces/default/pods/squid:PATCH",
+		"/namespaces/default/pods/octopus:GET",
+	}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Errorf("expected requests %v, got %v", expectedActions, actions)
+	}
+}
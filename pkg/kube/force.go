@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"log"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// maxPatchConflictRetries is the number of times a forced update patches a
+// resource again after a conflict with another writer.
+const maxPatchConflictRetries = 3
+
+// forceUpdate updates a resource whose patch failed with patchErr. Conflicting
+// patches are computed again from the live object and retried, then the
+// resource is replaced. Only if recreate is set and the replace fails too is
+// the resource deleted and created again, as that drops the traffic to
+// Services and restarts the pods of controllers.
+//
+// It returns the updated object, or nil if the resource was recreated.
+func forceUpdate(target *resource.Info, originalObj runtime.Object, patchErr error, recreate bool) (runtime.Object, error) {
+	kind := target.Mapping.GroupVersionKind.Kind
+	helper := resource.NewHelper(target.Client, target.Mapping)
+
+	for i := 0; i < maxPatchConflictRetries && errors.IsConflict(patchErr); i++ {
+		current, err := helper.Get(target.Namespace, target.Name, target.Export)
+		if err != nil {
+			return nil, err
+		}
+		patch, patchType, err := createPatch(target, originalObj, current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create patch: %s", err)
+		}
+		obj, err := helper.Patch(target.Namespace, target.Name, patchType, patch, nil)
+		if err == nil {
+			log.Printf("Patched %s %q after a conflict", kind, target.Name)
+			return obj, nil
+		}
+		patchErr = err
+	}
+
+	if err := keepAllocatedFields(helper, target); err != nil {
+		return nil, err
+	}
+	obj, err := helper.Replace(target.Namespace, target.Name, true, target.Object)
+	if err == nil {
+		log.Printf("Replaced %s %q", kind, target.Name)
+		return obj, nil
+	}
+	log.Printf("Cannot replace %s %q (%v)", kind, target.Name, err)
+
+	if !recreate {
+		log.Print("Use --force-recreate with --force to delete and recreate the resource")
+		return nil, err
+	}
+	if err := deleteResource(target); err != nil {
+		return nil, err
+	}
+	log.Printf("Deleted %s: %q", kind, target.Name)
+	if err := createResource(target); err != nil {
+		return nil, err
+	}
+	log.Printf("Created a new %s called %q\n", kind, target.Name)
+	return nil, nil
+}
+
+// keepAllocatedFields copies the fields the API server allocated to the live
+// resource into the target, when its manifest leaves them empty. Replacing a
+// Service would otherwise try to unset its cluster IP, which is immutable.
+func keepAllocatedFields(helper *resource.Helper, target *resource.Info) error {
+	if target.Mapping.GroupVersionKind.Kind != "Service" {
+		return nil
+	}
+	obj, ok := target.Object.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	if ip, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterIP"); ip != "" {
+		return nil
+	}
+
+	current, err := helper.Get(target.Namespace, target.Name, target.Export)
+	if err != nil {
+		return err
+	}
+	live, ok := current.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	if ip, _, _ := unstructured.NestedString(live.Object, "spec", "clusterIP"); ip != "" {
+		return unstructured.SetNestedField(obj.Object, ip, "spec", "clusterIP")
+	}
+	return nil
+}
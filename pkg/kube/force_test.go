@@ -0,0 +1,135 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func statusBody(code int32, reason metav1.StatusReason) *metav1.Status {
+	return &metav1.Status{
+		Code:    code,
+		Status:  metav1.StatusFailure,
+		Reason:  reason,
+		Message: string(reason),
+		Details: &metav1.StatusDetails{},
+	}
+}
+
+func TestUpdateForce(t *testing.T) {
+	original := newService("web")
+	original.Spec.Ports = []v1.ServicePort{{Name: "http", Port: 80}}
+	target := newService("web")
+	target.Spec.Ports = []v1.ServicePort{{Name: "https", Port: 443}}
+	live := original.DeepCopy()
+	live.ResourceVersion = "7"
+	live.Spec.ClusterIP = "10.0.0.10"
+
+	tests := []struct {
+		name      string
+		replace   int
+		recreate  bool
+		expectErr bool
+		// expected counts of requests by method
+		patches, puts, deletes, posts int
+	}{
+		{
+			name:    "replaced after a conflict",
+			replace: 200,
+			patches: 2, puts: 1,
+		},
+		{
+			name:      "not recreated",
+			replace:   422,
+			expectErr: true,
+			patches:   2, puts: 1,
+		},
+		{
+			name:     "recreated",
+			replace:  422,
+			recreate: true,
+			patches:  2, puts: 1, deletes: 1, posts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		counts := map[string]int{}
+		tf := cmdtesting.NewTestFactory()
+		defer tf.Cleanup()
+		tf.UnstructuredClient = &fake.RESTClient{
+			NegotiatedSerializer: unstructuredSerializer,
+			Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+				p, m := req.URL.Path, req.Method
+				counts[m]++
+				switch {
+				case p == "/namespaces/default/services/web" && m == "GET":
+					return newResponse(200, live)
+				case p == "/namespaces/default/services/web" && m == "PATCH":
+					// Someone else updates the service during the first
+					// patch, and the second one changes an immutable field.
+					if counts[m] == 1 {
+						return newResponse(409, statusBody(409, metav1.StatusReasonConflict))
+					}
+					return newResponse(422, statusBody(422, metav1.StatusReasonInvalid))
+				case p == "/namespaces/default/services/web" && m == "PUT":
+					var svc v1.Service
+					if err := json.NewDecoder(req.Body).Decode(&svc); err != nil {
+						t.Fatal(err)
+					}
+					if svc.Spec.ClusterIP != live.Spec.ClusterIP {
+						t.Errorf("%s: expected the replaced service to keep cluster IP %s, got %q", tt.name, live.Spec.ClusterIP, svc.Spec.ClusterIP)
+					}
+					if tt.replace != 200 {
+						return newResponse(tt.replace, statusBody(int32(tt.replace), metav1.StatusReasonInvalid))
+					}
+					return newResponse(200, &svc)
+				case p == "/namespaces/default/services/web" && m == "DELETE":
+					return newResponse(200, live)
+				case p == "/namespaces/default/services" && m == "POST":
+					return newResponse(201, &target)
+				default:
+					t.Fatalf("%s: unexpected request: %s %s", tt.name, req.Method, req.URL.Path)
+					return nil, nil
+				}
+			}),
+		}
+
+		c := &Client{
+			Factory: tf,
+			Log:     nopLogger,
+		}
+		err := c.UpdateWithOptions(v1.NamespaceDefault, objBody(&original), objBody(&target), UpdateOptions{
+			Force:         true,
+			ForceRecreate: tt.recreate,
+		})
+		if tt.expectErr && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		} else if !tt.expectErr && err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+		if counts["PATCH"] != tt.patches || counts["PUT"] != tt.puts || counts["DELETE"] != tt.deletes || counts["POST"] != tt.posts {
+			t.Errorf("%s: expected %d patches, %d replaces, %d deletes and %d creates, got %v", tt.name, tt.patches, tt.puts, tt.deletes, tt.posts, counts)
+		}
+	}
+}
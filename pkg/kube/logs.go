@@ -0,0 +1,91 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// PodLog holds the tail of the log of a single container.
+type PodLog struct {
+	Pod       string
+	Container string
+	Log       string
+}
+
+// PodLogs returns the last tailLines lines of the logs of every container in
+// the pods created for the resources in reader, including the pods that are
+// directly listed in it.
+//
+// Namespace will set the namespace.
+func (c *Client) PodLogs(namespace string, reader io.Reader, tailLines int64) ([]PodLog, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	objPods := make(map[string][]v1.Pod)
+	err = perform(infos, func(info *resource.Info) error {
+		if err := info.Get(); err != nil {
+			c.Log("WARNING: Failed Get for resource %q: %s", info.Name, err)
+			return nil
+		}
+		if pod, ok := asVersionedOrUnstructured(info).(*v1.Pod); ok {
+			if !isFoundPod(objPods["v1/Pod"], *pod) {
+				objPods["v1/Pod"] = append(objPods["v1/Pod"], *pod)
+			}
+			return nil
+		}
+		objPods, err = c.getSelectRelationPod(info, objPods)
+		if err != nil {
+			c.Log("Warning: get the relation pod is failed, err:%s", err.Error())
+		}
+		return nil
+	})
+	if err != nil && err != ErrNoObjectsVisited {
+		return nil, err
+	}
+
+	client, err := c.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	pods := objPods["v1/Pod"]
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var logs []PodLog
+	for _, pod := range pods {
+		containers := append([]v1.Container{}, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, container := range containers {
+			opts := &v1.PodLogOptions{Container: container.Name, TailLines: &tailLines}
+			raw, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Do().Raw()
+			log := string(raw)
+			if err != nil {
+				log = fmt.Sprintf("unable to retrieve logs: %s", err)
+			}
+			logs = append(logs, PodLog{Pod: pod.Name, Container: container.Name, Log: log})
+		}
+	}
+	return logs, nil
+}
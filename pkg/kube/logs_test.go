@@ -0,0 +1,96 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/rest/fake"
+)
+
+func TestPodLogs(t *testing.T) {
+	list := newPodList("starfish", "otter")
+	list.Items[0].Spec.InitContainers = []v1.Container{{Name: "init", Image: "abc/init:v1"}}
+
+	c := newTestClient()
+	defer c.Cleanup()
+
+	handler := fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		p, m := req.URL.Path, req.Method
+		t.Logf("got request %s %s", p, m)
+		switch {
+		case p == "/namespaces/default/pods/starfish" && m == "GET":
+			return newResponse(200, &list.Items[0])
+		case p == "/namespaces/default/pods/otter" && m == "GET":
+			return newResponse(200, &list.Items[1])
+		case strings.HasSuffix(p, "/namespaces/default/pods/starfish/log") && m == "GET":
+			if tail := req.URL.Query().Get("tailLines"); tail != "10" {
+				t.Errorf("expected the last 10 lines to be asked for, got %q", tail)
+			}
+			container := req.URL.Query().Get("container")
+			header := http.Header{}
+			header.Set("Content-Type", "text/plain")
+			body := ioutil.NopCloser(bytes.NewBufferString("log of " + container + "\n"))
+			return &http.Response{StatusCode: 200, Header: header, Body: body}, nil
+		case strings.HasSuffix(p, "/namespaces/default/pods/otter/log") && m == "GET":
+			return newResponse(404, notFoundBody())
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}
+	})
+	c.TestFactory.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client:               handler,
+	}
+	// The logs are read with the clientset, which is built on Client.
+	c.TestFactory.Client = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client:               handler,
+	}
+
+	logs, err := c.PodLogs(v1.NamespaceDefault, objBody(&list), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The pods are sorted by name, and the init containers come first.
+	var got []string
+	for _, l := range logs {
+		got = append(got, l.Pod+"/"+l.Container)
+	}
+	expected := []string{"otter/app:v4", "starfish/init", "starfish/app:v4"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the logs of %v, got %v", expected, got)
+	}
+
+	if !strings.HasPrefix(logs[0].Log, "unable to retrieve logs: ") {
+		t.Errorf("expected the otter's log to report the error, got %q", logs[0].Log)
+	}
+	if logs[1].Log != "log of init\n" {
+		t.Errorf("expected the log of the init container, got %q", logs[1].Log)
+	}
+	if logs[2].Log != "log of app:v4\n" {
+		t.Errorf("expected the log of the app container, got %q", logs[2].Log)
+	}
+}
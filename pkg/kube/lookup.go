@@ -0,0 +1,76 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// Lookup returns the live resource with the given API version, kind,
+// namespace and name as a map, or an empty map if it does not exist. If name
+// is empty, it returns the list of the resources of that kind in the
+// namespace instead. Cluster-scoped kinds cannot be looked up, so that a
+// lookup never reads outside of the namespace it is given.
+//
+// It backs the lookup template function.
+func (c *Client) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	// Let the builder map the kind to its resource, as for a manifest.
+	stub, err := json.Marshal(map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	infos, err := c.BuildUnstructured(namespace, bytes.NewReader(stub))
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up %s %q: %s", kind, name, err)
+	}
+	if len(infos) != 1 {
+		return nil, fmt.Errorf("cannot look up %s %q", kind, name)
+	}
+	info := infos[0]
+	if !info.Namespaced() {
+		return nil, fmt.Errorf("cannot look up %s %q: the kind is cluster-scoped", kind, name)
+	}
+	helper := resource.NewHelper(info.Client, info.Mapping)
+
+	if name == "" {
+		gv := info.Mapping.GroupVersionKind.GroupVersion().String()
+		list, err := helper.List(info.Namespace, gv, false, &metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return toUnstructured(list)
+	}
+
+	obj, err := helper.Get(info.Namespace, info.Name, false)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, err
+	}
+	return toUnstructured(obj)
+}
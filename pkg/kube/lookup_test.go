@@ -0,0 +1,72 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestLookup(t *testing.T) {
+	starfish := newPod("starfish")
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/ocean/pods/starfish" && m == "GET":
+				return newResponse(200, &starfish)
+			case p == "/namespaces/ocean/pods/otter" && m == "GET":
+				return newResponse(404, notFoundBody())
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+	obj, err := c.Lookup("v1", "Pod", "ocean", "starfish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _, _ := unstructured.NestedString(obj, "metadata", "name"); name != "starfish" {
+		t.Errorf("expected to find the starfish, got %v", obj)
+	}
+
+	obj, err = c.Lookup("v1", "Pod", "ocean", "otter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obj) != 0 {
+		t.Errorf("expected nothing for the missing otter, got %v", obj)
+	}
+
+	if _, err := c.Lookup("v1", "Namespace", "ocean", "ocean"); err == nil {
+		t.Error("expected the lookup of a cluster-scoped kind to fail")
+	}
+}
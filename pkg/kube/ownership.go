@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	// ReleaseNameAnno is the annotation naming the release that took
+	// ownership of a resource.
+	ReleaseNameAnno = "meta.helm.sh/release-name"
+	// ReleaseNamespaceAnno is the annotation holding the namespace of the
+	// release that took ownership of a resource.
+	ReleaseNamespaceAnno = "meta.helm.sh/release-namespace"
+	// ManagedByLabel is the label set to "Tiller" on the resources whose
+	// ownership was taken by a release.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	managedByTiller = "Tiller"
+)
+
+// ReleaseOfFunc returns the name of the release whose manifest holds a
+// resource, or "" if there is none. The namespace is empty for cluster-scoped
+// resources.
+type ReleaseOfFunc func(kind, namespace, name string) string
+
+// takeOwnership returns a ResourceActorFunc creating a resource or, if it
+// exists already, patching it to match its manifest. Either way, the resource
+// is marked as owned by the release. Resources owned by another release are
+// left alone and reported as an error: either they are annotated, or, for
+// releases that did not take ownership of their resources, releaseOf finds
+// them in their manifest.
+func (c *Client) takeOwnership(releaseName, releaseNamespace string, releaseOf ReleaseOfFunc) ResourceActorFunc {
+	return func(info *resource.Info) error {
+		if err := setOwner(info.Object, releaseName, releaseNamespace); err != nil {
+			return err
+		}
+
+		helper := resource.NewHelper(info.Client, info.Mapping)
+		current, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if errors.IsNotFound(err) {
+			return createResource(info)
+		} else if err != nil {
+			return err
+		}
+
+		kind := info.Mapping.GroupVersionKind.Kind
+		annotations, err := metadataAccessor.Annotations(current)
+		if err != nil {
+			return err
+		}
+		if owner := annotations[ReleaseNameAnno]; owner != "" && (owner != releaseName || annotations[ReleaseNamespaceAnno] != releaseNamespace) {
+			return fmt.Errorf("%s %q is owned by release %q in namespace %q", kind, info.Name, owner, annotations[ReleaseNamespaceAnno])
+		}
+		if annotations[ReleaseNameAnno] == "" && releaseOf != nil {
+			namespace := ""
+			if info.Namespaced() {
+				namespace = info.Namespace
+			}
+			if owner := releaseOf(kind, namespace, info.Name); owner != "" && owner != releaseName {
+				return fmt.Errorf("%s %q is part of release %q", kind, info.Name, owner)
+			}
+		}
+
+		// The manifest is used as the original configuration, so that the
+		// patch sets the fields of the manifest without removing the others.
+		patch, patchType, err := createPatch(info, info.Object, current)
+		if err != nil {
+			return fmt.Errorf("failed to create patch: %s", err)
+		}
+		if patch == nil || string(patch) == "{}" {
+			c.Log("%s %q already matches the manifest", kind, info.Name)
+			return info.Refresh(current, true)
+		}
+		obj, err := helper.Patch(info.Namespace, info.Name, patchType, patch, nil)
+		if err != nil {
+			return fmt.Errorf("failed to take ownership of %s %q: %s", kind, info.Name, err)
+		}
+		c.Log("Took ownership of %s %q", kind, info.Name)
+		return info.Refresh(obj, true)
+	}
+}
+
+// setOwner marks obj as owned by a release.
+func setOwner(obj runtime.Object, releaseName, releaseNamespace string) error {
+	annotations, err := metadataAccessor.Annotations(obj)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ReleaseNameAnno] = releaseName
+	annotations[ReleaseNamespaceAnno] = releaseNamespace
+	if err := metadataAccessor.SetAnnotations(obj, annotations); err != nil {
+		return err
+	}
+
+	labels, err := metadataAccessor.Labels(obj)
+	if err != nil {
+		return err
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ManagedByLabel] = managedByTiller
+	return metadataAccessor.SetLabels(obj, labels)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubernetes/pkg/kubectl/cmd/testing"
+)
+
+func TestTakeOwnership(t *testing.T) {
+	pods := newPodList("starfish", "otter", "squid")
+	pods.Items[0].Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "https", ContainerPort: 443}}
+
+	// The live starfish was created with kubectl, and the squid belongs to
+	// another release.
+	starfish := newPod("starfish")
+	starfish.Labels = map[string]string{"manual": "true"}
+	squid := newPod("squid")
+	squid.Annotations = map[string]string{ReleaseNameAnno: "other", ReleaseNamespaceAnno: "default"}
+
+	checkOwner := func(pod *v1.Pod) {
+		if pod.Annotations[ReleaseNameAnno] != "ocean" || pod.Annotations[ReleaseNamespaceAnno] != "default" || pod.Labels[ManagedByLabel] != "Tiller" {
+			t.Errorf("expected %s to be owned by the release, got annotations %v and labels %v", pod.Name, pod.Annotations, pod.Labels)
+		}
+	}
+
+	var actions []string
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &starfish)
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				liveData, err := json.Marshal(starfish)
+				if err != nil {
+					t.Fatal(err)
+				}
+				patched, err := strategicpatch.StrategicMergePatch(liveData, data, v1.Pod{})
+				if err != nil {
+					t.Fatalf("could not apply patch %s: %s", data, err)
+				}
+				var pod v1.Pod
+				if err := json.Unmarshal(patched, &pod); err != nil {
+					t.Fatal(err)
+				}
+				checkOwner(&pod)
+				if pod.Labels["manual"] != "true" {
+					t.Errorf("expected the patch %s to keep the live label, got %v", data, pod.Labels)
+				}
+				if len(pod.Spec.Containers[0].Ports) != 1 {
+					t.Errorf("expected the patch %s to set the ports of the manifest, got %v", data, pod.Spec.Containers[0].Ports)
+				}
+				return newResponse(200, &pod)
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods" && m == "POST":
+				var pod v1.Pod
+				if err := json.NewDecoder(req.Body).Decode(&pod); err != nil {
+					t.Fatal(err)
+				}
+				checkOwner(&pod)
+				return newResponse(201, &pod)
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &squid)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+	infos, err := c.BuildUnstructured(v1.NamespaceDefault, objBody(&pods))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = perform(infos, c.takeOwnership("ocean", v1.NamespaceDefault, nil))
+	if err == nil || !strings.Contains(err.Error(), `is owned by release "other"`) {
+		t.Errorf("expected the squid to be left to its release, got %v", err)
+	}
+
+	expectedActions := []string{
+		"/namespaces/default/pods/starfish:GET",
+		"/namespaces/default/pods/starfish:PATCH",
+		"/namespaces/default/pods/otter:GET",
+		"/namespaces/default/pods:POST",
+		"/namespaces/default/pods/squid:GET",
+	}
+	if strings.Join(actions, " ") != strings.Join(expectedActions, " ") {
+		t.Errorf("expected actions %v, got %v", expectedActions, actions)
+	}
+}
+
+func TestTakeOwnershipOfReleaseResources(t *testing.T) {
+	pods := newPodList("crab")
+	crab := newPod("crab")
+
+	var actions []string
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			actions = append(actions, p+":"+m)
+			switch {
+			case p == "/namespaces/default/pods/crab" && m == "GET":
+				return newResponse(200, &crab)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+	infos, err := c.BuildUnstructured(v1.NamespaceDefault, objBody(&pods))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The crab was created by a release that did not annotate its resources.
+	releaseOf := func(kind, namespace, name string) string {
+		if kind == "Pod" && namespace == v1.NamespaceDefault && name == "crab" {
+			return "legacy"
+		}
+		return ""
+	}
+	err = perform(infos, c.takeOwnership("ocean", v1.NamespaceDefault, releaseOf))
+	if err == nil || !strings.Contains(err.Error(), `is part of release "legacy"`) {
+		t.Errorf("expected the crab to be left to its release, got %v", err)
+	}
+	if strings.Join(actions, " ") != "/namespaces/default/pods/crab:GET" {
+		t.Errorf("expected the crab to be left untouched, got %v", actions)
+	}
+}
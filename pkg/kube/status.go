@@ -0,0 +1,156 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// ResourceStatus is a summary of the live state of a single resource.
+type ResourceStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Scalable is true for kinds that report a desired and ready count, such
+	// as workloads, jobs and pods.
+	Scalable bool  `json:"scalable,omitempty"`
+	Desired  int64 `json:"desired,omitempty"`
+	Ready    int64 `json:"ready,omitempty"`
+	// Phase is the reported phase of resources which have one (e.g. pods and
+	// persistent volume claims).
+	Phase string `json:"phase,omitempty"`
+	// Conditions holds the resource's status conditions formatted as
+	// "Type=Status".
+	Conditions []string  `json:"conditions,omitempty"`
+	Created    time.Time `json:"created"`
+	// Missing is true when the resource could not be found in the cluster.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// ResourceStatuses reads the resources in reader and returns the live state of
+// each of them as reported by the cluster.
+//
+// Namespace will set the namespace.
+func (c *Client) ResourceStatuses(namespace string, reader io.Reader) ([]ResourceStatus, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []ResourceStatus
+	err = perform(infos, func(info *resource.Info) error {
+		rs := ResourceStatus{
+			Kind:      info.Mapping.GroupVersionKind.Kind,
+			Name:      info.Name,
+			Namespace: info.Namespace,
+		}
+		if err := info.Get(); err != nil {
+			c.Log("WARNING: Failed Get for resource %q: %s", info.Name, err)
+			rs.Missing = true
+			statuses = append(statuses, rs)
+			return nil
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(info.Object)
+		if err != nil {
+			return err
+		}
+		summarizeStatus(&rs, obj)
+		statuses = append(statuses, rs)
+		return nil
+	})
+	if err != nil && err != ErrNoObjectsVisited {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// summarizeStatus fills in rs from the unstructured form of a live object.
+//
+// The fields are read from the unstructured content rather than typed objects
+// so that every API version of a kind, as well as custom resources, are
+// handled the same way.
+func summarizeStatus(rs *ResourceStatus, obj map[string]interface{}) {
+	u := &unstructured.Unstructured{Object: obj}
+	rs.Created = u.GetCreationTimestamp().Time
+
+	switch rs.Kind {
+	case "Deployment", "ReplicaSet", "StatefulSet", "ReplicationController":
+		rs.Scalable = true
+		rs.Desired = nestedInt64(obj, 1, "spec", "replicas")
+		rs.Ready = nestedInt64(obj, 0, "status", "readyReplicas")
+	case "DaemonSet":
+		rs.Scalable = true
+		rs.Desired = nestedInt64(obj, 0, "status", "desiredNumberScheduled")
+		rs.Ready = nestedInt64(obj, 0, "status", "numberReady")
+	case "Job":
+		rs.Scalable = true
+		rs.Desired = nestedInt64(obj, 1, "spec", "completions")
+		rs.Ready = nestedInt64(obj, 0, "status", "succeeded")
+	case "Pod":
+		rs.Scalable = true
+		containers, _, _ := unstructured.NestedSlice(obj, "spec", "containers")
+		rs.Desired = int64(len(containers))
+		statuses, _, _ := unstructured.NestedSlice(obj, "status", "containerStatuses")
+		for _, s := range statuses {
+			if m, ok := s.(map[string]interface{}); ok {
+				if ready, _, _ := unstructured.NestedBool(m, "ready"); ready {
+					rs.Ready++
+				}
+			}
+		}
+	}
+
+	rs.Phase, _, _ = unstructured.NestedString(obj, "status", "phase")
+
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	for _, cond := range conditions {
+		m, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _, _ := unstructured.NestedString(m, "type")
+		s, _, _ := unstructured.NestedString(m, "status")
+		if t != "" {
+			rs.Conditions = append(rs.Conditions, fmt.Sprintf("%s=%s", t, s))
+		}
+	}
+}
+
+// nestedInt64 returns the integer found at fields, or def if it is not set.
+func nestedInt64(obj map[string]interface{}, def int64, fields ...string) int64 {
+	val, found, err := unstructured.NestedFieldNoCopy(obj, fields...)
+	if !found || err != nil {
+		return def
+	}
+	switch v := val.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+	return def
+}
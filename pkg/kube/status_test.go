@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest/fake"
+)
+
+func TestResourceStatuses(t *testing.T) {
+	pod := newPodWithStatus("otter", v1.PodStatus{
+		Phase: v1.PodRunning,
+		Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: v1.ConditionTrue},
+		},
+		ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app:v4", Ready: true},
+		},
+	}, "")
+
+	c := newTestClient()
+	defer c.Cleanup()
+	c.TestFactory.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			t.Logf("got request %s %s", p, m)
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &pod)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	data := strings.NewReader("kind: Pod\napiVersion: v1\nmetadata:\n  name: otter\n---\nkind: Pod\napiVersion: v1\nmetadata:\n  name: starfish")
+	statuses, err := c.ResourceStatuses("default", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 statuses, got %d", len(statuses))
+	}
+
+	otter := statuses[0]
+	if otter.Name != "otter" || otter.Missing {
+		t.Errorf("expected otter to be found, got %+v", otter)
+	}
+	if !otter.Scalable || otter.Desired != 1 || otter.Ready != 1 {
+		t.Errorf("expected 1/1 ready containers, got %d/%d", otter.Ready, otter.Desired)
+	}
+	if otter.Phase != "Running" {
+		t.Errorf("expected phase Running, got %q", otter.Phase)
+	}
+	if expected := []string{"Ready=True"}; !reflect.DeepEqual(otter.Conditions, expected) {
+		t.Errorf("expected conditions %v, got %v", expected, otter.Conditions)
+	}
+
+	if starfish := statuses[1]; starfish.Name != "starfish" || !starfish.Missing {
+		t.Errorf("expected starfish to be missing, got %+v", starfish)
+	}
+}
@@ -17,17 +17,21 @@ limitations under the License.
 package kube // import "k8s.io/helm/pkg/kube"
 
 import (
+	"fmt"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/kubernetes"
 	deploymentutil "k8s.io/kubernetes/pkg/controller/deployment/util"
 )
@@ -39,8 +43,10 @@ type deployment struct {
 }
 
 // waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
-func (c *Client) waitForResources(timeout time.Duration, created Result) error {
+// until all are ready or a timeout is reached. If waitForJobs is set, it also
+// waits for all Jobs to complete. Custom resources are waited for when their
+// status follows the conventions checked by customResourcesReady.
+func (c *Client) waitForResources(timeout time.Duration, created Result, waitForJobs bool) error {
 	c.Log("beginning wait for %d resources with timeout of %v", len(created), timeout)
 
 	kcs, err := c.KubernetesClientSet()
@@ -52,6 +58,8 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 		services := []v1.Service{}
 		pvc := []v1.PersistentVolumeClaim{}
 		deployments := []deployment{}
+		jobs := []batchv1.Job{}
+		customResources := []*unstructured.Unstructured{}
 		for _, v := range created {
 			switch value := asVersionedOrUnstructured(v).(type) {
 			case *v1.ReplicationController:
@@ -192,9 +200,34 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 					return false, err
 				}
 				services = append(services, *svc)
+			case *batchv1.Job:
+				if !waitForJobs {
+					continue
+				}
+				job, err := kcs.BatchV1().Jobs(value.Namespace).Get(value.Name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				jobs = append(jobs, *job)
+			case *unstructured.Unstructured:
+				obj, err := resource.NewHelper(v.Client, v.Mapping).Get(v.Namespace, v.Name, false)
+				if err != nil {
+					return false, err
+				}
+				if cr, ok := obj.(*unstructured.Unstructured); ok {
+					customResources = append(customResources, cr)
+				}
 			}
 		}
-		isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments)
+		jobsReady, err := c.jobsReady(jobs)
+		if err != nil {
+			return false, err
+		}
+		customResourcesReady, err := c.customResourcesReady(customResources)
+		if err != nil {
+			return false, err
+		}
+		isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments) && jobsReady && customResourcesReady
 		return isReady, nil
 	})
 }
@@ -250,6 +283,63 @@ func (c *Client) deploymentsReady(deployments []deployment) bool {
 	return true
 }
 
+// jobsReady returns whether all jobs have completed. It returns an error as
+// soon as one of them has failed, as waiting any longer would not change that.
+func (c *Client) jobsReady(jobs []batchv1.Job) (bool, error) {
+	for _, job := range jobs {
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+				return false, fmt.Errorf("job %s/%s failed: %s", job.GetNamespace(), job.GetName(), cond.Reason)
+			}
+		}
+		completions := int32(1)
+		if job.Spec.Completions != nil {
+			completions = *job.Spec.Completions
+		}
+		if job.Status.Succeeded < completions {
+			c.Log("Job is not ready: %s/%s", job.GetNamespace(), job.GetName())
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// customResourcesReady returns whether all custom resources are ready, following
+// the status conventions of Kubernetes controllers: a resource is not ready
+// while status.observedGeneration lags behind metadata.generation, or while it
+// has a Ready condition that is not True or a Reconciling condition that is.
+// A Stalled condition that is True is reported as an error. Resources that
+// have none of these fields are considered ready.
+func (c *Client) customResourcesReady(resources []*unstructured.Unstructured) (bool, error) {
+	for _, r := range resources {
+		observed, found, err := unstructured.NestedInt64(r.Object, "status", "observedGeneration")
+		if err == nil && found && observed < r.GetGeneration() {
+			c.Log("%s is not ready: %s/%s", r.GetKind(), r.GetNamespace(), r.GetName())
+			return false, nil
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(r.Object, "status", "conditions")
+		for _, item := range conditions {
+			cond, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condType, _, _ := unstructured.NestedString(cond, "type")
+			status, _, _ := unstructured.NestedString(cond, "status")
+			switch {
+			case condType == "Stalled" && status == string(v1.ConditionTrue):
+				message, _, _ := unstructured.NestedString(cond, "message")
+				return false, fmt.Errorf("%s %s/%s is stalled: %s", r.GetKind(), r.GetNamespace(), r.GetName(), message)
+			case condType == "Ready" && status != string(v1.ConditionTrue),
+				condType == "Reconciling" && status == string(v1.ConditionTrue):
+				c.Log("%s is not ready: %s/%s", r.GetKind(), r.GetNamespace(), r.GetName())
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
 func getPods(client kubernetes.Interface, namespace string, selector map[string]string) ([]v1.Pod, error) {
 	list, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{
 		FieldSelector: fields.Everything().String(),
@@ -0,0 +1,179 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newJob(name string, completions *int32, status batchv1.JobStatus) batchv1.Job {
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       batchv1.JobSpec{Completions: completions},
+		Status:     status,
+	}
+}
+
+func TestJobsReady(t *testing.T) {
+	three := int32(3)
+	tests := []struct {
+		name    string
+		jobs    []batchv1.Job
+		ready   bool
+		wantErr bool
+	}{
+		{
+			name:  "no jobs",
+			ready: true,
+		},
+		{
+			name:  "completed job",
+			jobs:  []batchv1.Job{newJob("migrate", nil, batchv1.JobStatus{Succeeded: 1})},
+			ready: true,
+		},
+		{
+			name: "running job",
+			jobs: []batchv1.Job{newJob("migrate", nil, batchv1.JobStatus{Active: 1})},
+		},
+		{
+			name: "partially completed job",
+			jobs: []batchv1.Job{newJob("migrate", &three, batchv1.JobStatus{Succeeded: 2})},
+		},
+		{
+			name: "failed job",
+			jobs: []batchv1.Job{
+				newJob("migrate", nil, batchv1.JobStatus{Succeeded: 1}),
+				newJob("seed", nil, batchv1.JobStatus{
+					Failed: 6,
+					Conditions: []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+					},
+				}),
+			},
+			wantErr: true,
+		},
+	}
+
+	c := newTestClient()
+	defer c.Cleanup()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, err := c.jobsReady(tt.jobs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %t, got %v", tt.wantErr, err)
+			}
+			if ready != tt.ready {
+				t.Errorf("expected ready: %t, got %t", tt.ready, ready)
+			}
+		})
+	}
+}
+
+func newCustomResource(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Database",
+		"metadata": map[string]interface{}{
+			"name":       "orders",
+			"namespace":  "default",
+			"generation": generation,
+		},
+	}}
+	if status != nil {
+		u.Object["status"] = status
+	}
+	return u
+}
+
+func condition(condType, status string) map[string]interface{} {
+	return map[string]interface{}{"type": condType, "status": status, "message": condType + " is " + status}
+}
+
+func TestCustomResourcesReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource *unstructured.Unstructured
+		ready    bool
+		wantErr  bool
+	}{
+		{
+			name:     "no status",
+			resource: newCustomResource(1, nil),
+			ready:    true,
+		},
+		{
+			name: "ready",
+			resource: newCustomResource(2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"conditions":         []interface{}{condition("Ready", "True")},
+			}),
+			ready: true,
+		},
+		{
+			name: "not ready",
+			resource: newCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{condition("Ready", "False")},
+			}),
+		},
+		{
+			name: "generation not observed",
+			resource: newCustomResource(3, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"conditions":         []interface{}{condition("Ready", "True")},
+			}),
+		},
+		{
+			name: "reconciling",
+			resource: newCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{condition("Reconciling", "True")},
+			}),
+		},
+		{
+			name: "other conditions only",
+			resource: newCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{condition("Available", "False")},
+			}),
+			ready: true,
+		},
+		{
+			name: "stalled",
+			resource: newCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{condition("Stalled", "True")},
+			}),
+			wantErr: true,
+		},
+	}
+
+	c := newTestClient()
+	defer c.Cleanup()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, err := c.customResourcesReady([]*unstructured.Unstructured{tt.resource})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %t, got %v", tt.wantErr, err)
+			}
+			if ready != tt.ready {
+				t.Errorf("expected ready: %t, got %t", tt.ready, ready)
+			}
+		})
+	}
+}
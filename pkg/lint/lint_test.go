@@ -32,6 +32,8 @@ const (
 	badChartDir      = "rules/testdata/badchartfile"
 	badValuesFileDir = "rules/testdata/badvaluesfile"
 	badYamlFileDir   = "rules/testdata/albatross"
+	badSchemaDir     = "rules/testdata/badschema"
+	libraryChartDir  = "rules/testdata/library"
 	goodChartDir     = "rules/testdata/goodone"
 )
 
@@ -95,6 +97,37 @@ func TestBadValues(t *testing.T) {
 	}
 }
 
+func TestBadSchema(t *testing.T) {
+	m := All(badSchemaDir, values, namespace, strict).Messages
+	if len(m) != 1 {
+		t.Fatalf("All didn't fail with expected errors, got %#v", m)
+	}
+	for _, want := range []string{"image is required", "replicaCount: Invalid type"} {
+		if !strings.Contains(m[0].Err.Error(), want) {
+			t.Errorf("All didn't have the error %q: %s", want, m[0].Err)
+		}
+	}
+
+	// Values given on the command line are validated too.
+	m = All(badSchemaDir, []byte("image: nginx\nreplicaCount: 3"), namespace, strict).Messages
+	if len(m) != 0 {
+		t.Errorf("All failed but shouldn't have: %#v", m)
+	}
+}
+
+func TestLibraryChart(t *testing.T) {
+	m := All(libraryChartDir, values, namespace, strict).Messages
+	if len(m) != 1 {
+		t.Fatalf("All didn't fail with expected errors, got %#v", m)
+	}
+	if m[0].Severity != support.WarningSev || !strings.Contains(m[0].Err.Error(), "not rendered") {
+		t.Errorf("All didn't warn about the template that is not rendered: %s", m[0].Err)
+	}
+	if m[0].Path != "templates/configmap.yaml" {
+		t.Errorf("Expected the warning to be about templates/configmap.yaml, got %s", m[0].Path)
+	}
+}
+
 func TestGoodChart(t *testing.T) {
 	m := All(goodChartDir, values, namespace, strict).Messages
 	if len(m) != 0 {
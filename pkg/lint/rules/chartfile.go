@@ -27,6 +27,7 @@ import (
 
 	"github.com/asaskevich/govalidator"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/lint/support"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
@@ -54,6 +55,8 @@ func Chartfile(linter *support.Linter) {
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartAPIVersion(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartEngine(chartFile))
+	linter.RunLinterRule(support.ErrorSev, chartFileName, chartutil.ValidateChartType(chartFile))
+	linter.RunLinterRule(support.ErrorSev, chartFileName, engine.ValidateMissingKey(chartFile.MissingKey))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartMaintainer(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartSources(chartFile))
 	linter.RunLinterRule(support.InfoSev, chartFileName, validateChartIconPresence(chartFile))
@@ -102,8 +105,8 @@ func validateChartAPIVersion(cf *chart.Metadata) error {
 		return errors.New("apiVersion is required")
 	}
 
-	if cf.ApiVersion != "v1" {
-		return fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\"", cf.ApiVersion)
+	if cf.ApiVersion != chartutil.ApiVersionV1 && cf.ApiVersion != chartutil.ApiVersionV2 {
+		return fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\" or \"v2\"", cf.ApiVersion)
 	}
 
 	return nil
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/helm/pkg/chartutil"
@@ -52,16 +53,20 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		return
 	}
 
-	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: namespace}
+	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: namespace, IsInstall: true, Revision: 1}
 	caps := &chartutil.Capabilities{
 		APIVersions:   chartutil.DefaultVersionSet,
 		KubeVersion:   chartutil.DefaultKubeVersion,
 		TillerVersion: tversion.GetVersionProto(),
+		HelmVersion:   tversion.GetVersionProto(),
 	}
 	cvals, err := chartutil.CoalesceValues(chart, &cpb.Config{Raw: string(values)})
 	if err != nil {
 		return
 	}
+	if !linter.RunLinterRule(support.ErrorSev, chartutil.SchemaFile, chartutil.ValidateAgainstSchema(chart, cvals)) {
+		return
+	}
 	// convert our values back into config
 	yvals, err := cvals.YAML()
 	if err != nil {
@@ -77,9 +82,13 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	}
 	e := engine.New()
 	e.LintMode = true
-	if strict {
+	if strict || chart.Metadata.Strict {
 		e.Strict = true
 	}
+	// An invalid setting is reported by the Chart.yaml rules.
+	if engine.ValidateMissingKey(chart.Metadata.MissingKey) == nil {
+		e.MissingKey = chart.Metadata.MissingKey
+	}
 	renderedContentMap, err := e.Render(chart, valuesToRender)
 
 	renderOk := linter.RunLinterRule(support.ErrorSev, path, err)
@@ -101,6 +110,12 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 
 		linter.RunLinterRule(support.WarningSev, path, validateAllowedExtension(fileName))
 
+		// The other templates of a library chart are not rendered.
+		if chartutil.IsLibraryChart(chart) {
+			linter.RunLinterRule(support.WarningSev, path, validateLibraryTemplate(fileName))
+			continue
+		}
+
 		// We only apply the following lint rules to yaml files
 		if filepath.Ext(fileName) != ".yaml" || filepath.Ext(fileName) == ".yml" {
 			continue
@@ -143,6 +158,13 @@ func validateAllowedExtension(fileName string) error {
 	return fmt.Errorf("file extension '%s' not valid. Valid extensions are .yaml, .yml, .tpl, or .txt", ext)
 }
 
+func validateLibraryTemplate(fileName string) error {
+	if !strings.HasPrefix(filepath.Base(fileName), "_") {
+		return errors.New("library charts only provide named templates, so this template is not rendered; move its definitions to a file starting with an underscore")
+	}
+	return nil
+}
+
 func validateYamlContent(err error) error {
 	if err != nil {
 		return fmt.Errorf("unable to parse YAML\n\t%s", err)
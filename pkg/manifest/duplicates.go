@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// CheckDuplicates fails if two documents of the rendered templates define the
+// same resource, that is the same kind, namespace and name, as the second one
+// would silently overwrite the first. The error names the templates of every
+// duplicate. Documents without a namespace are in the given namespace.
+//
+// Partials, hooks, skipped resources and documents that are not resources are
+// left out.
+func CheckDuplicates(templates map[string]string, namespace string) error {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		if !strings.HasPrefix(path.Base(name), "_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	type resource struct{ kind, namespace, name string }
+	sources := map[resource][]string{}
+	var order []resource
+	for _, name := range names {
+		docs := releaseutil.SplitManifests(templates[name])
+		for i := 0; i < len(docs); i++ {
+			var head releaseutil.SimpleHead
+			if err := yaml.Unmarshal([]byte(docs[fmt.Sprintf("manifest-%d", i)]), &head); err != nil {
+				continue
+			}
+			if head.Kind == "" || head.Metadata == nil || head.Metadata.Name == "" {
+				continue
+			}
+			if _, ok := head.Metadata.Annotations[hooks.HookAnno]; ok || releaseutil.IsSkipped(&head) {
+				continue
+			}
+
+			r := resource{kind: head.Kind, namespace: head.Metadata.Namespace, name: head.Metadata.Name}
+			if r.namespace == "" {
+				r.namespace = namespace
+			}
+			if _, ok := sources[r]; !ok {
+				order = append(order, r)
+			}
+			sources[r] = append(sources[r], name)
+		}
+	}
+
+	var dups []string
+	for _, r := range order {
+		if len(sources[r]) < 2 {
+			continue
+		}
+		dups = append(dups, fmt.Sprintf("%s %q in namespace %q is defined more than once, by %s", r.kind, r.name, r.namespace, strings.Join(sources[r], ", ")))
+	}
+	if len(dups) > 0 {
+		return errors.New(strings.Join(dups, "\n"))
+	}
+	return nil
+}
@@ -38,7 +38,7 @@ func (x Metadata_Engine) String() string {
 	return proto.EnumName(Metadata_Engine_name, int32(x))
 }
 func (Metadata_Engine) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{1, 0}
+	return fileDescriptor_metadata_c9d7d3dfd275306a, []int{1, 0}
 }
 
 // Maintainer describes a Chart maintainer.
@@ -58,7 +58,7 @@ func (m *Maintainer) Reset()         { *m = Maintainer{} }
 func (m *Maintainer) String() string { return proto.CompactTextString(m) }
 func (*Maintainer) ProtoMessage()    {}
 func (*Maintainer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{0}
+	return fileDescriptor_metadata_c9d7d3dfd275306a, []int{0}
 }
 func (m *Maintainer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Maintainer.Unmarshal(m, b)
@@ -138,7 +138,15 @@ type Metadata struct {
 	// made available for inspection by other applications.
 	Annotations map[string]string `protobuf:"bytes,16,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// KubeVersion is a SemVer constraint specifying the version of Kubernetes required.
-	KubeVersion          string   `protobuf:"bytes,17,opt,name=kubeVersion,proto3" json:"kubeVersion,omitempty"`
+	KubeVersion string `protobuf:"bytes,17,opt,name=kubeVersion,proto3" json:"kubeVersion,omitempty"`
+	// Strict, if true, fails the rendering of the chart on references to values that are not defined.
+	Strict bool `protobuf:"varint,18,opt,name=strict,proto3" json:"strict,omitempty"`
+	// Type is the type of the chart: "application", the default, or "library".
+	// A library chart only provides named templates to the charts that depend on it.
+	Type string `protobuf:"bytes,19,opt,name=type,proto3" json:"type,omitempty"`
+	// MissingKey is what the templates of the chart render for values that are not defined:
+	// "zero", the default, renders nothing, "invalid" renders "<no value>" and "error" fails.
+	MissingKey           string   `protobuf:"bytes,20,opt,name=missingKey,proto3" json:"missingKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,7 +156,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{1}
+	return fileDescriptor_metadata_c9d7d3dfd275306a, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -287,6 +295,27 @@ func (m *Metadata) GetKubeVersion() string {
 	return ""
 }
 
+func (m *Metadata) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+func (m *Metadata) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Metadata) GetMissingKey() string {
+	if m != nil {
+		return m.MissingKey
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Maintainer)(nil), "hapi.chart.Maintainer")
 	proto.RegisterType((*Metadata)(nil), "hapi.chart.Metadata")
@@ -294,36 +323,38 @@ func init() {
 	proto.RegisterEnum("hapi.chart.Metadata_Engine", Metadata_Engine_name, Metadata_Engine_value)
 }
 
-func init() { proto.RegisterFile("hapi/chart/metadata.proto", fileDescriptor_metadata_d6c714c73a051dcb) }
-
-var fileDescriptor_metadata_d6c714c73a051dcb = []byte{
-	// 435 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x5d, 0x6b, 0xd4, 0x40,
-	0x14, 0x35, 0xcd, 0x66, 0x77, 0x73, 0x63, 0x35, 0x0e, 0x52, 0xc6, 0x22, 0x12, 0x16, 0x85, 0x7d,
-	0xda, 0x82, 0xbe, 0x14, 0x1f, 0x04, 0x85, 0x52, 0x41, 0xbb, 0x95, 0xe0, 0x07, 0xf8, 0x36, 0x4d,
-	0x2e, 0xdd, 0x61, 0x93, 0x99, 0x30, 0x99, 0xad, 0xec, 0xaf, 0xf0, 0x2f, 0xcb, 0xdc, 0x64, 0x9a,
-	0xac, 0xf4, 0xed, 0x9e, 0x73, 0x66, 0xce, 0xcc, 0xbd, 0xf7, 0xc0, 0x8b, 0x8d, 0x68, 0xe4, 0x59,
-	0xb1, 0x11, 0xc6, 0x9e, 0xd5, 0x68, 0x45, 0x29, 0xac, 0x58, 0x35, 0x46, 0x5b, 0xcd, 0xc0, 0x49,
-	0x2b, 0x92, 0x16, 0x9f, 0x01, 0xae, 0x84, 0x54, 0x56, 0x48, 0x85, 0x86, 0x31, 0x98, 0x28, 0x51,
-	0x23, 0x0f, 0xb2, 0x60, 0x19, 0xe7, 0x54, 0xb3, 0xe7, 0x10, 0x61, 0x2d, 0x64, 0xc5, 0x8f, 0x88,
-	0xec, 0x00, 0x4b, 0x21, 0xdc, 0x99, 0x8a, 0x87, 0xc4, 0xb9, 0x72, 0xf1, 0x37, 0x82, 0xf9, 0x55,
-	0xff, 0xd0, 0x83, 0x46, 0x0c, 0x26, 0x1b, 0x5d, 0x63, 0xef, 0x43, 0x35, 0xe3, 0x30, 0x6b, 0xf5,
-	0xce, 0x14, 0xd8, 0xf2, 0x30, 0x0b, 0x97, 0x71, 0xee, 0xa1, 0x53, 0xee, 0xd0, 0xb4, 0x52, 0x2b,
-	0x3e, 0xa1, 0x0b, 0x1e, 0xb2, 0x0c, 0x92, 0x12, 0xdb, 0xc2, 0xc8, 0xc6, 0x3a, 0x35, 0x22, 0x75,
-	0x4c, 0xb1, 0x53, 0x98, 0x6f, 0x71, 0xff, 0x47, 0x9b, 0xb2, 0xe5, 0x53, 0xb2, 0xbd, 0xc7, 0xec,
-	0x1c, 0x92, 0xfa, 0xbe, 0xe1, 0x96, 0xcf, 0xb2, 0x70, 0x99, 0xbc, 0x3d, 0x59, 0x0d, 0x23, 0x59,
-	0x0d, 0xf3, 0xc8, 0xc7, 0x47, 0xd9, 0x09, 0x4c, 0x51, 0xdd, 0x4a, 0x85, 0x7c, 0x4e, 0x4f, 0xf6,
-	0xc8, 0xf5, 0x25, 0x0b, 0xad, 0x78, 0xdc, 0xf5, 0xe5, 0x6a, 0xf6, 0x0a, 0x40, 0x34, 0xf2, 0x67,
-	0xdf, 0x00, 0x90, 0x32, 0x62, 0xd8, 0x4b, 0x88, 0x0b, 0xad, 0x4a, 0x49, 0x1d, 0x24, 0x24, 0x0f,
-	0x84, 0x73, 0xb4, 0xe2, 0xb6, 0xe5, 0x8f, 0x3b, 0x47, 0x57, 0x77, 0x8e, 0x8d, 0x77, 0x3c, 0xf6,
-	0x8e, 0x9e, 0x71, 0x7a, 0x89, 0x8d, 0xc1, 0x42, 0x58, 0x2c, 0xf9, 0x93, 0x2c, 0x58, 0xce, 0xf3,
-	0x11, 0xc3, 0x5e, 0xc3, 0xb1, 0x95, 0x55, 0x85, 0xc6, 0x5b, 0x3c, 0x25, 0x8b, 0x43, 0x92, 0x5d,
-	0x42, 0x22, 0x94, 0xd2, 0x56, 0xb8, 0x7f, 0xb4, 0x3c, 0xa5, 0xe9, 0xbc, 0x39, 0x98, 0x8e, 0xcf,
-	0xd2, 0xc7, 0xe1, 0xdc, 0x85, 0xb2, 0x66, 0x9f, 0x8f, 0x6f, 0xba, 0x25, 0x6d, 0x77, 0x37, 0xe8,
-	0x1f, 0x7b, 0xd6, 0x2d, 0x69, 0x44, 0x9d, 0x7e, 0x80, 0xf4, 0x7f, 0x0b, 0x97, 0xaa, 0x2d, 0xee,
-	0xfb, 0xd4, 0xb8, 0xd2, 0xa5, 0xef, 0x4e, 0x54, 0x3b, 0x9f, 0x9a, 0x0e, 0xbc, 0x3f, 0x3a, 0x0f,
-	0x16, 0x19, 0x4c, 0x2f, 0xba, 0x05, 0x24, 0x30, 0xfb, 0xb1, 0xfe, 0xb2, 0xbe, 0xfe, 0xb5, 0x4e,
-	0x1f, 0xb1, 0x18, 0xa2, 0xcb, 0xeb, 0xef, 0xdf, 0xbe, 0xa6, 0xc1, 0xa7, 0xd9, 0xef, 0x88, 0xfe,
-	0x7c, 0x33, 0xa5, 0xdc, 0xbf, 0xfb, 0x17, 0x00, 0x00, 0xff, 0xff, 0x36, 0xf9, 0x0d, 0xa6, 0x14,
-	0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/chart/metadata.proto", fileDescriptor_metadata_c9d7d3dfd275306a) }
+
+var fileDescriptor_metadata_c9d7d3dfd275306a = []byte{
+	// 470 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x5d, 0x6b, 0xd4, 0x40,
+	0x14, 0x35, 0xcd, 0x66, 0x77, 0x73, 0x63, 0x35, 0x8e, 0xa5, 0x8c, 0x45, 0x24, 0x2c, 0x0a, 0xfb,
+	0xb4, 0x05, 0x7d, 0x29, 0x3e, 0x08, 0x0a, 0xa5, 0x42, 0xed, 0x56, 0x82, 0x1f, 0xe0, 0xdb, 0x34,
+	0xb9, 0xec, 0x0e, 0x9b, 0x4c, 0xc2, 0xcc, 0x6c, 0x25, 0xbf, 0xd5, 0x3f, 0x23, 0x73, 0x93, 0x34,
+	0x59, 0xf1, 0xed, 0x9e, 0x73, 0x32, 0x67, 0xe6, 0xdc, 0x7b, 0x03, 0x2f, 0xb6, 0xa2, 0x96, 0xe7,
+	0xd9, 0x56, 0x68, 0x7b, 0x5e, 0xa2, 0x15, 0xb9, 0xb0, 0x62, 0x55, 0xeb, 0xca, 0x56, 0x0c, 0x9c,
+	0xb4, 0x22, 0x69, 0xf1, 0x19, 0xe0, 0x46, 0x48, 0x65, 0x85, 0x54, 0xa8, 0x19, 0x83, 0x89, 0x12,
+	0x25, 0x72, 0x2f, 0xf1, 0x96, 0x61, 0x4a, 0x35, 0x3b, 0x81, 0x00, 0x4b, 0x21, 0x0b, 0x7e, 0x44,
+	0x64, 0x0b, 0x58, 0x0c, 0xfe, 0x5e, 0x17, 0xdc, 0x27, 0xce, 0x95, 0x8b, 0x3f, 0x01, 0xcc, 0x6f,
+	0xba, 0x8b, 0xfe, 0x6b, 0xc4, 0x60, 0xb2, 0xad, 0x4a, 0xec, 0x7c, 0xa8, 0x66, 0x1c, 0x66, 0xa6,
+	0xda, 0xeb, 0x0c, 0x0d, 0xf7, 0x13, 0x7f, 0x19, 0xa6, 0x3d, 0x74, 0xca, 0x3d, 0x6a, 0x23, 0x2b,
+	0xc5, 0x27, 0x74, 0xa0, 0x87, 0x2c, 0x81, 0x28, 0x47, 0x93, 0x69, 0x59, 0x5b, 0xa7, 0x06, 0xa4,
+	0x8e, 0x29, 0x76, 0x06, 0xf3, 0x1d, 0x36, 0xbf, 0x2b, 0x9d, 0x1b, 0x3e, 0x25, 0xdb, 0x07, 0xcc,
+	0x2e, 0x20, 0x2a, 0x1f, 0x02, 0x1b, 0x3e, 0x4b, 0xfc, 0x65, 0xf4, 0xf6, 0x74, 0x35, 0xb4, 0x64,
+	0x35, 0xf4, 0x23, 0x1d, 0x7f, 0xca, 0x4e, 0x61, 0x8a, 0x6a, 0x23, 0x15, 0xf2, 0x39, 0x5d, 0xd9,
+	0x21, 0x97, 0x4b, 0x66, 0x95, 0xe2, 0x61, 0x9b, 0xcb, 0xd5, 0xec, 0x15, 0x80, 0xa8, 0xe5, 0x8f,
+	0x2e, 0x00, 0x90, 0x32, 0x62, 0xd8, 0x4b, 0x08, 0xb3, 0x4a, 0xe5, 0x92, 0x12, 0x44, 0x24, 0x0f,
+	0x84, 0x73, 0xb4, 0x62, 0x63, 0xf8, 0xe3, 0xd6, 0xd1, 0xd5, 0xad, 0x63, 0xdd, 0x3b, 0x1e, 0xf7,
+	0x8e, 0x3d, 0xe3, 0xf4, 0x1c, 0x6b, 0x8d, 0x99, 0xb0, 0x98, 0xf3, 0x27, 0x89, 0xb7, 0x9c, 0xa7,
+	0x23, 0x86, 0xbd, 0x86, 0x63, 0x2b, 0x8b, 0x02, 0x75, 0x6f, 0xf1, 0x94, 0x2c, 0x0e, 0x49, 0x76,
+	0x05, 0x91, 0x50, 0xaa, 0xb2, 0xc2, 0xbd, 0xc3, 0xf0, 0x98, 0xba, 0xf3, 0xe6, 0xa0, 0x3b, 0xfd,
+	0x2e, 0x7d, 0x1c, 0xbe, 0xbb, 0x54, 0x56, 0x37, 0xe9, 0xf8, 0xa4, 0x1b, 0xd2, 0x6e, 0x7f, 0x87,
+	0xfd, 0x65, 0xcf, 0xda, 0x21, 0x8d, 0x28, 0xd7, 0x4e, 0x63, 0xb5, 0xcc, 0x2c, 0x67, 0xf4, 0xd8,
+	0x0e, 0x51, 0xf8, 0xa6, 0x46, 0xfe, 0xbc, 0x0b, 0xdf, 0xd4, 0xe8, 0xc2, 0x95, 0xd2, 0x18, 0xa9,
+	0x36, 0xd7, 0xd8, 0xf0, 0x93, 0x36, 0xfc, 0xc0, 0x9c, 0x7d, 0x80, 0xf8, 0xdf, 0xe7, 0xb8, 0x0d,
+	0xdd, 0x61, 0xd3, 0x6d, 0xa0, 0x2b, 0xdd, 0x26, 0xdf, 0x8b, 0x62, 0xdf, 0x6f, 0x60, 0x0b, 0xde,
+	0x1f, 0x5d, 0x78, 0x8b, 0x04, 0xa6, 0x97, 0xed, 0x30, 0x23, 0x98, 0x7d, 0x5f, 0x5f, 0xaf, 0x6f,
+	0x7f, 0xae, 0xe3, 0x47, 0x2c, 0x84, 0xe0, 0xea, 0xf6, 0xdb, 0xd7, 0x2f, 0xb1, 0xf7, 0x69, 0xf6,
+	0x2b, 0xa0, 0xfc, 0x77, 0x53, 0xfa, 0x87, 0xde, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xe1, 0xad,
+	0x7b, 0xf3, 0x60, 0x03, 0x00, 0x00,
 }
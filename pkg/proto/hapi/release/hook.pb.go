@@ -69,7 +69,7 @@ func (x Hook_Event) String() string {
 	return proto.EnumName(Hook_Event_name, int32(x))
 }
 func (Hook_Event) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0, 0}
+	return fileDescriptor_hook_a4efc529b2465b19, []int{0, 0}
 }
 
 type Hook_DeletePolicy int32
@@ -95,7 +95,36 @@ func (x Hook_DeletePolicy) String() string {
 	return proto.EnumName(Hook_DeletePolicy_name, int32(x))
 }
 func (Hook_DeletePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0, 1}
+	return fileDescriptor_hook_a4efc529b2465b19, []int{0, 1}
+}
+
+type HookExecution_Phase int32
+
+const (
+	HookExecution_UNKNOWN   HookExecution_Phase = 0
+	HookExecution_RUNNING   HookExecution_Phase = 1
+	HookExecution_SUCCEEDED HookExecution_Phase = 2
+	HookExecution_FAILED    HookExecution_Phase = 3
+)
+
+var HookExecution_Phase_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "RUNNING",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var HookExecution_Phase_value = map[string]int32{
+	"UNKNOWN":   0,
+	"RUNNING":   1,
+	"SUCCEEDED": 2,
+	"FAILED":    3,
+}
+
+func (x HookExecution_Phase) String() string {
+	return proto.EnumName(HookExecution_Phase_name, int32(x))
+}
+func (HookExecution_Phase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_hook_a4efc529b2465b19, []int{1, 0}
 }
 
 // Hook defines a hook object.
@@ -116,7 +145,9 @@ type Hook struct {
 	// DeletePolicies are the policies that indicate when to delete the hook
 	DeletePolicies []Hook_DeletePolicy `protobuf:"varint,8,rep,packed,name=delete_policies,json=deletePolicies,proto3,enum=hapi.release.Hook_DeletePolicy" json:"delete_policies,omitempty"`
 	// DeleteTimeout indicates how long to wait for a resource to be deleted before timing out
-	DeleteTimeout        int64    `protobuf:"varint,9,opt,name=delete_timeout,json=deleteTimeout,proto3" json:"delete_timeout,omitempty"`
+	DeleteTimeout int64 `protobuf:"varint,9,opt,name=delete_timeout,json=deleteTimeout,proto3" json:"delete_timeout,omitempty"`
+	// Timeout overrides the release timeout, in seconds, when waiting for the hook to be ready
+	Timeout              int64    `protobuf:"varint,10,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -126,7 +157,7 @@ func (m *Hook) Reset()         { *m = Hook{} }
 func (m *Hook) String() string { return proto.CompactTextString(m) }
 func (*Hook) ProtoMessage()    {}
 func (*Hook) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0}
+	return fileDescriptor_hook_a4efc529b2465b19, []int{0}
 }
 func (m *Hook) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Hook.Unmarshal(m, b)
@@ -209,44 +240,146 @@ func (m *Hook) GetDeleteTimeout() int64 {
 	return 0
 }
 
+func (m *Hook) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+// HookExecution records the most recent execution of a hook.
+type HookExecution struct {
+	// Name is the name of the hook.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Kind is the Kubernetes kind of the hook.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Event is the event the hook was executed for.
+	Event Hook_Event `protobuf:"varint,3,opt,name=event,proto3,enum=hapi.release.Hook_Event" json:"event,omitempty"`
+	// Phase is the phase the execution is in.
+	Phase HookExecution_Phase `protobuf:"varint,4,opt,name=phase,proto3,enum=hapi.release.HookExecution_Phase" json:"phase,omitempty"`
+	// StartedAt indicates the date/time the hook was started.
+	StartedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// CompletedAt indicates the date/time the hook completed.
+	CompletedAt          *timestamp.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HookExecution) Reset()         { *m = HookExecution{} }
+func (m *HookExecution) String() string { return proto.CompactTextString(m) }
+func (*HookExecution) ProtoMessage()    {}
+func (*HookExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hook_a4efc529b2465b19, []int{1}
+}
+func (m *HookExecution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HookExecution.Unmarshal(m, b)
+}
+func (m *HookExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HookExecution.Marshal(b, m, deterministic)
+}
+func (dst *HookExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HookExecution.Merge(dst, src)
+}
+func (m *HookExecution) XXX_Size() int {
+	return xxx_messageInfo_HookExecution.Size(m)
+}
+func (m *HookExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_HookExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HookExecution proto.InternalMessageInfo
+
+func (m *HookExecution) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HookExecution) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HookExecution) GetEvent() Hook_Event {
+	if m != nil {
+		return m.Event
+	}
+	return Hook_UNKNOWN
+}
+
+func (m *HookExecution) GetPhase() HookExecution_Phase {
+	if m != nil {
+		return m.Phase
+	}
+	return HookExecution_UNKNOWN
+}
+
+func (m *HookExecution) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *HookExecution) GetCompletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CompletedAt
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Hook)(nil), "hapi.release.Hook")
+	proto.RegisterType((*HookExecution)(nil), "hapi.release.HookExecution")
 	proto.RegisterEnum("hapi.release.Hook_Event", Hook_Event_name, Hook_Event_value)
 	proto.RegisterEnum("hapi.release.Hook_DeletePolicy", Hook_DeletePolicy_name, Hook_DeletePolicy_value)
+	proto.RegisterEnum("hapi.release.HookExecution_Phase", HookExecution_Phase_name, HookExecution_Phase_value)
 }
 
-func init() { proto.RegisterFile("hapi/release/hook.proto", fileDescriptor_hook_e64400ca8195038e) }
-
-var fileDescriptor_hook_e64400ca8195038e = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xdb, 0x8e, 0xda, 0x3c,
-	0x10, 0x80, 0x37, 0x1c, 0x02, 0x0c, 0x87, 0xf5, 0x6f, 0xfd, 0x6a, 0x2d, 0x6e, 0x16, 0x21, 0x55,
-	0xe2, 0x2a, 0x54, 0x5b, 0xf5, 0x01, 0x42, 0xe2, 0x2d, 0x88, 0x88, 0x20, 0x27, 0xa8, 0x52, 0x6f,
-	0xa2, 0x6c, 0xf1, 0x42, 0x44, 0x88, 0x23, 0x62, 0x5a, 0xf5, 0x81, 0xfb, 0x18, 0x95, 0x2a, 0x3b,
-	0x21, 0x5d, 0xa9, 0xbd, 0x9b, 0xf9, 0xe6, 0xf3, 0x78, 0xc6, 0x86, 0xb7, 0xc7, 0x38, 0x4f, 0xe6,
-	0x17, 0x9e, 0xf2, 0xb8, 0xe0, 0xf3, 0xa3, 0x10, 0x27, 0x2b, 0xbf, 0x08, 0x29, 0xf0, 0x40, 0x15,
-	0xac, 0xaa, 0x30, 0x7e, 0x38, 0x08, 0x71, 0x48, 0xf9, 0x5c, 0xd7, 0x9e, 0xaf, 0x2f, 0x73, 0x99,
-	0x9c, 0x79, 0x21, 0xe3, 0x73, 0x5e, 0xea, 0xd3, 0x5f, 0x2d, 0x68, 0x2d, 0x85, 0x38, 0x61, 0x0c,
-	0xad, 0x2c, 0x3e, 0x73, 0x62, 0x4c, 0x8c, 0x59, 0x8f, 0xe9, 0x58, 0xb1, 0x53, 0x92, 0xed, 0x49,
-	0xa3, 0x64, 0x2a, 0x56, 0x2c, 0x8f, 0xe5, 0x91, 0x34, 0x4b, 0xa6, 0x62, 0x3c, 0x86, 0xee, 0x39,
-	0xce, 0x92, 0x17, 0x5e, 0x48, 0xd2, 0xd2, 0xbc, 0xce, 0xf1, 0x7b, 0x30, 0xf9, 0x37, 0x9e, 0xc9,
-	0x82, 0xb4, 0x27, 0xcd, 0xd9, 0xe8, 0x91, 0x58, 0xaf, 0x07, 0xb4, 0xd4, 0xdd, 0x16, 0x55, 0x02,
-	0xab, 0x3c, 0xfc, 0x11, 0xba, 0x69, 0x5c, 0xc8, 0xe8, 0x72, 0xcd, 0x88, 0x39, 0x31, 0x66, 0xfd,
-	0xc7, 0xb1, 0x55, 0xae, 0x61, 0xdd, 0xd6, 0xb0, 0xc2, 0xdb, 0x1a, 0xac, 0xa3, 0x5c, 0x76, 0xcd,
-	0xf0, 0x1b, 0x30, 0xbf, 0xf3, 0xe4, 0x70, 0x94, 0xa4, 0x33, 0x31, 0x66, 0x6d, 0x56, 0x65, 0x78,
-	0x09, 0xf7, 0x7b, 0x9e, 0x72, 0xc9, 0xa3, 0x5c, 0xa4, 0xc9, 0xd7, 0x84, 0x17, 0xa4, 0xab, 0x27,
-	0x79, 0xf8, 0xc7, 0x24, 0xae, 0x36, 0xb7, 0x4a, 0xfc, 0xc1, 0x46, 0xfb, 0x3f, 0x59, 0xc2, 0x0b,
-	0xfc, 0x0e, 0x2a, 0x12, 0xa9, 0x57, 0x14, 0x57, 0x49, 0x7a, 0x13, 0x63, 0xd6, 0x64, 0xc3, 0x92,
-	0x86, 0x25, 0x9c, 0xfe, 0x34, 0xa0, 0xad, 0x37, 0xc2, 0x7d, 0xe8, 0xec, 0x36, 0xeb, 0x8d, 0xff,
-	0x79, 0x83, 0xee, 0xf0, 0x3d, 0xf4, 0xb7, 0x8c, 0x46, 0xab, 0x4d, 0x10, 0xda, 0x9e, 0x87, 0x0c,
-	0x8c, 0x60, 0xb0, 0xf5, 0x83, 0xb0, 0x26, 0x0d, 0x3c, 0x02, 0x50, 0x8a, 0x4b, 0x3d, 0x1a, 0x52,
-	0xd4, 0xd4, 0x47, 0x94, 0x51, 0x81, 0xd6, 0xad, 0xc7, 0x6e, 0xfb, 0x89, 0xd9, 0x2e, 0x45, 0xed,
-	0xba, 0xc7, 0x8d, 0x98, 0x9a, 0x30, 0x1a, 0x31, 0xdf, 0xf3, 0x16, 0xb6, 0xb3, 0x46, 0x1d, 0xfc,
-	0x1f, 0x0c, 0xb5, 0x53, 0xa3, 0x2e, 0x26, 0xf0, 0x3f, 0xa3, 0x1e, 0xb5, 0x03, 0x1a, 0x85, 0x34,
-	0x08, 0xa3, 0x60, 0xe7, 0x38, 0x34, 0x08, 0x50, 0xef, 0xaf, 0xca, 0x93, 0xbd, 0xf2, 0x76, 0x8c,
-	0x22, 0x50, 0x77, 0x3b, 0xcc, 0xad, 0xa7, 0xed, 0x4f, 0x1d, 0x18, 0xbc, 0x7e, 0x2e, 0x3c, 0x84,
-	0x9e, 0xee, 0x43, 0x5d, 0xea, 0xa2, 0x3b, 0x0c, 0x60, 0xaa, 0xc3, 0xd4, 0x45, 0x86, 0xea, 0xba,
-	0xa0, 0x4f, 0x3e, 0xa3, 0xd1, 0xd2, 0xf7, 0xd7, 0x91, 0xc3, 0xa8, 0x1d, 0xae, 0xfc, 0x0d, 0x6a,
-	0x2c, 0x7a, 0x5f, 0x3a, 0xd5, 0x07, 0x3c, 0x9b, 0xfa, 0x77, 0x3f, 0xfc, 0x0e, 0x00, 0x00, 0xff,
-	0xff, 0xce, 0x84, 0xd9, 0x98, 0xdb, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/release/hook.proto", fileDescriptor_hook_a4efc529b2465b19) }
+
+var fileDescriptor_hook_a4efc529b2465b19 = []byte{
+	// 598 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xdb, 0x6a, 0xdb, 0x4c,
+	0x10, 0xc7, 0x23, 0xdb, 0xb2, 0xec, 0xf1, 0x21, 0xfa, 0x96, 0x8f, 0x76, 0xc9, 0x4d, 0x5c, 0x43,
+	0xc1, 0x57, 0x72, 0x49, 0x29, 0xa5, 0xd0, 0x5e, 0x28, 0xd6, 0x26, 0x31, 0x11, 0x92, 0x59, 0xc9,
+	0x14, 0x7a, 0x23, 0x94, 0x78, 0x13, 0x8b, 0xd8, 0x5a, 0x61, 0xad, 0x7b, 0x78, 0x92, 0xbe, 0x5a,
+	0x5f, 0xa0, 0xef, 0x51, 0x76, 0x75, 0x68, 0x42, 0x4a, 0xd3, 0xbb, 0x9d, 0xff, 0xfc, 0x76, 0x76,
+	0x66, 0xf6, 0x0f, 0xcf, 0xd7, 0x71, 0x96, 0x4c, 0x77, 0x6c, 0xc3, 0xe2, 0x9c, 0x4d, 0xd7, 0x9c,
+	0xdf, 0x59, 0xd9, 0x8e, 0x0b, 0x8e, 0xfa, 0x32, 0x61, 0x95, 0x89, 0xa3, 0xe3, 0x5b, 0xce, 0x6f,
+	0x37, 0x6c, 0xaa, 0x72, 0x57, 0xfb, 0x9b, 0xa9, 0x48, 0xb6, 0x2c, 0x17, 0xf1, 0x36, 0x2b, 0xf0,
+	0xf1, 0x77, 0x1d, 0x5a, 0x17, 0x9c, 0xdf, 0x21, 0x04, 0xad, 0x34, 0xde, 0x32, 0xac, 0x8d, 0xb4,
+	0x49, 0x97, 0xaa, 0xb3, 0xd4, 0xee, 0x92, 0x74, 0x85, 0x1b, 0x85, 0x26, 0xcf, 0x52, 0xcb, 0x62,
+	0xb1, 0xc6, 0xcd, 0x42, 0x93, 0x67, 0x74, 0x04, 0x9d, 0x6d, 0x9c, 0x26, 0x37, 0x2c, 0x17, 0xb8,
+	0xa5, 0xf4, 0x3a, 0x46, 0xaf, 0xa0, 0xcd, 0x3e, 0xb3, 0x54, 0xe4, 0x58, 0x1f, 0x35, 0x27, 0xc3,
+	0x13, 0x6c, 0xdd, 0x6f, 0xd0, 0x92, 0x6f, 0x5b, 0x44, 0x02, 0xb4, 0xe4, 0xd0, 0x1b, 0xe8, 0x6c,
+	0xe2, 0x5c, 0x44, 0xbb, 0x7d, 0x8a, 0xdb, 0x23, 0x6d, 0xd2, 0x3b, 0x39, 0xb2, 0x8a, 0x31, 0xac,
+	0x6a, 0x0c, 0x2b, 0xac, 0xc6, 0xa0, 0x86, 0x64, 0xe9, 0x3e, 0x45, 0xcf, 0xa0, 0xfd, 0x85, 0x25,
+	0xb7, 0x6b, 0x81, 0x8d, 0x91, 0x36, 0xd1, 0x69, 0x19, 0xa1, 0x0b, 0x38, 0x5c, 0xb1, 0x0d, 0x13,
+	0x2c, 0xca, 0xf8, 0x26, 0xb9, 0x4e, 0x58, 0x8e, 0x3b, 0xaa, 0x93, 0xe3, 0x3f, 0x74, 0xe2, 0x28,
+	0x72, 0x21, 0xc1, 0x6f, 0x74, 0xb8, 0xfa, 0x1d, 0x25, 0x2c, 0x47, 0x2f, 0xa1, 0x54, 0x22, 0xb9,
+	0x45, 0xbe, 0x17, 0xb8, 0x3b, 0xd2, 0x26, 0x4d, 0x3a, 0x28, 0xd4, 0xb0, 0x10, 0x11, 0x06, 0xa3,
+	0xca, 0x83, 0xca, 0x57, 0xe1, 0xf8, 0xa7, 0x06, 0xba, 0x9a, 0x15, 0xf5, 0xc0, 0x58, 0x7a, 0x97,
+	0x9e, 0xff, 0xd1, 0x33, 0x0f, 0xd0, 0x21, 0xf4, 0x16, 0x94, 0x44, 0x73, 0x2f, 0x08, 0x6d, 0xd7,
+	0x35, 0x35, 0x64, 0x42, 0x7f, 0xe1, 0x07, 0x61, 0xad, 0x34, 0xd0, 0x10, 0x40, 0x22, 0x0e, 0x71,
+	0x49, 0x48, 0xcc, 0xa6, 0xba, 0x22, 0x89, 0x52, 0x68, 0x55, 0x35, 0x96, 0x8b, 0x73, 0x6a, 0x3b,
+	0xc4, 0xd4, 0xeb, 0x1a, 0x95, 0xd2, 0x56, 0x0a, 0x25, 0x11, 0xf5, 0x5d, 0xf7, 0xd4, 0x9e, 0x5d,
+	0x9a, 0x06, 0xfa, 0x0f, 0x06, 0x8a, 0xa9, 0xa5, 0x0e, 0xc2, 0xf0, 0x3f, 0x25, 0x2e, 0xb1, 0x03,
+	0x12, 0x85, 0x24, 0x08, 0xa3, 0x60, 0x39, 0x9b, 0x91, 0x20, 0x30, 0xbb, 0x8f, 0x32, 0x67, 0xf6,
+	0xdc, 0x5d, 0x52, 0x62, 0x82, 0x7c, 0x7b, 0x46, 0x9d, 0xba, 0xdb, 0xde, 0x78, 0x06, 0xfd, 0xfb,
+	0x8b, 0x44, 0x03, 0xe8, 0xaa, 0x3a, 0xc4, 0x21, 0x8e, 0x79, 0x80, 0x00, 0xda, 0xf2, 0x32, 0x71,
+	0x4c, 0x4d, 0x56, 0x3d, 0x25, 0x67, 0x3e, 0x25, 0xd1, 0x85, 0xef, 0x5f, 0x46, 0x33, 0x4a, 0xec,
+	0x70, 0xee, 0x7b, 0x66, 0x63, 0xfc, 0xa3, 0x01, 0x03, 0xf9, 0x27, 0xe4, 0x2b, 0xbb, 0xde, 0x8b,
+	0x84, 0xa7, 0xff, 0x6c, 0x51, 0x0b, 0x74, 0x65, 0x25, 0xe5, 0xd1, 0xbf, 0x39, 0xae, 0xc0, 0xd0,
+	0x5b, 0xd0, 0xb3, 0x75, 0x9c, 0x33, 0xe5, 0xdd, 0xe1, 0xc9, 0x8b, 0xc7, 0x7c, 0xdd, 0x83, 0xb5,
+	0x90, 0x20, 0x2d, 0x78, 0xf4, 0x0e, 0x20, 0x17, 0xf1, 0x4e, 0xb0, 0x55, 0x14, 0x0b, 0xac, 0x3f,
+	0xe9, 0xd5, 0x6e, 0x49, 0xdb, 0x02, 0x7d, 0x80, 0xfe, 0x35, 0xdf, 0x66, 0x72, 0x49, 0xea, 0xf2,
+	0xd3, 0x46, 0xef, 0xd5, 0xbc, 0x2d, 0xc6, 0xef, 0x41, 0x57, 0x9d, 0x3c, 0x34, 0x52, 0x0f, 0x0c,
+	0xba, 0xf4, 0xbc, 0xb9, 0x77, 0x6e, 0x6a, 0x0f, 0x97, 0xde, 0xb8, 0xb7, 0xf4, 0xe6, 0x69, 0xf7,
+	0x93, 0x51, 0x4e, 0x77, 0xd5, 0x56, 0x2f, 0xbd, 0xfe, 0x15, 0x00, 0x00, 0xff, 0xff, 0x21, 0xfe,
+	0x96, 0x57, 0x50, 0x04, 0x00, 0x00,
 }
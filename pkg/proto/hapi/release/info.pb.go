@@ -27,7 +27,12 @@ type Info struct {
 	// Deleted tracks when this object was deleted.
 	Deleted *timestamp.Timestamp `protobuf:"bytes,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
-	Description          string   `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	// DeployedBy is the identity of who installed, upgraded or rolled back
+	// to this release.
+	DeployedBy string `protobuf:"bytes,6,opt,name=deployed_by,json=deployedBy,proto3" json:"deployed_by,omitempty"`
+	// Warnings are the messages of the warn function of the templates of the chart.
+	Warnings             []string `protobuf:"bytes,7,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -37,7 +42,7 @@ func (m *Info) Reset()         { *m = Info{} }
 func (m *Info) String() string { return proto.CompactTextString(m) }
 func (*Info) ProtoMessage()    {}
 func (*Info) Descriptor() ([]byte, []int) {
-	return fileDescriptor_info_1c62b71ed76c67c1, []int{0}
+	return fileDescriptor_info_f92ac7d8d9182bd5, []int{0}
 }
 func (m *Info) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Info.Unmarshal(m, b)
@@ -92,27 +97,43 @@ func (m *Info) GetDescription() string {
 	return ""
 }
 
+func (m *Info) GetDeployedBy() string {
+	if m != nil {
+		return m.DeployedBy
+	}
+	return ""
+}
+
+func (m *Info) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
 }
 
-func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor_info_1c62b71ed76c67c1) }
-
-var fileDescriptor_info_1c62b71ed76c67c1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor_info_f92ac7d8d9182bd5) }
+
+var fileDescriptor_info_f92ac7d8d9182bd5 = []byte{
+	// 266 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x41, 0x4f, 0x83, 0x30,
+	0x18, 0x86, 0xc3, 0x36, 0x41, 0x3e, 0x36, 0x0f, 0x8d, 0x89, 0x95, 0xcb, 0x88, 0x27, 0x0e, 0xa6,
+	0x24, 0xea, 0xdd, 0xb8, 0xec, 0xe2, 0x15, 0x3d, 0x79, 0x59, 0x8a, 0x7c, 0x60, 0x13, 0x46, 0x49,
+	0xdb, 0xc5, 0xf0, 0x23, 0xfd, 0x4f, 0x66, 0x85, 0x2e, 0xec, 0xb4, 0x23, 0xdf, 0xf3, 0xbe, 0x2f,
+	0x4f, 0x0a, 0x77, 0x3f, 0xbc, 0x13, 0x99, 0xc2, 0x06, 0xb9, 0xc6, 0x4c, 0xb4, 0x95, 0x64, 0x9d,
+	0x92, 0x46, 0x92, 0xe5, 0x11, 0xb0, 0x11, 0xc4, 0xeb, 0x5a, 0xca, 0xba, 0xc1, 0xcc, 0xb2, 0xe2,
+	0x50, 0x65, 0x46, 0xec, 0x51, 0x1b, 0xbe, 0xef, 0x86, 0x78, 0x7c, 0x7f, 0xb6, 0xa3, 0x0d, 0x37,
+	0x07, 0x3d, 0xa0, 0x87, 0xbf, 0x19, 0x2c, 0xde, 0xdb, 0x4a, 0x92, 0x47, 0xf0, 0x07, 0x40, 0xbd,
+	0xc4, 0x4b, 0xa3, 0xa7, 0x5b, 0x36, 0xfd, 0x07, 0xfb, 0xb0, 0x2c, 0x1f, 0x33, 0xe4, 0x0d, 0x6e,
+	0x2a, 0xa1, 0xb4, 0xd9, 0x95, 0xd8, 0x35, 0xb2, 0xc7, 0x92, 0xce, 0x6c, 0x2b, 0x66, 0x83, 0x0b,
+	0x73, 0x2e, 0xec, 0xd3, 0xb9, 0xe4, 0x2b, 0xdb, 0xd8, 0x8e, 0x05, 0xf2, 0x0a, 0xab, 0x86, 0x4f,
+	0x17, 0xe6, 0x17, 0x17, 0x96, 0xc7, 0xc2, 0x69, 0xe0, 0x05, 0x82, 0x12, 0x1b, 0x34, 0x58, 0xd2,
+	0xc5, 0xc5, 0xaa, 0x8b, 0x92, 0x04, 0xa2, 0x2d, 0xea, 0x6f, 0x25, 0x3a, 0x23, 0x64, 0x4b, 0xaf,
+	0x12, 0x2f, 0x0d, 0xf3, 0xe9, 0x89, 0xac, 0x21, 0x72, 0x4e, 0xbb, 0xa2, 0xa7, 0xbe, 0x4d, 0x80,
+	0x3b, 0x6d, 0x7a, 0x12, 0xc3, 0xf5, 0x2f, 0x57, 0xad, 0x68, 0x6b, 0x4d, 0x83, 0x64, 0x9e, 0x86,
+	0xf9, 0xe9, 0x7b, 0x13, 0x7e, 0x05, 0xe3, 0x93, 0x15, 0xbe, 0xd5, 0x78, 0xfe, 0x0f, 0x00, 0x00,
+	0xff, 0xff, 0x02, 0xfd, 0xd5, 0xb4, 0xc6, 0x01, 0x00, 0x00,
 }
@@ -38,7 +38,12 @@ type Release struct {
 	// Version is an int32 which represents the version of the release.
 	Version int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	// Namespace is the kubernetes namespace of the release.
-	Namespace            string   `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespace string `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Labels are the labels attached to the release when it was installed,
+	// used to select releases when listing them.
+	Labels map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Protected releases can only be deleted when the deletion forces it.
+	Protected            bool     `protobuf:"varint,10,opt,name=protected,proto3" json:"protected,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -48,7 +53,7 @@ func (m *Release) Reset()         { *m = Release{} }
 func (m *Release) String() string { return proto.CompactTextString(m) }
 func (*Release) ProtoMessage()    {}
 func (*Release) Descriptor() ([]byte, []int) {
-	return fileDescriptor_release_4bea5d16ba219619, []int{0}
+	return fileDescriptor_release_ed564f37b8721955, []int{0}
 }
 func (m *Release) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Release.Unmarshal(m, b)
@@ -124,28 +129,48 @@ func (m *Release) GetNamespace() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Release)(nil), "hapi.release.Release")
+func (m *Release) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_4bea5d16ba219619) }
-
-var fileDescriptor_release_4bea5d16ba219619 = []byte{
-	// 256 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xbf, 0x4e, 0xc3, 0x40,
-	0x0c, 0xc6, 0x95, 0x36, 0x7f, 0x1a, 0xc3, 0x82, 0x07, 0xb0, 0x22, 0x86, 0x88, 0x01, 0x22, 0x86,
-	0x54, 0x82, 0x37, 0x80, 0x05, 0xd6, 0x1b, 0xd9, 0x8e, 0xe8, 0x42, 0x4e, 0xa5, 0xe7, 0x28, 0x17,
-	0xf1, 0x2c, 0x3c, 0x2e, 0xba, 0x3f, 0x85, 0x94, 0x2e, 0x4e, 0xec, 0xdf, 0xa7, 0xcf, 0xdf, 0x19,
-	0xaa, 0x41, 0x8e, 0x7a, 0x3b, 0xa9, 0x4f, 0x25, 0xad, 0x3a, 0x7c, 0xdb, 0x71, 0xe2, 0x99, 0xf1,
-	0xdc, 0xb1, 0x36, 0xce, 0xaa, 0xab, 0x23, 0xe5, 0xc0, 0xbc, 0x0b, 0xb2, 0x7f, 0x40, 0x9b, 0x9e,
-	0x8f, 0x40, 0x37, 0xc8, 0x69, 0xde, 0x76, 0x6c, 0x7a, 0xfd, 0x11, 0xc1, 0xe5, 0x12, 0xb8, 0x1a,
-	0xe6, 0x37, 0xdf, 0x2b, 0x28, 0x44, 0xf0, 0x41, 0x84, 0xd4, 0xc8, 0xbd, 0xa2, 0xa4, 0x4e, 0x9a,
-	0x52, 0xf8, 0x7f, 0xbc, 0x85, 0xd4, 0xd9, 0xd3, 0xaa, 0x4e, 0x9a, 0xb3, 0x07, 0x6c, 0x97, 0xf9,
-	0xda, 0x57, 0xd3, 0xb3, 0xf0, 0x1c, 0xef, 0x20, 0xf3, 0xb6, 0xb4, 0xf6, 0xc2, 0x8b, 0x20, 0x0c,
-	0x9b, 0x9e, 0x5d, 0x15, 0x81, 0xe3, 0x3d, 0xe4, 0x21, 0x18, 0xa5, 0x4b, 0xcb, 0xa8, 0xf4, 0x44,
-	0x44, 0x05, 0x56, 0xb0, 0xd9, 0x4b, 0xa3, 0x7b, 0x65, 0x67, 0xca, 0x7c, 0xa8, 0xdf, 0x1e, 0x1b,
-	0xc8, 0xdc, 0x41, 0x2c, 0xe5, 0xf5, 0xfa, 0x34, 0xd9, 0x0b, 0xf3, 0x4e, 0x04, 0x01, 0x12, 0x14,
-	0x5f, 0x6a, 0xb2, 0x9a, 0x0d, 0x15, 0x75, 0xd2, 0x64, 0xe2, 0xd0, 0xe2, 0x35, 0x94, 0xee, 0x91,
-	0x76, 0x94, 0x9d, 0xa2, 0x8d, 0x5f, 0xf0, 0x37, 0x78, 0x2a, 0xdf, 0x8a, 0x68, 0xf7, 0x9e, 0xfb,
-	0x63, 0x3d, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0xc8, 0x8f, 0xec, 0x97, 0xbb, 0x01, 0x00, 0x00,
+func (m *Release) GetProtected() bool {
+	if m != nil {
+		return m.Protected
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Release)(nil), "hapi.release.Release")
+	proto.RegisterMapType((map[string]string)(nil), "hapi.release.Release.LabelsEntry")
+}
+
+func init() { proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_ed564f37b8721955) }
+
+var fileDescriptor_release_ed564f37b8721955 = []byte{
+	// 331 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x51, 0x4f, 0x4f, 0xbb, 0x40,
+	0x10, 0x0d, 0xa5, 0x40, 0x99, 0xfe, 0x0e, 0x3f, 0x27, 0x46, 0x37, 0xc4, 0x03, 0x7a, 0x50, 0xe2,
+	0x81, 0x26, 0x7a, 0xb1, 0x1e, 0x35, 0x26, 0x9a, 0x78, 0xda, 0xa3, 0xb7, 0x2d, 0x0e, 0x96, 0x94,
+	0xee, 0x36, 0x80, 0x4d, 0xfa, 0x9d, 0xfc, 0x90, 0x66, 0xff, 0xd4, 0x82, 0x5e, 0x96, 0x9d, 0x79,
+	0x6f, 0xde, 0x1b, 0xde, 0x42, 0xb2, 0x14, 0x9b, 0x6a, 0xd6, 0x50, 0x4d, 0xa2, 0xa5, 0xfd, 0x37,
+	0xdf, 0x34, 0xaa, 0x53, 0xf8, 0x4f, 0x63, 0xb9, 0xeb, 0x25, 0xa7, 0x03, 0xe6, 0x52, 0xa9, 0x95,
+	0xa5, 0xfd, 0x02, 0x2a, 0x59, 0xaa, 0x01, 0x50, 0x2c, 0x45, 0xd3, 0xcd, 0x0a, 0x25, 0xcb, 0xea,
+	0xc3, 0x01, 0x27, 0x7d, 0x40, 0x9f, 0xb6, 0x7f, 0xf1, 0xe5, 0x43, 0xc4, 0xad, 0x0e, 0x22, 0x8c,
+	0xa5, 0x58, 0x13, 0xf3, 0x52, 0x2f, 0x8b, 0xb9, 0xb9, 0xe3, 0x25, 0x8c, 0xb5, 0x3c, 0x1b, 0xa5,
+	0x5e, 0x36, 0xbd, 0xc1, 0xbc, 0xbf, 0x5f, 0xfe, 0x22, 0x4b, 0xc5, 0x0d, 0x8e, 0x57, 0x10, 0x18,
+	0x59, 0xe6, 0x1b, 0xe2, 0x91, 0x25, 0x5a, 0xa7, 0x47, 0x7d, 0x72, 0x8b, 0xe3, 0x35, 0x84, 0x76,
+	0x31, 0x36, 0xee, 0x4b, 0x3a, 0xa6, 0x41, 0xb8, 0x63, 0x60, 0x02, 0x93, 0xb5, 0x90, 0x55, 0x49,
+	0x6d, 0xc7, 0x02, 0xb3, 0xd4, 0x4f, 0x8d, 0x19, 0x04, 0x3a, 0x90, 0x96, 0x85, 0xa9, 0xff, 0x77,
+	0xb3, 0x67, 0xa5, 0x56, 0xdc, 0x12, 0x90, 0x41, 0xb4, 0xa5, 0xa6, 0xad, 0x94, 0x64, 0x51, 0xea,
+	0x65, 0x01, 0xdf, 0x97, 0x78, 0x06, 0xb1, 0xfe, 0xc9, 0x76, 0x23, 0x0a, 0x62, 0x13, 0x63, 0x70,
+	0x68, 0xe0, 0x1c, 0xc2, 0x5a, 0x2c, 0xa8, 0x6e, 0x59, 0x6c, 0x2c, 0xce, 0x87, 0x16, 0x2e, 0xb5,
+	0xfc, 0xd5, 0x70, 0x9e, 0x64, 0xd7, 0xec, 0xb8, 0x1b, 0xd0, 0xc2, 0x3a, 0x5e, 0x2a, 0x3a, 0x7a,
+	0x67, 0x90, 0x7a, 0xd9, 0x84, 0x1f, 0x1a, 0xc9, 0x1c, 0xa6, 0xbd, 0x21, 0xfc, 0x0f, 0xfe, 0x8a,
+	0x76, 0x2e, 0x75, 0x7d, 0xc5, 0x63, 0x08, 0xb6, 0xa2, 0xfe, 0x24, 0x93, 0x7a, 0xcc, 0x6d, 0x71,
+	0x3f, 0xba, 0xf3, 0x1e, 0xe2, 0xb7, 0xc8, 0xf9, 0x2f, 0x42, 0xf3, 0x80, 0xb7, 0xdf, 0x01, 0x00,
+	0x00, 0xff, 0xff, 0xaf, 0x64, 0x24, 0x11, 0x4f, 0x02, 0x00, 0x00,
 }
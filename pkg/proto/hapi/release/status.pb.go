@@ -69,7 +69,7 @@ func (x Status_Code) String() string {
 	return proto.EnumName(Status_Code_name, int32(x))
 }
 func (Status_Code) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_status_933517e5a50981ed, []int{0, 0}
+	return fileDescriptor_status_9d7984e92e444052, []int{0, 0}
 }
 
 // Status defines the status of a release.
@@ -80,17 +80,19 @@ type Status struct {
 	// Contains the rendered templates/NOTES.txt if available
 	Notes string `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
 	// LastTestSuiteRun provides results on the last test run on a release
-	LastTestSuiteRun     *TestSuite `protobuf:"bytes,5,opt,name=last_test_suite_run,json=lastTestSuiteRun,proto3" json:"last_test_suite_run,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	LastTestSuiteRun *TestSuite `protobuf:"bytes,5,opt,name=last_test_suite_run,json=lastTestSuiteRun,proto3" json:"last_test_suite_run,omitempty"`
+	// HookExecutions provides the most recent execution of each hook run for the release
+	HookExecutions       []*HookExecution `protobuf:"bytes,6,rep,name=hook_executions,json=hookExecutions,proto3" json:"hook_executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *Status) Reset()         { *m = Status{} }
 func (m *Status) String() string { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()    {}
 func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_status_933517e5a50981ed, []int{0}
+	return fileDescriptor_status_9d7984e92e444052, []int{0}
 }
 func (m *Status) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Status.Unmarshal(m, b)
@@ -138,34 +140,44 @@ func (m *Status) GetLastTestSuiteRun() *TestSuite {
 	return nil
 }
 
+func (m *Status) GetHookExecutions() []*HookExecution {
+	if m != nil {
+		return m.HookExecutions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "hapi.release.Status")
 	proto.RegisterEnum("hapi.release.Status_Code", Status_Code_name, Status_Code_value)
 }
 
-func init() { proto.RegisterFile("hapi/release/status.proto", fileDescriptor_status_933517e5a50981ed) }
-
-var fileDescriptor_status_933517e5a50981ed = []byte{
-	// 333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xd1, 0x6e, 0xa2, 0x40,
-	0x14, 0x86, 0x17, 0x45, 0xd4, 0xa3, 0x71, 0x27, 0xa3, 0xc9, 0xa2, 0xd9, 0x4d, 0x8c, 0x57, 0xde,
-	0x2c, 0x24, 0xf6, 0x09, 0xd0, 0x19, 0x0d, 0x71, 0x82, 0x04, 0x30, 0x4d, 0x7b, 0x43, 0x50, 0xa7,
-	0xd6, 0xc4, 0x30, 0x86, 0x19, 0x2e, 0xfa, 0x26, 0x7d, 0xaa, 0x3e, 0x53, 0x03, 0xd8, 0xa8, 0x97,
-	0xff, 0xff, 0x7d, 0x87, 0x73, 0x18, 0x18, 0xbe, 0x27, 0x97, 0x93, 0x9d, 0xf1, 0x33, 0x4f, 0x24,
-	0xb7, 0xa5, 0x4a, 0x54, 0x2e, 0xad, 0x4b, 0x26, 0x94, 0xc0, 0xdd, 0x02, 0x59, 0x57, 0x34, 0xfa,
-	0xf7, 0x20, 0x2a, 0x2e, 0x55, 0x2c, 0xf3, 0x93, 0xe2, 0x95, 0x3c, 0x1a, 0x1e, 0x85, 0x38, 0x9e,
-	0xb9, 0x5d, 0xa6, 0x5d, 0xfe, 0x66, 0x27, 0xe9, 0x47, 0x85, 0x26, 0x5f, 0x35, 0x30, 0xc2, 0xf2,
-	0xc3, 0xf8, 0x3f, 0xe8, 0x7b, 0x71, 0xe0, 0xa6, 0x36, 0xd6, 0xa6, 0xbd, 0xd9, 0xd0, 0xba, 0xdf,
-	0x60, 0x55, 0x8e, 0xb5, 0x10, 0x07, 0x1e, 0x94, 0x1a, 0xfe, 0x0b, 0xed, 0x8c, 0x4b, 0x91, 0x67,
-	0x7b, 0x2e, 0xcd, 0xfa, 0x58, 0x9b, 0xb6, 0x83, 0x5b, 0x81, 0x07, 0xd0, 0x48, 0x85, 0xe2, 0xd2,
-	0xd4, 0x4b, 0x52, 0x05, 0xbc, 0x84, 0xfe, 0x39, 0x91, 0x2a, 0xbe, 0x5d, 0x18, 0x67, 0x79, 0x6a,
-	0x36, 0xc6, 0xda, 0xb4, 0x33, 0xfb, 0xf3, 0xb8, 0x31, 0xe2, 0x52, 0x85, 0x85, 0x12, 0xa0, 0x62,
-	0xe6, 0x16, 0xf3, 0x74, 0xf2, 0xa9, 0x81, 0x5e, 0x9c, 0x82, 0x3b, 0xd0, 0xdc, 0x7a, 0x6b, 0x6f,
-	0xf3, 0xec, 0xa1, 0x5f, 0xb8, 0x0b, 0x2d, 0x42, 0x7d, 0xb6, 0x79, 0xa1, 0x04, 0x69, 0x05, 0x22,
-	0x94, 0xd1, 0x88, 0x12, 0x54, 0xc3, 0x3d, 0x80, 0x70, 0xeb, 0xd3, 0x20, 0xa4, 0x84, 0x12, 0x54,
-	0xc7, 0x00, 0xc6, 0xd2, 0x71, 0x19, 0x25, 0x48, 0xaf, 0xc6, 0x18, 0x8d, 0x5c, 0x6f, 0x85, 0x1a,
-	0xb8, 0x0f, 0xbf, 0x7d, 0xea, 0x11, 0xd7, 0x5b, 0xc5, 0xae, 0x17, 0x46, 0x0e, 0x63, 0xc8, 0xb8,
-	0x2f, 0xb7, 0xfe, 0x2a, 0x70, 0x08, 0x45, 0x4d, 0x3c, 0x00, 0xf4, 0x53, 0x06, 0x1b, 0xc6, 0xe6,
-	0xce, 0x62, 0x8d, 0x5a, 0xf3, 0xf6, 0x6b, 0xf3, 0xfa, 0x07, 0x3b, 0xa3, 0x7c, 0xe2, 0xa7, 0xef,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x09, 0x48, 0x18, 0xba, 0xc7, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/release/status.proto", fileDescriptor_status_9d7984e92e444052) }
+
+var fileDescriptor_status_9d7984e92e444052 = []byte{
+	// 375 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xd1, 0x8e, 0x9a, 0x40,
+	0x14, 0x86, 0x4b, 0x41, 0x5c, 0x8f, 0x1b, 0x77, 0x32, 0xbb, 0xc9, 0xe2, 0xb6, 0x4d, 0xc8, 0x5e,
+	0x71, 0x53, 0x48, 0xec, 0x13, 0xb0, 0x3b, 0xb3, 0x96, 0x38, 0x41, 0x02, 0x98, 0xa6, 0xbd, 0x21,
+	0xa8, 0x53, 0x35, 0x1a, 0xc6, 0x30, 0x43, 0xd2, 0xbe, 0x47, 0x2f, 0xfa, 0xb8, 0x1b, 0x40, 0xa3,
+	0x5c, 0xfe, 0xe7, 0xfb, 0x0e, 0x87, 0x3f, 0x03, 0xe3, 0x6d, 0x7e, 0xdc, 0x79, 0x25, 0x3f, 0xf0,
+	0x5c, 0x72, 0x4f, 0xaa, 0x5c, 0x55, 0xd2, 0x3d, 0x96, 0x42, 0x09, 0x7c, 0x5b, 0x23, 0xf7, 0x84,
+	0x9e, 0xbe, 0x74, 0x44, 0xc5, 0xa5, 0xca, 0x64, 0xb5, 0x53, 0xbc, 0x95, 0x9f, 0x1e, 0x3b, 0x78,
+	0x2b, 0xc4, 0xfe, 0x04, 0xc6, 0x1b, 0x21, 0x36, 0x07, 0xee, 0x35, 0x69, 0x59, 0xfd, 0xf6, 0xf2,
+	0xe2, 0x6f, 0x8b, 0x9e, 0xff, 0xe9, 0x60, 0x26, 0xcd, 0x45, 0xfc, 0x15, 0x8c, 0x95, 0x58, 0x73,
+	0x4b, 0xb3, 0x35, 0x67, 0x34, 0x19, 0xbb, 0xd7, 0xa7, 0xdd, 0xd6, 0x71, 0x5f, 0xc5, 0x9a, 0xc7,
+	0x8d, 0x86, 0x3f, 0xc3, 0xa0, 0xe4, 0x52, 0x54, 0xe5, 0x8a, 0x4b, 0x4b, 0xb7, 0x35, 0x67, 0x10,
+	0x5f, 0x06, 0xf8, 0x01, 0x7a, 0x85, 0x50, 0x5c, 0x5a, 0x46, 0x43, 0xda, 0x80, 0xdf, 0xe0, 0xfe,
+	0x90, 0x4b, 0x95, 0x5d, 0x7e, 0x3d, 0x2b, 0xab, 0xc2, 0xea, 0xd9, 0x9a, 0x33, 0x9c, 0x3c, 0x76,
+	0x2f, 0xa6, 0x5c, 0xaa, 0xa4, 0x56, 0x62, 0x54, 0xef, 0x5c, 0x62, 0x55, 0x60, 0x02, 0x77, 0x75,
+	0xbd, 0x8c, 0xff, 0xe1, 0xab, 0x4a, 0xed, 0x44, 0x21, 0x2d, 0xd3, 0xd6, 0x9d, 0xe1, 0xe4, 0x53,
+	0xf7, 0x1b, 0xdf, 0x85, 0xd8, 0xd3, 0xb3, 0x13, 0x8f, 0xb6, 0xd7, 0x51, 0x3e, 0xff, 0xd7, 0xc0,
+	0xa8, 0x0b, 0xe1, 0x21, 0xf4, 0x17, 0xe1, 0x2c, 0x9c, 0xff, 0x08, 0xd1, 0x07, 0x7c, 0x0b, 0x37,
+	0x84, 0x46, 0x6c, 0xfe, 0x93, 0x12, 0xa4, 0xd5, 0x88, 0x50, 0x46, 0x53, 0x4a, 0xd0, 0x47, 0x3c,
+	0x02, 0x48, 0x16, 0x11, 0x8d, 0x13, 0x4a, 0x28, 0x41, 0x3a, 0x06, 0x30, 0xdf, 0xfc, 0x80, 0x51,
+	0x82, 0x8c, 0x76, 0x8d, 0xd1, 0x34, 0x08, 0xa7, 0xa8, 0x87, 0xef, 0xe1, 0x2e, 0xa2, 0x21, 0x09,
+	0xc2, 0x69, 0x16, 0x84, 0x49, 0xea, 0x33, 0x86, 0xcc, 0xeb, 0xe1, 0x22, 0x9a, 0xc6, 0x3e, 0xa1,
+	0xa8, 0x8f, 0x1f, 0x00, 0x9d, 0x87, 0xf1, 0x9c, 0xb1, 0x17, 0xff, 0x75, 0x86, 0x6e, 0x5e, 0x06,
+	0xbf, 0xfa, 0xa7, 0x0e, 0x4b, 0xb3, 0x79, 0xa8, 0x6f, 0xef, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa8,
+	0x0e, 0xaa, 0x2c, 0x26, 0x02, 0x00, 0x00,
 }
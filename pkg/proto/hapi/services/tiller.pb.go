@@ -34,6 +34,7 @@ const (
 	ListSort_NAME          ListSort_SortBy = 1
 	ListSort_LAST_RELEASED ListSort_SortBy = 2
 	ListSort_CHART_NAME    ListSort_SortBy = 3
+	ListSort_REVISION      ListSort_SortBy = 4
 )
 
 var ListSort_SortBy_name = map[int32]string{
@@ -41,19 +42,21 @@ var ListSort_SortBy_name = map[int32]string{
 	1: "NAME",
 	2: "LAST_RELEASED",
 	3: "CHART_NAME",
+	4: "REVISION",
 }
 var ListSort_SortBy_value = map[string]int32{
 	"UNKNOWN":       0,
 	"NAME":          1,
 	"LAST_RELEASED": 2,
 	"CHART_NAME":    3,
+	"REVISION":      4,
 }
 
 func (x ListSort_SortBy) String() string {
 	return proto.EnumName(ListSort_SortBy_name, int32(x))
 }
 func (ListSort_SortBy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{1, 0}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{1, 0}
 }
 
 // SortOrder defines sort orders to augment sorting operations.
@@ -77,7 +80,7 @@ func (x ListSort_SortOrder) String() string {
 	return proto.EnumName(ListSort_SortOrder_name, int32(x))
 }
 func (ListSort_SortOrder) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{1, 1}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{1, 1}
 }
 
 // ListReleasesRequest requests a list of releases.
@@ -103,8 +106,26 @@ type ListReleasesRequest struct {
 	// SortOrder is the ordering directive used for sorting.
 	SortOrder   ListSort_SortOrder    `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=hapi.services.tiller.ListSort_SortOrder" json:"sort_order,omitempty"`
 	StatusCodes []release.Status_Code `protobuf:"varint,6,rep,packed,name=status_codes,json=statusCodes,proto3,enum=hapi.release.Status_Code" json:"status_codes,omitempty"`
-	// Namespace is the filter to select releases only from a specific namespace.
-	Namespace            string   `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Namespace is the filter to select releases only from specific namespaces.
+	// It is a comma-separated list of namespaces or of glob patterns, such as
+	// "team-a,team-b-*".
+	Namespace string `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Selector is a label selector, such as "team=payments", that the labels
+	// of the listed releases must match.
+	Selector string `protobuf:"bytes,8,opt,name=selector,proto3" json:"selector,omitempty"`
+	// ContinueToken is the continue_token of the previous page of the listing.
+	// Unlike offset, it stays valid when releases are installed or deleted
+	// between pages. It cannot be combined with offset.
+	ContinueToken string `protobuf:"bytes,9,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	// ChartName is the name of the chart of the listed releases.
+	ChartName string `protobuf:"bytes,10,opt,name=chart_name,json=chartName,proto3" json:"chart_name,omitempty"`
+	// ChartVersion is a semantic version constraint, such as ">=1.0.0 <2.0.0",
+	// that the version of the chart of the listed releases must satisfy.
+	ChartVersion string `protobuf:"bytes,11,opt,name=chart_version,json=chartVersion,proto3" json:"chart_version,omitempty"`
+	// ChartAnnotations is a selector, with the syntax of label selectors, such as
+	// "tier=frontend", that the annotations of the chart of the listed releases
+	// must match.
+	ChartAnnotations     string   `protobuf:"bytes,12,opt,name=chart_annotations,json=chartAnnotations,proto3" json:"chart_annotations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -114,7 +135,7 @@ func (m *ListReleasesRequest) Reset()         { *m = ListReleasesRequest{} }
 func (m *ListReleasesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesRequest) ProtoMessage()    {}
 func (*ListReleasesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{0}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{0}
 }
 func (m *ListReleasesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesRequest.Unmarshal(m, b)
@@ -183,6 +204,41 @@ func (m *ListReleasesRequest) GetNamespace() string {
 	return ""
 }
 
+func (m *ListReleasesRequest) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetContinueToken() string {
+	if m != nil {
+		return m.ContinueToken
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetChartName() string {
+	if m != nil {
+		return m.ChartName
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetChartVersion() string {
+	if m != nil {
+		return m.ChartVersion
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetChartAnnotations() string {
+	if m != nil {
+		return m.ChartAnnotations
+	}
+	return ""
+}
+
 // ListSort defines sorting fields on a release list.
 type ListSort struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -194,7 +250,7 @@ func (m *ListSort) Reset()         { *m = ListSort{} }
 func (m *ListSort) String() string { return proto.CompactTextString(m) }
 func (*ListSort) ProtoMessage()    {}
 func (*ListSort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{1}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{1}
 }
 func (m *ListSort) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSort.Unmarshal(m, b)
@@ -224,17 +280,20 @@ type ListReleasesResponse struct {
 	// Total is the total number of queryable releases.
 	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	// Releases is the list of found release objects.
-	Releases             []*release.Release `protobuf:"bytes,4,rep,name=releases,proto3" json:"releases,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Releases []*release.Release `protobuf:"bytes,4,rep,name=releases,proto3" json:"releases,omitempty"`
+	// ContinueToken, if set, lists the next page of results when passed as
+	// the continue_token of a request with the same filters and sort order.
+	ContinueToken        string   `protobuf:"bytes,5,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListReleasesResponse) Reset()         { *m = ListReleasesResponse{} }
 func (m *ListReleasesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesResponse) ProtoMessage()    {}
 func (*ListReleasesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{2}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{2}
 }
 func (m *ListReleasesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesResponse.Unmarshal(m, b)
@@ -282,6 +341,13 @@ func (m *ListReleasesResponse) GetReleases() []*release.Release {
 	return nil
 }
 
+func (m *ListReleasesResponse) GetContinueToken() string {
+	if m != nil {
+		return m.ContinueToken
+	}
+	return ""
+}
+
 // GetReleaseStatusRequest is a request to get the status of a release.
 type GetReleaseStatusRequest struct {
 	// Name is the name of the release
@@ -297,7 +363,7 @@ func (m *GetReleaseStatusRequest) Reset()         { *m = GetReleaseStatusRequest
 func (m *GetReleaseStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusRequest) ProtoMessage()    {}
 func (*GetReleaseStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{3}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{3}
 }
 func (m *GetReleaseStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusRequest.Unmarshal(m, b)
@@ -338,7 +404,15 @@ type GetReleaseStatusResponse struct {
 	// Info contains information about the release.
 	Info *release.Info `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
 	// Namespace the release was released into
-	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Chart is the name of the chart the release was deployed from.
+	Chart string `protobuf:"bytes,4,opt,name=chart,proto3" json:"chart,omitempty"`
+	// ChartVersion is the version of the chart the release was deployed from.
+	ChartVersion string `protobuf:"bytes,5,opt,name=chart_version,json=chartVersion,proto3" json:"chart_version,omitempty"`
+	// ValuesDigest is the SHA256 digest of the user-supplied values.
+	ValuesDigest string `protobuf:"bytes,6,opt,name=values_digest,json=valuesDigest,proto3" json:"values_digest,omitempty"`
+	// Version is the revision of the release.
+	Version              int32    `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -348,7 +422,7 @@ func (m *GetReleaseStatusResponse) Reset()         { *m = GetReleaseStatusRespon
 func (m *GetReleaseStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusResponse) ProtoMessage()    {}
 func (*GetReleaseStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{4}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{4}
 }
 func (m *GetReleaseStatusResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusResponse.Unmarshal(m, b)
@@ -389,6 +463,34 @@ func (m *GetReleaseStatusResponse) GetNamespace() string {
 	return ""
 }
 
+func (m *GetReleaseStatusResponse) GetChart() string {
+	if m != nil {
+		return m.Chart
+	}
+	return ""
+}
+
+func (m *GetReleaseStatusResponse) GetChartVersion() string {
+	if m != nil {
+		return m.ChartVersion
+	}
+	return ""
+}
+
+func (m *GetReleaseStatusResponse) GetValuesDigest() string {
+	if m != nil {
+		return m.ValuesDigest
+	}
+	return ""
+}
+
+func (m *GetReleaseStatusResponse) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 // GetReleaseContentRequest is a request to get the contents of a release.
 type GetReleaseContentRequest struct {
 	// The name of the release
@@ -404,7 +506,7 @@ func (m *GetReleaseContentRequest) Reset()         { *m = GetReleaseContentReque
 func (m *GetReleaseContentRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentRequest) ProtoMessage()    {}
 func (*GetReleaseContentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{5}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{5}
 }
 func (m *GetReleaseContentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentRequest.Unmarshal(m, b)
@@ -451,7 +553,7 @@ func (m *GetReleaseContentResponse) Reset()         { *m = GetReleaseContentResp
 func (m *GetReleaseContentResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentResponse) ProtoMessage()    {}
 func (*GetReleaseContentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{6}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{6}
 }
 func (m *GetReleaseContentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentResponse.Unmarshal(m, b)
@@ -502,14 +604,41 @@ type UpdateReleaseRequest struct {
 	// ReuseValues will cause Tiller to reuse the values from the last release.
 	// This is ignored if reset_values is set.
 	ReuseValues bool `protobuf:"varint,10,opt,name=reuse_values,json=reuseValues,proto3" json:"reuse_values,omitempty"`
-	// Force resource update through delete/recreate if needed.
+	// Force resource update by retrying conflicting patches, then replacing the resources.
 	Force bool `protobuf:"varint,11,opt,name=force,proto3" json:"force,omitempty"`
 	// Description, if set, will set the description for the updated release
 	Description string `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"`
 	// Render subchart notes if enabled
 	SubNotes bool `protobuf:"varint,13,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
 	// Allow deletion of new resources created in this update when update failed
-	CleanupOnFail        bool     `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	CleanupOnFail bool `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	// wait_for_jobs, if true, will also wait until all Jobs have completed when wait is set
+	WaitForJobs bool `protobuf:"varint,15,opt,name=wait_for_jobs,json=waitForJobs,proto3" json:"wait_for_jobs,omitempty"`
+	// max_history, if greater than zero, limits the number of revisions kept for this release
+	MaxHistory int32 `protobuf:"varint,16,opt,name=max_history,json=maxHistory,proto3" json:"max_history,omitempty"`
+	// validate, if true, submits the manifests of a dry run to the apiserver as a server-side dry run
+	Validate bool `protobuf:"varint,17,opt,name=validate,proto3" json:"validate,omitempty"`
+	// post_rendered_manifest, if set, replaces the rendered manifests and hooks of the chart
+	PostRenderedManifest string `protobuf:"bytes,18,opt,name=post_rendered_manifest,json=postRenderedManifest,proto3" json:"post_rendered_manifest,omitempty"`
+	// skip_crds, if true, will not install the CRDs of the crds/ directory of the chart
+	SkipCrds bool `protobuf:"varint,19,opt,name=skip_crds,json=skipCrds,proto3" json:"skip_crds,omitempty"`
+	// reset_then_reuse_values will reset the values to the chart's defaults, then apply the values of the last release.
+	// This is ignored if reset_values or reuse_values is set.
+	ResetThenReuseValues bool `protobuf:"varint,20,opt,name=reset_then_reuse_values,json=resetThenReuseValues,proto3" json:"reset_then_reuse_values,omitempty"`
+	// force_recreate, if true, will delete and recreate the resources that cannot be replaced when force is set.
+	ForceRecreate bool `protobuf:"varint,21,opt,name=force_recreate,json=forceRecreate,proto3" json:"force_recreate,omitempty"`
+	// deployed_by is the identity of the user reported by the client. The common name of a verified TLS client certificate takes precedence.
+	DeployedBy string `protobuf:"bytes,22,opt,name=deployed_by,json=deployedBy,proto3" json:"deployed_by,omitempty"`
+	// recreate_pods_for restarts the pods of the listed resources only, given as kind/name.
+	RecreatePodsFor []string `protobuf:"bytes,23,rep,name=recreate_pods_for,json=recreatePodsFor,proto3" json:"recreate_pods_for,omitempty"`
+	// strict, if true, fails the rendering on references to values that are not defined.
+	Strict bool `protobuf:"varint,24,opt,name=strict,proto3" json:"strict,omitempty"`
+	// strip_comments, if true, drops the comments and empty documents from the rendered manifests.
+	StripComments bool `protobuf:"varint,25,opt,name=strip_comments,json=stripComments,proto3" json:"strip_comments,omitempty"`
+	// check_recreated, if true, lists in the response of a dry run the resources that cannot be updated in place.
+	CheckRecreated bool `protobuf:"varint,26,opt,name=check_recreated,json=checkRecreated,proto3" json:"check_recreated,omitempty"`
+	// missing_key is what the templates render for values that are not defined: "zero", "invalid" or "error". If empty, the setting of the chart is used.
+	MissingKey           string   `protobuf:"bytes,27,opt,name=missing_key,json=missingKey,proto3" json:"missing_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -519,7 +648,7 @@ func (m *UpdateReleaseRequest) Reset()         { *m = UpdateReleaseRequest{} }
 func (m *UpdateReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseRequest) ProtoMessage()    {}
 func (*UpdateReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{7}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{7}
 }
 func (m *UpdateReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseRequest.Unmarshal(m, b)
@@ -637,19 +766,115 @@ func (m *UpdateReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetWaitForJobs() bool {
+	if m != nil {
+		return m.WaitForJobs
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetMaxHistory() int32 {
+	if m != nil {
+		return m.MaxHistory
+	}
+	return 0
+}
+
+func (m *UpdateReleaseRequest) GetValidate() bool {
+	if m != nil {
+		return m.Validate
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetPostRenderedManifest() string {
+	if m != nil {
+		return m.PostRenderedManifest
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetSkipCrds() bool {
+	if m != nil {
+		return m.SkipCrds
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetResetThenReuseValues() bool {
+	if m != nil {
+		return m.ResetThenReuseValues
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetForceRecreate() bool {
+	if m != nil {
+		return m.ForceRecreate
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetDeployedBy() string {
+	if m != nil {
+		return m.DeployedBy
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetRecreatePodsFor() []string {
+	if m != nil {
+		return m.RecreatePodsFor
+	}
+	return nil
+}
+
+func (m *UpdateReleaseRequest) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetStripComments() bool {
+	if m != nil {
+		return m.StripComments
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetCheckRecreated() bool {
+	if m != nil {
+		return m.CheckRecreated
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetMissingKey() string {
+	if m != nil {
+		return m.MissingKey
+	}
+	return ""
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
-	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Release *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	// recreated_resources lists, as Kind/name, the resources that the API
+	// server refuses to update in place, for example because of a change to
+	// an immutable field, and that are deleted and created again with force
+	// and force_recreate. It is only set for dry runs with check_recreated.
+	RecreatedResources   []string `protobuf:"bytes,2,rep,name=recreated_resources,json=recreatedResources,proto3" json:"recreated_resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UpdateReleaseResponse) Reset()         { *m = UpdateReleaseResponse{} }
 func (m *UpdateReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseResponse) ProtoMessage()    {}
 func (*UpdateReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{8}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{8}
 }
 func (m *UpdateReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseResponse.Unmarshal(m, b)
@@ -676,6 +901,13 @@ func (m *UpdateReleaseResponse) GetRelease() *release.Release {
 	return nil
 }
 
+func (m *UpdateReleaseResponse) GetRecreatedResources() []string {
+	if m != nil {
+		return m.RecreatedResources
+	}
+	return nil
+}
+
 type RollbackReleaseRequest struct {
 	// The name of the release
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -683,7 +915,8 @@ type RollbackReleaseRequest struct {
 	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	// DisableHooks causes the server to skip running any hooks for the rollback
 	DisableHooks bool `protobuf:"varint,3,opt,name=disable_hooks,json=disableHooks,proto3" json:"disable_hooks,omitempty"`
-	// Version is the version of the release to deploy.
+	// Version is the version of the release to deploy. Zero selects the most
+	// recent successfully deployed version before the current one.
 	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 	// Performs pods restart for resources if applicable
 	Recreate bool `protobuf:"varint,5,opt,name=recreate,proto3" json:"recreate,omitempty"`
@@ -692,12 +925,24 @@ type RollbackReleaseRequest struct {
 	// wait, if true, will wait until all Pods, PVCs, and Services are in a ready state
 	// before marking the release as successful. It will wait for as long as timeout
 	Wait bool `protobuf:"varint,7,opt,name=wait,proto3" json:"wait,omitempty"`
-	// Force resource update through delete/recreate if needed.
+	// Force resource update by retrying conflicting patches, then replacing the resources.
 	Force bool `protobuf:"varint,8,opt,name=force,proto3" json:"force,omitempty"`
 	// Description, if set, will set the description for the rollback
 	Description string `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
 	// Allow deletion of new resources created in this rollback when rollback failed
-	CleanupOnFail        bool     `protobuf:"varint,10,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	CleanupOnFail bool `protobuf:"varint,10,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	// force_recreate, if true, will delete and recreate the resources that cannot be replaced when force is set.
+	ForceRecreate bool `protobuf:"varint,11,opt,name=force_recreate,json=forceRecreate,proto3" json:"force_recreate,omitempty"`
+	// deployed_by is the identity of the user reported by the client. The common name of a verified TLS client certificate takes precedence.
+	DeployedBy string `protobuf:"bytes,12,opt,name=deployed_by,json=deployedBy,proto3" json:"deployed_by,omitempty"`
+	// wait_for_jobs, if true, will also wait until all Jobs have completed when wait is set
+	WaitForJobs bool `protobuf:"varint,13,opt,name=wait_for_jobs,json=waitForJobs,proto3" json:"wait_for_jobs,omitempty"`
+	// prune, if true, also deletes the resources of the versions between the target
+	// and the current one that the target does not define, such as the ones left
+	// behind by failed upgrades.
+	Prune bool `protobuf:"varint,14,opt,name=prune,proto3" json:"prune,omitempty"`
+	// recreate_pods_for restarts the pods of the listed resources only, given as kind/name.
+	RecreatePodsFor      []string `protobuf:"bytes,15,rep,name=recreate_pods_for,json=recreatePodsFor,proto3" json:"recreate_pods_for,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -707,7 +952,7 @@ func (m *RollbackReleaseRequest) Reset()         { *m = RollbackReleaseRequest{}
 func (m *RollbackReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseRequest) ProtoMessage()    {}
 func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{9}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{9}
 }
 func (m *RollbackReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseRequest.Unmarshal(m, b)
@@ -797,6 +1042,41 @@ func (m *RollbackReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *RollbackReleaseRequest) GetForceRecreate() bool {
+	if m != nil {
+		return m.ForceRecreate
+	}
+	return false
+}
+
+func (m *RollbackReleaseRequest) GetDeployedBy() string {
+	if m != nil {
+		return m.DeployedBy
+	}
+	return ""
+}
+
+func (m *RollbackReleaseRequest) GetWaitForJobs() bool {
+	if m != nil {
+		return m.WaitForJobs
+	}
+	return false
+}
+
+func (m *RollbackReleaseRequest) GetPrune() bool {
+	if m != nil {
+		return m.Prune
+	}
+	return false
+}
+
+func (m *RollbackReleaseRequest) GetRecreatePodsFor() []string {
+	if m != nil {
+		return m.RecreatePodsFor
+	}
+	return nil
+}
+
 // RollbackReleaseResponse is the response to an update request.
 type RollbackReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -809,7 +1089,7 @@ func (m *RollbackReleaseResponse) Reset()         { *m = RollbackReleaseResponse
 func (m *RollbackReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseResponse) ProtoMessage()    {}
 func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{10}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{10}
 }
 func (m *RollbackReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseResponse.Unmarshal(m, b)
@@ -863,8 +1143,28 @@ type InstallReleaseRequest struct {
 	Wait           bool `protobuf:"varint,9,opt,name=wait,proto3" json:"wait,omitempty"`
 	DisableCrdHook bool `protobuf:"varint,10,opt,name=disable_crd_hook,json=disableCrdHook,proto3" json:"disable_crd_hook,omitempty"`
 	// Description, if set, will set the description for the installed release
-	Description          string   `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
-	SubNotes             bool     `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	Description string `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	SubNotes    bool   `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	// wait_for_jobs, if true, will also wait until all Jobs have completed when wait is set
+	WaitForJobs bool `protobuf:"varint,13,opt,name=wait_for_jobs,json=waitForJobs,proto3" json:"wait_for_jobs,omitempty"`
+	// validate, if true, submits the manifests of a dry run to the apiserver as a server-side dry run
+	Validate bool `protobuf:"varint,14,opt,name=validate,proto3" json:"validate,omitempty"`
+	// post_rendered_manifest, if set, replaces the rendered manifests and hooks of the chart
+	PostRenderedManifest string `protobuf:"bytes,15,opt,name=post_rendered_manifest,json=postRenderedManifest,proto3" json:"post_rendered_manifest,omitempty"`
+	// skip_crds, if true, will not install the CRDs of the crds/ directory of the chart
+	SkipCrds bool `protobuf:"varint,16,opt,name=skip_crds,json=skipCrds,proto3" json:"skip_crds,omitempty"`
+	// take_ownership, if true, adopts the resources that already exist instead of failing
+	TakeOwnership bool `protobuf:"varint,17,opt,name=take_ownership,json=takeOwnership,proto3" json:"take_ownership,omitempty"`
+	// labels are attached to the release and can be used to select it when listing releases.
+	Labels map[string]string `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// deployed_by is the identity of the user reported by the client. The common name of a verified TLS client certificate takes precedence.
+	DeployedBy string `protobuf:"bytes,19,opt,name=deployed_by,json=deployedBy,proto3" json:"deployed_by,omitempty"`
+	// strict, if true, fails the rendering on references to values that are not defined.
+	Strict bool `protobuf:"varint,20,opt,name=strict,proto3" json:"strict,omitempty"`
+	// strip_comments, if true, drops the comments and empty documents from the rendered manifests.
+	StripComments bool `protobuf:"varint,21,opt,name=strip_comments,json=stripComments,proto3" json:"strip_comments,omitempty"`
+	// missing_key is what the templates render for values that are not defined: "zero", "invalid" or "error". If empty, the setting of the chart is used.
+	MissingKey           string   `protobuf:"bytes,22,opt,name=missing_key,json=missingKey,proto3" json:"missing_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -874,7 +1174,7 @@ func (m *InstallReleaseRequest) Reset()         { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()    {}
 func (*InstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{11}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{11}
 }
 func (m *InstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseRequest.Unmarshal(m, b)
@@ -978,6 +1278,76 @@ func (m *InstallReleaseRequest) GetSubNotes() bool {
 	return false
 }
 
+func (m *InstallReleaseRequest) GetWaitForJobs() bool {
+	if m != nil {
+		return m.WaitForJobs
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetValidate() bool {
+	if m != nil {
+		return m.Validate
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetPostRenderedManifest() string {
+	if m != nil {
+		return m.PostRenderedManifest
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetSkipCrds() bool {
+	if m != nil {
+		return m.SkipCrds
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetTakeOwnership() bool {
+	if m != nil {
+		return m.TakeOwnership
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *InstallReleaseRequest) GetDeployedBy() string {
+	if m != nil {
+		return m.DeployedBy
+	}
+	return ""
+}
+
+func (m *InstallReleaseRequest) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetStripComments() bool {
+	if m != nil {
+		return m.StripComments
+	}
+	return false
+}
+
+func (m *InstallReleaseRequest) GetMissingKey() string {
+	if m != nil {
+		return m.MissingKey
+	}
+	return ""
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -990,7 +1360,7 @@ func (m *InstallReleaseResponse) Reset()         { *m = InstallReleaseResponse{}
 func (m *InstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()    {}
 func (*InstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{12}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{12}
 }
 func (m *InstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseResponse.Unmarshal(m, b)
@@ -1028,7 +1398,19 @@ type UninstallReleaseRequest struct {
 	// timeout specifies the max amount of time any kubernetes client command can run.
 	Timeout int64 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	// Description, if set, will set the description for the uninstalled release
-	Description          string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// wait, if true, will wait until the deleted resources of the release, including
+	// the hooks deleted by the uninstall, are removed from the cluster. It will wait
+	// for as long as timeout.
+	Wait bool `protobuf:"varint,6,opt,name=wait,proto3" json:"wait,omitempty"`
+	// cascade is how the deletion propagates to the dependents of the resources:
+	// "background" (the default), "foreground" or "orphan".
+	Cascade string `protobuf:"bytes,7,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	// force_protected, if true, uninstalls the release even if it is protected.
+	ForceProtected bool `protobuf:"varint,8,opt,name=force_protected,json=forceProtected,proto3" json:"force_protected,omitempty"`
+	// ignore_resource_policy, if true, also deletes the resources kept by the
+	// "helm.sh/resource-policy: keep" annotation.
+	IgnoreResourcePolicy bool     `protobuf:"varint,9,opt,name=ignore_resource_policy,json=ignoreResourcePolicy,proto3" json:"ignore_resource_policy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1038,7 +1420,7 @@ func (m *UninstallReleaseRequest) Reset()         { *m = UninstallReleaseRequest
 func (m *UninstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseRequest) ProtoMessage()    {}
 func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{13}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{13}
 }
 func (m *UninstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseRequest.Unmarshal(m, b)
@@ -1093,22 +1475,52 @@ func (m *UninstallReleaseRequest) GetDescription() string {
 	return ""
 }
 
+func (m *UninstallReleaseRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *UninstallReleaseRequest) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
+func (m *UninstallReleaseRequest) GetForceProtected() bool {
+	if m != nil {
+		return m.ForceProtected
+	}
+	return false
+}
+
+func (m *UninstallReleaseRequest) GetIgnoreResourcePolicy() bool {
+	if m != nil {
+		return m.IgnoreResourcePolicy
+	}
+	return false
+}
+
 // UninstallReleaseResponse represents a successful response to an uninstall request.
 type UninstallReleaseResponse struct {
 	// Release is the release that was marked deleted.
 	Release *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
 	// Info is an uninstall message
-	Info                 string   `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Info string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// Kept are the resources left in the cluster because of their resource policy.
+	Kept                 []*KeptResource `protobuf:"bytes,3,rep,name=kept,proto3" json:"kept,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *UninstallReleaseResponse) Reset()         { *m = UninstallReleaseResponse{} }
 func (m *UninstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseResponse) ProtoMessage()    {}
 func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{14}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{14}
 }
 func (m *UninstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseResponse.Unmarshal(m, b)
@@ -1142,6 +1554,13 @@ func (m *UninstallReleaseResponse) GetInfo() string {
 	return ""
 }
 
+func (m *UninstallReleaseResponse) GetKept() []*KeptResource {
+	if m != nil {
+		return m.Kept
+	}
+	return nil
+}
+
 // GetVersionRequest requests for version information.
 type GetVersionRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1153,7 +1572,7 @@ func (m *GetVersionRequest) Reset()         { *m = GetVersionRequest{} }
 func (m *GetVersionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetVersionRequest) ProtoMessage()    {}
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{15}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{15}
 }
 func (m *GetVersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionRequest.Unmarshal(m, b)
@@ -1184,7 +1603,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{16}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{16}
 }
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
@@ -1226,7 +1645,7 @@ func (m *GetHistoryRequest) Reset()         { *m = GetHistoryRequest{} }
 func (m *GetHistoryRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryRequest) ProtoMessage()    {}
 func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{17}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{17}
 }
 func (m *GetHistoryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryRequest.Unmarshal(m, b)
@@ -1272,7 +1691,7 @@ func (m *GetHistoryResponse) Reset()         { *m = GetHistoryResponse{} }
 func (m *GetHistoryResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryResponse) ProtoMessage()    {}
 func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{18}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{18}
 }
 func (m *GetHistoryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryResponse.Unmarshal(m, b)
@@ -1318,7 +1737,7 @@ func (m *TestReleaseRequest) Reset()         { *m = TestReleaseRequest{} }
 func (m *TestReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseRequest) ProtoMessage()    {}
 func (*TestReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{19}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{19}
 }
 func (m *TestReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseRequest.Unmarshal(m, b)
@@ -1379,7 +1798,7 @@ func (m *TestReleaseResponse) Reset()         { *m = TestReleaseResponse{} }
 func (m *TestReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseResponse) ProtoMessage()    {}
 func (*TestReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_bb72ee4a42494734, []int{20}
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{20}
 }
 func (m *TestReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseResponse.Unmarshal(m, b)
@@ -1413,28 +1832,627 @@ func (m *TestReleaseResponse) GetStatus() release.TestRun_Status {
 	return release.TestRun_UNKNOWN
 }
 
-func init() {
-	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
-	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
-	proto.RegisterType((*ListReleasesResponse)(nil), "hapi.services.tiller.ListReleasesResponse")
-	proto.RegisterType((*GetReleaseStatusRequest)(nil), "hapi.services.tiller.GetReleaseStatusRequest")
-	proto.RegisterType((*GetReleaseStatusResponse)(nil), "hapi.services.tiller.GetReleaseStatusResponse")
-	proto.RegisterType((*GetReleaseContentRequest)(nil), "hapi.services.tiller.GetReleaseContentRequest")
-	proto.RegisterType((*GetReleaseContentResponse)(nil), "hapi.services.tiller.GetReleaseContentResponse")
-	proto.RegisterType((*UpdateReleaseRequest)(nil), "hapi.services.tiller.UpdateReleaseRequest")
-	proto.RegisterType((*UpdateReleaseResponse)(nil), "hapi.services.tiller.UpdateReleaseResponse")
-	proto.RegisterType((*RollbackReleaseRequest)(nil), "hapi.services.tiller.RollbackReleaseRequest")
-	proto.RegisterType((*RollbackReleaseResponse)(nil), "hapi.services.tiller.RollbackReleaseResponse")
-	proto.RegisterType((*InstallReleaseRequest)(nil), "hapi.services.tiller.InstallReleaseRequest")
-	proto.RegisterType((*InstallReleaseResponse)(nil), "hapi.services.tiller.InstallReleaseResponse")
-	proto.RegisterType((*UninstallReleaseRequest)(nil), "hapi.services.tiller.UninstallReleaseRequest")
-	proto.RegisterType((*UninstallReleaseResponse)(nil), "hapi.services.tiller.UninstallReleaseResponse")
+// GetReleaseDetailRequest requests the resources of a release to be compared
+// with the live ones.
+type GetReleaseDetailRequest struct {
+	// The name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The version of the release, or 0 for the last one.
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Diff, if true, also returns the manifest and the live state of the drifted resources.
+	Diff                 bool     `protobuf:"varint,3,opt,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReleaseDetailRequest) Reset()         { *m = GetReleaseDetailRequest{} }
+func (m *GetReleaseDetailRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReleaseDetailRequest) ProtoMessage()    {}
+func (*GetReleaseDetailRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{21}
+}
+func (m *GetReleaseDetailRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReleaseDetailRequest.Unmarshal(m, b)
+}
+func (m *GetReleaseDetailRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReleaseDetailRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetReleaseDetailRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReleaseDetailRequest.Merge(dst, src)
+}
+func (m *GetReleaseDetailRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReleaseDetailRequest.Size(m)
+}
+func (m *GetReleaseDetailRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReleaseDetailRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReleaseDetailRequest proto.InternalMessageInfo
+
+func (m *GetReleaseDetailRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetReleaseDetailRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetReleaseDetailRequest) GetDiff() bool {
+	if m != nil {
+		return m.Diff
+	}
+	return false
+}
+
+// GetReleaseDetailResponse is received in response to a GetReleaseDetail rpc.
+type GetReleaseDetailResponse struct {
+	// The number of resources in the manifest of the release.
+	ResourceCount int32 `protobuf:"varint,1,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	// The resources whose live state differs from the manifest, or which are
+	// missing from the cluster, as "Kind/name".
+	DriftedResources []string `protobuf:"bytes,2,rep,name=drifted_resources,json=driftedResources,proto3" json:"drifted_resources,omitempty"`
+	// Drifts are the drifted resources with their manifest and live state, when
+	// requested with diff.
+	Drifts               []*ResourceDrift `protobuf:"bytes,3,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetReleaseDetailResponse) Reset()         { *m = GetReleaseDetailResponse{} }
+func (m *GetReleaseDetailResponse) String() string { return proto.CompactTextString(m) }
+func (*GetReleaseDetailResponse) ProtoMessage()    {}
+func (*GetReleaseDetailResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{22}
+}
+func (m *GetReleaseDetailResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReleaseDetailResponse.Unmarshal(m, b)
+}
+func (m *GetReleaseDetailResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReleaseDetailResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetReleaseDetailResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReleaseDetailResponse.Merge(dst, src)
+}
+func (m *GetReleaseDetailResponse) XXX_Size() int {
+	return xxx_messageInfo_GetReleaseDetailResponse.Size(m)
+}
+func (m *GetReleaseDetailResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReleaseDetailResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReleaseDetailResponse proto.InternalMessageInfo
+
+func (m *GetReleaseDetailResponse) GetResourceCount() int32 {
+	if m != nil {
+		return m.ResourceCount
+	}
+	return 0
+}
+
+func (m *GetReleaseDetailResponse) GetDriftedResources() []string {
+	if m != nil {
+		return m.DriftedResources
+	}
+	return nil
+}
+
+func (m *GetReleaseDetailResponse) GetDrifts() []*ResourceDrift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
+
+// ResourceDrift is a resource of a release whose live state differs from its manifest.
+type ResourceDrift struct {
+	// Resource is the kind and name of the resource, as "Kind/name".
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Manifest is the manifest of the resource, as YAML with sorted keys.
+	Manifest string `protobuf:"bytes,2,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	// Live holds the live values of the fields set by the manifest, in the same
+	// form as the manifest, or is empty if the resource is missing.
+	Live                 string   `protobuf:"bytes,3,opt,name=live,proto3" json:"live,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceDrift) Reset()         { *m = ResourceDrift{} }
+func (m *ResourceDrift) String() string { return proto.CompactTextString(m) }
+func (*ResourceDrift) ProtoMessage()    {}
+func (*ResourceDrift) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{23}
+}
+func (m *ResourceDrift) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceDrift.Unmarshal(m, b)
+}
+func (m *ResourceDrift) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceDrift.Marshal(b, m, deterministic)
+}
+func (dst *ResourceDrift) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceDrift.Merge(dst, src)
+}
+func (m *ResourceDrift) XXX_Size() int {
+	return xxx_messageInfo_ResourceDrift.Size(m)
+}
+func (m *ResourceDrift) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceDrift.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceDrift proto.InternalMessageInfo
+
+func (m *ResourceDrift) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *ResourceDrift) GetManifest() string {
+	if m != nil {
+		return m.Manifest
+	}
+	return ""
+}
+
+func (m *ResourceDrift) GetLive() string {
+	if m != nil {
+		return m.Live
+	}
+	return ""
+}
+
+// UninstallReleasesRequest requests the releases matching a selector or a filter
+// to be uninstalled.
+type UninstallReleasesRequest struct {
+	// Selector is a label selector, such as "team=ci-ephemeral", that the labels
+	// of the uninstalled releases must match.
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Filter is a regular expression that the names of the uninstalled releases
+	// must match.
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// DisableHooks causes the server to skip running any hooks for the uninstalls.
+	DisableHooks bool `protobuf:"varint,3,opt,name=disable_hooks,json=disableHooks,proto3" json:"disable_hooks,omitempty"`
+	// Purge removes the releases from the store and make their names free for later use.
+	Purge bool `protobuf:"varint,4,opt,name=purge,proto3" json:"purge,omitempty"`
+	// timeout specifies the max amount of time any kubernetes client command can run.
+	Timeout int64 `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Description, if set, will set the description for the uninstalled releases
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// wait, if true, will wait until the resources of each release are removed from
+	// the cluster before uninstalling the next one.
+	Wait bool `protobuf:"varint,7,opt,name=wait,proto3" json:"wait,omitempty"`
+	// dry_run, if true, returns the matching releases without uninstalling them.
+	DryRun bool `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// cascade is how the deletion propagates to the dependents of the resources:
+	// "background" (the default), "foreground" or "orphan".
+	Cascade string `protobuf:"bytes,9,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	// force_protected, if true, also uninstalls the protected releases.
+	ForceProtected bool `protobuf:"varint,10,opt,name=force_protected,json=forceProtected,proto3" json:"force_protected,omitempty"`
+	// ignore_resource_policy, if true, also deletes the resources kept by the
+	// "helm.sh/resource-policy: keep" annotation.
+	IgnoreResourcePolicy bool     `protobuf:"varint,11,opt,name=ignore_resource_policy,json=ignoreResourcePolicy,proto3" json:"ignore_resource_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallReleasesRequest) Reset()         { *m = UninstallReleasesRequest{} }
+func (m *UninstallReleasesRequest) String() string { return proto.CompactTextString(m) }
+func (*UninstallReleasesRequest) ProtoMessage()    {}
+func (*UninstallReleasesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{24}
+}
+func (m *UninstallReleasesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallReleasesRequest.Unmarshal(m, b)
+}
+func (m *UninstallReleasesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallReleasesRequest.Marshal(b, m, deterministic)
+}
+func (dst *UninstallReleasesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallReleasesRequest.Merge(dst, src)
+}
+func (m *UninstallReleasesRequest) XXX_Size() int {
+	return xxx_messageInfo_UninstallReleasesRequest.Size(m)
+}
+func (m *UninstallReleasesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallReleasesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallReleasesRequest proto.InternalMessageInfo
+
+func (m *UninstallReleasesRequest) GetSelector() string {
+	if m != nil {
+		return m.Selector
+	}
+	return ""
+}
+
+func (m *UninstallReleasesRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *UninstallReleasesRequest) GetDisableHooks() bool {
+	if m != nil {
+		return m.DisableHooks
+	}
+	return false
+}
+
+func (m *UninstallReleasesRequest) GetPurge() bool {
+	if m != nil {
+		return m.Purge
+	}
+	return false
+}
+
+func (m *UninstallReleasesRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *UninstallReleasesRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *UninstallReleasesRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *UninstallReleasesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *UninstallReleasesRequest) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
+func (m *UninstallReleasesRequest) GetForceProtected() bool {
+	if m != nil {
+		return m.ForceProtected
+	}
+	return false
+}
+
+func (m *UninstallReleasesRequest) GetIgnoreResourcePolicy() bool {
+	if m != nil {
+		return m.IgnoreResourcePolicy
+	}
+	return false
+}
+
+// UninstallReleasesResponse represents a successful response to an uninstall releases request.
+type UninstallReleasesResponse struct {
+	// Releases are the uninstalled releases.
+	Releases []*release.Release `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	// Info is an uninstall message for each release that kept resources.
+	Info string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// Kept are the resources left in the cluster because of their resource policy.
+	Kept []*KeptResource `protobuf:"bytes,3,rep,name=kept,proto3" json:"kept,omitempty"`
+	// Failed are the matching releases that could not be uninstalled, or on a
+	// dry run, that would not be.
+	Failed               []*FailedUninstall `protobuf:"bytes,4,rep,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UninstallReleasesResponse) Reset()         { *m = UninstallReleasesResponse{} }
+func (m *UninstallReleasesResponse) String() string { return proto.CompactTextString(m) }
+func (*UninstallReleasesResponse) ProtoMessage()    {}
+func (*UninstallReleasesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{25}
+}
+func (m *UninstallReleasesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallReleasesResponse.Unmarshal(m, b)
+}
+func (m *UninstallReleasesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallReleasesResponse.Marshal(b, m, deterministic)
+}
+func (dst *UninstallReleasesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallReleasesResponse.Merge(dst, src)
+}
+func (m *UninstallReleasesResponse) XXX_Size() int {
+	return xxx_messageInfo_UninstallReleasesResponse.Size(m)
+}
+func (m *UninstallReleasesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallReleasesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallReleasesResponse proto.InternalMessageInfo
+
+func (m *UninstallReleasesResponse) GetReleases() []*release.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *UninstallReleasesResponse) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+func (m *UninstallReleasesResponse) GetKept() []*KeptResource {
+	if m != nil {
+		return m.Kept
+	}
+	return nil
+}
+
+func (m *UninstallReleasesResponse) GetFailed() []*FailedUninstall {
+	if m != nil {
+		return m.Failed
+	}
+	return nil
+}
+
+// FailedUninstall is a release that an uninstall releases request could not
+// uninstall.
+type FailedUninstall struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Error is the reason the release was not uninstalled.
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailedUninstall) Reset()         { *m = FailedUninstall{} }
+func (m *FailedUninstall) String() string { return proto.CompactTextString(m) }
+func (*FailedUninstall) ProtoMessage()    {}
+func (*FailedUninstall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{26}
+}
+func (m *FailedUninstall) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailedUninstall.Unmarshal(m, b)
+}
+func (m *FailedUninstall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailedUninstall.Marshal(b, m, deterministic)
+}
+func (dst *FailedUninstall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailedUninstall.Merge(dst, src)
+}
+func (m *FailedUninstall) XXX_Size() int {
+	return xxx_messageInfo_FailedUninstall.Size(m)
+}
+func (m *FailedUninstall) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailedUninstall.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailedUninstall proto.InternalMessageInfo
+
+func (m *FailedUninstall) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FailedUninstall) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// KeptResource is a resource that an uninstall left in the cluster because of
+// its resource policy.
+type KeptResource struct {
+	// Kind is the kind of the resource.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Name is the name of the resource.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Namespace is the namespace of the resource.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Release is the name of the uninstalled release the resource belonged to.
+	Release              string   `protobuf:"bytes,4,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeptResource) Reset()         { *m = KeptResource{} }
+func (m *KeptResource) String() string { return proto.CompactTextString(m) }
+func (*KeptResource) ProtoMessage()    {}
+func (*KeptResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{27}
+}
+func (m *KeptResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeptResource.Unmarshal(m, b)
+}
+func (m *KeptResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeptResource.Marshal(b, m, deterministic)
+}
+func (dst *KeptResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeptResource.Merge(dst, src)
+}
+func (m *KeptResource) XXX_Size() int {
+	return xxx_messageInfo_KeptResource.Size(m)
+}
+func (m *KeptResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeptResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeptResource proto.InternalMessageInfo
+
+func (m *KeptResource) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *KeptResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KeptResource) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *KeptResource) GetRelease() string {
+	if m != nil {
+		return m.Release
+	}
+	return ""
+}
+
+// ProtectReleaseRequest protects a release from deletion, or lifts the protection.
+type ProtectReleaseRequest struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Protect, if true, protects the release, and lifts the protection otherwise.
+	Protect              bool     `protobuf:"varint,2,opt,name=protect,proto3" json:"protect,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProtectReleaseRequest) Reset()         { *m = ProtectReleaseRequest{} }
+func (m *ProtectReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ProtectReleaseRequest) ProtoMessage()    {}
+func (*ProtectReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{28}
+}
+func (m *ProtectReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProtectReleaseRequest.Unmarshal(m, b)
+}
+func (m *ProtectReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProtectReleaseRequest.Marshal(b, m, deterministic)
+}
+func (dst *ProtectReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtectReleaseRequest.Merge(dst, src)
+}
+func (m *ProtectReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_ProtectReleaseRequest.Size(m)
+}
+func (m *ProtectReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtectReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtectReleaseRequest proto.InternalMessageInfo
+
+func (m *ProtectReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ProtectReleaseRequest) GetProtect() bool {
+	if m != nil {
+		return m.Protect
+	}
+	return false
+}
+
+// ProtectReleaseResponse represents a successful response to a protect request.
+type ProtectReleaseResponse struct {
+	// Release is the protected release.
+	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ProtectReleaseResponse) Reset()         { *m = ProtectReleaseResponse{} }
+func (m *ProtectReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*ProtectReleaseResponse) ProtoMessage()    {}
+func (*ProtectReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_ebf61cf17fd13715, []int{29}
+}
+func (m *ProtectReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProtectReleaseResponse.Unmarshal(m, b)
+}
+func (m *ProtectReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProtectReleaseResponse.Marshal(b, m, deterministic)
+}
+func (dst *ProtectReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtectReleaseResponse.Merge(dst, src)
+}
+func (m *ProtectReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_ProtectReleaseResponse.Size(m)
+}
+func (m *ProtectReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtectReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtectReleaseResponse proto.InternalMessageInfo
+
+func (m *ProtectReleaseResponse) GetRelease() *release.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
+	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
+	proto.RegisterType((*ListReleasesResponse)(nil), "hapi.services.tiller.ListReleasesResponse")
+	proto.RegisterType((*GetReleaseStatusRequest)(nil), "hapi.services.tiller.GetReleaseStatusRequest")
+	proto.RegisterType((*GetReleaseStatusResponse)(nil), "hapi.services.tiller.GetReleaseStatusResponse")
+	proto.RegisterType((*GetReleaseContentRequest)(nil), "hapi.services.tiller.GetReleaseContentRequest")
+	proto.RegisterType((*GetReleaseContentResponse)(nil), "hapi.services.tiller.GetReleaseContentResponse")
+	proto.RegisterType((*UpdateReleaseRequest)(nil), "hapi.services.tiller.UpdateReleaseRequest")
+	proto.RegisterType((*UpdateReleaseResponse)(nil), "hapi.services.tiller.UpdateReleaseResponse")
+	proto.RegisterType((*RollbackReleaseRequest)(nil), "hapi.services.tiller.RollbackReleaseRequest")
+	proto.RegisterType((*RollbackReleaseResponse)(nil), "hapi.services.tiller.RollbackReleaseResponse")
+	proto.RegisterType((*InstallReleaseRequest)(nil), "hapi.services.tiller.InstallReleaseRequest")
+	proto.RegisterMapType((map[string]string)(nil), "hapi.services.tiller.InstallReleaseRequest.LabelsEntry")
+	proto.RegisterType((*InstallReleaseResponse)(nil), "hapi.services.tiller.InstallReleaseResponse")
+	proto.RegisterType((*UninstallReleaseRequest)(nil), "hapi.services.tiller.UninstallReleaseRequest")
+	proto.RegisterType((*UninstallReleaseResponse)(nil), "hapi.services.tiller.UninstallReleaseResponse")
 	proto.RegisterType((*GetVersionRequest)(nil), "hapi.services.tiller.GetVersionRequest")
 	proto.RegisterType((*GetVersionResponse)(nil), "hapi.services.tiller.GetVersionResponse")
 	proto.RegisterType((*GetHistoryRequest)(nil), "hapi.services.tiller.GetHistoryRequest")
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*GetReleaseDetailRequest)(nil), "hapi.services.tiller.GetReleaseDetailRequest")
+	proto.RegisterType((*GetReleaseDetailResponse)(nil), "hapi.services.tiller.GetReleaseDetailResponse")
+	proto.RegisterType((*ResourceDrift)(nil), "hapi.services.tiller.ResourceDrift")
+	proto.RegisterType((*UninstallReleasesRequest)(nil), "hapi.services.tiller.UninstallReleasesRequest")
+	proto.RegisterType((*UninstallReleasesResponse)(nil), "hapi.services.tiller.UninstallReleasesResponse")
+	proto.RegisterType((*FailedUninstall)(nil), "hapi.services.tiller.FailedUninstall")
+	proto.RegisterType((*KeptResource)(nil), "hapi.services.tiller.KeptResource")
+	proto.RegisterType((*ProtectReleaseRequest)(nil), "hapi.services.tiller.ProtectReleaseRequest")
+	proto.RegisterType((*ProtectReleaseResponse)(nil), "hapi.services.tiller.ProtectReleaseResponse")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
 }
@@ -1474,6 +2492,12 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// GetReleaseDetail counts the resources of a release and compares them with the cluster.
+	GetReleaseDetail(ctx context.Context, in *GetReleaseDetailRequest, opts ...grpc.CallOption) (*GetReleaseDetailResponse, error)
+	// UninstallReleases uninstalls the releases matching a selector or a filter.
+	UninstallReleases(ctx context.Context, in *UninstallReleasesRequest, opts ...grpc.CallOption) (*UninstallReleasesResponse, error)
+	// ProtectRelease protects a release from deletion, or lifts the protection.
+	ProtectRelease(ctx context.Context, in *ProtectReleaseRequest, opts ...grpc.CallOption) (*ProtectReleaseResponse, error)
 }
 
 type releaseServiceClient struct {
@@ -1620,6 +2644,33 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) GetReleaseDetail(ctx context.Context, in *GetReleaseDetailRequest, opts ...grpc.CallOption) (*GetReleaseDetailResponse, error) {
+	out := new(GetReleaseDetailResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/GetReleaseDetail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) UninstallReleases(ctx context.Context, in *UninstallReleasesRequest, opts ...grpc.CallOption) (*UninstallReleasesResponse, error) {
+	out := new(UninstallReleasesResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/UninstallReleases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) ProtectRelease(ctx context.Context, in *ProtectReleaseRequest, opts ...grpc.CallOption) (*ProtectReleaseResponse, error) {
+	out := new(ProtectReleaseResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/ProtectRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReleaseServiceServer is the server API for ReleaseService service.
 type ReleaseServiceServer interface {
 	// ListReleases retrieves release history.
@@ -1645,6 +2696,12 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// GetReleaseDetail counts the resources of a release and compares them with the cluster.
+	GetReleaseDetail(context.Context, *GetReleaseDetailRequest) (*GetReleaseDetailResponse, error)
+	// UninstallReleases uninstalls the releases matching a selector or a filter.
+	UninstallReleases(context.Context, *UninstallReleasesRequest) (*UninstallReleasesResponse, error)
+	// ProtectRelease protects a release from deletion, or lifts the protection.
+	ProtectRelease(context.Context, *ProtectReleaseRequest) (*ProtectReleaseResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1837,6 +2894,60 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_GetReleaseDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReleaseDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetReleaseDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/GetReleaseDetail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetReleaseDetail(ctx, req.(*GetReleaseDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_UninstallReleases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UninstallReleasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).UninstallReleases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/UninstallReleases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).UninstallReleases(ctx, req.(*UninstallReleasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_ProtectRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtectReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).ProtectRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/ProtectRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).ProtectRelease(ctx, req.(*ProtectReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1873,6 +2984,18 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _ReleaseService_GetHistory_Handler,
 		},
+		{
+			MethodName: "GetReleaseDetail",
+			Handler:    _ReleaseService_GetReleaseDetail_Handler,
+		},
+		{
+			MethodName: "UninstallReleases",
+			Handler:    _ReleaseService_UninstallReleases_Handler,
+		},
+		{
+			MethodName: "ProtectRelease",
+			Handler:    _ReleaseService_ProtectRelease_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1889,92 +3012,153 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "hapi/services/tiller.proto",
 }
 
-func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_bb72ee4a42494734) }
-
-var fileDescriptor_tiller_bb72ee4a42494734 = []byte{
-	// 1337 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0x8e, 0x2d, 0xff, 0x1e, 0x27, 0xae, 0xbb, 0x4d, 0x13, 0xd5, 0x14, 0x26, 0x88, 0xa1, 0x75,
-	0x0b, 0x75, 0x20, 0x70, 0xc3, 0x0c, 0xc3, 0x4c, 0xea, 0x86, 0xa4, 0x10, 0xd2, 0x19, 0xb9, 0x2d,
-	0x33, 0xcc, 0x30, 0x1e, 0xc5, 0x5e, 0xb7, 0xa2, 0xb2, 0xd6, 0x68, 0x57, 0xa1, 0x79, 0x04, 0x2e,
-	0x79, 0x07, 0xae, 0x79, 0x06, 0x6e, 0x79, 0x06, 0x5e, 0x86, 0xd9, 0x3f, 0x45, 0x2b, 0x4b, 0xae,
-	0xc8, 0x4d, 0xac, 0xdd, 0x73, 0xf6, 0xfc, 0x7c, 0xdf, 0x9e, 0xb3, 0x67, 0x02, 0xfd, 0xd7, 0xde,
-	0xd2, 0xdf, 0xa7, 0x38, 0xba, 0xf0, 0xa7, 0x98, 0xee, 0x33, 0x3f, 0x08, 0x70, 0x34, 0x5c, 0x46,
-	0x84, 0x11, 0xb4, 0xcd, 0x65, 0x43, 0x2d, 0x1b, 0x4a, 0x59, 0x7f, 0x47, 0x9c, 0x98, 0xbe, 0xf6,
-	0x22, 0x26, 0xff, 0x4a, 0xed, 0xfe, 0x6e, 0x7a, 0x9f, 0x84, 0x73, 0xff, 0x95, 0x12, 0x48, 0x17,
-	0x11, 0x0e, 0xb0, 0x47, 0xb1, 0xfe, 0x35, 0x0e, 0x69, 0x99, 0x1f, 0xce, 0x89, 0x12, 0xbc, 0x67,
-	0x08, 0x18, 0xa6, 0x6c, 0x12, 0xc5, 0xa1, 0x12, 0xde, 0x31, 0x84, 0x94, 0x79, 0x2c, 0xa6, 0x86,
-	0xb3, 0x0b, 0x1c, 0x51, 0x9f, 0x84, 0xfa, 0x57, 0xca, 0x9c, 0xbf, 0xab, 0x70, 0xeb, 0xd4, 0xa7,
-	0xcc, 0x95, 0x07, 0xa9, 0x8b, 0x7f, 0x8d, 0x31, 0x65, 0x68, 0x1b, 0xea, 0x81, 0xbf, 0xf0, 0x99,
-	0x5d, 0xd9, 0xab, 0x0c, 0x2c, 0x57, 0x2e, 0xd0, 0x0e, 0x34, 0xc8, 0x7c, 0x4e, 0x31, 0xb3, 0xab,
-	0x7b, 0x95, 0x41, 0xdb, 0x55, 0x2b, 0xf4, 0x0d, 0x34, 0x29, 0x89, 0xd8, 0xe4, 0xfc, 0xd2, 0xb6,
-	0xf6, 0x2a, 0x83, 0xee, 0xc1, 0xc7, 0xc3, 0x3c, 0x9c, 0x86, 0xdc, 0xd3, 0x98, 0x44, 0x6c, 0xc8,
-	0xff, 0x3c, 0xbe, 0x74, 0x1b, 0x54, 0xfc, 0x72, 0xbb, 0x73, 0x3f, 0x60, 0x38, 0xb2, 0x6b, 0xd2,
-	0xae, 0x5c, 0xa1, 0x63, 0x00, 0x61, 0x97, 0x44, 0x33, 0x1c, 0xd9, 0x75, 0x61, 0x7a, 0x50, 0xc2,
-	0xf4, 0x33, 0xae, 0xef, 0xb6, 0xa9, 0xfe, 0x44, 0x5f, 0xc3, 0xa6, 0x84, 0x64, 0x32, 0x25, 0x33,
-	0x4c, 0xed, 0xc6, 0x9e, 0x35, 0xe8, 0x1e, 0xdc, 0x91, 0xa6, 0x34, 0xfc, 0x63, 0x09, 0xda, 0x88,
-	0xcc, 0xb0, 0xdb, 0x91, 0xea, 0xfc, 0x9b, 0xa2, 0xbb, 0xd0, 0x0e, 0xbd, 0x05, 0xa6, 0x4b, 0x6f,
-	0x8a, 0xed, 0xa6, 0x88, 0xf0, 0x6a, 0xc3, 0x09, 0xa1, 0xa5, 0x9d, 0x3b, 0x8f, 0xa1, 0x21, 0x53,
-	0x43, 0x1d, 0x68, 0xbe, 0x38, 0xfb, 0xfe, 0xec, 0xd9, 0x8f, 0x67, 0xbd, 0x0d, 0xd4, 0x82, 0xda,
-	0xd9, 0xe1, 0x0f, 0x47, 0xbd, 0x0a, 0xba, 0x09, 0x5b, 0xa7, 0x87, 0xe3, 0xe7, 0x13, 0xf7, 0xe8,
-	0xf4, 0xe8, 0x70, 0x7c, 0xf4, 0xa4, 0x57, 0x45, 0x5d, 0x80, 0xd1, 0xc9, 0xa1, 0xfb, 0x7c, 0x22,
-	0x54, 0x2c, 0xe7, 0x03, 0x68, 0x27, 0x39, 0xa0, 0x26, 0x58, 0x87, 0xe3, 0x91, 0x34, 0xf1, 0xe4,
-	0x68, 0x3c, 0xea, 0x55, 0x9c, 0xdf, 0x2b, 0xb0, 0x6d, 0x52, 0x46, 0x97, 0x24, 0xa4, 0x98, 0x73,
-	0x36, 0x25, 0x71, 0x98, 0x70, 0x26, 0x16, 0x08, 0x41, 0x2d, 0xc4, 0x6f, 0x35, 0x63, 0xe2, 0x9b,
-	0x6b, 0x32, 0xc2, 0xbc, 0x40, 0xb0, 0x65, 0xb9, 0x72, 0x81, 0x3e, 0x87, 0x96, 0x82, 0x82, 0xda,
-	0xb5, 0x3d, 0x6b, 0xd0, 0x39, 0xb8, 0x6d, 0x02, 0xa4, 0x3c, 0xba, 0x89, 0x9a, 0x73, 0x0c, 0xbb,
-	0xc7, 0x58, 0x47, 0x22, 0xf1, 0xd3, 0x37, 0x88, 0xfb, 0xf5, 0x16, 0x58, 0x04, 0xc3, 0xfd, 0x7a,
-	0x0b, 0x8c, 0x6c, 0x68, 0xaa, 0xeb, 0x27, 0xc2, 0xa9, 0xbb, 0x7a, 0xe9, 0x30, 0xb0, 0x57, 0x0d,
-	0xa9, 0xbc, 0xf2, 0x2c, 0xdd, 0x83, 0x1a, 0xaf, 0x0c, 0x61, 0xa6, 0x73, 0x80, 0xcc, 0x38, 0x9f,
-	0x86, 0x73, 0xe2, 0x0a, 0xb9, 0x49, 0x9d, 0x95, 0xa5, 0xee, 0x24, 0xed, 0x75, 0x44, 0x42, 0x86,
-	0x43, 0x76, 0xbd, 0xf8, 0x4f, 0xe1, 0x4e, 0x8e, 0x25, 0x95, 0xc0, 0x3e, 0x34, 0x55, 0x68, 0xc2,
-	0x5a, 0x21, 0xae, 0x5a, 0xcb, 0xf9, 0xc7, 0x82, 0xed, 0x17, 0xcb, 0x99, 0xc7, 0xb0, 0x16, 0xad,
-	0x09, 0xea, 0x3e, 0xd4, 0x45, 0x87, 0x51, 0x58, 0xdc, 0x94, 0xb6, 0x65, 0x1b, 0x1a, 0xf1, 0xbf,
-	0xae, 0x94, 0xa3, 0x87, 0xd0, 0xb8, 0xf0, 0x82, 0x18, 0x53, 0x01, 0x44, 0x82, 0x9a, 0xd2, 0x14,
-	0xed, 0xc9, 0x55, 0x1a, 0x68, 0x17, 0x9a, 0xb3, 0xe8, 0x92, 0xf7, 0x17, 0x51, 0x92, 0x2d, 0xb7,
-	0x31, 0x8b, 0x2e, 0xdd, 0x38, 0x44, 0x1f, 0xc1, 0xd6, 0xcc, 0xa7, 0xde, 0x79, 0x80, 0x27, 0xaf,
-	0x09, 0x79, 0x43, 0x45, 0x55, 0xb6, 0xdc, 0x4d, 0xb5, 0x79, 0xc2, 0xf7, 0x50, 0x9f, 0xdf, 0xa4,
-	0x69, 0x84, 0x3d, 0x86, 0xed, 0x86, 0x90, 0x27, 0x6b, 0x8e, 0x21, 0xf3, 0x17, 0x98, 0xc4, 0x4c,
-	0x94, 0x92, 0xe5, 0xea, 0x25, 0xfa, 0x10, 0x36, 0x23, 0x4c, 0x31, 0x9b, 0xa8, 0x28, 0x5b, 0xe2,
-	0x64, 0x47, 0xec, 0xbd, 0x94, 0x61, 0x21, 0xa8, 0xfd, 0xe6, 0xf9, 0xcc, 0x6e, 0x0b, 0x91, 0xf8,
-	0x96, 0xc7, 0x62, 0x8a, 0xf5, 0x31, 0xd0, 0xc7, 0x62, 0x8a, 0xd5, 0xb1, 0x6d, 0xa8, 0xcf, 0x49,
-	0x34, 0xc5, 0x76, 0x47, 0xc8, 0xe4, 0x02, 0xed, 0x41, 0x67, 0x86, 0xe9, 0x34, 0xf2, 0x97, 0x8c,
-	0x33, 0xba, 0x29, 0x30, 0x4d, 0x6f, 0xf1, 0x3c, 0x68, 0x7c, 0x7e, 0x46, 0x18, 0xa6, 0xf6, 0x96,
-	0xcc, 0x43, 0xaf, 0xd1, 0x3d, 0xb8, 0x31, 0x0d, 0xb0, 0x17, 0xc6, 0xcb, 0x09, 0x09, 0x27, 0x73,
-	0xcf, 0x0f, 0xec, 0xae, 0x50, 0xd9, 0x52, 0xdb, 0xcf, 0xc2, 0x6f, 0x3d, 0x3f, 0x70, 0x4e, 0xe0,
-	0x76, 0x86, 0xca, 0xeb, 0xde, 0x8a, 0xbf, 0xaa, 0xb0, 0xe3, 0x92, 0x20, 0x38, 0xf7, 0xa6, 0x6f,
-	0x4a, 0xdc, 0x8b, 0x14, 0x85, 0xd5, 0xf5, 0x14, 0x5a, 0x39, 0x14, 0xa6, 0xae, 0x7a, 0xcd, 0xb8,
-	0xea, 0x06, 0xb9, 0xf5, 0x62, 0x72, 0x1b, 0x26, 0xb9, 0x9a, 0xb9, 0x66, 0x8a, 0xb9, 0x84, 0x96,
-	0xd6, 0x1a, 0x5a, 0xda, 0xab, 0xb4, 0xe4, 0x40, 0x0f, 0x79, 0xd0, 0x7f, 0x07, 0xbb, 0x2b, 0x78,
-	0x5d, 0x17, 0xfc, 0x3f, 0x2c, 0xb8, 0xfd, 0x34, 0xa4, 0xcc, 0x0b, 0x82, 0x0c, 0xf6, 0x49, 0xfd,
-	0x55, 0x4a, 0xd7, 0x5f, 0xf5, 0xff, 0xd4, 0x9f, 0x65, 0x90, 0xa7, 0x99, 0xae, 0xa5, 0x98, 0x2e,
-	0x55, 0x93, 0x46, 0x27, 0x6c, 0x64, 0x3a, 0x21, 0x7a, 0x1f, 0x40, 0x16, 0x91, 0x30, 0x2e, 0x49,
-	0x6a, 0x8b, 0x9d, 0x33, 0xd5, 0xf8, 0x34, 0xaf, 0xad, 0x7c, 0x5e, 0xd3, 0x15, 0x39, 0x80, 0x9e,
-	0x8e, 0x67, 0x1a, 0xcd, 0x44, 0x4c, 0x8a, 0xa0, 0xae, 0xda, 0x1f, 0x45, 0x33, 0x1e, 0x55, 0x96,
-	0xeb, 0xce, 0xfa, 0x12, 0xdc, 0x34, 0x4b, 0xd0, 0x79, 0x0a, 0x3b, 0x59, 0x4a, 0xae, 0x4b, 0xef,
-	0x9f, 0x15, 0xd8, 0x7d, 0x11, 0xfa, 0xb9, 0x04, 0xe7, 0x15, 0xd7, 0x0a, 0xe4, 0xd5, 0x1c, 0xc8,
-	0xb7, 0xa1, 0xbe, 0x8c, 0xa3, 0x57, 0x58, 0x51, 0x28, 0x17, 0x69, 0x2c, 0x6b, 0x26, 0x96, 0x19,
-	0x34, 0xea, 0x2b, 0x68, 0x38, 0x13, 0xb0, 0x57, 0xa3, 0xbc, 0x66, 0xce, 0x3c, 0xaf, 0xe4, 0x0d,
-	0x6d, 0xcb, 0xf7, 0xd2, 0xb9, 0x05, 0x37, 0x8f, 0x31, 0x7b, 0x29, 0x4b, 0x5d, 0x01, 0xe0, 0x1c,
-	0x01, 0x4a, 0x6f, 0x5e, 0xf9, 0x53, 0x5b, 0xa6, 0x3f, 0x3d, 0x60, 0x6a, 0x7d, 0xad, 0xe5, 0x7c,
-	0x25, 0x6c, 0x9f, 0xf8, 0x94, 0x91, 0xe8, 0x72, 0x1d, 0xb8, 0x3d, 0xb0, 0x16, 0xde, 0x5b, 0xf5,
-	0xc4, 0xf2, 0x4f, 0xe7, 0x58, 0x44, 0x90, 0x1c, 0x55, 0x11, 0xa4, 0x07, 0x96, 0x4a, 0xb9, 0x81,
-	0xe5, 0x2d, 0xa0, 0xe7, 0x38, 0x99, 0x9d, 0xde, 0xf1, 0xd6, 0x6b, 0x9a, 0xaa, 0x26, 0x4d, 0x36,
-	0x34, 0x55, 0x9f, 0x51, 0xc4, 0xea, 0x25, 0xbf, 0xac, 0x4b, 0x2f, 0xf2, 0x82, 0x00, 0x07, 0xea,
-	0xd9, 0x4c, 0xd6, 0xce, 0xcf, 0x70, 0xcb, 0xf0, 0xac, 0x72, 0xe0, 0xb9, 0xd2, 0x57, 0xca, 0x33,
-	0xff, 0x44, 0x5f, 0x42, 0x43, 0x0e, 0x9f, 0xc2, 0x6f, 0xf7, 0xe0, 0xae, 0x99, 0x93, 0x30, 0x12,
-	0x87, 0x6a, 0x5a, 0x75, 0x95, 0xee, 0xc1, 0xbf, 0x2d, 0xe8, 0xea, 0xf1, 0x49, 0x8e, 0xc6, 0xc8,
-	0x87, 0xcd, 0xf4, 0x9c, 0x88, 0x1e, 0x14, 0x4f, 0xce, 0x99, 0xf1, 0xbf, 0xff, 0xb0, 0x8c, 0xaa,
-	0xcc, 0xc0, 0xd9, 0xf8, 0xac, 0x82, 0x28, 0xf4, 0xb2, 0xe3, 0x1b, 0x7a, 0x94, 0x6f, 0xa3, 0x60,
-	0x5e, 0xec, 0x0f, 0xcb, 0xaa, 0x6b, 0xb7, 0xe8, 0x42, 0xdc, 0x27, 0x73, 0xe6, 0x42, 0xef, 0x34,
-	0x63, 0x8e, 0x79, 0xfd, 0xfd, 0xd2, 0xfa, 0x89, 0xdf, 0x5f, 0x60, 0xcb, 0x78, 0xd1, 0x51, 0x01,
-	0x5a, 0x79, 0x13, 0x5c, 0xff, 0x93, 0x52, 0xba, 0x89, 0xaf, 0x05, 0x74, 0xcd, 0x16, 0x87, 0x0a,
-	0x0c, 0xe4, 0xbe, 0x4d, 0xfd, 0x4f, 0xcb, 0x29, 0x27, 0xee, 0x28, 0xf4, 0xb2, 0xfd, 0xa5, 0x88,
-	0xc7, 0x82, 0x6e, 0x59, 0xc4, 0x63, 0x51, 0xdb, 0x72, 0x36, 0x90, 0x07, 0x70, 0xd5, 0x5e, 0xd0,
-	0xfd, 0x42, 0x42, 0xcc, 0xae, 0xd4, 0x1f, 0xbc, 0x5b, 0x31, 0x71, 0xb1, 0x84, 0x1b, 0x99, 0x49,
-	0x00, 0x15, 0x40, 0x93, 0x3f, 0x60, 0xf5, 0x1f, 0x95, 0xd4, 0xce, 0x24, 0xa5, 0x3a, 0xd6, 0x9a,
-	0xa4, 0xcc, 0x76, 0xb8, 0x26, 0xa9, 0x4c, 0xf3, 0x73, 0x36, 0x90, 0x0f, 0x5d, 0x37, 0x0e, 0x95,
-	0x6b, 0xde, 0x16, 0x50, 0xc1, 0xe9, 0xd5, 0x8e, 0xd7, 0x7f, 0x50, 0x42, 0xf3, 0xaa, 0xbe, 0x1f,
-	0xc3, 0x4f, 0x2d, 0xad, 0x7a, 0xde, 0x10, 0xff, 0x39, 0xf8, 0xe2, 0xbf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x20, 0xcd, 0x9e, 0x3a, 0x27, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_ebf61cf17fd13715) }
+
+var fileDescriptor_tiller_ebf61cf17fd13715 = []byte{
+	// 2307 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xeb, 0x6e, 0xdb, 0xc8,
+	0x15, 0x8e, 0xee, 0xd2, 0x91, 0x25, 0xcb, 0x63, 0xd9, 0x66, 0x94, 0xdd, 0xae, 0xcb, 0x20, 0x1b,
+	0x6f, 0xd2, 0x75, 0x5a, 0x77, 0x7b, 0xd9, 0x6e, 0x5b, 0xc0, 0x51, 0x9c, 0xcb, 0x26, 0x6b, 0x07,
+	0x74, 0x92, 0x02, 0x5d, 0x14, 0x04, 0x4d, 0x8e, 0x6c, 0xae, 0x29, 0x8e, 0x3a, 0x33, 0xf2, 0x46,
+	0x40, 0xdf, 0xa3, 0x0f, 0xd1, 0x3f, 0xed, 0xaf, 0xbe, 0x4a, 0x0b, 0xf4, 0x0d, 0x0a, 0xf4, 0x57,
+	0x1f, 0xa0, 0x98, 0x1b, 0x4d, 0x52, 0x94, 0xad, 0x18, 0xfd, 0x63, 0x71, 0xce, 0x39, 0x33, 0x73,
+	0xe6, 0x9c, 0xef, 0x5c, 0x66, 0x0c, 0x83, 0x33, 0x6f, 0x12, 0x3e, 0x62, 0x98, 0x5e, 0x84, 0x3e,
+	0x66, 0x8f, 0x78, 0x18, 0x45, 0x98, 0xee, 0x4e, 0x28, 0xe1, 0x04, 0xf5, 0x05, 0x6f, 0xd7, 0xf0,
+	0x76, 0x15, 0x6f, 0xb0, 0x29, 0x67, 0xf8, 0x67, 0x1e, 0xe5, 0xea, 0xaf, 0x92, 0x1e, 0x6c, 0xa5,
+	0xe9, 0x24, 0x1e, 0x85, 0xa7, 0x9a, 0xa1, 0xb6, 0xa0, 0x38, 0xc2, 0x1e, 0xc3, 0xe6, 0x37, 0x33,
+	0xc9, 0xf0, 0xc2, 0x78, 0x44, 0x34, 0xe3, 0x4e, 0x86, 0xc1, 0x31, 0xe3, 0x2e, 0x9d, 0xc6, 0x9a,
+	0x79, 0x3b, 0xc3, 0x64, 0xdc, 0xe3, 0x53, 0x96, 0xd9, 0xec, 0x02, 0x53, 0x16, 0x92, 0xd8, 0xfc,
+	0x2a, 0x9e, 0xfd, 0xdf, 0x0a, 0xac, 0xbf, 0x0a, 0x19, 0x77, 0xd4, 0x44, 0xe6, 0xe0, 0x3f, 0x4e,
+	0x31, 0xe3, 0xa8, 0x0f, 0xb5, 0x28, 0x1c, 0x87, 0xdc, 0x2a, 0x6d, 0x97, 0x76, 0x2a, 0x8e, 0x1a,
+	0xa0, 0x4d, 0xa8, 0x93, 0xd1, 0x88, 0x61, 0x6e, 0x95, 0xb7, 0x4b, 0x3b, 0x2d, 0x47, 0x8f, 0xd0,
+	0x6f, 0xa1, 0xc1, 0x08, 0xe5, 0xee, 0xc9, 0xcc, 0xaa, 0x6c, 0x97, 0x76, 0xba, 0x7b, 0xf7, 0x76,
+	0x8b, 0xec, 0xb4, 0x2b, 0x76, 0x3a, 0x26, 0x94, 0xef, 0x8a, 0x3f, 0x8f, 0x67, 0x4e, 0x9d, 0xc9,
+	0x5f, 0xb1, 0xee, 0x28, 0x8c, 0x38, 0xa6, 0x56, 0x55, 0xad, 0xab, 0x46, 0xe8, 0x19, 0x80, 0x5c,
+	0x97, 0xd0, 0x00, 0x53, 0xab, 0x26, 0x97, 0xde, 0x59, 0x62, 0xe9, 0x23, 0x21, 0xef, 0xb4, 0x98,
+	0xf9, 0x44, 0xbf, 0x86, 0x15, 0x65, 0x12, 0xd7, 0x27, 0x01, 0x66, 0x56, 0x7d, 0xbb, 0xb2, 0xd3,
+	0xdd, 0xbb, 0xad, 0x96, 0x32, 0xe6, 0x3f, 0x56, 0x46, 0x1b, 0x92, 0x00, 0x3b, 0x6d, 0x25, 0x2e,
+	0xbe, 0x19, 0xfa, 0x08, 0x5a, 0xb1, 0x37, 0xc6, 0x6c, 0xe2, 0xf9, 0xd8, 0x6a, 0x48, 0x0d, 0x2f,
+	0x09, 0x68, 0x00, 0x4d, 0x86, 0x23, 0xec, 0x73, 0x42, 0xad, 0xa6, 0x64, 0x26, 0x63, 0x74, 0x0f,
+	0xba, 0x3e, 0x89, 0x79, 0x18, 0x4f, 0xb1, 0xcb, 0xc9, 0x39, 0x8e, 0xad, 0x96, 0x94, 0xe8, 0x18,
+	0xea, 0x1b, 0x41, 0x44, 0x1f, 0x03, 0x48, 0x90, 0xb8, 0x62, 0x55, 0x0b, 0xd4, 0x0e, 0x92, 0x72,
+	0xe8, 0x8d, 0x31, 0xba, 0x0b, 0x1d, 0xc5, 0xd6, 0xbe, 0xb3, 0xda, 0x52, 0x62, 0x45, 0x12, 0xdf,
+	0x29, 0x1a, 0x7a, 0x08, 0x6b, 0x4a, 0xc8, 0x8b, 0x63, 0xc2, 0x3d, 0x1e, 0x92, 0x98, 0x59, 0x2b,
+	0x52, 0xb0, 0x27, 0x19, 0xfb, 0x97, 0x74, 0xfb, 0x4f, 0xd0, 0x34, 0x06, 0xb3, 0x5f, 0x43, 0x5d,
+	0xb9, 0x03, 0xb5, 0xa1, 0xf1, 0xf6, 0xf0, 0xe5, 0xe1, 0xd1, 0xef, 0x0e, 0x7b, 0xb7, 0x50, 0x13,
+	0xaa, 0x87, 0xfb, 0xdf, 0x1c, 0xf4, 0x4a, 0x68, 0x0d, 0x3a, 0xaf, 0xf6, 0x8f, 0xdf, 0xb8, 0xce,
+	0xc1, 0xab, 0x83, 0xfd, 0xe3, 0x83, 0x27, 0xbd, 0x32, 0xea, 0x02, 0x0c, 0x9f, 0xef, 0x3b, 0x6f,
+	0x5c, 0x29, 0x52, 0x41, 0x2b, 0xd0, 0x74, 0x0e, 0xde, 0xbd, 0x38, 0x7e, 0x71, 0x74, 0xd8, 0xab,
+	0xda, 0x3f, 0x80, 0x56, 0xe2, 0x05, 0xd4, 0x80, 0xca, 0xfe, 0xf1, 0x50, 0x2d, 0xf8, 0xe4, 0xe0,
+	0x78, 0xd8, 0x2b, 0xd9, 0x7f, 0x2d, 0x41, 0x3f, 0x0b, 0x3a, 0x36, 0x21, 0x31, 0xc3, 0x02, 0x75,
+	0x3e, 0x99, 0xc6, 0x09, 0xea, 0xe4, 0x00, 0x21, 0xa8, 0xc6, 0xf8, 0xbd, 0xc1, 0x9c, 0xfc, 0x16,
+	0x92, 0x9c, 0x70, 0x2f, 0x92, 0x78, 0xab, 0x38, 0x6a, 0x80, 0x7e, 0x02, 0x4d, 0xed, 0x4c, 0x66,
+	0x55, 0xb7, 0x2b, 0x3b, 0xed, 0xbd, 0x8d, 0xac, 0x8b, 0xf5, 0x8e, 0x4e, 0x22, 0x56, 0xe0, 0xa1,
+	0x5a, 0x81, 0x87, 0xec, 0x67, 0xb0, 0xf5, 0x0c, 0x1b, 0x85, 0x15, 0x50, 0x4c, 0xa8, 0x08, 0xf5,
+	0x84, 0xdb, 0x4a, 0x5a, 0x3d, 0xe1, 0x31, 0x0b, 0x1a, 0xc6, 0x57, 0x42, 0xeb, 0x9a, 0x63, 0x86,
+	0xf6, 0x7f, 0x4a, 0x60, 0xcd, 0xaf, 0xa4, 0xcf, 0x5f, 0xb4, 0xd4, 0xa7, 0x50, 0x15, 0x39, 0x40,
+	0xae, 0xd3, 0xde, 0x43, 0xd9, 0xf3, 0xbc, 0x88, 0x47, 0xc4, 0x91, 0xfc, 0x2c, 0x48, 0x2b, 0x79,
+	0x90, 0x0a, 0xcb, 0x0a, 0x10, 0xe8, 0x00, 0x53, 0x83, 0x79, 0x60, 0xd5, 0x0a, 0x80, 0x75, 0x17,
+	0x3a, 0x17, 0x5e, 0x34, 0xc5, 0xcc, 0x0d, 0xc2, 0x53, 0xcc, 0xb8, 0x55, 0x57, 0x42, 0x8a, 0xf8,
+	0x44, 0xd2, 0xd2, 0x07, 0x6e, 0x64, 0x0f, 0xfc, 0x3c, 0x7d, 0xde, 0x21, 0x89, 0x39, 0x8e, 0xf9,
+	0xcd, 0x4c, 0xf7, 0x0a, 0x6e, 0x17, 0xac, 0xa4, 0x4d, 0xf7, 0x08, 0x1a, 0xda, 0x28, 0x72, 0xb5,
+	0x85, 0x9e, 0x37, 0x52, 0xf6, 0xdf, 0x1a, 0xd0, 0x7f, 0x3b, 0x09, 0x3c, 0x8e, 0x0d, 0xeb, 0x0a,
+	0xa5, 0xee, 0x1b, 0xf3, 0x29, 0x2f, 0xac, 0xa9, 0xb5, 0x55, 0xaa, 0x1f, 0x8a, 0xbf, 0xc6, 0xa2,
+	0x0f, 0xa0, 0xae, 0xec, 0x22, 0x5d, 0x90, 0xf8, 0x4b, 0x4b, 0xca, 0x12, 0xe0, 0x68, 0x09, 0xb4,
+	0x05, 0x8d, 0x80, 0xce, 0x44, 0x0e, 0x97, 0x5e, 0x69, 0x3a, 0xf5, 0x80, 0xce, 0x9c, 0xa9, 0xb4,
+	0x78, 0x10, 0x32, 0xef, 0x24, 0xc2, 0xee, 0x19, 0x21, 0xe7, 0x4c, 0xba, 0xa5, 0xe9, 0xac, 0x68,
+	0xe2, 0x73, 0x41, 0x13, 0x69, 0x87, 0x62, 0x9f, 0x62, 0x8f, 0x63, 0xe9, 0x91, 0xa6, 0x93, 0x8c,
+	0x85, 0x0d, 0x79, 0x38, 0xc6, 0x64, 0xca, 0xa5, 0x37, 0x2a, 0x8e, 0x19, 0xa2, 0x1f, 0xc2, 0x0a,
+	0xc5, 0x0c, 0x73, 0x57, 0x6b, 0xd9, 0x94, 0x33, 0xdb, 0x92, 0xf6, 0x4e, 0xa9, 0x85, 0xa0, 0xfa,
+	0xbd, 0x17, 0x72, 0x99, 0xa9, 0x9a, 0x8e, 0xfc, 0x56, 0xd3, 0xa6, 0x0c, 0x9b, 0x69, 0x60, 0xa6,
+	0x4d, 0x19, 0xd6, 0xd3, 0xfa, 0x50, 0x1b, 0x11, 0xea, 0x63, 0x99, 0x9c, 0x9a, 0x8e, 0x1a, 0xa0,
+	0x6d, 0x68, 0x07, 0x98, 0xf9, 0x34, 0x9c, 0x88, 0xc4, 0xa3, 0xf3, 0x51, 0x9a, 0x24, 0xd3, 0xe7,
+	0xf4, 0xe4, 0x90, 0x70, 0xcc, 0xac, 0x8e, 0x3a, 0x87, 0x19, 0xa3, 0x4f, 0x61, 0xd5, 0x8f, 0xb0,
+	0x17, 0x4f, 0x27, 0x2e, 0x89, 0xdd, 0x91, 0x17, 0x46, 0x56, 0x57, 0x8a, 0x74, 0x34, 0xf9, 0x28,
+	0x7e, 0xea, 0x85, 0x11, 0xb2, 0xa1, 0x23, 0xd4, 0x74, 0x47, 0x84, 0xba, 0xdf, 0x91, 0x13, 0x66,
+	0xad, 0x2a, 0xfd, 0x04, 0xf1, 0x29, 0xa1, 0x5f, 0x93, 0x13, 0x86, 0x3e, 0x81, 0xf6, 0xd8, 0x7b,
+	0xef, 0x9e, 0x85, 0x8c, 0x13, 0x3a, 0xb3, 0x7a, 0x12, 0x5b, 0x30, 0xf6, 0xde, 0x3f, 0x57, 0x14,
+	0xa1, 0xc8, 0x85, 0x17, 0x85, 0x02, 0x11, 0xd6, 0x9a, 0x52, 0xc4, 0x8c, 0xd1, 0x17, 0xb0, 0x39,
+	0x21, 0xa2, 0xde, 0xe2, 0x38, 0xc0, 0x14, 0x07, 0xee, 0xd8, 0x8b, 0xc3, 0x91, 0x08, 0x06, 0x24,
+	0x4f, 0xd4, 0x17, 0x5c, 0x47, 0x33, 0xbf, 0xd1, 0x3c, 0x74, 0x07, 0x5a, 0xec, 0x3c, 0x9c, 0xb8,
+	0x3e, 0x0d, 0x98, 0xb5, 0xae, 0xcf, 0x76, 0x1e, 0x4e, 0x86, 0x34, 0x60, 0xe8, 0x67, 0xb0, 0xa5,
+	0x3c, 0xc1, 0xcf, 0x70, 0xec, 0x66, 0xac, 0xdb, 0x97, 0xa2, 0x7d, 0xc9, 0x7e, 0x73, 0x86, 0x63,
+	0x27, 0x65, 0xe6, 0x7b, 0xd0, 0x95, 0x96, 0x75, 0x13, 0xe7, 0x6f, 0x28, 0x8b, 0x48, 0xaa, 0x63,
+	0x10, 0xf0, 0x89, 0xb0, 0xfb, 0x24, 0x22, 0x33, 0x1c, 0x88, 0xaa, 0xbc, 0x29, 0xb5, 0x04, 0x43,
+	0x7a, 0x3c, 0x43, 0x0f, 0x60, 0xcd, 0xac, 0xe0, 0x4e, 0x48, 0xc0, 0x84, 0xed, 0xac, 0xad, 0xed,
+	0xca, 0x4e, 0xcb, 0x59, 0x35, 0x8c, 0xd7, 0x24, 0x60, 0x4f, 0x09, 0x15, 0xe5, 0x99, 0x71, 0x1a,
+	0xfa, 0xdc, 0xb2, 0x14, 0x4e, 0xd5, 0x48, 0xe8, 0x22, 0xbe, 0x26, 0xae, 0x4f, 0xc6, 0x63, 0x1c,
+	0x73, 0x66, 0xdd, 0x56, 0xba, 0x48, 0xea, 0x50, 0x13, 0xd1, 0x7d, 0x58, 0xf5, 0xcf, 0xb0, 0x7f,
+	0x9e, 0xa8, 0x1c, 0x58, 0x03, 0x29, 0xd7, 0x95, 0x64, 0xa3, 0x73, 0x20, 0x5d, 0x14, 0x32, 0x16,
+	0xc6, 0xa7, 0xee, 0x39, 0x9e, 0x59, 0x77, 0x94, 0xd2, 0x9a, 0xf4, 0x12, 0xcf, 0xec, 0x19, 0x6c,
+	0xe4, 0x42, 0xf6, 0x86, 0xd1, 0x8f, 0x1e, 0xc1, 0x7a, 0xa2, 0x8d, 0x4b, 0x31, 0x23, 0x53, 0xea,
+	0x63, 0x66, 0x95, 0xa5, 0x01, 0x50, 0xc2, 0x72, 0x0c, 0xc7, 0xfe, 0x57, 0x05, 0x36, 0x1d, 0x12,
+	0x45, 0x27, 0x9e, 0xd0, 0xf8, 0xda, 0x84, 0x91, 0x8a, 0xed, 0xf2, 0xd5, 0xb1, 0x5d, 0x29, 0x88,
+	0xed, 0x54, 0x0e, 0xac, 0x66, 0x72, 0x60, 0x26, 0xea, 0x6b, 0x8b, 0xa3, 0xbe, 0x9e, 0x8d, 0x7a,
+	0x13, 0xd2, 0x8d, 0x54, 0x48, 0x27, 0xf1, 0xda, 0xbc, 0x22, 0x5e, 0x5b, 0xf3, 0xf1, 0x5a, 0x10,
+	0x93, 0x50, 0x14, 0x93, 0xf3, 0x40, 0x6d, 0x2f, 0x01, 0xd4, 0x95, 0x39, 0xa0, 0xce, 0xc5, 0x76,
+	0x67, 0x3e, 0xb6, 0xfb, 0x50, 0x9b, 0xd0, 0x69, 0x8c, 0x75, 0x76, 0x50, 0x83, 0x62, 0x88, 0xaf,
+	0x16, 0x42, 0xdc, 0xfe, 0x1a, 0xb6, 0xe6, 0xbc, 0x7b, 0xd3, 0xca, 0xf2, 0x8f, 0x3a, 0x6c, 0xbc,
+	0x88, 0x19, 0xf7, 0xa2, 0x28, 0x87, 0x94, 0xa4, 0x8c, 0x94, 0x96, 0x2e, 0x23, 0xe5, 0x0f, 0x29,
+	0x23, 0x95, 0x0c, 0xd4, 0x0c, 0x2e, 0xab, 0x29, 0x5c, 0x2e, 0x55, 0x5a, 0x32, 0xad, 0x44, 0x3d,
+	0xdf, 0x4a, 0x7c, 0x0c, 0xa0, 0xb2, 0x95, 0x5c, 0x5c, 0x41, 0xaa, 0x25, 0x29, 0x87, 0xba, 0x7e,
+	0x1b, 0x14, 0x36, 0x8b, 0x51, 0x98, 0x2e, 0x2c, 0x3b, 0xd0, 0x33, 0xfa, 0xf8, 0x34, 0x90, 0x3a,
+	0x69, 0x38, 0x75, 0x35, 0x7d, 0x48, 0x03, 0xa1, 0x55, 0x1e, 0x99, 0xed, 0xab, 0x2b, 0xc9, 0x4a,
+	0xae, 0x92, 0x2c, 0x83, 0xa2, 0x74, 0x01, 0xe8, 0x2e, 0x5d, 0x00, 0x56, 0x97, 0x2d, 0x00, 0xbd,
+	0x5c, 0x01, 0xb8, 0x07, 0x5d, 0xee, 0x9d, 0x63, 0x97, 0x7c, 0x1f, 0x63, 0xca, 0xce, 0xc2, 0x89,
+	0xae, 0x3a, 0x1d, 0x41, 0x3d, 0x32, 0x44, 0x74, 0x04, 0xf5, 0xc8, 0x3b, 0xc1, 0x11, 0xb3, 0x90,
+	0xec, 0x68, 0x7f, 0x51, 0x7c, 0xff, 0x29, 0x04, 0xdc, 0xee, 0x2b, 0x39, 0xf3, 0x20, 0xe6, 0x74,
+	0xe6, 0xe8, 0x65, 0xf2, 0x11, 0xb7, 0x3e, 0x17, 0x71, 0x97, 0xe9, 0xbe, 0x7f, 0x4d, 0xba, 0xdf,
+	0x28, 0x4a, 0xf7, 0xb9, 0x2c, 0xbe, 0x99, 0xcf, 0xe2, 0x83, 0x2f, 0xa1, 0x9d, 0xd2, 0x0b, 0xf5,
+	0xa0, 0x22, 0xe4, 0x54, 0xf6, 0x14, 0x9f, 0x22, 0x9c, 0x25, 0xb6, 0x75, 0xc7, 0xaf, 0x06, 0xbf,
+	0x2a, 0xff, 0xb2, 0x64, 0xbf, 0x80, 0xcd, 0xfc, 0x41, 0x6f, 0xdc, 0xff, 0x95, 0x61, 0xeb, 0x6d,
+	0x1c, 0x16, 0xc6, 0x69, 0x51, 0x46, 0x9f, 0x8b, 0x9c, 0x72, 0x41, 0xe4, 0x88, 0x44, 0x34, 0xa5,
+	0xa7, 0x58, 0x47, 0xa2, 0x1a, 0xa4, 0x43, 0xa2, 0x9a, 0x0d, 0x89, 0x1c, 0xa8, 0x6b, 0xf3, 0xa0,
+	0x36, 0x41, 0x53, 0x4f, 0x05, 0x8d, 0x05, 0x0d, 0xdf, 0x63, 0xbe, 0x17, 0x98, 0xdb, 0xa8, 0x19,
+	0x8a, 0x52, 0xab, 0x92, 0xae, 0xb8, 0xdd, 0x63, 0x5f, 0x94, 0x5a, 0x95, 0xde, 0x55, 0x2e, 0x7e,
+	0x6d, 0xa8, 0x02, 0xcf, 0xe1, 0x69, 0x4c, 0x28, 0x4e, 0x8a, 0x9f, 0x3b, 0x21, 0x51, 0xe8, 0xcf,
+	0x74, 0x74, 0xf6, 0x15, 0xd7, 0xd4, 0xbf, 0xd7, 0x92, 0x67, 0xff, 0xb9, 0x04, 0xd6, 0xbc, 0xcd,
+	0x6e, 0x5a, 0x83, 0x51, 0xea, 0x66, 0xd3, 0xd2, 0xb7, 0x98, 0x9f, 0x43, 0xf5, 0x1c, 0x4f, 0xb8,
+	0x55, 0x91, 0x58, 0xb7, 0x8b, 0xb1, 0xfe, 0x12, 0x4f, 0xb8, 0xd1, 0xcc, 0x91, 0xf2, 0xf6, 0x3a,
+	0xac, 0x3d, 0xc3, 0xe6, 0xca, 0xa2, 0xdd, 0x68, 0x1f, 0x00, 0x4a, 0x13, 0x2f, 0xf5, 0xd4, 0xa4,
+	0xac, 0x9e, 0xe6, 0x61, 0xc4, 0xc8, 0x1b, 0x29, 0xfb, 0x4b, 0xb9, 0xb6, 0x6e, 0x13, 0xaf, 0x82,
+	0x48, 0x0f, 0x2a, 0x63, 0xef, 0xbd, 0xbe, 0xb6, 0x88, 0x4f, 0xfb, 0x99, 0xd4, 0x20, 0x99, 0xaa,
+	0x35, 0x48, 0x5f, 0x53, 0x4b, 0x4b, 0x5d, 0x53, 0xed, 0xf7, 0x80, 0xde, 0xe0, 0xe4, 0xc6, 0x7c,
+	0xcd, 0xfd, 0xc9, 0x80, 0xad, 0x9c, 0x05, 0x9b, 0x80, 0x8d, 0x2a, 0xd1, 0x1a, 0x9e, 0x66, 0x28,
+	0x32, 0xdf, 0xc4, 0xa3, 0x5e, 0x14, 0xe1, 0x48, 0x5f, 0x45, 0x92, 0xb1, 0xfd, 0x07, 0x58, 0xcf,
+	0xec, 0xac, 0xcf, 0x20, 0xce, 0xca, 0x4e, 0x4d, 0xd4, 0x8e, 0xd9, 0x29, 0xfa, 0x42, 0xa4, 0x0d,
+	0x71, 0x9d, 0x95, 0xfb, 0x76, 0xf7, 0x3e, 0xca, 0x9e, 0x49, 0x2e, 0x32, 0x8d, 0xf5, 0x2b, 0x8b,
+	0xa3, 0x65, 0xed, 0x6f, 0xd3, 0x17, 0xeb, 0x27, 0x98, 0x7b, 0x61, 0x74, 0xa3, 0xdb, 0xa1, 0x90,
+	0x0e, 0xc2, 0xd1, 0x48, 0x1f, 0x4d, 0x7e, 0xdb, 0x7f, 0xc9, 0x5c, 0xb6, 0xcd, 0xea, 0xfa, 0x04,
+	0xf7, 0xa0, 0x9b, 0x60, 0xff, 0xf2, 0xd5, 0xa1, 0xe6, 0x74, 0x0c, 0x75, 0x28, 0x5f, 0x1f, 0x1e,
+	0xc2, 0x5a, 0x40, 0xc3, 0x51, 0x51, 0x9f, 0xd8, 0xd3, 0x8c, 0xa4, 0x4b, 0x44, 0x5f, 0x41, 0x5d,
+	0xd2, 0x98, 0x06, 0xf0, 0xdd, 0x62, 0x00, 0x9b, 0x09, 0x4f, 0x84, 0xac, 0xa3, 0xa7, 0xd8, 0xdf,
+	0x42, 0x27, 0xc3, 0x50, 0xcd, 0x9e, 0x22, 0x68, 0x23, 0x24, 0x63, 0xc1, 0x4b, 0x4a, 0x90, 0x0a,
+	0xa0, 0x64, 0x2c, 0x4c, 0x11, 0x85, 0x17, 0xe6, 0x15, 0x40, 0x7e, 0xdb, 0xff, 0x2e, 0xcf, 0x87,
+	0x6e, 0xf2, 0x84, 0x91, 0x7e, 0xc2, 0x2a, 0xe5, 0x9e, 0xb0, 0x2e, 0xdf, 0xe6, 0xca, 0x99, 0xb7,
+	0xb9, 0xa5, 0x1a, 0xd9, 0x24, 0x1f, 0x56, 0x17, 0xe4, 0xc3, 0xda, 0x95, 0xf9, 0xb0, 0xbe, 0x38,
+	0x1f, 0xa6, 0x5b, 0xd9, 0x54, 0x07, 0xd4, 0xcc, 0x74, 0x40, 0xa9, 0x44, 0xd9, 0xba, 0x36, 0x51,
+	0xc2, 0x07, 0x26, 0xca, 0xf6, 0x15, 0x89, 0xf2, 0x9f, 0x25, 0xb8, 0x5d, 0x60, 0xed, 0x1b, 0xc7,
+	0xff, 0xff, 0x33, 0x57, 0xa2, 0xdf, 0x40, 0x5d, 0xb4, 0xed, 0x38, 0xd0, 0x6f, 0x64, 0x0b, 0x1e,
+	0x6b, 0x9f, 0x4a, 0x99, 0xcb, 0x53, 0xe8, 0x49, 0xf6, 0x57, 0xb0, 0x9a, 0x63, 0x15, 0x46, 0x6a,
+	0x1f, 0x6a, 0x98, 0x52, 0x62, 0x60, 0xa3, 0x06, 0x76, 0x0c, 0x2b, 0x69, 0x8d, 0xc4, 0xcc, 0xf3,
+	0x30, 0x0e, 0xcc, 0x4c, 0xf1, 0x9d, 0xac, 0x56, 0x4e, 0xad, 0x76, 0xf5, 0xeb, 0x96, 0x75, 0x59,
+	0x7a, 0x54, 0xb3, 0x9b, 0x54, 0xf9, 0x03, 0xd8, 0xd0, 0xbe, 0x5c, 0x2e, 0x75, 0x6a, 0x38, 0xe8,
+	0xe2, 0x6e, 0x86, 0xa2, 0xef, 0xc8, 0x2f, 0x73, 0xc3, 0xaa, 0xb7, 0xf7, 0xf7, 0x36, 0x74, 0xcd,
+	0xeb, 0x9f, 0xb2, 0x38, 0x0a, 0x61, 0x25, 0xfd, 0x1c, 0x8a, 0x3e, 0x5b, 0xfc, 0xc4, 0x9d, 0x8b,
+	0xdc, 0xc1, 0x83, 0x65, 0x44, 0x95, 0xaa, 0xf6, 0xad, 0x1f, 0x97, 0x10, 0x83, 0x5e, 0xfe, 0xf5,
+	0x11, 0x7d, 0x5e, 0xbc, 0xc6, 0x82, 0xf7, 0xce, 0xc1, 0xee, 0xb2, 0xe2, 0x66, 0x5b, 0x74, 0x21,
+	0x0b, 0x68, 0xf6, 0xe1, 0x0e, 0x5d, 0xbb, 0x4c, 0xf6, 0xad, 0x70, 0xf0, 0x68, 0x69, 0xf9, 0x64,
+	0xdf, 0xef, 0xa0, 0x93, 0x79, 0x2e, 0x40, 0x0b, 0xac, 0x55, 0xf4, 0x0c, 0x38, 0x78, 0xb8, 0x94,
+	0x6c, 0xb2, 0xd7, 0x18, 0xba, 0xd9, 0xce, 0x14, 0x3d, 0xfc, 0x80, 0x46, 0x7d, 0xf0, 0xa3, 0xe5,
+	0x84, 0x93, 0xed, 0x18, 0xf4, 0xf2, 0xf9, 0x65, 0x91, 0x1f, 0x17, 0x34, 0xb9, 0x8b, 0xfc, 0xb8,
+	0xa8, 0xbf, 0xb3, 0x6f, 0x21, 0x0f, 0xe0, 0xb2, 0x9f, 0x42, 0xf7, 0x17, 0x3a, 0x24, 0xdb, 0x86,
+	0x0d, 0x76, 0xae, 0x17, 0x4c, 0xb6, 0x98, 0xc0, 0x6a, 0xee, 0x1e, 0x8e, 0x16, 0x98, 0xa6, 0xf8,
+	0x31, 0x66, 0xf0, 0xf9, 0x92, 0xd2, 0xb9, 0x43, 0x99, 0x47, 0xc0, 0xc5, 0x87, 0xca, 0xf6, 0x7f,
+	0x57, 0x1c, 0x2a, 0xd7, 0xed, 0xd9, 0xb7, 0x50, 0x08, 0x5d, 0x67, 0x1a, 0xeb, 0xad, 0x45, 0x1f,
+	0x84, 0x16, 0xcc, 0x9e, 0x6f, 0xf1, 0x06, 0x9f, 0x2d, 0x21, 0xb9, 0x28, 0xbe, 0x55, 0xc3, 0x73,
+	0x7d, 0x7c, 0x67, 0xda, 0xae, 0xeb, 0xe3, 0x3b, 0xdb, 0x47, 0xa9, 0xf8, 0x9e, 0x2b, 0x76, 0x68,
+	0x49, 0x78, 0xb1, 0x6b, 0xe2, 0x7b, 0x61, 0x15, 0x55, 0x31, 0x97, 0xcd, 0xca, 0x8b, 0x62, 0xae,
+	0xb0, 0x04, 0x2c, 0x8a, 0xb9, 0xe2, 0x44, 0x6f, 0xdf, 0x7a, 0x0c, 0xbf, 0x6f, 0x1a, 0xd9, 0x93,
+	0xba, 0xfc, 0xf7, 0xe9, 0x4f, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0x3c, 0x16, 0xb7, 0xeb, 0x2c,
+	0x1e, 0x00, 0x00,
 }
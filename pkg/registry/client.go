@@ -0,0 +1,302 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/helm/pkg/version"
+)
+
+const (
+	// ManifestMediaType is the media type of OCI image manifests.
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ChartLayerMediaType is the media type of the layer holding a chart archive.
+	ChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	// legacyChartLayerMediaType was used for chart layers by early registry
+	// support in Helm 3.
+	legacyChartLayerMediaType = "application/tar+gzip"
+)
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Client pulls charts from OCI registries.
+type Client struct {
+	// HTTPClient performs the requests. http.DefaultClient is used if nil.
+	HTTPClient *http.Client
+	// Credentials returns the credentials of a registry, if any.
+	Credentials Credentials
+	// CacheDir is where pulled chart archives are kept, by digest. Nothing is
+	// cached if it is empty.
+	CacheDir string
+
+	// tokens holds the bearer tokens issued for each repository.
+	tokens map[string]string
+}
+
+type manifest struct {
+	Layers []descriptor `json:"layers"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// Pull returns the chart archive that ref points to. The archive is read from
+// the cache when it was pulled before.
+func (c *Client) Pull(ref *Reference) ([]byte, error) {
+	if ref.Tag == "" {
+		return nil, fmt.Errorf("%s: a tag is required to pull a chart", ref)
+	}
+
+	body, err := c.get(ref, "manifests/"+ref.Tag, ManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("%s: invalid manifest: %s", ref, err)
+	}
+	layer, err := chartLayer(m)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ref, err)
+	}
+
+	if data, err := c.cached(layer.Digest); err == nil {
+		return data, nil
+	}
+
+	data, err := c.get(ref, "blobs/"+layer.Digest, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyDigest(data, layer.Digest); err != nil {
+		return nil, fmt.Errorf("%s: %s", ref, err)
+	}
+	if err := c.store(layer.Digest, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func chartLayer(m manifest) (descriptor, error) {
+	for _, l := range m.Layers {
+		if l.MediaType == ChartLayerMediaType || l.MediaType == legacyChartLayerMediaType {
+			return l, nil
+		}
+	}
+	return descriptor{}, errors.New("manifest does not contain a chart")
+}
+
+func verifyDigest(data []byte, digest string) error {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] != "sha256" {
+		return fmt.Errorf("unsupported digest %q", digest)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != parts[1] {
+		return fmt.Errorf("digest mismatch: expected %s", digest)
+	}
+	return nil
+}
+
+func (c *Client) cachePath(digest string) string {
+	return filepath.Join(c.CacheDir, strings.Replace(digest, ":", "-", 1)+".tgz")
+}
+
+func (c *Client) cached(digest string) ([]byte, error) {
+	if c.CacheDir == "" {
+		return nil, os.ErrNotExist
+	}
+	data, err := ioutil.ReadFile(c.cachePath(digest))
+	if err != nil {
+		return nil, err
+	}
+	// A corrupted cache entry is pulled again.
+	if err := verifyDigest(data, digest); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) store(digest string, data []byte) error {
+	if c.CacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.cachePath(digest), data, 0644)
+}
+
+// get fetches a path of the repository of ref through the distribution API,
+// authenticating if the registry asks for it.
+func (c *Client) get(ref *Reference, p, accept string) ([]byte, error) {
+	u := fmt.Sprintf("https://%s/v2/%s/%s", ref.Host, ref.Repository, p)
+	resp, err := c.do(ref, u, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		if err := c.authenticate(ref, resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, fmt.Errorf("%s: authentication failed: %s", ref, err)
+		}
+		if resp, err = c.do(ref, u, accept); err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (c *Client) do(ref *Reference, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Helm/"+strings.TrimPrefix(version.GetVersion(), "v"))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token, ok := c.tokens[ref.Host+"/"+ref.Repository]; ok {
+		if strings.HasPrefix(token, "Basic ") {
+			req.Header.Set("Authorization", token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return c.httpClient().Do(req)
+}
+
+// authenticate answers the challenge of a registry, remembering the
+// credentials or token to use for the repository of ref.
+func (c *Client) authenticate(ref *Reference, challenge string) error {
+	username, password, err := c.credentials(ref.Host)
+	if err != nil {
+		return err
+	}
+
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	switch scheme {
+	case "basic":
+		if username == "" {
+			return errors.New("credentials are required")
+		}
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.SetBasicAuth(username, password)
+		c.setToken(ref, req.Header.Get("Authorization"))
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication scheme %q", scheme)
+	}
+
+	params := map[string]string{}
+	for _, m := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("invalid realm %q", params["realm"])
+	}
+	q := realm.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.Repository + ":pull"
+	}
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("invalid token response: %s", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return errors.New("no token in token response")
+	}
+	c.setToken(ref, token.Token)
+	return nil
+}
+
+func (c *Client) setToken(ref *Reference, token string) {
+	if c.tokens == nil {
+		c.tokens = map[string]string{}
+	}
+	c.tokens[ref.Host+"/"+ref.Repository] = token
+}
+
+func (c *Client) credentials(host string) (string, string, error) {
+	if c.Credentials == nil {
+		return "", "", nil
+	}
+	// The Docker CLI keeps the credentials of Docker Hub under its index URL.
+	if host == DockerHubHost {
+		host = dockerHubAuthKey
+	}
+	return c.Credentials(host)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
@@ -0,0 +1,154 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestRegistry serves a single chart as charts/nginx:1.2.3, requiring a
+// bearer token obtained with the credentials user/secret.
+func newTestRegistry(t *testing.T, chart []byte, blobRequests *int) *httptest.Server {
+	sum := sha256.Sum256(chart)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got := r.URL.Query().Get("scope"); got != "repository:charts/nginx:pull" {
+			t.Errorf("unexpected scope %q", got)
+		}
+		fmt.Fprint(w, `{"token": "t0k3n"}`)
+	})
+	mux.HandleFunc("/v2/charts/nginx/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:charts/nginx:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/charts/nginx/manifests/1.2.3":
+			if r.Header.Get("Accept") != ManifestMediaType {
+				t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+			}
+			fmt.Fprintf(w, `{"schemaVersion": 2, "layers": [{"mediaType": %q, "digest": %q, "size": %d}]}`, ChartLayerMediaType, digest, len(chart))
+		case "/v2/charts/nginx/blobs/" + digest:
+			*blobRequests++
+			w.Write(chart)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	srv = httptest.NewTLSServer(mux)
+	return srv
+}
+
+func TestClientPull(t *testing.T) {
+	chart := []byte("not really a chart archive")
+	var blobRequests int
+	srv := newTestRegistry(t, chart, &blobRequests)
+	defer srv.Close()
+
+	cache, err := ioutil.TempDir("", "helm-registry-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cache)
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	c := &Client{
+		HTTPClient: srv.Client(),
+		Credentials: func(h string) (string, string, error) {
+			if h != host {
+				t.Errorf("unexpected credentials request for %q", h)
+			}
+			return "user", "secret", nil
+		},
+		CacheDir: cache,
+	}
+	ref := &Reference{Host: host, Repository: "charts/nginx", Tag: "1.2.3"}
+
+	for i := 0; i < 2; i++ {
+		data, err := c.Pull(ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, chart) {
+			t.Errorf("expected %q, got %q", chart, data)
+		}
+	}
+	if blobRequests != 1 {
+		t.Errorf("expected the chart to be pulled once and then read from the cache, got %d pulls", blobRequests)
+	}
+	if files, _ := filepath.Glob(filepath.Join(cache, "sha256-*.tgz")); len(files) != 1 {
+		t.Errorf("expected one cached chart, got %v", files)
+	}
+
+	if _, err := c.Pull(&Reference{Host: host, Repository: "charts/nginx", Tag: "9.9.9"}); err == nil {
+		t.Error("expected an error for a missing tag")
+	}
+
+	anonymous := &Client{HTTPClient: srv.Client()}
+	if _, err := anonymous.Pull(ref); err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+}
+
+func TestDockerConfigCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-registry-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	config := `{"auths": {"registry.example.com": {"auth": "dXNlcjpzZWNyZXQ="}}}`
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	creds := DockerConfigCredentials(path)
+	username, password, err := creds("registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if username != "user" || password != "secret" {
+		t.Errorf("expected user/secret, got %s/%s", username, password)
+	}
+	if username, _, err := creds("other.example.com"); err != nil || username != "" {
+		t.Errorf("expected no credentials for an unknown registry, got %q, %v", username, err)
+	}
+
+	missing := DockerConfigCredentials(filepath.Join(dir, "missing.json"))
+	if username, _, err := missing("registry.example.com"); err != nil || username != "" {
+		t.Errorf("expected no credentials without a config file, got %q, %v", username, err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/util/homedir"
+)
+
+// Credentials returns the user name and password to use for a registry host.
+// Empty values mean that the registry is accessed anonymously.
+type Credentials func(host string) (username, password string, err error)
+
+// dockerConfig is the part of the Docker CLI configuration file that holds
+// registry credentials.
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// DockerCredentials reads registry credentials the same way the Docker CLI
+// does: from the config.json file in $DOCKER_CONFIG or ~/.docker, using the
+// credential helper configured for the host, or the default credential
+// store, before falling back to the credentials stored in the file itself.
+func DockerCredentials() Credentials {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		dir = filepath.Join(homedir.HomeDir(), ".docker")
+	}
+	return DockerConfigCredentials(filepath.Join(dir, "config.json"))
+}
+
+// DockerConfigCredentials reads registry credentials from the Docker CLI
+// configuration file at path. A missing file means no credentials.
+func DockerConfigCredentials(path string) Credentials {
+	return func(host string) (string, string, error) {
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return "", "", nil
+		} else if err != nil {
+			return "", "", err
+		}
+		var cfg dockerConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return "", "", fmt.Errorf("could not parse %s: %s", path, err)
+		}
+
+		if helper, ok := cfg.CredHelpers[host]; ok {
+			return credentialHelper(helper, host)
+		}
+		if cfg.CredsStore != "" {
+			username, password, err := credentialHelper(cfg.CredsStore, host)
+			if err != nil || username != "" {
+				return username, password, err
+			}
+		}
+		if auth, ok := cfg.Auths[host]; ok && auth.Auth != "" {
+			return decodeAuth(auth.Auth)
+		}
+		return "", "", nil
+	}
+}
+
+func decodeAuth(auth string) (string, string, error) {
+	b, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid registry auth: %s", err)
+	}
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid registry auth: expected username:password")
+	}
+	return parts[0], parts[1], nil
+}
+
+// credentialHelper asks the docker-credential-<helper> program for the
+// credentials of host. A host unknown to the helper has no credentials.
+func credentialHelper(helper, host string) (string, string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(host)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(msg, "credentials not found") {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("credential helper %s failed: %s: %s", helper, err, msg)
+	}
+
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return "", "", fmt.Errorf("invalid output from credential helper %s: %s", helper, err)
+	}
+	return creds.Username, creds.Secret, nil
+}
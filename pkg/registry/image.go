@@ -0,0 +1,88 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// DockerHubHost is the registry host serving the images of Docker Hub.
+	DockerHubHost = "registry-1.docker.io"
+	// dockerHubAuthKey is the key of the Docker Hub credentials in the
+	// Docker CLI configuration.
+	dockerHubAuthKey = "https://index.docker.io/v1/"
+)
+
+// imageManifestMediaTypes are the manifests accepted when resolving the digest
+// of an image. Manifest lists and indexes are preferred, so that the digest
+// covers every platform of a multi-platform image.
+var imageManifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	ManifestMediaType,
+}
+
+// ParseImageReference parses a container image reference as found in pod
+// specs, such as nginx:1.17 or quay.io/prometheus/prometheus. Images without
+// a registry host are Docker Hub images, and images without a tag are tagged
+// latest. References that already have a digest are rejected.
+func ParseImageReference(image string) (*Reference, error) {
+	if strings.Contains(image, "@") {
+		return nil, fmt.Errorf("invalid image reference %q: already pinned to a digest", image)
+	}
+
+	r := &Reference{Host: DockerHubHost, Repository: image, Tag: "latest"}
+	if i := strings.Index(image, "/"); i > 0 {
+		// The first element is a host if it looks like one.
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			r.Host, r.Repository = host, image[i+1:]
+		}
+	}
+	if r.Host == "docker.io" || r.Host == "index.docker.io" {
+		r.Host = DockerHubHost
+	}
+
+	if j := strings.LastIndex(r.Repository, ":"); j > strings.LastIndex(r.Repository, "/") {
+		r.Repository, r.Tag = r.Repository[:j], r.Repository[j+1:]
+		if !tagRegexp.MatchString(r.Tag) {
+			return nil, fmt.Errorf("invalid image reference %q: invalid tag %q", image, r.Tag)
+		}
+	}
+	if r.Host == DockerHubHost && !strings.Contains(r.Repository, "/") {
+		r.Repository = "library/" + r.Repository
+	}
+	if !repositoryRegexp.MatchString(r.Repository) {
+		return nil, fmt.Errorf("invalid image reference %q: invalid repository %q", image, r.Repository)
+	}
+	return r, nil
+}
+
+// ImageDigest returns the digest of the manifest that the tag of ref points
+// to, as in sha256:0123...
+func (c *Client) ImageDigest(ref *Reference) (string, error) {
+	body, err := c.get(ref, "manifests/"+ref.Tag, strings.Join(imageManifestMediaTypes, ", "))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  Reference
+		err   bool
+	}{
+		{image: "nginx", want: Reference{Host: DockerHubHost, Repository: "library/nginx", Tag: "latest"}},
+		{image: "nginx:1.17", want: Reference{Host: DockerHubHost, Repository: "library/nginx", Tag: "1.17"}},
+		{image: "bitnami/redis:5.0", want: Reference{Host: DockerHubHost, Repository: "bitnami/redis", Tag: "5.0"}},
+		{image: "docker.io/nginx", want: Reference{Host: DockerHubHost, Repository: "library/nginx", Tag: "latest"}},
+		{image: "quay.io/prometheus/prometheus:v2.12.0", want: Reference{Host: "quay.io", Repository: "prometheus/prometheus", Tag: "v2.12.0"}},
+		{image: "localhost:5000/app", want: Reference{Host: "localhost:5000", Repository: "app", Tag: "latest"}},
+		{image: "nginx@sha256:0123", err: true},
+		{image: "Nginx", err: true},
+		{image: "nginx:", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseImageReference(tt.image)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", tt.image, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.image, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.image, tt.want, *got)
+		}
+	}
+}
+
+func TestClientImageDigest(t *testing.T) {
+	manifest := []byte(`{"schemaVersion": 2, "manifests": []}`)
+	sum := sha256.Sum256(manifest)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/apps/web/manifests/1.0" {
+			http.NotFound(w, r)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.list.v2+json") {
+			t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+		}
+		w.Write(manifest)
+	}))
+	defer srv.Close()
+
+	c := &Client{HTTPClient: srv.Client()}
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	digest, err := c.ImageDigest(&Reference{Host: host, Repository: "apps/web", Tag: "1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "sha256:" + hex.EncodeToString(sum[:]); digest != expected {
+		t.Errorf("expected %s, got %s", expected, digest)
+	}
+
+	if _, err := c.ImageDigest(&Reference{Host: host, Repository: "apps/web", Tag: "2.0"}); err == nil {
+		t.Error("expected an error for a missing tag")
+	}
+}
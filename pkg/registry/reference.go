@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry // import "k8s.io/helm/pkg/registry"
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Scheme is the URL scheme of chart references in OCI registries.
+const Scheme = "oci"
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+// Reference identifies a chart in an OCI registry, as in
+// oci://registry.example.com/charts/nginx:1.2.3.
+type Reference struct {
+	// Host is the registry host, with an optional port.
+	Host string
+	// Repository is the path of the chart in the registry.
+	Repository string
+	// Tag is the tag of the chart, which is usually its version.
+	Tag string
+}
+
+// ParseReference parses an oci:// chart reference. The tag is optional.
+func ParseReference(ref string) (*Reference, error) {
+	prefix := Scheme + "://"
+	if !strings.HasPrefix(ref, prefix) {
+		return nil, fmt.Errorf("invalid registry reference %q: must start with %s", ref, prefix)
+	}
+	rest := strings.TrimPrefix(ref, prefix)
+
+	i := strings.Index(rest, "/")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid registry reference %q: missing repository", ref)
+	}
+	r := &Reference{Host: rest[:i], Repository: rest[i+1:]}
+
+	if j := strings.LastIndex(r.Repository, ":"); j > strings.LastIndex(r.Repository, "/") {
+		r.Repository, r.Tag = r.Repository[:j], r.Repository[j+1:]
+		if !tagRegexp.MatchString(r.Tag) {
+			return nil, fmt.Errorf("invalid registry reference %q: invalid tag %q", ref, r.Tag)
+		}
+	}
+	if !repositoryRegexp.MatchString(r.Repository) {
+		return nil, fmt.Errorf("invalid registry reference %q: invalid repository %q", ref, r.Repository)
+	}
+	return r, nil
+}
+
+// Name returns the name of the chart, which is the last element of the
+// repository.
+func (r *Reference) Name() string {
+	return path.Base(r.Repository)
+}
+
+// String returns the reference in its oci:// form.
+func (r *Reference) String() string {
+	s := Scheme + "://" + r.Host + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	return s
+}
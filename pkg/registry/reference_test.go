@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref        string
+		host       string
+		repository string
+		tag        string
+		name       string
+		err        bool
+	}{
+		{ref: "oci://registry.example.com/charts/nginx:1.2.3", host: "registry.example.com", repository: "charts/nginx", tag: "1.2.3", name: "nginx"},
+		{ref: "oci://localhost:5000/nginx", host: "localhost:5000", repository: "nginx", name: "nginx"},
+		{ref: "oci://localhost:5000/nginx:0.1.0-rc.1_build.2", host: "localhost:5000", repository: "nginx", tag: "0.1.0-rc.1_build.2", name: "nginx"},
+		{ref: "https://registry.example.com/charts/nginx:1.2.3", err: true},
+		{ref: "oci://registry.example.com", err: true},
+		{ref: "oci://registry.example.com/Charts/nginx", err: true},
+		{ref: "oci://registry.example.com/charts/nginx:1.2.3+build", err: true},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseReference(tt.ref)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.ref, err)
+			continue
+		}
+		if r.Host != tt.host || r.Repository != tt.repository || r.Tag != tt.tag {
+			t.Errorf("%s: got host %q, repository %q, tag %q", tt.ref, r.Host, r.Repository, r.Tag)
+		}
+		if r.Name() != tt.name {
+			t.Errorf("%s: expected name %q, got %q", tt.ref, tt.name, r.Name())
+		}
+		if r.String() != tt.ref {
+			t.Errorf("expected %q, got %q", tt.ref, r.String())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"strings"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/provenance"
+)
+
+// ValuesDigest returns the SHA256 digest of the user-supplied values of a
+// release, as "sha256:<hex>", which tells whether they changed between two
+// revisions without showing them.
+func ValuesDigest(rel *rspb.Release) (string, error) {
+	digest, err := provenance.Digest(strings.NewReader(rel.GetConfig().GetRaw()))
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + digest, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestValuesDigest(t *testing.T) {
+	a, err := ValuesDigest(&rspb.Release{Config: &chart.Config{Raw: "name: value\n"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != "sha256:23dc9ee71eed1c3cca0ab3d080330cf24469fa0d57a2f1156ee3ceccb3ae25c7" {
+		t.Errorf("Expected the digest of the values, got %q", a)
+	}
+
+	b, err := ValuesDigest(&rspb.Release{Config: &chart.Config{Raw: "name: other\n"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("Expected different values to have different digests")
+	}
+
+	empty, err := ValuesDigest(&rspb.Release{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty != "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("Expected the digest of no values, got %q", empty)
+	}
+}
@@ -17,17 +17,26 @@ limitations under the License.
 package releaseutil
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/ghodss/yaml"
 )
 
+// SkipAnno is the annotation that leaves a rendered manifest out of its
+// release when it is set to "true".
+const SkipAnno = "helm.sh/skip"
+
 // SimpleHead defines what the structure of the head of a manifest file
 type SimpleHead struct {
 	Version  string `json:"apiVersion"`
 	Kind     string `json:"kind,omitempty"`
 	Metadata *struct {
 		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace,omitempty"`
 		Annotations map[string]string `json:"annotations"`
 	} `json:"metadata,omitempty"`
 }
@@ -57,3 +66,80 @@ func SplitManifests(bigFile string) map[string]string {
 	}
 	return res
 }
+
+// IsSkipped reports whether a manifest is annotated to be left out of its
+// release.
+func IsSkipped(head *SimpleHead) bool {
+	if head == nil || head.Metadata == nil {
+		return false
+	}
+	skip, err := strconv.ParseBool(strings.TrimSpace(head.Metadata.Annotations[SkipAnno]))
+	return err == nil && skip
+}
+
+// RemoveSkipped removes the documents of a YAML stream that are annotated
+// with SkipAnno, keeping the others in order. The stream is returned as it is
+// when no document is skipped.
+func RemoveSkipped(bigFile string) string {
+	docs := SplitManifests(bigFile)
+	kept := make([]string, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		var head SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil && IsSkipped(&head) {
+			continue
+		}
+		kept = append(kept, doc)
+	}
+	if len(kept) == len(docs) {
+		return bigFile
+	}
+	return strings.Join(kept, "\n---\n")
+}
+
+// blockScalarStart matches a line whose value is a literal or folded block
+// scalar, such as "script: |" or "- >-".
+var blockScalarStart = regexp.MustCompile(`(?:^|[:-])\s*[|>][-+0-9]*\s*(?:#.*)?$`)
+
+// StripComments removes the comments that take whole lines from a stream of
+// YAML documents, and the documents that are left with nothing else. The
+// lines of block scalars, such as a script in a ConfigMap, are kept even if
+// they start with '#', as are comments that follow a value on its line.
+func StripComments(bigFile string) string {
+	var docs []string
+	var doc bytes.Buffer
+	flush := func() {
+		if d := strings.TrimSpace(doc.String()); d != "" {
+			docs = append(docs, d)
+		}
+		doc.Reset()
+	}
+
+	// blockIndent is the indentation of the line that started the current
+	// block scalar, or -1 outside of block scalars.
+	blockIndent := -1
+	for _, line := range strings.Split(bigFile, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				doc.WriteString(line + "\n")
+				continue
+			}
+			blockIndent = -1
+		}
+
+		switch {
+		case line == "---" || strings.HasPrefix(line, "--- "):
+			flush()
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			continue
+		case blockScalarStart.MatchString(trimmed):
+			blockIndent = indent
+		}
+		doc.WriteString(line + "\n")
+	}
+	flush()
+	return strings.Join(docs, "\n---\n")
+}
@@ -59,3 +59,92 @@ func TestSplitManifest(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, manifests)
 	}
 }
+
+func TestRemoveSkipped(t *testing.T) {
+	stream := `apiVersion: v1
+kind: Service
+metadata:
+  name: kept
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: skipped
+  annotations:
+    helm.sh/skip: "true"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-skipped
+  annotations:
+    helm.sh/skip: "false"
+`
+	expected := `apiVersion: v1
+kind: Service
+metadata:
+  name: kept
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-skipped
+  annotations:
+    helm.sh/skip: "false"`
+
+	if got := RemoveSkipped(stream); got != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+	}
+	if got := RemoveSkipped(manifestFile); got != manifestFile {
+		t.Errorf("Expected a stream without skipped documents to be unchanged, got %q", got)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	stream := `# Source: fish/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: script # the name is kept
+  # labels are not set
+data:
+  run.sh: |
+    #!/bin/sh
+
+    # a comment of the script
+    echo hello
+  other: value
+---
+# Source: fish/templates/empty.yaml
+# nothing is rendered when disabled
+
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: kept
+`
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: script # the name is kept
+data:
+  run.sh: |
+    #!/bin/sh
+
+    # a comment of the script
+    echo hello
+  other: value
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: kept`
+
+	if got := StripComments(stream); got != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, got)
+	}
+	if got := StripComments("# only a comment\n---\n\n"); got != "" {
+		t.Errorf("Expected nothing to be left, got %q", got)
+	}
+}
@@ -18,6 +18,9 @@ package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
 import (
 	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 )
@@ -42,59 +45,93 @@ func Reverse(list []*rspb.Release, sortFn func([]*rspb.Release)) {
 // SortByName returns the list of releases sorted
 // in lexicographical order.
 func SortByName(list []*rspb.Release) {
-	s := &sorter{list: list}
-	s.less = func(i, j int) bool {
-		ni := s.list[i].Name
-		nj := s.list[j].Name
-		return ni < nj
-	}
-	sort.Sort(s)
+	sortBy(list, LessByName)
 }
 
 // SortByDate returns the list of releases sorted by a
-// release's last deployed time (in seconds).
+// release's last deployed time. Releases deployed at the
+// same time are sorted by name, so that the order is the
+// same from one listing to the next.
 func SortByDate(list []*rspb.Release) {
-	s := &sorter{list: list}
-
-	s.less = func(i, j int) bool {
-		ti := s.list[i].Info.LastDeployed.Seconds
-		tj := s.list[j].Info.LastDeployed.Seconds
-		return ti < tj
-	}
-	sort.Sort(s)
+	sortBy(list, LessByDate)
 }
 
 // SortByRevision returns the list of releases sorted by a
-// release's revision number (release.Version).
+// release's revision number (release.Version), then by name.
 func SortByRevision(list []*rspb.Release) {
-	s := &sorter{list: list}
-	s.less = func(i, j int) bool {
-		vi := s.list[i].Version
-		vj := s.list[j].Version
-		return vi < vj
-	}
-	sort.Sort(s)
+	sortBy(list, LessByRevision)
 }
 
 // SortByChartName sorts the list of releases by a
-// release's chart name in lexicographical order.
+// release's chart name in lexicographical order. Releases
+// of the same chart are sorted by chart version, then by
+// name.
 func SortByChartName(list []*rspb.Release) {
+	sortBy(list, LessByChartName)
+}
+
+func sortBy(list []*rspb.Release, less func(a, b *rspb.Release) bool) {
 	s := &sorter{list: list}
 	s.less = func(i, j int) bool {
-		chi := s.list[i].Chart
-		chj := s.list[j].Chart
+		return less(s.list[i], s.list[j])
+	}
+	sort.Sort(s)
+}
+
+// LessByName reports whether release a sorts before release b
+// in SortByName. Revisions of the same release are sorted by
+// revision number.
+func LessByName(a, b *rspb.Release) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	return a.Version < b.Version
+}
+
+// LessByDate reports whether release a sorts before release b
+// in SortByDate.
+func LessByDate(a, b *rspb.Release) bool {
+	ta := a.GetInfo().GetLastDeployed()
+	tb := b.GetInfo().GetLastDeployed()
+	if ta.GetSeconds() != tb.GetSeconds() {
+		return ta.GetSeconds() < tb.GetSeconds()
+	}
+	if ta.GetNanos() != tb.GetNanos() {
+		return ta.GetNanos() < tb.GetNanos()
+	}
+	return LessByName(a, b)
+}
 
-		ni := ""
-		if chi != nil && chi.Metadata != nil {
-			ni = chi.Metadata.Name
-		}
+// LessByRevision reports whether release a sorts before release b
+// in SortByRevision.
+func LessByRevision(a, b *rspb.Release) bool {
+	if a.Version != b.Version {
+		return a.Version < b.Version
+	}
+	return a.Name < b.Name
+}
 
-		nj := ""
-		if chj != nil && chj.Metadata != nil {
-			nj = chj.Metadata.Name
-		}
+// LessByChartName reports whether release a sorts before release b
+// in SortByChartName.
+func LessByChartName(a, b *rspb.Release) bool {
+	ma := a.GetChart().GetMetadata()
+	mb := b.GetChart().GetMetadata()
+	if na, nb := ma.GetName(), mb.GetName(); na != nb {
+		return na < nb
+	}
+	if c := compareVersions(ma.GetVersion(), mb.GetVersion()); c != 0 {
+		return c < 0
+	}
+	return LessByName(a, b)
+}
 
-		return ni < nj
+// compareVersions compares two chart versions as semantic
+// versions, or as strings if either is not one.
+func compareVersions(a, b string) int {
+	va, erra := semver.NewVersion(a)
+	vb, errb := semver.NewVersion(b)
+	if erra != nil || errb != nil {
+		return strings.Compare(a, b)
 	}
-	sort.Sort(s)
+	return va.Compare(vb)
 }
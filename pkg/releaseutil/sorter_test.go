@@ -17,6 +17,7 @@ limitations under the License.
 package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -96,3 +97,28 @@ func TestSortByChartName(t *testing.T) {
 		return ni < nj
 	})
 }
+
+func TestSortByChartNameVersion(t *testing.T) {
+	rels := []*rspb.Release{
+		tsRelease("web-b", 1, 0, rspb.Status_DEPLOYED),
+		tsRelease("web-a", 1, 0, rspb.Status_DEPLOYED),
+		tsRelease("web-c", 1, 0, rspb.Status_DEPLOYED),
+		tsRelease("db", 1, 0, rspb.Status_DEPLOYED),
+	}
+	versions := []string{"1.10.0", "1.10.0", "1.9.0", "2.0.0"}
+	for i, r := range rels {
+		r.Chart.Metadata.Name = "nginx"
+		r.Chart.Metadata.Version = versions[i]
+	}
+	rels[3].Chart.Metadata.Name = "mariadb"
+
+	SortByChartName(rels)
+
+	var names []string
+	for _, r := range rels {
+		names = append(names, r.Name)
+	}
+	if got := strings.Join(names, " "); got != "db web-c web-a web-b" {
+		t.Errorf("expected releases sorted by chart name, chart version and name, got %s", got)
+	}
+}
@@ -31,6 +31,25 @@ import (
 type Options struct {
 	ReleaseOptions chartutil.ReleaseOptions
 	KubeVersion    string
+	// APIVersions are added to the default API versions of Capabilities.
+	APIVersions []string
+	// Strict fails the rendering on references to values that are not
+	// defined. Charts can also ask for it in their metadata.
+	Strict bool
+	// MissingKey is what templates render for values that are not defined,
+	// one of the engine.MissingKey settings. If empty, the setting of the
+	// chart metadata is used.
+	MissingKey string
+	// WarnFunc receives the messages of the 'warn' template function.
+	WarnFunc func(msg string)
+	// MaxIncludeDepth limits the nested 'include' and 'tpl' calls, if set.
+	MaxIncludeDepth int
+	// AllowedEnv lists the environment variables the 'env' template function
+	// may read.
+	AllowedEnv []string
+	// ValuesFunc, if set, receives the values the chart is rendered with,
+	// once they are coalesced.
+	ValuesFunc func(vals chartutil.Values)
 }
 
 // Render chart templates locally and display the output.
@@ -43,6 +62,9 @@ type Options struct {
 // if you want the normal behavior of merging the defaults with the new config,
 // you should pass `&chart.Config{Raw: "{}"},
 func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]string, error) {
+	if err := chartutil.CheckInstallable(c); err != nil {
+		return nil, err
+	}
 	if req, err := chartutil.LoadRequirements(c); err == nil {
 		if err := CheckDependencies(c, req); err != nil {
 			return nil, err
@@ -62,11 +84,22 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 
 	// Set up engine.
 	renderer := engine.New()
+	renderer.Strict = opts.Strict || c.Metadata.Strict
+	renderer.MissingKey = opts.MissingKey
+	if renderer.MissingKey == "" {
+		renderer.MissingKey = c.Metadata.MissingKey
+	}
+	renderer.MaxIncludeDepth = opts.MaxIncludeDepth
+	renderer.WarnFunc = opts.WarnFunc
+	renderer.AllowedEnv = opts.AllowedEnv
 
+	// Copy the defaults, as they are shared with the rest of the process.
+	kubeVersion := *chartutil.DefaultKubeVersion
 	caps := &chartutil.Capabilities{
 		APIVersions:   chartutil.DefaultVersionSet,
-		KubeVersion:   chartutil.DefaultKubeVersion,
+		KubeVersion:   &kubeVersion,
 		TillerVersion: tversion.GetVersionProto(),
+		HelmVersion:   tversion.GetVersionProto(),
 	}
 
 	if opts.KubeVersion != "" {
@@ -76,13 +109,22 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 		}
 		caps.KubeVersion.Major = fmt.Sprint(kv.Major())
 		caps.KubeVersion.Minor = fmt.Sprint(kv.Minor())
-		caps.KubeVersion.GitVersion = fmt.Sprintf("v%d.%d.0", kv.Major(), kv.Minor())
+		caps.KubeVersion.GitVersion = fmt.Sprintf("v%d.%d.%d", kv.Major(), kv.Minor(), kv.Patch())
+	}
+	if len(opts.APIVersions) > 0 {
+		caps.APIVersions = chartutil.NewVersionSet(opts.APIVersions...)
+		for v := range chartutil.DefaultVersionSet {
+			caps.APIVersions[v] = struct{}{}
+		}
 	}
 
 	vals, err := chartutil.ToRenderValuesCaps(c, config, opts.ReleaseOptions, caps)
 	if err != nil {
 		return nil, err
 	}
+	if opts.ValuesFunc != nil {
+		opts.ValuesFunc(vals)
+	}
 
 	return renderer.Render(c, vals)
 }
@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	tversion "k8s.io/helm/pkg/version"
 )
 
 const cmTemplate = `kind: ConfigMap
@@ -151,3 +152,77 @@ data:
 		})
 	}
 }
+
+func TestRenderCapabilities(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/caps.txt", Data: []byte(`{{ .Capabilities.APIVersions.Has "monitoring.coreos.com/v1" }} {{ .Capabilities.APIVersions.Has "v1" }} {{ .Capabilities.KubeVersion.GitVersion }}`)},
+		},
+	}
+
+	got, err := Render(testChart, &chart.Config{Raw: "{}"}, Options{
+		KubeVersion: "1.15.3",
+		APIVersions: []string{"monitoring.coreos.com/v1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "true true v1.15.3", got["hello/templates/caps.txt"])
+
+	// The overrides do not leak into the defaults.
+	got, err = Render(testChart, &chart.Config{Raw: "{}"}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "false true v1.14.0", got["hello/templates/caps.txt"])
+}
+
+func TestRenderHelmVersion(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/version.txt", Data: []byte(`{{ .Capabilities.HelmVersion.SemVer }}`)},
+		},
+	}
+
+	got, err := Render(testChart, &chart.Config{Raw: "{}"}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, tversion.GetVersion(), got["hello/templates/version.txt"])
+}
+
+func TestRenderStrict(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/image.txt", Data: []byte(`image: {{ .Values.imageTag }}`)},
+		},
+	}
+
+	got, err := Render(testChart, &chart.Config{Raw: "{}"}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "image: ", got["hello/templates/image.txt"])
+
+	_, err = Render(testChart, &chart.Config{Raw: "{}"}, Options{Strict: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "imageTag")
+
+	// Charts can ask for it themselves.
+	testChart.Metadata.Strict = true
+	_, err = Render(testChart, &chart.Config{Raw: "{}"}, Options{})
+	require.Error(t, err)
+}
+
+func TestRenderMissingKey(t *testing.T) {
+	testChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello", MissingKey: "invalid"},
+		Templates: []*chart.Template{
+			{Name: "templates/image.txt", Data: []byte(`image: {{ .Values.imageTag }}`)},
+		},
+	}
+
+	got, err := Render(testChart, &chart.Config{Raw: "{}"}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "image: <no value>", got["hello/templates/image.txt"])
+
+	// The option of the user wins over the chart.
+	got, err = Render(testChart, &chart.Config{Raw: "{}"}, Options{MissingKey: "zero"})
+	require.NoError(t, err)
+	require.Equal(t, "image: ", got["hello/templates/image.txt"])
+}
@@ -18,6 +18,7 @@ package storage // import "k8s.io/helm/pkg/storage"
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
@@ -166,6 +167,13 @@ func (s *Storage) History(name string) ([]*rspb.Release, error) {
 	return s.Driver.Query(map[string]string{"NAME": name, "OWNER": "TILLER"})
 }
 
+// PruneHistory removes the oldest revisions of the named release until at most
+// max of them are left. The deployed revision is never removed, and superseded
+// revisions are removed before any others.
+func (s *Storage) PruneHistory(name string, max int) error {
+	return s.removeLeastRecent(name, max)
+}
+
 // removeLeastRecent removes items from history until the length number of releases
 // does not exceed max.
 //
@@ -191,19 +199,26 @@ func (s *Storage) removeLeastRecent(name string, max int) error {
 		return err
 	}
 
-	var toDelete []*rspb.Release
+	var candidates []*rspb.Release
 	for _, rel := range h {
+		if lastDeployed == nil || rel.GetVersion() != lastDeployed.GetVersion() {
+			candidates = append(candidates, rel)
+		}
+	}
+	// Superseded revisions are pruned before the ones that were never
+	// deployed successfully, which are more useful when debugging a release.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].GetInfo().GetStatus().GetCode() == rspb.Status_SUPERSEDED &&
+			candidates[j].GetInfo().GetStatus().GetCode() != rspb.Status_SUPERSEDED
+	})
+
+	var toDelete []*rspb.Release
+	for _, rel := range candidates {
 		// once we have enough releases to delete to reach the max, stop
 		if len(h)-len(toDelete) == max {
 			break
 		}
-		if lastDeployed != nil {
-			if rel.GetVersion() != lastDeployed.GetVersion() {
-				toDelete = append(toDelete, rel)
-			}
-		} else {
-			toDelete = append(toDelete, rel)
-		}
+		toDelete = append(toDelete, rel)
 	}
 
 	// Delete as many as possible. In the case of API throughput limitations,
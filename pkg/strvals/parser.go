@@ -17,9 +17,11 @@ package strvals
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strconv"
 	"strings"
 
@@ -103,16 +105,28 @@ func ParseIntoString(s string, dest map[string]interface{}) error {
 	return t.parse()
 }
 
+// ParseJSON parses a set-json line and merges the result into dest.
+//
+// A set-json line is of the form name1=jsonval1,name2=jsonval2, where each
+// value is decoded as JSON so that it keeps its structure and types.
+func ParseJSON(s string, dest map[string]interface{}) error {
+	scanner := bytes.NewBufferString(s)
+	t := newJSONParser(scanner, dest)
+	return t.parse()
+}
+
 // parser is a simple parser that takes a strvals line and parses it into a
 // map representation.
 //
 // where sc is the source of the original data being parsed
 // where data is the final parsed data from the parses with correct types
 // where st is a boolean to figure out if we're forcing it to parse values as string
+// where isjsonval is a boolean to figure out if values are JSON documents
 type parser struct {
 	sc         *bytes.Buffer
 	data       map[string]interface{}
 	runesToVal runesToVal
+	isjsonval  bool
 }
 
 type runesToVal func([]rune) (interface{}, error)
@@ -128,6 +142,10 @@ func newFileParser(sc *bytes.Buffer, data map[string]interface{}, runesToVal run
 	return &parser{sc: sc, data: data, runesToVal: runesToVal}
 }
 
+func newJSONParser(sc *bytes.Buffer, data map[string]interface{}) *parser {
+	return &parser{sc: sc, data: data, isjsonval: true}
+}
+
 func (t *parser) parse() error {
 	for {
 		err := t.key(t.data)
@@ -179,6 +197,14 @@ func (t *parser) key(data map[string]interface{}) error {
 			return err
 		case last == '=':
 			//End of key. Consume =, Get value.
+			if t.isjsonval {
+				v, e := t.jsonVal()
+				if e != nil {
+					return fmt.Errorf("key %q: %s", string(k), e)
+				}
+				set(data, string(k), v)
+				return nil
+			}
 			// FIXME: Get value list first
 			vl, e := t.valList()
 			switch e {
@@ -259,6 +285,13 @@ func (t *parser) listItem(list []interface{}, i int) ([]interface{}, error) {
 	case err != nil:
 		return list, err
 	case last == '=':
+		if t.isjsonval {
+			v, e := t.jsonVal()
+			if e != nil {
+				return list, e
+			}
+			return setIndex(list, i, v), nil
+		}
 		vl, e := t.valList()
 		switch e {
 		case nil:
@@ -311,6 +344,36 @@ func (t *parser) val() ([]rune, error) {
 	return v, err
 }
 
+// jsonVal decodes the JSON document at the start of the remaining input, and
+// consumes the ',' separating it from the next key, if any.
+func (t *parser) jsonVal() (interface{}, error) {
+	dec := json.NewDecoder(t.sc)
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("no JSON value")
+		}
+		return nil, fmt.Errorf("invalid JSON value: %s", err)
+	}
+
+	// The decoder reads ahead, so put back what it did not consume.
+	rest, err := ioutil.ReadAll(dec.Buffered())
+	if err != nil {
+		return nil, err
+	}
+	t.sc = bytes.NewBuffer(append(rest, t.sc.Bytes()...))
+
+	switch r, _, err := t.sc.ReadRune(); {
+	case err == io.EOF:
+		return v, nil
+	case err != nil:
+		return nil, err
+	case r != ',':
+		return nil, fmt.Errorf("unexpected data after JSON value: %q", string(r)+t.sc.String())
+	}
+	return v, nil
+}
+
 func (t *parser) valList() ([]interface{}, error) {
 	r, _, e := t.sc.ReadRune()
 	if e != nil {
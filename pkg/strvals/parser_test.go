@@ -482,6 +482,80 @@ func TestParseIntoFile(t *testing.T) {
 	}
 }
 
+func TestParseJSON(t *testing.T) {
+	tests := []struct {
+		input  string
+		got    map[string]interface{}
+		expect map[string]interface{}
+		err    bool
+	}{
+		{
+			input: `outer.inner1={"a":[1,2],"b":true},outer.inner2="x,y"`,
+			got: map[string]interface{}{
+				"outer": map[string]interface{}{
+					"inner1": "overwrite",
+					"inner3": "value3",
+				},
+			},
+			expect: map[string]interface{}{
+				"outer": map[string]interface{}{
+					"inner1": map[string]interface{}{"a": []interface{}{1, 2}, "b": true},
+					"inner2": "x,y",
+					"inner3": "value3",
+				},
+			},
+		},
+		{
+			input: `list[1]=["a","b"],name=null`,
+			expect: map[string]interface{}{
+				"list": []interface{}{nil, []interface{}{"a", "b"}},
+				"name": nil,
+			},
+		},
+		{
+			input: `name=`,
+			err:   true,
+		},
+		{
+			input: `name={"a":`,
+			err:   true,
+		},
+		{
+			input: `name=1 2`,
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		got := tt.got
+		if got == nil {
+			got = map[string]interface{}{}
+		}
+		if err := ParseJSON(tt.input, got); err != nil {
+			if !tt.err {
+				t.Fatalf("%s: %s", tt.input, err)
+			}
+			continue
+		}
+		if tt.err {
+			t.Fatalf("%s: Expected error, got %v", tt.input, got)
+		}
+
+		y1, err := yaml.Marshal(tt.expect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		y2, err := yaml.Marshal(got)
+		if err != nil {
+			t.Fatalf("Error serializing parsed value: %s", err)
+		}
+
+		if string(y1) != string(y2) {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", tt.input, y1, y2)
+		}
+	}
+}
+
 func TestToYAML(t *testing.T) {
 	// The TestParse does the hard part. We just verify that YAML formatting is
 	// happening.
@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var errContinueTokenMismatch = errors.New("continue token was issued for a listing with other filters or sort order")
+
+// continueToken records the sort key of the last release of a page of
+// ListReleases, so that the next page starts right after it.
+type continueToken struct {
+	// Query identifies the filters and sort order of the listing.
+	Query        string `json:"q"`
+	Name         string `json:"n"`
+	Version      int32  `json:"v"`
+	Seconds      int64  `json:"s,omitempty"`
+	Nanos        int32  `json:"ns,omitempty"`
+	Chart        string `json:"c,omitempty"`
+	ChartVersion string `json:"cv,omitempty"`
+}
+
+func newContinueToken(last *release.Release, query string) *continueToken {
+	md := last.GetChart().GetMetadata()
+	deployed := last.GetInfo().GetLastDeployed()
+	return &continueToken{
+		Query:        query,
+		Name:         last.Name,
+		Version:      last.Version,
+		Seconds:      deployed.GetSeconds(),
+		Nanos:        deployed.GetNanos(),
+		Chart:        md.GetName(),
+		ChartVersion: md.GetVersion(),
+	}
+}
+
+func (t *continueToken) encode() (string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// release returns a release with the sort key of the token, to be compared
+// with the releases of the listing.
+func (t *continueToken) release() *release.Release {
+	return &release.Release{
+		Name:    t.Name,
+		Version: t.Version,
+		Info: &release.Info{
+			LastDeployed: &timestamp.Timestamp{Seconds: t.Seconds, Nanos: t.Nanos},
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: t.Chart, Version: t.ChartVersion},
+		},
+	}
+}
+
+// decodeContinueToken decodes a token and checks that it was issued for the
+// same query.
+func decodeContinueToken(s, query string) (*continueToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continue token: %s", err)
+	}
+	t := &continueToken{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("invalid continue token: %s", err)
+	}
+	if t.Query != query {
+		return nil, errContinueTokenMismatch
+	}
+	return t, nil
+}
+
+// listQuery identifies the filters and sort order of a ListReleases request,
+// which the pages of a listing must share.
+func listQuery(req *services.ListReleasesRequest) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%d\x00%v\x00%s\x00%s\x00%s\x00%s\x00%s", req.SortBy, req.SortOrder, req.StatusCodes, req.Namespace, req.Filter, req.Selector, req.ChartName, req.ChartVersion)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,64 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+// installCRDs creates the CustomResourceDefinitions found in the crds/
+// directories of a chart and its subcharts, and waits for them to be
+// established so that the chart can be rendered and installed against them.
+//
+// CRDs are not part of a release: those that already exist are left as they
+// are, and none of them are ever upgraded or deleted, since that would affect
+// the custom resources of every release using them.
+func (s *ReleaseServer) installCRDs(ch *chart.Chart, namespace string, timeout int64) error {
+	kubeCli := s.env.KubeClient
+	for _, f := range chartutil.CRDs(ch) {
+		docs := relutil.SplitManifests(string(f.Value))
+		keys := make([]string, 0, len(docs))
+		for k := range docs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			err := kubeCli.Create(namespace, bytes.NewBufferString(docs[k]), timeout, false)
+			if apierrors.IsAlreadyExists(err) {
+				s.Log("CRD from %s already exists, skipping", f.TypeUrl)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to install CRD from %s: %s", f.TypeUrl, err)
+			}
+			if err := kubeCli.WaitUntilCRDEstablished(bytes.NewBufferString(docs[k]), time.Duration(timeout)*time.Second); err != nil {
+				return fmt.Errorf("CRD from %s was not established: %s", f.TypeUrl, err)
+			}
+		}
+	}
+	return nil
+}
@@ -110,6 +110,12 @@ type KubeClient interface {
 	// by "\n---\n").
 	Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// CreateWithOptions creates one or more resources.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error
+
 	// Get gets one or more resources. Returned string hsa the format like kubectl
 	// provides with the column headers separating the resource types.
 	//
@@ -137,6 +143,15 @@ type KubeClient interface {
 	// by "\n---\n").
 	DeleteWithTimeout(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// DeleteWithOptions destroys one or more resources, propagating the
+	// deletion to their dependents as opts says.
+	//
+	// namespace must contain a valid existing namespace.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	DeleteWithOptions(namespace string, reader io.Reader, opts kube.DeleteOptions) error
+
 	// WatchUntilReady watch the resource in reader until it is "ready".
 	//
 	// For Jobs, "ready" means the job ran to completion (excited without error).
@@ -171,6 +186,30 @@ type KubeClient interface {
 	// reader must contain a YAML stream (one or more YAML documents separated by "\n---\n").
 	Validate(namespace string, reader io.Reader) error
 
+	// ServerDryRun submits a stream of manifests to the apiserver as a server-side
+	// dry run, so that they are validated and admitted without being persisted.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated by "\n---\n").
+	ServerDryRun(namespace string, reader io.Reader) error
+
+	// RecreatedResources lists, as Kind/name, the resources that updating
+	// from the original manifests to the target ones would have to delete and
+	// create again, because the apiserver refuses to patch them in place.
+	//
+	// readers must contain a YAML stream (one or more YAML documents separated by "\n---\n").
+	RecreatedResources(namespace string, originalReader, targetReader io.Reader) ([]string, error)
+
+	// DriftedResources lists the resources that are missing from the cluster
+	// or whose live state differs from their manifest.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated by "\n---\n").
+	DriftedResources(namespace string, reader io.Reader) ([]kube.ResourceDrift, error)
+
+	// Lookup returns the live resource with the given API version, kind,
+	// namespace and name, or an empty map if it does not exist. With an empty
+	// name, it returns the list of the resources of the namespace.
+	Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error)
+
 	// WaitAndGetCompletedPodPhase waits up to a timeout until a pod enters a completed phase
 	// and returns said phase (PodSucceeded or PodFailed qualify).
 	WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (v1.PodPhase, error)
@@ -186,6 +225,14 @@ type PrintingKubeClient struct {
 
 // Create prints the values of what would be created with a real KubeClient.
 func (p *PrintingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return p.CreateWithOptions(ns, r, kube.CreateOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// CreateWithOptions implements KubeClient CreateWithOptions.
+func (p *PrintingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
 	_, err := io.Copy(p.Out, r)
 	return err
 }
@@ -208,6 +255,16 @@ func (p *PrintingKubeClient) Delete(ns string, r io.Reader) error {
 //
 // It only prints out the content to be deleted.
 func (p *PrintingKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return p.DeleteWithOptions(ns, r, kube.DeleteOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// DeleteWithOptions implements KubeClient DeleteWithOptions.
+//
+// It only prints out the content to be deleted.
+func (p *PrintingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
 	_, err := io.Copy(p.Out, r)
 	return err
 }
@@ -249,6 +306,28 @@ func (p *PrintingKubeClient) Validate(ns string, reader io.Reader) error {
 	return nil
 }
 
+// ServerDryRun implements KubeClient ServerDryRun
+func (p *PrintingKubeClient) ServerDryRun(ns string, reader io.Reader) error {
+	return nil
+}
+
+// RecreatedResources implements KubeClient RecreatedResources
+func (p *PrintingKubeClient) RecreatedResources(ns string, originalReader, targetReader io.Reader) ([]string, error) {
+	return nil, nil
+}
+
+// DriftedResources implements KubeClient DriftedResources
+func (p *PrintingKubeClient) DriftedResources(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	return nil, nil
+}
+
+// Lookup implements KubeClient Lookup.
+//
+// It finds nothing.
+func (p *PrintingKubeClient) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
 // WaitAndGetCompletedPodPhase implements KubeClient WaitAndGetCompletedPodPhase.
 func (p *PrintingKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (v1.PodPhase, error) {
 	_, err := io.Copy(p.Out, reader)
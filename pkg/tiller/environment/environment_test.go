@@ -43,6 +43,9 @@ type mockKubeClient struct{}
 func (k *mockKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
@@ -52,6 +55,9 @@ func (k *mockKubeClient) Delete(ns string, r io.Reader) error {
 func (k *mockKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
 	return nil
 }
@@ -70,6 +76,18 @@ func (k *mockKubeClient) BuildUnstructured(ns string, reader io.Reader) (kube.Re
 func (k *mockKubeClient) Validate(ns string, reader io.Reader) error {
 	return nil
 }
+func (k *mockKubeClient) ServerDryRun(ns string, reader io.Reader) error {
+	return nil
+}
+func (k *mockKubeClient) RecreatedResources(ns string, originalReader, targetReader io.Reader) ([]string, error) {
+	return nil, nil
+}
+func (k *mockKubeClient) DriftedResources(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	return nil, nil
+}
+func (k *mockKubeClient) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
 func (k *mockKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (v1.PodPhase, error) {
 	return v1.PodUnknown, nil
 }
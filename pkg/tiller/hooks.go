@@ -32,20 +32,6 @@ import (
 	util "k8s.io/helm/pkg/releaseutil"
 )
 
-var events = map[string]release.Hook_Event{
-	hooks.PreInstall:         release.Hook_PRE_INSTALL,
-	hooks.PostInstall:        release.Hook_POST_INSTALL,
-	hooks.PreDelete:          release.Hook_PRE_DELETE,
-	hooks.PostDelete:         release.Hook_POST_DELETE,
-	hooks.PreUpgrade:         release.Hook_PRE_UPGRADE,
-	hooks.PostUpgrade:        release.Hook_POST_UPGRADE,
-	hooks.PreRollback:        release.Hook_PRE_ROLLBACK,
-	hooks.PostRollback:       release.Hook_POST_ROLLBACK,
-	hooks.ReleaseTestSuccess: release.Hook_RELEASE_TEST_SUCCESS,
-	hooks.ReleaseTestFailure: release.Hook_RELEASE_TEST_FAILURE,
-	hooks.CRDInstall:         release.Hook_CRD_INSTALL,
-}
-
 // deletePolices represents a mapping between the key in the annotation for label deleting policy and its real meaning
 var deletePolices = map[string]release.Hook_DeletePolicy{
 	hooks.HookSucceeded:      release.Hook_SUCCEEDED,
@@ -138,6 +124,11 @@ func (file *manifestFile) sort(result *result) error {
 			return e
 		}
 
+		if util.IsSkipped(&entry) {
+			log.Printf("info: skipping %s %q of %s, annotated with %s", entry.Kind, entry.Metadata.Name, file.path, util.SkipAnno)
+			continue
+		}
+
 		if !hasAnyAnnotation(entry) {
 			result.generic = append(result.generic, Manifest{
 				Name:    file.path,
@@ -172,7 +163,7 @@ func (file *manifestFile) sort(result *result) error {
 		isUnknownHook := false
 		for _, hookType := range strings.Split(hookTypes, ",") {
 			hookType = strings.ToLower(strings.TrimSpace(hookType))
-			e, ok := events[hookType]
+			e, ok := hooks.Events[hookType]
 			if !ok {
 				isUnknownHook = true
 				break
@@ -187,6 +178,15 @@ func (file *manifestFile) sort(result *result) error {
 
 		result.hooks = append(result.hooks, h)
 
+		if value, ok := entry.Metadata.Annotations[hooks.HookTimeoutAnno]; ok {
+			timeout, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil || timeout <= 0 {
+				log.Printf("info: ignoring invalid hook timeout value: %q", value)
+			} else {
+				h.Timeout = timeout
+			}
+		}
+
 		operateAnnotationValues(entry, hooks.HookDeleteAnno, func(value string) {
 			policy, exist := deletePolices[value]
 			if exist {
@@ -335,6 +335,83 @@ func TestSortManifestsHookDeletion(t *testing.T) {
 	}
 }
 
+// hookTimeoutManifest is a hook that the timeout annotations of
+// TestSortManifestsHookTimeout are appended to.
+var hookTimeoutManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    helm.sh/hook: pre-install
+`
+
+func TestSortManifestsHookTimeout(t *testing.T) {
+	tests := []struct {
+		annotation string
+		expect     int64
+	}{
+		{"", 0},
+		{`helm.sh/hook-timeout: "900"`, 900},
+		{`helm.sh/hook-timeout: "0"`, 0},
+		{`helm.sh/hook-timeout: "-5"`, 0},
+		{`helm.sh/hook-timeout: "ten minutes"`, 0},
+	}
+
+	for _, tt := range tests {
+		manifest := hookTimeoutManifest
+		if tt.annotation != "" {
+			manifest += "    " + tt.annotation + "\n"
+		}
+
+		hs, _, err := sortManifests(map[string]string{"templates/migrate.yaml": manifest}, chartutil.NewVersionSet("v1", "batch/v1"), InstallOrder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hs) != 1 {
+			t.Fatalf("expected 1 hook, got %d", len(hs))
+		}
+		if hs[0].Timeout != tt.expect {
+			t.Errorf("%q: expected timeout %d, got %d", tt.annotation, tt.expect, hs[0].Timeout)
+		}
+	}
+}
+
+func TestSortManifestsSkip(t *testing.T) {
+	data := map[string]string{
+		"templates/service.yaml": `apiVersion: v1
+kind: Service
+metadata:
+  name: kept
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: skipped
+  annotations:
+    helm.sh/skip: "true"
+`,
+		"templates/job.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: skipped-hook
+  annotations:
+    helm.sh/hook: pre-install
+    helm.sh/skip: "True"
+`,
+	}
+
+	hs, generic, err := sortManifests(data, chartutil.NewVersionSet("v1", "batch/v1"), InstallOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hs) != 0 {
+		t.Errorf("expected the skipped hook to be left out, got %d hooks", len(hs))
+	}
+	if len(generic) != 1 || generic[0].Head.Metadata.Name != "kept" {
+		t.Errorf("expected only the service named kept, got %v", generic)
+	}
+}
+
 func TestVersionSet(t *testing.T) {
 	vs := chartutil.NewVersionSet("v1", "v1beta1", "extensions/alpha5", "batch/v1")
 
@@ -103,10 +103,11 @@ var UninstallOrder SortOrder = []string{
 
 // sortByKind does an in-place sort of manifests by Kind.
 //
-// Results are sorted by 'ordering'
+// Results are sorted by 'ordering'. The sort is stable, so the documents of
+// a template keep their order within a kind.
 func sortByKind(manifests []Manifest, ordering SortOrder) []Manifest {
 	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
+	sort.Stable(ks)
 	return ks.manifests
 }
 
@@ -163,8 +164,5 @@ func (k *kindSorter) Less(i, j int) bool {
 
 // SortByKind sorts manifests in InstallOrder
 func SortByKind(manifests []Manifest) []Manifest {
-	ordering := InstallOrder
-	ks := newKindSorter(manifests, ordering)
-	sort.Sort(ks)
-	return ks.manifests
+	return sortByKind(manifests, InstallOrder)
 }
@@ -18,6 +18,7 @@ package tiller
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 
 	util "k8s.io/helm/pkg/releaseutil"
@@ -268,3 +269,24 @@ func TestKindSorterNamespaceAgainstUnknown(t *testing.T) {
 		}
 	}
 }
+
+// TestKindSorterStable verifies the documents of a template keep their order
+// within a kind.
+func TestKindSorterStable(t *testing.T) {
+	var manifests []Manifest
+	for i, kind := range []string{"Service", "ConfigMap", "Service", "ConfigMap", "Namespace"} {
+		manifests = append(manifests, Manifest{
+			Name:    "templates/all.yaml",
+			Content: fmt.Sprint(i),
+			Head:    &util.SimpleHead{Kind: kind},
+		})
+	}
+
+	var buf bytes.Buffer
+	for _, m := range SortByKind(manifests) {
+		buf.WriteString(m.Content)
+	}
+	if got, expected := buf.String(), "41302"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/technosophos/moniker"
+)
+
+// nameWordRegexp matches the words and prefixes that can be part of a
+// generated release name, which is also used in the names of resources.
+var nameWordRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// nameWords is the file listing the words of generated release names.
+type nameWords struct {
+	Adjectives []string `json:"adjectives"`
+	Nouns      []string `json:"nouns"`
+}
+
+// NewNamer returns the moniker.Namer generating the names of the releases
+// installed without one.
+//
+// The words are read from wordsFile, a YAML file with lists of 'adjectives'
+// and 'nouns', or are those of moniker if it is empty. The prefix, such as the
+// name of a team, is prepended to the generated names if it is set.
+func NewNamer(wordsFile, prefix string) (moniker.Namer, error) {
+	namer := moniker.New()
+	if wordsFile != "" {
+		data, err := ioutil.ReadFile(wordsFile)
+		if err != nil {
+			return nil, err
+		}
+		var words nameWords
+		if err := yaml.Unmarshal(data, &words); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", wordsFile, err)
+		}
+		if len(words.Adjectives) == 0 || len(words.Nouns) == 0 {
+			return nil, fmt.Errorf("%s must list both adjectives and nouns", wordsFile)
+		}
+		for _, w := range append(words.Adjectives, words.Nouns...) {
+			if !nameWordRegexp.MatchString(w) {
+				return nil, fmt.Errorf("%s: %q cannot be part of a release name", wordsFile, w)
+			}
+		}
+		namer = &wordsNamer{
+			words: words,
+			rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		}
+	}
+
+	if prefix != "" {
+		if !nameWordRegexp.MatchString(prefix) {
+			return nil, fmt.Errorf("%q cannot be the prefix of release names", prefix)
+		}
+		namer = &prefixNamer{Namer: namer, prefix: prefix}
+	}
+	return namer, nil
+}
+
+// wordsNamer pairs a random adjective with a random noun.
+type wordsNamer struct {
+	words nameWords
+
+	mu   sync.Mutex // guards rand, as releases are installed concurrently
+	rand *rand.Rand
+}
+
+func (w *wordsNamer) Name() string {
+	return w.NameSep(" ")
+}
+
+func (w *wordsNamer) NameSep(sep string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	adjective := w.words.Adjectives[w.rand.Intn(len(w.words.Adjectives))]
+	noun := w.words.Nouns[w.rand.Intn(len(w.words.Nouns))]
+	return adjective + sep + noun
+}
+
+// prefixNamer prepends a prefix to the names of another moniker.Namer.
+type prefixNamer struct {
+	moniker.Namer
+	prefix string
+}
+
+func (p *prefixNamer) Name() string {
+	return p.NameSep(" ")
+}
+
+func (p *prefixNamer) NameSep(sep string) string {
+	return p.prefix + sep + p.Namer.NameSep(sep)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestNewNamer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-namer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	words := write("words.yaml", "adjectives: [brave]\nnouns: [falcon, heron]\n")
+
+	tests := []struct {
+		words, prefix string
+		expect        string
+		err           bool
+	}{
+		{expect: "^[a-z]+-[a-z]+$"},
+		{prefix: "team-a", expect: "^team-a-[a-z]+-[a-z]+$"},
+		{words: words, expect: "^brave-(falcon|heron)$"},
+		{words: words, prefix: "payments", expect: "^payments-brave-(falcon|heron)$"},
+		{prefix: "Team A", err: true},
+		{words: write("nouns.yaml", "nouns: [falcon]\n"), err: true},
+		{words: write("invalid.yaml", "adjectives: [Brave]\nnouns: [falcon]\n"), err: true},
+		{words: filepath.Join(dir, "missing.yaml"), err: true},
+	}
+
+	for _, tt := range tests {
+		namer, err := NewNamer(tt.words, tt.prefix)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%q, %q: expected an error", tt.words, tt.prefix)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name := namer.NameSep("-"); !regexp.MustCompile(tt.expect).MatchString(name) {
+			t.Errorf("%q, %q: expected %q to match %q", tt.words, tt.prefix, name, tt.expect)
+		}
+	}
+}
+
+func TestUniqNameWithNamer(t *testing.T) {
+	rs := rsFixture()
+	rs.Namer = NewFakeNamer("team-a-brave-falcon")
+
+	name, err := rs.uniqName("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "team-a-brave-falcon" {
+		t.Errorf("Expected the configured namer to be used, got %q", name)
+	}
+}
@@ -31,9 +31,9 @@ func (s *ReleaseServer) GetReleaseContent(c ctx.Context, req *services.GetReleas
 
 	if req.Version <= 0 {
 		rel, err := s.env.Releases.Last(req.Name)
-		return &services.GetReleaseContentResponse{Release: rel}, err
+		return &services.GetReleaseContentResponse{Release: s.withNotes(rel)}, err
 	}
 
 	rel, err := s.env.Releases.Get(req.Name, req.Version)
-	return &services.GetReleaseContentResponse{Release: rel}, err
+	return &services.GetReleaseContentResponse{Release: s.withNotes(rel)}, err
 }
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"fmt"
+
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+// GetReleaseDetail counts the resources in the manifest of a release and
+// lists those whose live state drifted from it, along with their manifest
+// and live state if the request asks for a diff.
+func (s *ReleaseServer) GetReleaseDetail(c ctx.Context, req *services.GetReleaseDetailRequest) (*services.GetReleaseDetailResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("releaseDetail: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	var rel *release.Release
+	var err error
+	if req.Version <= 0 {
+		rel, err = s.env.Releases.Last(req.Name)
+	} else {
+		rel, err = s.env.Releases.Get(req.Name, req.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &services.GetReleaseDetailResponse{
+		ResourceCount: int32(len(relutil.SplitManifests(rel.Manifest))),
+	}
+	// The resources of a deleted release are gone on purpose.
+	if res.ResourceCount == 0 || rel.GetInfo().GetStatus().GetCode() == release.Status_DELETED {
+		return res, nil
+	}
+
+	s.Log("comparing the resources of release %s with the cluster", req.Name)
+	drifts, err := s.env.KubeClient.DriftedResources(rel.Namespace, bytes.NewBufferString(rel.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("unable to compare release %s with the cluster: %s", rel.Name, err)
+	}
+	for _, d := range drifts {
+		res.DriftedResources = append(res.DriftedResources, d.Resource)
+		if req.Diff {
+			res.Drifts = append(res.Drifts, &services.ResourceDrift{
+				Resource: d.Resource,
+				Manifest: d.Manifest,
+				Live:     d.Live,
+			})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestGetReleaseDetail(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newDriftingKubeClient("ConfigMap/two")
+	rel := releaseStub()
+	rel.Manifest = "---\n# Source: hello/templates/one.yaml\nkind: ConfigMap\nmetadata:\n  name: one\n---\n# Source: hello/templates/two.yaml\nkind: ConfigMap\nmetadata:\n  name: two\n"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseDetail(c, &services.GetReleaseDetailRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release detail: %s", err)
+	}
+	if res.ResourceCount != 2 {
+		t.Errorf("Expected 2 resources, got %d", res.ResourceCount)
+	}
+	if !reflect.DeepEqual(res.DriftedResources, []string{"ConfigMap/two"}) {
+		t.Errorf("Expected the drifted config map to be reported, got %v", res.DriftedResources)
+	}
+}
+
+func TestGetReleaseDetailDiff(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newDriftingKubeClient("ConfigMap/two")
+	rel := releaseStub()
+	rel.Manifest = "---\n# Source: hello/templates/two.yaml\nkind: ConfigMap\nmetadata:\n  name: two\n"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseDetail(c, &services.GetReleaseDetailRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release detail: %s", err)
+	}
+	if len(res.Drifts) != 0 {
+		t.Errorf("Expected no drifts without a diff, got %v", res.Drifts)
+	}
+
+	res, err = rs.GetReleaseDetail(c, &services.GetReleaseDetailRequest{Name: rel.Name, Diff: true})
+	if err != nil {
+		t.Fatalf("Error getting release detail: %s", err)
+	}
+	expected := []*services.ResourceDrift{{Resource: "ConfigMap/two", Manifest: "manifest of ConfigMap/two", Live: "live state of ConfigMap/two"}}
+	if !reflect.DeepEqual(res.Drifts, expected) {
+		t.Errorf("Expected the drift of the config map, got %v", res.Drifts)
+	}
+}
+
+func TestGetReleaseDetailDeleted(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newDriftingKubeClient("ConfigMap/one")
+	rel := releaseStub()
+	rel.Manifest = "---\n# Source: hello/templates/one.yaml\nkind: ConfigMap\nmetadata:\n  name: one\n"
+	rel.Info.Status.Code = release.Status_DELETED
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseDetail(c, &services.GetReleaseDetailRequest{Name: rel.Name, Version: 1})
+	if err != nil {
+		t.Fatalf("Error getting release detail: %s", err)
+	}
+	if res.ResourceCount != 1 || len(res.DriftedResources) != 0 {
+		t.Errorf("Expected 1 resource and no drift for a deleted release, got %d and %v", res.ResourceCount, res.DriftedResources)
+	}
+}
+
+func TestGetReleaseDetailMissing(t *testing.T) {
+	rs := rsFixture()
+	if _, err := rs.GetReleaseDetail(helm.NewContext(), &services.GetReleaseDetailRequest{Name: "missing"}); err == nil {
+		t.Error("Expected an error for a release that does not exist")
+	}
+}
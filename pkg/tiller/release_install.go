@@ -17,6 +17,7 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -32,6 +33,7 @@ import (
 
 // InstallRelease installs a release and stores the release record.
 func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	req.DeployedBy = deployedBy(c, req.DeployedBy)
 	s.Log("preparing install for %s", req.Name)
 	rel, err := s.prepareRelease(req)
 	if err != nil {
@@ -46,11 +48,22 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		return res, err
 	}
 
+	// CRDs are only installed once the name is granted and the chart renders,
+	// so that an install that cannot succeed leaves nothing behind.
+	if !req.DryRun && !req.SkipCrds {
+		s.Log("installing CRDs for %s", rel.Name)
+		if err := s.installCRDs(req.Chart, req.Namespace, req.Timeout); err != nil {
+			s.Log("failed to install CRDs: %s", err)
+			return nil, err
+		}
+	}
+
 	s.Log("performing install for %s", req.Name)
 	res, err := s.performRelease(rel, req)
 	if err != nil {
 		s.Log("failed install perform step: %s", err)
 	}
+	res.Release = s.withNotes(res.Release)
 	return res, err
 }
 
@@ -59,6 +72,12 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	if req.Chart == nil {
 		return nil, errMissingChart
 	}
+	if err := validateReleaseLabels(req.Labels); err != nil {
+		return nil, err
+	}
+	if err := chartutil.CheckInstallable(req.Chart); err != nil {
+		return nil, err
+	}
 
 	name, err := s.uniqName(req.Name, req.ReuseName)
 	if err != nil {
@@ -84,13 +103,21 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 		return nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
+	hooks, manifestDoc, notesTxt, warnings, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions, renderOptions{
+		subNotes:      req.SubNotes,
+		postRendered:  req.PostRenderedManifest,
+		dryRun:        req.DryRun,
+		strict:        req.Strict,
+		missingKey:    req.MissingKey,
+		stripComments: req.StripComments,
+	})
 	if err != nil {
 		// Return a release with partial data so that client can show debugging
 		// information.
 		rel := &release.Release{
 			Name:      name,
 			Namespace: req.Namespace,
+			Labels:    req.Labels,
 			Chart:     req.Chart,
 			Config:    req.Values,
 			Info: &release.Info{
@@ -98,6 +125,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 				LastDeployed:  ts,
 				Status:        &release.Status{Code: release.Status_UNKNOWN},
 				Description:   fmt.Sprintf("Install failed: %s", err),
+				DeployedBy:    req.DeployedBy,
 			},
 			Version: 0,
 		}
@@ -111,6 +139,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	rel := &release.Release{
 		Name:      name,
 		Namespace: req.Namespace,
+		Labels:    req.Labels,
 		Chart:     req.Chart,
 		Config:    req.Values,
 		Info: &release.Info{
@@ -118,6 +147,8 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_INSTALL},
 			Description:   "Initial install underway", // Will be overwritten.
+			DeployedBy:    req.DeployedBy,
+			Warnings:      warnings,
 		},
 		Manifest: manifestDoc.String(),
 		Hooks:    hooks,
@@ -133,7 +164,7 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 func hasCRDHook(hs []*release.Hook) bool {
 	for _, h := range hs {
 		for _, e := range h.Events {
-			if e == events[hooks.CRDInstall] {
+			if e == hooks.Events[hooks.CRDInstall] {
 				return true
 			}
 		}
@@ -154,12 +185,22 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			res.Release.Info.Description = "Validation skipped because CRDs are not installed"
 			return res, nil
 		}
+		if !req.SkipCrds && len(chartutil.CRDs(req.Chart)) > 0 {
+			s.Log("validation skipped because the chart has CRDs")
+			res.Release.Info.Description = "Validation skipped because CRDs are not installed"
+			return res, nil
+		}
 
 		// Here's the problem with dry runs and CRDs: We can't install a CRD
 		// during a dry run, which means it cannot be validated.
 		if err := validateManifest(s.env.KubeClient, req.Namespace, manifestDoc); err != nil {
 			return res, err
 		}
+		if req.Validate {
+			if err := s.env.KubeClient.ServerDryRun(req.Namespace, bytes.NewBuffer(manifestDoc)); err != nil {
+				return res, err
+			}
+		}
 
 		res.Release.Info.Description = "Dry run complete"
 		return res, nil
@@ -167,7 +208,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 	// crd-install hooks
 	if !req.DisableHooks && !req.DisableCrdHook {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.CRDInstall, req.Timeout); err != nil {
+		if err := s.execHook(r, hooks.CRDInstall, req.Timeout); err != nil {
 			fmt.Printf("Finished installing CRD: %s", err)
 			return res, err
 		}
@@ -182,7 +223,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 	// pre-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+		if err := s.execHook(r, hooks.PreInstall, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -207,9 +248,10 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		// so as to append to the old release's history
 		r.Version = old.Version + 1
 		updateReq := &services.UpdateReleaseRequest{
-			Wait:     req.Wait,
-			Recreate: false,
-			Timeout:  req.Timeout,
+			Wait:        req.Wait,
+			WaitForJobs: req.WaitForJobs,
+			Recreate:    false,
+			Timeout:     req.Timeout,
 		}
 		s.recordRelease(r, false)
 		if err := s.ReleaseModule.Update(old, r, updateReq, s.env); err != nil {
@@ -239,7 +281,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 
 	// post-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PostInstall, req.Timeout); err != nil {
+		if err := s.execHook(r, hooks.PostInstall, req.Timeout); err != nil {
 			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
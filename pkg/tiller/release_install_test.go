@@ -18,13 +18,16 @@ package tiller
 
 import (
 	"fmt"
+	"io/ioutil"
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -112,6 +115,30 @@ func TestInstallRelease(t *testing.T) {
 	if rel.Info.Description != "Install complete" {
 		t.Errorf("unexpected description: %s", rel.Info.Description)
 	}
+
+	if execs := rel.Info.Status.HookExecutions; len(execs) != 1 || execs[0].Phase != release.HookExecution_SUCCEEDED {
+		t.Errorf("Expected a succeeded hook execution, got %v", execs)
+	}
+}
+
+func TestInstallRelease_DeployedBy(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest()
+	req.DeployedBy = "jane"
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.DeployedBy != "jane" {
+		t.Errorf("Expected the release to be deployed by jane, got %q", rel.Info.DeployedBy)
+	}
 }
 
 func TestInstallRelease_WithNotes(t *testing.T) {
@@ -268,6 +295,60 @@ func TestInstallRelease_WrongTillerVersion(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_LibraryChart(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(
+		withChart(withType(chartutil.LibraryChart)),
+	)
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatalf("Expected a library chart not to be installable")
+	}
+	expect := "library chart, which cannot be installed"
+	if !strings.Contains(err.Error(), expect) {
+		t.Errorf("Expected %q to contain %q", err.Error(), expect)
+	}
+
+	// As a dependency, it only provides its named templates.
+	req = installRequest(
+		withChart(withDependency(withType(chartutil.LibraryChart))),
+	)
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if strings.Contains(res.Release.Manifest, "hello/charts/hello/") {
+		t.Errorf("Expected no resources of the library chart, got:\n%s", res.Release.Manifest)
+	}
+}
+
+func TestInstallRelease_Labels(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest()
+	req.Labels = map[string]string{"team": "payments"}
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatalf("Expected release for %s (%v).", res.Release.Name, rs.env.Releases)
+	}
+	if rel.Labels["team"] != "payments" {
+		t.Errorf("Expected the labels to be stored with the release, got %v", rel.Labels)
+	}
+
+	req = installRequest()
+	req.Labels = map[string]string{"team": "pay ments"}
+	if _, err := rs.InstallRelease(c, req); err == nil || !strings.Contains(err.Error(), "invalid value") {
+		t.Errorf("Expected an invalid label value to fail, got %v", err)
+	}
+}
+
 func TestInstallRelease_WithChartAndDependencyParentNotes(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -324,8 +405,9 @@ func TestInstallRelease_WithChartAndDependencyAllNotes(t *testing.T) {
 
 	t.Logf("rel: %v", rel)
 
-	if !strings.Contains(rel.Info.Status.Notes, notesText) || !strings.Contains(rel.Info.Status.Notes, notesText+" child") {
-		t.Fatalf("Expected '%s', got '%s'", notesText+"\n"+notesText+" child", rel.Info.Status.Notes)
+	// The parent's notes always come before those of its subcharts.
+	if expected := notesText + "\n" + notesText + " child"; rel.Info.Status.Notes != expected {
+		t.Fatalf("Expected '%s', got '%s'", expected, rel.Info.Status.Notes)
 	}
 
 	if rel.Info.Description != "Install complete" {
@@ -428,6 +510,134 @@ func TestInstallRelease_CRDInstallHook(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_DryRunValidate(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newServerDryRunFailingKubeClient()
+
+	req := installRequest(withDryRun())
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Expected the server-side dry run to be skipped, got %s", err)
+	}
+
+	req = installRequest(withDryRun())
+	req.Validate = true
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "admission webhook denied the request") {
+		t.Errorf("Expected the server-side dry run to fail, got %v", err)
+	}
+}
+
+func TestInstallRelease_PostRenderedManifest(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest()
+	req.PostRenderedManifest = `---
+# Source: hello/templates/hooks
+` + manifestWithHook + `
+---
+# Source: hello/templates/hello
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: patched
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: added
+`
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	rel := res.Release
+	for _, expect := range []string{"# Source: hello/templates/hello\n", "name: patched", "# Source: post-renderer\n", "name: added"} {
+		if !strings.Contains(rel.Manifest, expect) {
+			t.Errorf("Expected %q in manifest, got %q", expect, rel.Manifest)
+		}
+	}
+	if len(rel.Hooks) != 1 || rel.Hooks[0].Path != "hello/templates/hooks" || rel.Hooks[0].Name != "test-cm" {
+		t.Errorf("Expected the post-rendered hook, got %v", rel.Hooks)
+	}
+}
+
+func TestInstallRelease_CRDs(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newCRDRecordingKubeClient("widgets.stable.example.com")
+	rs.env.KubeClient = kc
+
+	req := installRequest(withChart(
+		withCRDs("crds/stable.yaml", crdManifests),
+		withDependency(withCRDs("crds/gadgets.yaml", strings.Replace(crdManifests, "crontabs", "gadgets", 1))),
+	))
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(kc.created) != 2 || !strings.Contains(kc.created[0], "crontabs") || !strings.Contains(kc.created[1], "gadgets") {
+		t.Errorf("Expected the missing CRDs of the chart and its dependency to be created, got %v", kc.created)
+	}
+	if kc.established != 2 {
+		t.Errorf("Expected to wait for 2 CRDs to be established, waited for %d", kc.established)
+	}
+
+	kc = newCRDRecordingKubeClient("")
+	rs.env.KubeClient = kc
+	req = installRequest(withChart(withCRDs("crds/stable.yaml", crdManifests)))
+	req.SkipCrds = true
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(kc.created) != 0 {
+		t.Errorf("Expected no CRDs to be created with SkipCrds, got %v", kc.created)
+	}
+
+	req = installRequest(withChart(withCRDs("crds/stable.yaml", crdManifests)), withDryRun())
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(kc.created) != 0 {
+		t.Errorf("Expected no CRDs to be created on a dry run, got %v", kc.created)
+	}
+	expect := "Validation skipped because CRDs are not installed"
+	if res.Release.Info.Description != expect {
+		t.Errorf("Expected Description %q, got %q", expect, res.Release.Info.Description)
+	}
+}
+
+func TestInstallRelease_CRDsAfterPrepare(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := newCRDRecordingKubeClient("")
+	rs.env.KubeClient = kc
+	rs.env.Releases.Create(releaseStub())
+
+	req := installRequest(withName(releaseStub().Name), withChart(withCRDs("crds/stable.yaml", crdManifests)))
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected an error for a name in use")
+	}
+	if len(kc.created) != 0 {
+		t.Errorf("Expected no CRDs to be created for a failed install, got %v", kc.created)
+	}
+
+	req = installRequest(withChart(
+		withCRDs("crds/stable.yaml", crdManifests),
+		func(opts *chartOptions) {
+			opts.Templates = append(opts.Templates, &chart.Template{Name: "templates/broken", Data: []byte("{{ end }}")})
+		},
+	))
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected an error for a chart that does not render")
+	}
+	if len(kc.created) != 0 {
+		t.Errorf("Expected no CRDs to be created for a failed render, got %v", kc.created)
+	}
+}
+
 func TestInstallRelease_DryRunCRDInstallHook(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -465,6 +675,17 @@ func TestInstallRelease_FailedHooks(t *testing.T) {
 	if hl := res.Release.Info.Status.Code; hl != release.Status_FAILED {
 		t.Errorf("Expected FAILED release. Got %d", hl)
 	}
+
+	execs := res.Release.Info.Status.HookExecutions
+	if len(execs) != 1 {
+		t.Fatalf("Expected 1 hook execution, got %d", len(execs))
+	}
+	if execs[0].Event != release.Hook_POST_INSTALL || execs[0].Phase != release.HookExecution_FAILED {
+		t.Errorf("Expected failed post-install hook execution, got %s %s", execs[0].Event, execs[0].Phase)
+	}
+	if execs[0].StartedAt == nil || execs[0].CompletedAt == nil {
+		t.Errorf("Expected hook execution to be timestamped, got %v", execs[0])
+	}
 }
 
 func TestInstallRelease_ReuseName(t *testing.T) {
@@ -548,3 +769,353 @@ func TestInstallRelease_Description(t *testing.T) {
 		t.Errorf("Expected description %q. Got %q", customDescription, desc)
 	}
 }
+
+// secretKubeClient finds an existing "db" Secret on lookup.
+type secretKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (k *secretKubeClient) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	if apiVersion == "v1" && kind == "Secret" && name == "db" {
+		return map[string]interface{}{"data": map[string]interface{}{"password": "ZXhpc3Rpbmc="}}, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func TestInstallReleaseLookup(t *testing.T) {
+	withPassword := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/password",
+			Data: []byte(`password: {{ $s := lookup "v1" "Secret" .Release.Namespace "db" }}{{ if $s }}{{ $s.data.password }}{{ else }}generated{{ end }}`),
+		})
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		rs := rsFixture()
+		rs.env.KubeClient = &secretKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+
+		req := installRequest(withChart(withPassword))
+		req.DryRun = dryRun
+		res, err := rs.InstallRelease(helm.NewContext(), req)
+		if err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+
+		expected := "password: ZXhpc3Rpbmc="
+		if dryRun {
+			expected = "password: generated"
+		}
+		if !strings.Contains(res.Release.Manifest, expected) {
+			t.Errorf("Expected %q with dry run %t, got %s", expected, dryRun, res.Release.Manifest)
+		}
+	}
+}
+
+func TestInstallReleaseLookupOtherNamespace(t *testing.T) {
+	rs := rsFixture()
+	rs.env.KubeClient = &secretKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+
+	req := installRequest(withChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/password",
+			Data: []byte(`password: {{ (lookup "v1" "Secret" "kube-system" "db").data.password }}`),
+		})
+	}))
+	_, err := rs.InstallRelease(helm.NewContext(), req)
+	if err == nil {
+		t.Fatal("Expected a lookup outside of the release namespace to fail")
+	}
+	if !strings.Contains(err.Error(), `only the release namespace "spaced" can be looked up`) {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+// serviceKubeClient finds Services with a load balancer on lookup.
+type serviceKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (k *serviceKubeClient) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	if apiVersion == "v1" && kind == "Service" {
+		return map[string]interface{}{
+			"status": map[string]interface{}{
+				"loadBalancer": map[string]interface{}{
+					"ingress": []interface{}{map[string]interface{}{"ip": "10.0.0.1"}},
+				},
+			},
+		}, nil
+	}
+	return map[string]interface{}{}, nil
+}
+
+func TestInstallReleaseDeployedNotes(t *testing.T) {
+	notes := `IP: {{ deployed "v1" "Service" "web" "{.status.loadBalancer.ingress[0].ip}" }}, hostname: {{ deployed "v1" "Service" "web" ".status.loadBalancer.ingress[0].hostname" }}, other: {{ deployed "v1" "Service" "other" "{.status.loadBalancer.ingress[0].ip}" }}`
+	withService := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/service",
+			Data: []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n"),
+		})
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		rs := rsFixture()
+		rs.env.KubeClient = &serviceKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+
+		req := installRequest(withChart(withNotes(notes), withService))
+		req.DryRun = dryRun
+		res, err := rs.InstallRelease(helm.NewContext(), req)
+		if err != nil {
+			t.Fatalf("Failed install: %s", err)
+		}
+
+		// Only the resources of the release can be read.
+		expected := "IP: 10.0.0.1, hostname: <pending>, other: <pending>"
+		if dryRun {
+			expected = "IP: <pending>, hostname: <pending>, other: <pending>"
+		}
+		if got := res.Release.Info.Status.Notes; got != expected {
+			t.Errorf("Expected notes %q with dry run %t, got %q", expected, dryRun, got)
+		}
+		if dryRun {
+			continue
+		}
+
+		// The placeholders are stored, and resolved whenever the notes are
+		// shown.
+		rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+		if err != nil {
+			t.Fatalf("Expected release to be stored: %s", err)
+		}
+		if !strings.Contains(rel.Info.Status.Notes, "__HELM_DEPLOYED(") {
+			t.Errorf("Expected the stored notes to keep the placeholders, got %q", rel.Info.Status.Notes)
+		}
+		status, err := rs.GetReleaseStatus(helm.NewContext(), &services.GetReleaseStatusRequest{Name: rel.Name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := status.Info.Status.Notes; got != expected {
+			t.Errorf("Expected status notes %q, got %q", expected, got)
+		}
+		content, err := rs.GetReleaseContent(helm.NewContext(), &services.GetReleaseContentRequest{Name: rel.Name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := content.Release.Info.Status.Notes; got != expected {
+			t.Errorf("Expected content notes %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestInstallReleaseDeployedOutsideNotes(t *testing.T) {
+	rs := rsFixture()
+	req := installRequest(withChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/configmap",
+			Data: []byte(`ip: {{ deployed "v1" "Service" "web" ".spec.clusterIP" }}`),
+		})
+	}))
+	_, err := rs.InstallRelease(helm.NewContext(), req)
+	if err == nil {
+		t.Fatal("Expected the 'deployed' function to fail outside of the notes")
+	}
+	if !strings.Contains(err.Error(), "hello/templates/configmap") {
+		t.Errorf("Expected the error to name the template, got %s", err)
+	}
+}
+
+func TestInstallReleaseStrict(t *testing.T) {
+	withTypo := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/typo",
+			Data: []byte(`image: {{ .Values.imageTag }}`),
+		})
+	}
+	withStrictMetadata := func(opts *chartOptions) {
+		opts.Metadata.Strict = true
+	}
+
+	tests := []struct {
+		name    string
+		req     *services.InstallReleaseRequest
+		wantErr bool
+	}{
+		{"lenient", installRequest(withChart(withTypo)), false},
+		{"strict request", installRequest(withChart(withTypo)), true},
+		{"strict chart", installRequest(withChart(withTypo, withStrictMetadata)), true},
+	}
+	tests[1].req.Strict = true
+
+	for _, tt := range tests {
+		rs := rsFixture()
+		_, err := rs.InstallRelease(helm.NewContext(), tt.req)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected the undefined value to fail the install", tt.name)
+			} else if !strings.Contains(err.Error(), "imageTag") {
+				t.Errorf("%s: expected the error to name the value, got %s", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: failed install: %s", tt.name, err)
+		}
+	}
+}
+
+func TestInstallReleaseMissingKey(t *testing.T) {
+	withTypo := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/typo",
+			Data: []byte(`image: {{ .Values.imageTag }}`),
+		})
+	}
+	withMissingKeyMetadata := func(opts *chartOptions) {
+		opts.Metadata.MissingKey = "error"
+	}
+
+	tests := []struct {
+		name       string
+		missingKey string
+		metadata   bool
+		expect     string
+		wantErr    bool
+	}{
+		{"chart default", "", false, "image: \n", false},
+		{"request", "invalid", false, "image: <no value>", false},
+		{"request error", "error", false, "", true},
+		{"chart", "", true, "", true},
+		{"request overrides chart", "zero", true, "image: \n", false},
+	}
+
+	for _, tt := range tests {
+		opts := []chartOption{withTypo}
+		if tt.metadata {
+			opts = append(opts, withMissingKeyMetadata)
+		}
+		req := installRequest(withChart(opts...))
+		req.MissingKey = tt.missingKey
+
+		rs := rsFixture()
+		res, err := rs.InstallRelease(helm.NewContext(), req)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected the undefined value to fail the install", tt.name)
+			} else if !strings.Contains(err.Error(), "imageTag") {
+				t.Errorf("%s: expected the error to name the value, got %s", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: failed install: %s", tt.name, err)
+			continue
+		}
+		if !strings.Contains(res.Release.Manifest, tt.expect) {
+			t.Errorf("%s: expected the manifest to contain %q, got %q", tt.name, tt.expect, res.Release.Manifest)
+		}
+	}
+}
+
+func TestInstallReleaseDuplicateResources(t *testing.T) {
+	rs := rsFixture()
+	req := installRequest(withChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates,
+			&chart.Template{Name: "templates/a", Data: []byte("kind: ConfigMap\nmetadata:\n  name: settings\n")},
+			&chart.Template{Name: "templates/b", Data: []byte("kind: ConfigMap\nmetadata:\n  name: settings\n  namespace: spaced\n")},
+		)
+	}))
+	req.Namespace = "spaced"
+
+	_, err := rs.InstallRelease(helm.NewContext(), req)
+	if err == nil {
+		t.Fatal("Expected the duplicate ConfigMap to fail the install")
+	}
+	expected := `ConfigMap "settings" in namespace "spaced" is defined more than once, by hello/templates/a, hello/templates/b`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected %q, got %q", expected, err)
+	}
+}
+
+func TestInstallReleaseWarnings(t *testing.T) {
+	withDeprecation := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/deprecated",
+			Data: []byte(`{{ warn "imageTag is deprecated, use image.tag" }}`),
+		})
+	}
+
+	rs := rsFixture()
+	res, err := rs.InstallRelease(helm.NewContext(), installRequest(withChart(withDeprecation)))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	expect := []string{"imageTag is deprecated, use image.tag"}
+	if got := res.Release.Info.Warnings; len(got) != 1 || got[0] != expect[0] {
+		t.Errorf("Expected the warnings %v, got %v", expect, got)
+	}
+
+	// They are stored with the release.
+	stored, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Info.Warnings) != 1 {
+		t.Errorf("Expected the warnings to be stored, got %v", stored.Info.Warnings)
+	}
+}
+
+func TestInstallRelease_StripComments(t *testing.T) {
+	withComments := func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates,
+			&chart.Template{Name: "templates/commented", Data: []byte("# a comment\nkind: ConfigMap\nmetadata:\n  name: commented # kept\n")},
+			&chart.Template{Name: "templates/disabled", Data: []byte("# nothing is enabled\n---\n")},
+		)
+	}
+
+	rs := rsFixture()
+	req := installRequest(withChart(withComments))
+	req.StripComments = true
+	res, err := rs.InstallRelease(helm.NewContext(), req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	manifest := res.Release.Manifest
+	if strings.Contains(manifest, "# a comment") || strings.Contains(manifest, "# nothing is enabled") {
+		t.Errorf("Expected the comments to be stripped, got %q", manifest)
+	}
+	if !strings.Contains(manifest, "name: commented # kept") {
+		t.Errorf("Expected the inline comment to be kept, got %q", manifest)
+	}
+	if strings.Contains(manifest, "templates/disabled") {
+		t.Errorf("Expected the empty template to be left out, got %q", manifest)
+	}
+}
+
+func TestDeployedReleaseOf(t *testing.T) {
+	rs := rsFixture()
+	legacy := namedReleaseStub("legacy", release.Status_DEPLOYED)
+	legacy.Namespace = "spaced"
+	legacy.Manifest = "kind: Service\nmetadata:\n  name: web\n---\nkind: ClusterRole\nmetadata:\n  name: reader\n"
+	rs.env.Releases.Create(legacy)
+	deleted := namedReleaseStub("gone", release.Status_DELETED)
+	deleted.Manifest = "kind: Service\nmetadata:\n  name: old\n  namespace: spaced\n"
+	rs.env.Releases.Create(deleted)
+
+	releaseOf, err := deployedReleaseOf(namedReleaseStub("ocean", release.Status_UNKNOWN), rs.env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		kind, namespace, name, expected string
+	}{
+		{"Service", "spaced", "web", "legacy"},
+		{"Service", "default", "web", ""},
+		{"ClusterRole", "", "reader", "legacy"},
+		{"Service", "spaced", "old", ""},
+	}
+	for _, tt := range tests {
+		if got := releaseOf(tt.kind, tt.namespace, tt.name); got != tt.expected {
+			t.Errorf("%s %s/%s: expected release %q, got %q", tt.kind, tt.namespace, tt.name, tt.expected, got)
+		}
+	}
+}
@@ -17,10 +17,16 @@ limitations under the License.
 package tiller
 
 import (
+	"errors"
 	"fmt"
+	"path"
 	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/golang/protobuf/proto"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
@@ -31,6 +37,9 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 	if len(req.StatusCodes) == 0 {
 		req.StatusCodes = []release.Status_Code{release.Status_DEPLOYED}
 	}
+	if req.Offset != "" && req.ContinueToken != "" {
+		return errors.New("offset and continue token cannot be used together")
+	}
 
 	//rels, err := s.env.Releases.ListDeployed()
 	rels, err := s.env.Releases.ListFilterAll(func(r *release.Release) bool {
@@ -59,15 +68,43 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 		}
 	}
 
+	if req.Selector != "" {
+		rels, err = filterBySelector(req.Selector, rels)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.ChartName != "" || req.ChartVersion != "" {
+		rels, err = filterByChart(req.ChartName, req.ChartVersion, rels)
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.ChartAnnotations != "" {
+		rels, err = filterByChartAnnotations(req.ChartAnnotations, rels)
+		if err != nil {
+			return err
+		}
+	}
+
 	total := int64(len(rels))
 
+	var less func(a, b *release.Release) bool
 	switch req.SortBy {
-	case services.ListSort_NAME:
-		relutil.SortByName(rels)
 	case services.ListSort_LAST_RELEASED:
 		relutil.SortByDate(rels)
+		less = relutil.LessByDate
 	case services.ListSort_CHART_NAME:
 		relutil.SortByChartName(rels)
+		less = relutil.LessByChartName
+	case services.ListSort_REVISION:
+		relutil.SortByRevision(rels)
+		less = relutil.LessByRevision
+	default:
+		relutil.SortByName(rels)
+		less = relutil.LessByName
 	}
 
 	if req.SortOrder == services.ListSort_DESC {
@@ -100,20 +137,43 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 		l = int64(len(rels))
 	}
 
+	query := listQuery(req)
+	if req.ContinueToken != "" {
+		tok, err := decodeContinueToken(req.ContinueToken, query)
+		if err != nil {
+			return err
+		}
+		// Resume after the last release of the previous page, wherever it
+		// is now: releases may have been added or removed in the meantime.
+		last := tok.release()
+		i := sort.Search(len(rels), func(i int) bool {
+			if req.SortOrder == services.ListSort_DESC {
+				return less(rels[i], last)
+			}
+			return less(last, rels[i])
+		})
+		rels = rels[i:]
+		l = int64(len(rels))
+	}
+
 	if req.Limit == 0 {
 		req.Limit = ListDefaultLimit
 	}
 
-	next := ""
+	next, continueToken := "", ""
 	if l > req.Limit {
 		next = rels[req.Limit].Name
 		rels = rels[0:req.Limit]
 		l = int64(len(rels))
+		if continueToken, err = newContinueToken(rels[l-1], query).encode(); err != nil {
+			return err
+		}
 	}
 	res := &services.ListReleasesResponse{
-		Next:  next,
-		Count: l,
-		Total: total,
+		Next:          next,
+		Count:         l,
+		Total:         total,
+		ContinueToken: continueToken,
 	}
 	chunks := s.partition(rels[:min(len(rels), int(req.Limit))], maxMsgSize-proto.Size(res))
 	for res.Releases = range chunks {
@@ -156,10 +216,83 @@ func (s *ReleaseServer) partition(rels []*release.Release, cap int) <-chan []*re
 	return chunks
 }
 
+// filterByNamespace keeps the releases in the namespaces matching any of the
+// comma-separated names or glob patterns in namespace.
 func filterByNamespace(namespace string, rels []*release.Release) ([]*release.Release, error) {
+	var patterns []string
+	for _, p := range strings.Split(namespace, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	matches := []*release.Release{}
+	for _, r := range rels {
+		for _, p := range patterns {
+			ok, err := path.Match(p, r.Namespace)
+			if err != nil {
+				return rels, fmt.Errorf("invalid namespace pattern %q: %s", p, err)
+			}
+			if ok {
+				matches = append(matches, r)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+func filterBySelector(selector string, rels []*release.Release) ([]*release.Release, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return rels, fmt.Errorf("invalid selector %q: %s", selector, err)
+	}
+	matches := []*release.Release{}
+	for _, r := range rels {
+		if sel.Matches(labels.Set(r.Labels)) {
+			matches = append(matches, r)
+		}
+	}
+	return matches, nil
+}
+
+// filterByChart keeps the releases of the chart with the given name, if it is
+// set, whose version satisfies the constraint, if it is set. Releases of a
+// chart without a semantic version never satisfy a constraint.
+func filterByChart(name, constraint string, rels []*release.Release) ([]*release.Release, error) {
+	var c *semver.Constraints
+	if constraint != "" {
+		var err error
+		if c, err = semver.NewConstraint(constraint); err != nil {
+			return rels, fmt.Errorf("invalid chart version constraint %q: %s", constraint, err)
+		}
+	}
+	matches := []*release.Release{}
+	for _, r := range rels {
+		md := r.GetChart().GetMetadata()
+		if name != "" && md.GetName() != name {
+			continue
+		}
+		if c != nil {
+			v, err := semver.NewVersion(md.GetVersion())
+			if err != nil || !c.Check(v) {
+				continue
+			}
+		}
+		matches = append(matches, r)
+	}
+	return matches, nil
+}
+
+// filterByChartAnnotations keeps the releases whose chart has annotations, set
+// in its Chart.yaml, that match the selector.
+func filterByChartAnnotations(selector string, rels []*release.Release) ([]*release.Release, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return rels, fmt.Errorf("invalid chart annotation selector %q: %s", selector, err)
+	}
 	matches := []*release.Release{}
 	for _, r := range rels {
-		if namespace == r.Namespace {
+		if sel.Matches(labels.Set(r.GetChart().GetMetadata().GetAnnotations())) {
 			matches = append(matches, r)
 		}
 	}
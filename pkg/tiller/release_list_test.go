@@ -18,6 +18,7 @@ package tiller
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -148,6 +149,105 @@ func TestListReleasesSort(t *testing.T) {
 	}
 }
 
+func TestListReleasesSortByRevision(t *testing.T) {
+	rs := rsFixture()
+
+	// Releases with the same revision are sorted by name, so that paging
+	// through them with an offset does not skip or repeat any.
+	revisions := map[string]int32{"rel-a": 3, "rel-b": 1, "rel-c": 3, "rel-d": 2}
+	for name, v := range revisions {
+		rel := releaseStub()
+		rel.Name = name
+		rel.Version = v
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	var names []string
+	offset := ""
+	for {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Offset:    offset,
+			Limit:     2,
+			SortBy:    services.ListSort_REVISION,
+			SortOrder: services.ListSort_DESC,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		for _, r := range mrs.val.Releases {
+			names = append(names, r.Name)
+		}
+		if offset = mrs.val.Next; offset == "" {
+			break
+		}
+	}
+
+	if got := strings.Join(names, " "); got != "rel-c rel-a rel-d rel-b" {
+		t.Errorf("Expected releases sorted by descending revision, got %s", got)
+	}
+}
+
+func TestListReleasesContinueToken(t *testing.T) {
+	rs := rsFixture()
+	for _, name := range []string{"rel-a", "rel-b", "rel-c", "rel-d", "rel-e"} {
+		rel := releaseStub()
+		rel.Name = name
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	list := func(token string) *services.ListReleasesResponse {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Limit:         2,
+			SortBy:        services.ListSort_NAME,
+			ContinueToken: token,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		return mrs.val
+	}
+
+	page := list("")
+	if page.ContinueToken == "" || page.Releases[1].Name != "rel-b" {
+		t.Fatalf("Expected a continue token after rel-b, got %v", page)
+	}
+
+	// The release the next page would start at with --offset is deleted,
+	// and one is installed before the end of the previous page.
+	if _, err := rs.env.Releases.Delete("rel-c", 1); err != nil {
+		t.Fatal(err)
+	}
+	rel := releaseStub()
+	rel.Name = "rel-0"
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatal(err)
+	}
+
+	page = list(page.ContinueToken)
+	if len(page.Releases) != 2 || page.Releases[0].Name != "rel-d" || page.Releases[1].Name != "rel-e" {
+		t.Errorf("Expected the next page to hold rel-d and rel-e, got %v", page.Releases)
+	}
+	if page.ContinueToken != "" {
+		t.Errorf("Expected no continue token on the last page, got %q", page.ContinueToken)
+	}
+
+	mrs := &mockListServer{}
+	req := &services.ListReleasesRequest{
+		Limit:         2,
+		SortBy:        services.ListSort_LAST_RELEASED,
+		ContinueToken: list("").ContinueToken,
+	}
+	if err := rs.ListReleases(req, mrs); err != errContinueTokenMismatch {
+		t.Errorf("Expected a token to be refused for another sort order, got %v", err)
+	}
+}
+
 func TestListReleasesSortByChartName(t *testing.T) {
 	rs := rsFixture()
 
@@ -273,6 +373,196 @@ func TestReleasesNamespace(t *testing.T) {
 	if len(mrs.val.Releases) != 2 {
 		t.Errorf("Expected 2 releases, got %d", len(mrs.val.Releases))
 	}
+
+	tests := []struct {
+		namespace string
+		expected  []string
+	}{
+		{"default,cerebellum", []string{"axon", "ribosome"}},
+		{"test*", []string{"dendrite", "neuron"}},
+		{"c?rebellum, test1[0-9]3", []string{"dendrite", "neuron", "ribosome"}},
+		{"kube-*", nil},
+	}
+	for _, tt := range tests {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Limit:     64,
+			Namespace: tt.namespace,
+			SortBy:    services.ListSort_NAME,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		var names []string
+		for _, r := range mrs.val.GetReleases() {
+			names = append(names, r.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(tt.expected, " ") {
+			t.Errorf("%q: expected releases %v, got %v", tt.namespace, tt.expected, names)
+		}
+	}
+
+	req = &services.ListReleasesRequest{Namespace: "test[12"}
+	if err := rs.ListReleases(req, &mockListServer{}); err == nil {
+		t.Error("Expected an invalid namespace pattern to fail")
+	}
+}
+
+func TestListReleasesSelector(t *testing.T) {
+	rs := rsFixture()
+
+	labels := map[string]map[string]string{
+		"axon":     {"team": "payments", "tier": "web"},
+		"dendrite": {"team": "payments", "tier": "db"},
+		"neuron":   {"team": "search"},
+		"ribosome": nil,
+	}
+	for name, l := range labels {
+		rel := releaseStub()
+		rel.Name = name
+		rel.Labels = l
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"team=payments", []string{"axon", "dendrite"}},
+		{"team=payments,tier!=db", []string{"axon"}},
+		{"!team", []string{"ribosome"}},
+		{"team in (search)", []string{"neuron"}},
+	}
+	for _, tt := range tests {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Limit:    64,
+			Selector: tt.selector,
+			SortBy:   services.ListSort_NAME,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		var names []string
+		for _, r := range mrs.val.Releases {
+			names = append(names, r.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(tt.expected, " ") {
+			t.Errorf("%q: expected releases %v, got %v", tt.selector, tt.expected, names)
+		}
+	}
+
+	req := &services.ListReleasesRequest{Selector: "team in (payments"}
+	if err := rs.ListReleases(req, &mockListServer{}); err == nil {
+		t.Error("Expected an invalid selector to fail")
+	}
+}
+
+func TestListReleasesChart(t *testing.T) {
+	rs := rsFixture()
+
+	charts := map[string][2]string{
+		"axon":     {"nginx-ingress", "0.9.5"},
+		"dendrite": {"nginx-ingress", "1.4.0"},
+		"neuron":   {"nginx-ingress", "2.0.0"},
+		"ribosome": {"redis", "1.4.0"},
+		"synapse":  {"nginx-ingress", "latest"},
+	}
+	for name, c := range charts {
+		rel := releaseStub()
+		rel.Name = name
+		rel.Chart.Metadata.Name, rel.Chart.Metadata.Version = c[0], c[1]
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	tests := []struct {
+		chart, version string
+		expected       []string
+	}{
+		{"nginx-ingress", "", []string{"axon", "dendrite", "neuron", "synapse"}},
+		{"nginx-ingress", ">=1.0.0 <2.0.0", []string{"dendrite"}},
+		{"nginx-ingress", "<1.0.0 || >=2.0.0", []string{"axon", "neuron"}},
+		{"", "~1.4", []string{"dendrite", "ribosome"}},
+	}
+	for _, tt := range tests {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Limit:        64,
+			ChartName:    tt.chart,
+			ChartVersion: tt.version,
+			SortBy:       services.ListSort_NAME,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		var names []string
+		for _, r := range mrs.val.Releases {
+			names = append(names, r.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(tt.expected, " ") {
+			t.Errorf("%q %q: expected releases %v, got %v", tt.chart, tt.version, tt.expected, names)
+		}
+	}
+
+	req := &services.ListReleasesRequest{ChartVersion: "newest"}
+	if err := rs.ListReleases(req, &mockListServer{}); err == nil {
+		t.Error("Expected an invalid version constraint to fail")
+	}
+}
+
+func TestListReleasesChartAnnotations(t *testing.T) {
+	rs := rsFixture()
+
+	annotations := map[string]map[string]string{
+		"axon":     {"artifacthub.io/license": "Apache-2.0", "tier": "frontend"},
+		"dendrite": {"tier": "db"},
+		"neuron":   nil,
+	}
+	for name, a := range annotations {
+		rel := releaseStub()
+		rel.Name = name
+		rel.Chart.Metadata.Annotations = a
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"tier=frontend", []string{"axon"}},
+		{"tier", []string{"axon", "dendrite"}},
+		{"artifacthub.io/license=Apache-2.0", []string{"axon"}},
+		{"!tier", []string{"neuron"}},
+	}
+	for _, tt := range tests {
+		mrs := &mockListServer{}
+		req := &services.ListReleasesRequest{
+			Limit:            64,
+			ChartAnnotations: tt.selector,
+			SortBy:           services.ListSort_NAME,
+		}
+		if err := rs.ListReleases(req, mrs); err != nil {
+			t.Fatalf("Failed listing: %s", err)
+		}
+		var names []string
+		for _, r := range mrs.val.Releases {
+			names = append(names, r.Name)
+		}
+		if strings.Join(names, " ") != strings.Join(tt.expected, " ") {
+			t.Errorf("%q: expected releases %v, got %v", tt.selector, tt.expected, names)
+		}
+	}
+
+	req := &services.ListReleasesRequest{ChartAnnotations: "tier in (db"}
+	if err := rs.ListReleases(req, &mockListServer{}); err == nil {
+		t.Error("Expected an invalid chart annotation selector to fail")
+	}
 }
 
 func TestReleasePartition(t *testing.T) {
@@ -18,10 +18,13 @@ package tiller
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"k8s.io/helm/pkg/chartutil"
@@ -50,8 +53,55 @@ type LocalReleaseModule struct {
 
 // Create creates a release via kubeclient from provided environment
 func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
+	opts := kube.CreateOptions{
+		Timeout:       req.Timeout,
+		ShouldWait:    req.Wait,
+		WaitForJobs:   req.WaitForJobs,
+		TakeOwnership: req.TakeOwnership,
+		ReleaseName:   r.Name,
+	}
+	if req.TakeOwnership {
+		releaseOf, err := deployedReleaseOf(r, env)
+		if err != nil {
+			return err
+		}
+		opts.ReleaseOf = releaseOf
+	}
 	b := bytes.NewBufferString(r.Manifest)
-	return env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait)
+	return env.KubeClient.CreateWithOptions(r.Namespace, b, opts)
+}
+
+// deployedReleaseOf returns a kube.ReleaseOfFunc looking up resources in the
+// manifests of the deployed releases other than r. Resources without a
+// namespace in a manifest are in the namespace of their release, unless they
+// are cluster-scoped, which is only known to the lookup.
+func deployedReleaseOf(r *release.Release, env *environment.Environment) (kube.ReleaseOfFunc, error) {
+	deployed, err := env.Releases.ListDeployed()
+	if err != nil {
+		return nil, err
+	}
+	type resource struct{ kind, namespace, name string }
+	owners := map[resource]string{}
+	for _, rel := range deployed {
+		if rel.Name == r.Name {
+			continue
+		}
+		for _, m := range relutil.SplitManifests(rel.Manifest) {
+			var head relutil.SimpleHead
+			if err := yaml.Unmarshal([]byte(m), &head); err != nil || head.Metadata == nil {
+				continue
+			}
+			namespace := head.Metadata.Namespace
+			if namespace == "" {
+				namespace = rel.Namespace
+			}
+			owners[resource{head.Kind, namespace, head.Metadata.Name}] = rel.Name
+			owners[resource{head.Kind, "", head.Metadata.Name}] = rel.Name
+		}
+	}
+	return func(kind, namespace, name string) string {
+		return owners[resource{kind, namespace, name}]
+	}, nil
 }
 
 // Update performs an update from current to target release
@@ -59,11 +109,14 @@ func (m *LocalReleaseModule) Update(current, target *release.Release, req *servi
 	c := bytes.NewBufferString(current.Manifest)
 	t := bytes.NewBufferString(target.Manifest)
 	return env.KubeClient.UpdateWithOptions(target.Namespace, c, t, kube.UpdateOptions{
-		Force:         req.Force,
-		Recreate:      req.Recreate,
-		Timeout:       req.Timeout,
-		ShouldWait:    req.Wait,
-		CleanupOnFail: req.CleanupOnFail,
+		Force:           req.Force,
+		ForceRecreate:   req.ForceRecreate,
+		Recreate:        req.Recreate,
+		RecreatePodsFor: req.RecreatePodsFor,
+		Timeout:         req.Timeout,
+		ShouldWait:      req.Wait,
+		CleanupOnFail:   req.CleanupOnFail,
+		WaitForJobs:     req.WaitForJobs,
 	})
 }
 
@@ -72,11 +125,14 @@ func (m *LocalReleaseModule) Rollback(current, target *release.Release, req *ser
 	c := bytes.NewBufferString(current.Manifest)
 	t := bytes.NewBufferString(target.Manifest)
 	return env.KubeClient.UpdateWithOptions(target.Namespace, c, t, kube.UpdateOptions{
-		Force:         req.Force,
-		Recreate:      req.Recreate,
-		Timeout:       req.Timeout,
-		ShouldWait:    req.Wait,
-		CleanupOnFail: req.CleanupOnFail,
+		Force:           req.Force,
+		ForceRecreate:   req.ForceRecreate,
+		Recreate:        req.Recreate,
+		RecreatePodsFor: req.RecreatePodsFor,
+		Timeout:         req.Timeout,
+		ShouldWait:      req.Wait,
+		CleanupOnFail:   req.CleanupOnFail,
+		WaitForJobs:     req.WaitForJobs,
 	})
 }
 
@@ -91,7 +147,9 @@ func (m *LocalReleaseModule) Delete(rel *release.Release, req *services.Uninstal
 	if err != nil {
 		return rel.Manifest, []error{fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)}
 	}
-	return DeleteRelease(rel, vs, env.KubeClient)
+	// The request was validated by the release server.
+	policy, _ := propagationPolicy(req.GetCascade())
+	return DeleteReleaseWithPolicy(rel, vs, env.KubeClient, policy, req.GetIgnoreResourcePolicy())
 }
 
 // RemoteReleaseModule is a ReleaseModule which calls Rudder service to operate on a release
@@ -99,6 +157,9 @@ type RemoteReleaseModule struct{}
 
 // Create calls rudder.InstallRelease
 func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
+	if req.TakeOwnership {
+		return errors.New("taking ownership of existing resources is not supported with Rudder")
+	}
 	request := &rudderAPI.InstallReleaseRequest{Release: r}
 	_, err := rudder.InstallRelease(request)
 	return err
@@ -160,6 +221,14 @@ func (m *RemoteReleaseModule) Delete(r *release.Release, req *services.Uninstall
 
 // DeleteRelease is a helper that allows Rudder to delete a release without exposing most of Tiller inner functions
 func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient) (kept string, errs []error) {
+	return DeleteReleaseWithPolicy(rel, vs, kubeClient, metav1.DeletePropagationBackground, false)
+}
+
+// DeleteReleaseWithPolicy deletes a release like DeleteRelease, propagating
+// the deletion of its resources to their dependents with the given policy.
+// With ignoreResourcePolicy, the resources annotated to be kept are deleted
+// too.
+func DeleteReleaseWithPolicy(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient, policy metav1.DeletionPropagation, ignoreResourcePolicy bool) (kept string, errs []error) {
 	manifests := relutil.SplitManifests(rel.Manifest)
 	_, files, err := sortManifests(manifests, vs, UninstallOrder)
 	if err != nil {
@@ -171,6 +240,9 @@ func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient env
 	}
 
 	filesToKeep, filesToDelete := filterManifestsToKeep(files)
+	if ignoreResourcePolicy {
+		filesToKeep, filesToDelete = nil, files
+	}
 	if len(filesToKeep) > 0 {
 		kept = summarizeKeptManifests(filesToKeep, kubeClient, rel.Namespace)
 	}
@@ -181,7 +253,7 @@ func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient env
 		if b.Len() == 0 {
 			continue
 		}
-		if err := kubeClient.Delete(rel.Namespace, b); err != nil {
+		if err := kubeClient.DeleteWithOptions(rel.Namespace, b, kube.DeleteOptions{PropagationPolicy: policy}); err != nil {
 			log.Printf("uninstall: Failed deletion of %q: %s", rel.Name, err)
 			if err == kube.ErrNoObjectsVisited {
 				// Rewrite the message from "no objects visited"
@@ -0,0 +1,46 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// ProtectRelease protects the latest revision of a release from deletion, or
+// lifts the protection. Later revisions inherit it.
+func (s *ReleaseServer) ProtectRelease(c ctx.Context, req *services.ProtectReleaseRequest) (*services.ProtectReleaseResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("protectRelease: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	rel, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rel.Protected != req.Protect {
+		rel.Protected = req.Protect
+		if err := s.env.Releases.Update(rel); err != nil {
+			s.Log("protect: Failed to store updated release: %s", err)
+			return nil, err
+		}
+	}
+	return &services.ProtectReleaseResponse{Release: rel}, nil
+}
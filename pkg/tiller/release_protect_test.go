@@ -0,0 +1,108 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestProtectRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.ProtectRelease(c, &services.ProtectReleaseRequest{Name: rel.Name, Protect: true})
+	if err != nil {
+		t.Fatalf("Failed protect: %s", err)
+	}
+	if !res.Release.Protected {
+		t.Error("Expected the release to be protected")
+	}
+
+	_, err = rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: rel.Name, Purge: true})
+	if err == nil || !strings.Contains(err.Error(), "is protected") {
+		t.Fatalf("Expected the uninstall of a protected release to fail, got %v", err)
+	}
+	stored, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatalf("Expected the release to be kept: %s", err)
+	}
+	if stored.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected status code to be DEPLOYED, got %d", stored.Info.Status.Code)
+	}
+
+	ures, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: rel.Name, ForceProtected: true})
+	if err != nil {
+		t.Fatalf("Failed forced uninstall: %s", err)
+	}
+	if ures.Release.Info.Status.Code != release.Status_DELETED {
+		t.Errorf("Expected status code to be DELETED, got %d", ures.Release.Info.Status.Code)
+	}
+}
+
+func TestUnprotectRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Protected = true
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	if _, err := rs.ProtectRelease(c, &services.ProtectReleaseRequest{Name: rel.Name}); err != nil {
+		t.Fatalf("Failed unprotect: %s", err)
+	}
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: rel.Name}); err != nil {
+		t.Errorf("Expected the uninstall of an unprotected release to succeed, got %s", err)
+	}
+}
+
+func TestUpdateReleaseKeepsProtection(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Protected = true
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{
+		Name:  rel.Name,
+		Chart: rel.Chart,
+	})
+	if err != nil {
+		t.Fatalf("Failed update: %s", err)
+	}
+	if !res.Release.Protected {
+		t.Error("Expected the upgraded release to stay protected")
+	}
+}
+
+func TestProtectReleaseMissing(t *testing.T) {
+	rs := rsFixture()
+	if _, err := rs.ProtectRelease(helm.NewContext(), &services.ProtectReleaseRequest{Name: "missing", Protect: true}); err == nil {
+		t.Error("Expected an error for a release that does not exist")
+	}
+}
@@ -26,12 +26,14 @@ import (
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
 // RollbackRelease rolls back to a previous version of the given release.
 func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
 	s.Log("preparing rollback of %s", req.Name)
+	req.DeployedBy = deployedBy(c, req.DeployedBy)
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
 		return nil, err
@@ -56,6 +58,7 @@ func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackRel
 		}
 	}
 
+	res.Release = s.withNotes(res.Release)
 	return res, nil
 }
 
@@ -78,7 +81,12 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 
 	previousVersion := req.Version
 	if req.Version == 0 {
-		previousVersion = currentRelease.Version - 1
+		previousVersion, err = s.previousSuccessfulVersion(currentRelease)
+		if err != nil {
+			return nil, nil, err
+		}
+		// performRollback needs the version to prune the ones after it.
+		req.Version = previousVersion
 	}
 
 	s.Log("rolling back %s (current: v%d, target: v%d)", req.Name, currentRelease.Version, previousVersion)
@@ -97,6 +105,8 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 	targetRelease := &release.Release{
 		Name:      req.Name,
 		Namespace: currentRelease.Namespace,
+		Labels:    currentRelease.Labels,
+		Protected: currentRelease.Protected,
 		Chart:     previousRelease.Chart,
 		Config:    previousRelease.Config,
 		Info: &release.Info{
@@ -109,6 +119,7 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 			// Because we lose the reference to previous version elsewhere, we set the
 			// message here, and only override it later if we experience failure.
 			Description: description,
+			DeployedBy:  req.DeployedBy,
 		},
 		Version:  currentRelease.Version + 1,
 		Manifest: previousRelease.Manifest,
@@ -118,6 +129,31 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 	return currentRelease, targetRelease, nil
 }
 
+// previousSuccessfulVersion returns the most recent version of the release
+// before current that was deployed successfully, skipping the failed and
+// pending ones.
+func (s *ReleaseServer) previousSuccessfulVersion(current *release.Release) (int32, error) {
+	history, err := s.env.Releases.History(current.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int32
+	for _, r := range history {
+		if r.Version >= current.Version || r.Version <= version {
+			continue
+		}
+		switch r.Info.Status.Code {
+		case release.Status_DEPLOYED, release.Status_SUPERSEDED:
+			version = r.Version
+		}
+	}
+	if version == 0 {
+		return 0, fmt.Errorf("release %q has no successful revision before %d to roll back to", current.Name, current.Version)
+	}
+	return version, nil
+}
+
 func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.Release, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
 	res := &services.RollbackReleaseResponse{Release: targetRelease}
 
@@ -128,7 +164,7 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// pre-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PreRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease, hooks.PreRollback, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -148,11 +184,18 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	// post-rollback hooks
 	if !req.DisableHooks {
-		if err := s.execHook(targetRelease.Hooks, targetRelease.Name, targetRelease.Namespace, hooks.PostRollback, req.Timeout); err != nil {
+		if err := s.execHook(targetRelease, hooks.PostRollback, req.Timeout); err != nil {
+			if req.CleanupOnFail {
+				s.deleteCreatedResources(currentRelease, targetRelease)
+			}
 			return res, err
 		}
 	}
 
+	if req.Prune {
+		s.pruneSkippedResources(currentRelease, targetRelease, req)
+	}
+
 	// update the current release
 	s.Log("superseding previous deployment %d", currentRelease.Version)
 	currentRelease.Info.Status.Code = release.Status_SUPERSEDED
@@ -173,3 +216,49 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 
 	return res, nil
 }
+
+// pruneSkippedResources deletes the resources left by the versions between the
+// target of a rollback and the current version. Updating from the current
+// version only removes its own resources, so the ones created by failed
+// upgrades before it would otherwise stay in the cluster.
+func (s *ReleaseServer) pruneSkippedResources(current, target *release.Release, req *services.RollbackReleaseRequest) {
+	history, err := s.env.Releases.History(current.Name)
+	if err != nil {
+		s.Log("warning: could not prune the resources of %s: %s", current.Name, err)
+		return
+	}
+	stale := skippedManifests(history, current, target, req.Version)
+	if stale == "" {
+		return
+	}
+
+	s.Log("pruning the resources of %s left by versions %d to %d", current.Name, req.Version+1, current.Version-1)
+	rel := &release.Release{Name: current.Name, Namespace: current.Namespace, Manifest: stale}
+	kept, errs := s.ReleaseModule.Delete(rel, &services.UninstallReleaseRequest{Name: current.Name}, s.env)
+	if kept != "" {
+		s.Log("%s", kept)
+	}
+	for _, err := range errs {
+		s.Log("warning: failed to prune a resource of %s: %s", current.Name, err)
+	}
+}
+
+// skippedManifests returns the documents of the versions in history after from
+// and before current that describe resources which neither current nor target
+// define, each resource once.
+func skippedManifests(history []*release.Release, current, target *release.Release, from int32) string {
+	relutil.SortByRevision(history)
+
+	defined := current.Manifest + "\n---\n" + target.Manifest
+	var stale []string
+	for _, r := range history {
+		if r.Version <= from || r.Version >= current.Version {
+			continue
+		}
+		if created := createdManifests(defined, r.Manifest); created != "" {
+			stale = append(stale, created)
+			defined += "\n---\n" + created
+		}
+	}
+	return strings.Join(stale, "\n---\n")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package tiller
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -319,6 +320,78 @@ func TestRollbackReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestRollbackToPreviousSuccessfulRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	v2 := upgradeReleaseVersion(rel)
+	rs.env.Releases.Update(rel)
+	v3 := upgradeReleaseVersion(v2)
+	v2.Info.Status.Code = release.Status_FAILED
+	v3.Info.Status.Code = release.Status_FAILED
+	rs.env.Releases.Create(v2)
+	rs.env.Releases.Create(v3)
+
+	req := &services.RollbackReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+	}
+	res, err := rs.RollbackRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if res.Release.Version != 4 {
+		t.Errorf("Expected release version to be 4, got %d", res.Release.Version)
+	}
+	if res.Release.Info.Description != "Rollback to 1" {
+		t.Errorf("Expected rollback to 1, got %q", res.Release.Info.Description)
+	}
+}
+
+func TestRollbackWithoutPreviousSuccessfulRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Info.Status.Code = release.Status_FAILED
+	rs.env.Releases.Create(rel)
+	v2 := upgradeReleaseVersion(rel)
+	rel.Info.Status.Code = release.Status_FAILED
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(v2)
+
+	req := &services.RollbackReleaseRequest{
+		Name:         rel.Name,
+		DisableHooks: true,
+	}
+	if _, err := rs.RollbackRelease(c, req); err == nil {
+		t.Error("Expected an error when no previous revision was deployed successfully")
+	}
+}
+
+func TestSkippedManifests(t *testing.T) {
+	doc := func(kind, name string) string {
+		return fmt.Sprintf("apiVersion: v1\nkind: %s\nmetadata:\n  name: %s", kind, name)
+	}
+	rel := func(version int32, docs ...string) *release.Release {
+		return &release.Release{Name: "angry-panda", Version: version, Manifest: strings.Join(docs, "\n---\n")}
+	}
+	current := rel(4, doc("ConfigMap", "app"), doc("Secret", "current"))
+	history := []*release.Release{
+		current,
+		rel(2, doc("ConfigMap", "app"), doc("Secret", "two"), doc("Secret", "both")),
+		rel(1, doc("ConfigMap", "app"), doc("Secret", "one")),
+		rel(3, doc("ConfigMap", "app"), doc("Secret", "both"), doc("Secret", "current")),
+	}
+	target := rel(5, doc("ConfigMap", "app"), doc("Secret", "one"))
+
+	got := skippedManifests(history, current, target, 1)
+	expected := strings.Join([]string{doc("Secret", "two"), doc("Secret", "both")}, "\n---\n")
+	if got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
 func TestRollbackReleaseWithCustomDescription(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -22,16 +22,25 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/technosophos/moniker"
+	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/jsonpath"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/manifest"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -54,6 +63,10 @@ const (
 	// wants to see this file after rendering in the status command. However, it must be a suffix
 	// since there can be filepath in front of it.
 	notesFileSuffix = "NOTES.txt"
+
+	// postRendererSource is the path given to post-rendered documents that
+	// do not come from a template of the chart.
+	postRendererSource = "post-renderer"
 )
 
 var (
@@ -67,6 +80,10 @@ var (
 	errInvalidName = errors.New("invalid release name, must match regex ^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])+$ and the length must not be longer than 53")
 )
 
+// sourceCommentRegexp matches the comment that names the template a rendered
+// document comes from.
+var sourceCommentRegexp = regexp.MustCompile(`(?m)^# Source: (.+)$`)
+
 // ListDefaultLimit is the default limit for number of items returned in a list.
 var ListDefaultLimit int64 = 512
 
@@ -87,6 +104,9 @@ type ReleaseServer struct {
 	env       *environment.Environment
 	clientset kubernetes.Interface
 	Log       func(string, ...interface{})
+	// Namer generates the names of the releases installed without one. The
+	// words of moniker are used if it is nil.
+	Namer moniker.Namer
 }
 
 // NewReleaseServer creates a new release server.
@@ -115,7 +135,9 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 // release, this does nothing.
 //
 // This is skipped if the req.ResetValues flag is set, in which case the
-// request values are not altered.
+// request values are not altered. If req.ResetThenReuseValues is set, the
+// values of the current release are merged with the request values, but the
+// defaults of the new chart are kept.
 func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current *release.Release) error {
 	if req.ResetValues {
 		// If ResetValues is set, we completely ignore current.Config.
@@ -140,27 +162,14 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 		}
 		req.Chart.Values = &chart.Config{Raw: nv}
 
-		reqValues, err := chartutil.ReadValues([]byte(req.Values.Raw))
-		if err != nil {
-			return err
-		}
-
-		currentConfig := chartutil.Values{}
-		if current.Config != nil && current.Config.Raw != "" && current.Config.Raw != "{}\n" {
-			currentConfig, err = chartutil.ReadValues([]byte(current.Config.Raw))
-			if err != nil {
-				return err
-			}
-		}
-
-		currentConfig.MergeInto(reqValues)
-		data, err := currentConfig.YAML()
-		if err != nil {
-			return err
-		}
+		return mergeCurrentValues(req, current)
+	}
 
-		req.Values.Raw = data
-		return nil
+	// If the ResetThenReuseValues flag is set, the defaults of the new chart are
+	// kept and only the values supplied to the last release are carried over.
+	if req.ResetThenReuseValues {
+		s.Log("resetting values to the chart's defaults and reusing the old release's values")
+		return mergeCurrentValues(req, current)
 	}
 
 	// If req.Values is empty, but current.Config is not, copy current into the
@@ -175,6 +184,32 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 	return nil
 }
 
+// mergeCurrentValues merges the values of the request over the values supplied
+// to the current release, and stores the result in the request.
+func mergeCurrentValues(req *services.UpdateReleaseRequest, current *release.Release) error {
+	reqValues, err := chartutil.ReadValues([]byte(req.Values.GetRaw()))
+	if err != nil {
+		return err
+	}
+
+	currentConfig := chartutil.Values{}
+	if current.Config != nil && current.Config.Raw != "" && current.Config.Raw != "{}\n" {
+		currentConfig, err = chartutil.ReadValues([]byte(current.Config.Raw))
+		if err != nil {
+			return err
+		}
+	}
+
+	currentConfig.MergeInto(reqValues)
+	data, err := currentConfig.YAML()
+	if err != nil {
+		return err
+	}
+
+	req.Values = &chart.Config{Raw: data}
+	return nil
+}
+
 func (s *ReleaseServer) uniqName(start string, reuse bool) (string, error) {
 
 	// If a name is supplied, we check to see if that name is taken. If not, it
@@ -201,11 +236,14 @@ func (s *ReleaseServer) uniqName(start string, reuse bool) (string, error) {
 			return "", fmt.Errorf("a release named %s is in use, cannot re-use a name that is still in use", start)
 		}
 
-		return "", fmt.Errorf("a release named %s already exists.\nRun: helm ls --all %s; to check the status of the release\nOr run: helm del --purge %s; to delete it", start, start, start)
+		return "", fmt.Errorf("a release named %s already exists with status %s.\nRun: helm ls --all %s; to check the status of the release\nOr run: helm del --purge %s; to delete it", start, rel.Info.Status.Code, start, start)
 	}
 
-	moniker := moniker.New()
-	newname, err := s.createUniqName(moniker)
+	namer := s.Namer
+	if namer == nil {
+		namer = moniker.New()
+	}
+	newname, err := s.createUniqName(namer)
 	if err != nil {
 		return "ERROR", err
 	}
@@ -259,6 +297,7 @@ func capabilities(disc discovery.DiscoveryInterface) (*chartutil.Capabilities, e
 		APIVersions:   vs,
 		KubeVersion:   sv,
 		TillerVersion: version.GetVersionProto(),
+		HelmVersion:   version.GetVersionProto(),
 	}, nil
 }
 
@@ -334,12 +373,52 @@ func GetVersionSet(client discovery.ServerGroupsInterface) (chartutil.VersionSet
 	return chartutil.NewVersionSet(versions...), nil
 }
 
-func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, subNotes bool, vs chartutil.VersionSet) ([]*release.Hook, *bytes.Buffer, string, error) {
+// namespacedLookup returns the lookup template function of a release
+// installed to namespace. Tiller commonly runs with a service account that can
+// read every Secret of the cluster, so a chart may only look up the resources
+// of its own namespace rather than anything Tiller can see.
+func (s *ReleaseServer) namespacedLookup(namespace string) engine.LookupFunc {
+	return func(apiVersion, kind, ns, name string) (map[string]interface{}, error) {
+		if ns != namespace {
+			return nil, fmt.Errorf("cannot look up %s %q in namespace %q: only the release namespace %q can be looked up", kind, name, ns, namespace)
+		}
+		return s.env.KubeClient.Lookup(apiVersion, kind, ns, name)
+	}
+}
+
+// renderOptions are the options of a request that change how its chart is
+// rendered.
+type renderOptions struct {
+	// Render the notes of the subcharts as well
+	subNotes bool
+	// If set, replaces the rendered manifests and hooks of the chart. Only
+	// the notes are taken from the chart then.
+	postRendered string
+	// The lookup template function queries the cluster, except for a dry
+	// run, where it finds nothing so that the result does not depend on the
+	// cluster. It only reads the namespace of the release, see
+	// namespacedLookup.
+	dryRun bool
+	// If set, or if the chart asks for it, references to values that are
+	// not defined fail the rendering.
+	strict bool
+	// What the templates render for values that are not defined, one of
+	// the engine.MissingKey settings. If empty, the setting of the chart is
+	// used.
+	missingKey string
+	// If set, comments and documents left empty are dropped from the
+	// rendered templates, which keeps large releases within size limits.
+	stripComments bool
+}
+
+// renderResources renders a chart and sorts the result into hooks, manifests
+// and notes.
+func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values, vs chartutil.VersionSet, opts renderOptions) ([]*release.Hook, *bytes.Buffer, string, []string, error) {
 	// Guard to make sure Tiller is at the right version to handle this chart.
 	sver := version.GetVersion()
 	if ch.Metadata.TillerVersion != "" &&
 		!version.IsCompatibleRange(ch.Metadata.TillerVersion, sver) {
-		return nil, nil, "", fmt.Errorf("Chart incompatible with Tiller %s", sver)
+		return nil, nil, "", nil, fmt.Errorf("Chart incompatible with Tiller %s", sver)
 	}
 
 	if ch.Metadata.KubeVersion != "" {
@@ -347,15 +426,34 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		gitVersion := cap.KubeVersion.String()
 		k8sVersion := strings.Split(gitVersion, "+")[0]
 		if !version.IsCompatibleRange(ch.Metadata.KubeVersion, k8sVersion) {
-			return nil, nil, "", fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", ch.Metadata.KubeVersion, k8sVersion)
+			return nil, nil, "", nil, fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", ch.Metadata.KubeVersion, k8sVersion)
 		}
 	}
 
+	namespace, _ := values.PathValue("Release.Namespace")
+	ns, _ := namespace.(string)
+
 	s.Log("rendering %s chart using values", ch.GetMetadata().Name)
 	renderer := s.engine(ch)
+	warnings := &engine.Warnings{}
+	if e, ok := renderer.(*engine.Engine); ok {
+		// The engine is shared by all requests, so it is copied.
+		configured := *e
+		configured.Strict = e.Strict || opts.strict || ch.Metadata.Strict
+		if opts.missingKey != "" {
+			configured.MissingKey = opts.missingKey
+		} else if configured.MissingKey == "" {
+			configured.MissingKey = ch.Metadata.MissingKey
+		}
+		if !opts.dryRun {
+			configured.LookupFunc = s.namespacedLookup(ns)
+		}
+		configured.WarnFunc = warnings.Add
+		renderer = &configured
+	}
 	files, err := renderer.Render(ch, values)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, "", nil, err
 	}
 
 	// NOTES.txt gets rendered like all the other files, but because it's not a hook nor a resource,
@@ -363,23 +461,62 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 	// text file. We have to spin through this map because the file contains path information, so we
 	// look for terminating NOTES.txt. We also remove it from the files so that we don't have to skip
 	// it in the sortHooks.
-	var notesBuffer bytes.Buffer
-	for k, v := range files {
+	//
+	// The parent chart's notes come first, followed by those of the subcharts
+	// in the order of their paths, so that the output is stable.
+	parentNotes := path.Join(ch.Metadata.Name, "templates", notesFileSuffix)
+	var notesFiles []string
+	for k := range files {
 		if strings.HasSuffix(k, notesFileSuffix) {
-			if subNotes || (k == path.Join(ch.Metadata.Name, "templates", notesFileSuffix)) {
-
-				// If buffer contains data, add newline before adding more
-				if notesBuffer.Len() > 0 {
-					notesBuffer.WriteString("\n")
-				}
-				notesBuffer.WriteString(v)
+			if opts.subNotes || k == parentNotes {
+				notesFiles = append(notesFiles, k)
 			}
+		}
+	}
+	sort.Slice(notesFiles, func(i, j int) bool {
+		if notesFiles[i] == parentNotes || notesFiles[j] == parentNotes {
+			return notesFiles[i] == parentNotes
+		}
+		return notesFiles[i] < notesFiles[j]
+	})
+
+	var notesBuffer bytes.Buffer
+	for _, k := range notesFiles {
+		// If buffer contains data, add newline before adding more
+		if notesBuffer.Len() > 0 {
+			notesBuffer.WriteString("\n")
+		}
+		notesBuffer.WriteString(files[k])
+	}
+	for k := range files {
+		if strings.HasSuffix(k, notesFileSuffix) {
 			delete(files, k)
 		}
 	}
 
+	// The placeholders of the 'deployed' function are only resolved in the
+	// notes, so they would reach the cluster as they are from anywhere else.
+	if err := checkDeployed(files); err != nil {
+		return nil, nil, "", nil, err
+	}
+
 	notes := notesBuffer.String()
 
+	if opts.postRendered != "" {
+		files = postRenderedFiles(opts.postRendered)
+	}
+
+	// A resource defined twice would be overwritten by its last definition.
+	if err := manifest.CheckDuplicates(files, ns); err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	if opts.stripComments {
+		for name, content := range files {
+			files[name] = relutil.StripComments(content)
+		}
+	}
+
 	// Sort hooks, manifests, and partials. Only hooks and manifests are returned,
 	// as partials are not used after renderer.Render. Empty manifests are also
 	// removed here.
@@ -398,7 +535,7 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 			b.WriteString("\n---\n# Source: " + name + "\n")
 			b.WriteString(content)
 		}
-		return nil, b, "", err
+		return nil, b, "", nil, err
 	}
 
 	// Aggregate all valid manifests into one big doc.
@@ -408,7 +545,131 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		b.WriteString(m.Content)
 	}
 
-	return hooks, b, notes, nil
+	return hooks, b, notes, warnings.List(), nil
+}
+
+// postRenderedFiles groups the documents of a post-rendered manifest by the
+// "# Source:" comment that precedes each rendered template, so that resources
+// and hooks keep the path of the template they came from. Documents without
+// the comment, such as resources added by the post-renderer, are attributed
+// to postRendererSource.
+func postRenderedFiles(manifest string) map[string]string {
+	docs := relutil.SplitManifests(manifest)
+	files := map[string]string{}
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		source := postRendererSource
+		if m := sourceCommentRegexp.FindStringSubmatch(doc); m != nil {
+			source = strings.TrimSpace(m[1])
+			doc = strings.Replace(doc, m[0], "", 1)
+		}
+		files[source] += "\n---\n" + strings.TrimSpace(doc)
+	}
+	return files
+}
+
+// checkDeployed fails if one of the rendered files, other than the notes, uses
+// the 'deployed' template function.
+func checkDeployed(files map[string]string) error {
+	var names []string
+	for name, content := range files {
+		if engine.HasDeployed(content) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return fmt.Errorf("the 'deployed' function can only be used in %s, but is used in %s", notesFileSuffix, strings.Join(names, ", "))
+}
+
+// withNotes returns r with the placeholders of the 'deployed' template
+// function in its notes resolved. The stored release keeps the placeholders,
+// so that the notes tell how the resources are whenever they are shown, and r
+// is copied rather than changed. The placeholders are resolved with the
+// resources in the cluster once the release is deployed, and are left pending
+// before that, as for dry runs.
+func (s *ReleaseServer) withNotes(r *release.Release) *release.Release {
+	if r == nil || r.Info == nil || r.Info.Status == nil || r.Info.Status.Notes == "" {
+		return r
+	}
+	rel, info, status := *r, *r.Info, *r.Info.Status
+	info.Status = &status
+	rel.Info = &info
+	status.Notes = s.resolveNotes(r, status.Code == release.Status_DEPLOYED)
+	return &rel
+}
+
+// resolveNotes returns the notes of r with the placeholders of the 'deployed'
+// template function replaced. If live is set, they are resolved with the
+// resources of the release as they are in the cluster; otherwise they are
+// left pending. Only the resources of the manifest of the release can be
+// read, so that the notes of a chart cannot reveal anything else Tiller can
+// see. Values that cannot be read, for instance because a load balancer has
+// not been assigned yet, are pending too.
+func (s *ReleaseServer) resolveNotes(r *release.Release, live bool) string {
+	var resources map[string]bool
+	if live {
+		resources = manifestResources(r)
+	}
+	return engine.ResolveDeployed(r.Info.Status.Notes, func(apiVersion, kind, name, path string) string {
+		if !live {
+			return ""
+		}
+		if !resources[kind+"/"+name] {
+			s.Log("warning: %s %q is not a resource of %s, so its notes cannot read it", kind, name, r.Name)
+			return ""
+		}
+		obj, err := s.env.KubeClient.Lookup(apiVersion, kind, r.Namespace, name)
+		if err != nil {
+			s.Log("warning: cannot look up %s %q for the notes of %s: %s", kind, name, r.Name, err)
+			return ""
+		}
+		if len(obj) == 0 {
+			return ""
+		}
+		v, err := evalJSONPath(obj, path)
+		if err != nil {
+			s.Log("warning: cannot read %s of %s %q for the notes of %s: %s", path, kind, name, r.Name, err)
+			return ""
+		}
+		return v
+	})
+}
+
+// manifestResources returns the kinds and names, as "kind/name", of the
+// resources of the manifest of r that are in the namespace of the release.
+func manifestResources(r *release.Release) map[string]bool {
+	resources := map[string]bool{}
+	for _, m := range relutil.SplitManifests(r.Manifest) {
+		var head relutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(m), &head); err != nil || head.Metadata == nil {
+			continue
+		}
+		if ns := head.Metadata.Namespace; ns != "" && ns != r.Namespace {
+			continue
+		}
+		resources[head.Kind+"/"+head.Metadata.Name] = true
+	}
+	return resources
+}
+
+// evalJSONPath returns the value of a JSONPath expression, with or without the
+// surrounding braces, in obj. Missing fields give an empty string.
+func evalJSONPath(obj map[string]interface{}, path string) (string, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	j := jsonpath.New("notes").AllowMissingKeys(true)
+	if err := j.Parse(path); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
 // recordRelease with an update operation in case reuse has been set.
@@ -422,9 +683,10 @@ func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
 	}
 }
 
-func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) error {
+func (s *ReleaseServer) execHook(r *release.Release, hook string, timeout int64) error {
 	kubeCli := s.env.KubeClient
-	code, ok := events[hook]
+	hs, name, namespace := r.Hooks, r.Name, r.Namespace
+	code, ok := hooks.Events[hook]
 	if !ok {
 		return fmt.Errorf("unknown hook %s", hook)
 	}
@@ -441,14 +703,34 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 
 	executingHooks = sortByHookWeight(executingHooks)
 
+	// A release is only stored once its pre-install hooks have run, so the
+	// progress of those is recorded along with the release afterwards.
+	persist := hook != hooks.PreInstall && hook != hooks.CRDInstall
+
 	for _, h := range executingHooks {
 		if err := s.deleteHookByPolicy(h, hooks.BeforeHookCreation, name, namespace, hook, kubeCli); err != nil {
 			return err
 		}
 
+		execution := &release.HookExecution{
+			Name:      h.Name,
+			Kind:      h.Kind,
+			Event:     code,
+			Phase:     release.HookExecution_RUNNING,
+			StartedAt: timeconv.Now(),
+		}
+		s.recordHookExecution(r, execution, persist)
+
+		// A hook may declare its own timeout, which replaces the one of the release.
+		hookTimeout := timeout
+		if h.Timeout > 0 {
+			hookTimeout = h.Timeout
+		}
+
 		b := bytes.NewBufferString(h.Manifest)
-		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
+		if err := kubeCli.Create(namespace, b, hookTimeout, false); err != nil {
 			s.Log("warning: Release %s %s %s failed: %s", name, hook, h.Path, err)
+			s.completeHookExecution(r, execution, release.HookExecution_FAILED, persist)
 			return err
 		}
 		// No way to rewind a bytes.Buffer()?
@@ -457,8 +739,9 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 
 		// We can't watch CRDs, but need to wait until they reach the established state before continuing
 		if hook != hooks.CRDInstall {
-			if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
+			if err := kubeCli.WatchUntilReady(namespace, b, hookTimeout, false); err != nil {
 				s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+				s.completeHookExecution(r, execution, release.HookExecution_FAILED, persist)
 				// If a hook is failed, checkout the annotation of the hook to determine whether the hook should be deleted
 				// under failed condition. If so, then clear the corresponding resource object in the hook
 				if err := s.deleteHookByPolicy(h, hooks.HookFailed, name, namespace, hook, kubeCli); err != nil {
@@ -467,11 +750,13 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 				return err
 			}
 		} else {
-			if err := kubeCli.WaitUntilCRDEstablished(b, time.Duration(timeout)*time.Second); err != nil {
+			if err := kubeCli.WaitUntilCRDEstablished(b, time.Duration(hookTimeout)*time.Second); err != nil {
 				s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+				s.completeHookExecution(r, execution, release.HookExecution_FAILED, persist)
 				return err
 			}
 		}
+		s.completeHookExecution(r, execution, release.HookExecution_SUCCEEDED, persist)
 	}
 
 	s.Log("hooks complete for %s %s", hook, name)
@@ -487,11 +772,72 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	return nil
 }
 
+// recordHookExecution adds execution to the status of r, replacing any earlier
+// execution of the same hook. If persist is set, r is stored as well.
+func (s *ReleaseServer) recordHookExecution(r *release.Release, execution *release.HookExecution, persist bool) {
+	status := r.Info.Status
+	replaced := false
+	for i, e := range status.HookExecutions {
+		if e.Name == execution.Name {
+			status.HookExecutions[i] = execution
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		status.HookExecutions = append(status.HookExecutions, execution)
+	}
+	if persist {
+		s.recordRelease(r, true)
+	}
+}
+
+// completeHookExecution moves execution to its final phase.
+func (s *ReleaseServer) completeHookExecution(r *release.Release, execution *release.HookExecution, phase release.HookExecution_Phase, persist bool) {
+	execution.Phase = phase
+	execution.CompletedAt = timeconv.Now()
+	if persist {
+		s.recordRelease(r, true)
+	}
+}
+
 func validateManifest(c environment.KubeClient, ns string, manifest []byte) error {
 	r := bytes.NewReader(manifest)
 	return c.Validate(ns, r)
 }
 
+// validateReleaseLabels checks that the labels of a release are valid
+// Kubernetes labels, so that they can be matched by label selectors.
+func validateReleaseLabels(releaseLabels map[string]string) error {
+	for k, v := range releaseLabels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return fmt.Errorf("invalid release label key %q: %s", k, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return fmt.Errorf("invalid value %q of release label %q: %s", v, k, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// deployedBy returns the identity to record on a release for a request: the
+// common name of the TLS client certificate, when Tiller verified it, or else
+// the identity reported by the client.
+func deployedBy(c ctx.Context, reported string) string {
+	p, ok := peer.FromContext(c)
+	if !ok {
+		return reported
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		for _, chain := range tlsInfo.State.VerifiedChains {
+			if len(chain) > 0 && chain[0].Subject.CommonName != "" {
+				return chain[0].Subject.CommonName
+			}
+		}
+	}
+	return reported
+}
+
 func validateReleaseName(releaseName string) error {
 	if releaseName == "" {
 		return errMissingRelease
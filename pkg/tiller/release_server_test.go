@@ -17,21 +17,30 @@ limitations under the License.
 package tiller
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/technosophos/moniker"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -75,6 +84,21 @@ spec:
     - ct
 `
 
+var crdManifests = `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.stable.example.com
+spec:
+  group: stable.example.com
+`
+
 var manifestWithTestHook = `kind: Pod
 metadata:
   name: finding-nemo,
@@ -160,6 +184,12 @@ func withKube(version string) chartOption {
 	}
 }
 
+func withType(typ string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Metadata.Type = typ
+	}
+}
+
 func withTiller(version string) chartOption {
 	return func(opts *chartOptions) {
 		opts.Metadata.TillerVersion = version
@@ -181,6 +211,12 @@ func withNotes(notes string) chartOption {
 	}
 }
 
+func withCRDs(path, crds string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Files = append(opts.Files, &any.Any{TypeUrl: path, Value: []byte(crds)})
+	}
+}
+
 func withSampleTemplates() chartOption {
 	return func(opts *chartOptions) {
 		sampleTemplates := []*chart.Template{
@@ -338,6 +374,30 @@ func TestValidName(t *testing.T) {
 	}
 }
 
+func TestDeployedBy(t *testing.T) {
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "ci-bot"}}}},
+	}}
+	unverified := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "mallory"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		c        context.Context
+		expected string
+	}{
+		{"without a peer", helm.NewContext(), "jane"},
+		{"with a verified certificate", peer.NewContext(helm.NewContext(), &peer.Peer{AuthInfo: verified}), "ci-bot"},
+		{"with an unverified certificate", peer.NewContext(helm.NewContext(), &peer.Peer{AuthInfo: unverified}), "jane"},
+	}
+	for _, tt := range tests {
+		if got := deployedBy(tt.c, "jane"); got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
 func TestGetAllVersionSet(t *testing.T) {
 	rs := rsFixture()
 	vs, err := GetAllVersionSet(rs.clientset.Discovery())
@@ -540,6 +600,109 @@ func (h *hookFailingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout
 	return errors.New("Failed watch")
 }
 
+func newServerDryRunFailingKubeClient() *serverDryRunFailingKubeClient {
+	return &serverDryRunFailingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+	}
+}
+
+type serverDryRunFailingKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (s *serverDryRunFailingKubeClient) ServerDryRun(ns string, r io.Reader) error {
+	return errors.New("admission webhook denied the request")
+}
+
+func newRecreatingKubeClient(recreated ...string) *recreatingKubeClient {
+	return &recreatingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		recreated:          recreated,
+	}
+}
+
+// recreatingKubeClient reports that updates recreate the given resources.
+type recreatingKubeClient struct {
+	environment.PrintingKubeClient
+	recreated []string
+}
+
+func (r *recreatingKubeClient) RecreatedResources(ns string, originalReader, targetReader io.Reader) ([]string, error) {
+	return r.recreated, nil
+}
+
+func newDriftingKubeClient(drifted ...string) *driftingKubeClient {
+	return &driftingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		drifted:            drifted,
+	}
+}
+
+// driftingKubeClient reports that the given resources drifted from their
+// manifests.
+type driftingKubeClient struct {
+	environment.PrintingKubeClient
+	drifted []string
+}
+
+func (d *driftingKubeClient) DriftedResources(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	var drifts []kube.ResourceDrift
+	for _, r := range d.drifted {
+		drifts = append(drifts, kube.ResourceDrift{Resource: r, Manifest: "manifest of " + r, Live: "live state of " + r})
+	}
+	return drifts, nil
+}
+
+func newCRDRecordingKubeClient(existing string) *crdRecordingKubeClient {
+	return &crdRecordingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		existing:           existing,
+	}
+}
+
+// crdRecordingKubeClient records the CRDs it creates. The CRD named existing
+// is reported to exist already.
+type crdRecordingKubeClient struct {
+	environment.PrintingKubeClient
+	existing    string
+	created     []string
+	established int
+}
+
+func (c *crdRecordingKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(b), "kind: CustomResourceDefinition") {
+		return nil
+	}
+	if c.existing != "" && strings.Contains(string(b), "name: "+c.existing) {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, c.existing)
+	}
+	c.created = append(c.created, string(b))
+	return nil
+}
+
+func (c *crdRecordingKubeClient) WaitUntilCRDEstablished(r io.Reader, timeout time.Duration) error {
+	c.established++
+	return nil
+}
+
+type deleteRecordingKubeClient struct {
+	*hookFailingKubeClient
+	deleted []string
+}
+
+func (d *deleteRecordingKubeClient) Delete(ns string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	d.deleted = append(d.deleted, string(b))
+	return nil
+}
+
 func newDeleteFailingKubeClient() *deleteFailingKubeClient {
 	return &deleteFailingKubeClient{
 		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
@@ -558,6 +721,10 @@ func (d *deleteFailingKubeClient) DeleteWithTimeout(ns string, r io.Reader, time
 	return kube.ErrNoObjectsVisited
 }
 
+func (d *deleteFailingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	return kube.ErrNoObjectsVisited
+}
+
 type mockListServer struct {
 	val *services.ListReleasesResponse
 }
@@ -594,6 +761,8 @@ type mockHooksManifest struct {
 }
 type mockHooksKubeClient struct {
 	Resources map[string]*mockHooksManifest
+	// Timeouts records the timeout each resource was watched with.
+	Timeouts map[string]int64
 }
 
 var errResourceExists = errors.New("resource already exists")
@@ -613,6 +782,9 @@ func (kc *mockHooksKubeClient) makeManifest(r io.Reader) (*mockHooksManifest, er
 	return manifest, nil
 }
 func (kc *mockHooksKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return kc.CreateWithOptions(ns, r, kube.CreateOptions{Timeout: timeout, ShouldWait: shouldWait})
+}
+func (kc *mockHooksKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
 	manifest, err := kc.makeManifest(r)
 	if err != nil {
 		return err
@@ -633,6 +805,9 @@ func (kc *mockHooksKubeClient) Delete(ns string, r io.Reader) error {
 	return kc.DeleteWithTimeout(ns, r, 0, false)
 }
 func (kc *mockHooksKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	return kc.DeleteWithOptions(ns, r, kube.DeleteOptions{Timeout: timeout, ShouldWait: shouldWait})
+}
+func (kc *mockHooksKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
 	manifest, err := kc.makeManifest(r)
 	if err != nil {
 		return err
@@ -652,6 +827,9 @@ func (kc *mockHooksKubeClient) WatchUntilReady(ns string, r io.Reader, timeout i
 	if !hasManifest {
 		return fmt.Errorf("mockHooksKubeClient.WatchUntilReady: no such resource %s found", paramManifest.Metadata.Name)
 	}
+	if kc.Timeouts != nil {
+		kc.Timeouts[paramManifest.Metadata.Name] = timeout
+	}
 
 	if manifest.Metadata.Annotations["mockHooksKubeClient/Emulate"] == "hook-failed" {
 		return fmt.Errorf("mockHooksKubeClient.WatchUntilReady: hook-failed")
@@ -674,6 +852,18 @@ func (kc *mockHooksKubeClient) BuildUnstructured(ns string, reader io.Reader) (k
 func (kc *mockHooksKubeClient) Validate(ns string, reader io.Reader) error {
 	return nil
 }
+func (kc *mockHooksKubeClient) ServerDryRun(ns string, reader io.Reader) error {
+	return nil
+}
+func (kc *mockHooksKubeClient) RecreatedResources(ns string, originalReader, targetReader io.Reader) ([]string, error) {
+	return nil, nil
+}
+func (kc *mockHooksKubeClient) DriftedResources(ns string, reader io.Reader) ([]kube.ResourceDrift, error) {
+	return nil, nil
+}
+func (kc *mockHooksKubeClient) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
 func (kc *mockHooksKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (v1.PodPhase, error) {
 	return v1.PodUnknown, nil
 }
@@ -723,8 +913,17 @@ name: value`, hookName, extraAnnotationsStr),
 	}
 }
 
+func hookReleaseStub(hook *release.Hook, releaseName string, namespace string) *release.Release {
+	return &release.Release{
+		Name:      releaseName,
+		Namespace: namespace,
+		Info:      &release.Info{Status: &release.Status{Code: release.Status_PENDING_INSTALL}},
+		Hooks:     []*release.Hook{hook},
+	}
+}
+
 func execHookShouldSucceed(rs *ReleaseServer, hook *release.Hook, releaseName string, namespace string, hookType string) error {
-	err := rs.execHook([]*release.Hook{hook}, releaseName, namespace, hookType, 600)
+	err := rs.execHook(hookReleaseStub(hook, releaseName, namespace), hookType, 600)
 	if err != nil {
 		return fmt.Errorf("expected hook %s to be successful: %s", hook.Name, err)
 	}
@@ -732,7 +931,7 @@ func execHookShouldSucceed(rs *ReleaseServer, hook *release.Hook, releaseName st
 }
 
 func execHookShouldFail(rs *ReleaseServer, hook *release.Hook, releaseName string, namespace string, hookType string) error {
-	err := rs.execHook([]*release.Hook{hook}, releaseName, namespace, hookType, 600)
+	err := rs.execHook(hookReleaseStub(hook, releaseName, namespace), hookType, 600)
 	if err == nil {
 		return fmt.Errorf("expected hook %s to be failed", hook.Name)
 	}
@@ -740,7 +939,7 @@ func execHookShouldFail(rs *ReleaseServer, hook *release.Hook, releaseName strin
 }
 
 func execHookShouldFailWithError(rs *ReleaseServer, hook *release.Hook, releaseName string, namespace string, hookType string, expectedError error) error {
-	err := rs.execHook([]*release.Hook{hook}, releaseName, namespace, hookType, 600)
+	err := rs.execHook(hookReleaseStub(hook, releaseName, namespace), hookType, 600)
 	if err != expectedError {
 		return fmt.Errorf("expected hook %s to fail with error %v, got %v", hook.Name, expectedError, err)
 	}
@@ -758,6 +957,7 @@ type deletePolicyContext struct {
 func newDeletePolicyContext() *deletePolicyContext {
 	kubeClient := &mockHooksKubeClient{
 		Resources: make(map[string]*mockHooksManifest),
+		Timeouts:  make(map[string]int64),
 	}
 
 	return &deletePolicyContext{
@@ -782,6 +982,28 @@ func TestSuccessfulHookWithoutDeletePolicy(t *testing.T) {
 	}
 }
 
+func TestHookTimeout(t *testing.T) {
+	ctx := newDeletePolicyContext()
+	hook := deletePolicyHookStub(ctx.HookName, nil, nil)
+	hook.Timeout = 1200
+
+	if err := execHookShouldSucceed(ctx.ReleaseServer, hook, ctx.ReleaseName, ctx.Namespace, hooks.PreInstall); err != nil {
+		t.Error(err)
+	}
+	if got := ctx.KubeClient.Timeouts[hook.Name]; got != 1200 {
+		t.Errorf("expected hook %s to be watched with its own timeout 1200, got %d", hook.Name, got)
+	}
+
+	hook.Timeout = 0
+	delete(ctx.KubeClient.Resources, hook.Name)
+	if err := execHookShouldSucceed(ctx.ReleaseServer, hook, ctx.ReleaseName, ctx.Namespace, hooks.PreUpgrade); err != nil {
+		t.Error(err)
+	}
+	if got := ctx.KubeClient.Timeouts[hook.Name]; got != 600 {
+		t.Errorf("expected hook %s to be watched with the release timeout 600, got %d", hook.Name, got)
+	}
+}
+
 func TestFailedHookWithoutDeletePolicy(t *testing.T) {
 	ctx := newDeletePolicyContext()
 	hook := deletePolicyHookStub(ctx.HookName,
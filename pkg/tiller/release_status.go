@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 // GetReleaseStatus gets the status information for a named release.
@@ -54,12 +55,22 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 	if rel.Chart == nil {
 		return nil, errors.New("release chart is missing")
 	}
+	rel = s.withNotes(rel)
+
+	digest, err := releaseutil.ValuesDigest(rel)
+	if err != nil {
+		return nil, fmt.Errorf("computing values digest: %s", err)
+	}
 
 	sc := rel.Info.Status.Code
 	statusResp := &services.GetReleaseStatusResponse{
-		Name:      rel.Name,
-		Namespace: rel.Namespace,
-		Info:      rel.Info,
+		Name:         rel.Name,
+		Namespace:    rel.Namespace,
+		Info:         rel.Info,
+		Chart:        rel.Chart.GetMetadata().GetName(),
+		ChartVersion: rel.Chart.GetMetadata().GetVersion(),
+		ValuesDigest: digest,
+		Version:      rel.Version,
 	}
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
@@ -43,6 +43,13 @@ func TestGetReleaseStatus(t *testing.T) {
 	if res.Info.Status.Code != release.Status_DEPLOYED {
 		t.Errorf("Expected %d, got %d", release.Status_DEPLOYED, res.Info.Status.Code)
 	}
+	if res.Chart != "hello" {
+		t.Errorf("Expected chart %q, got %q", "hello", res.Chart)
+	}
+	// The digest of the stub's values, "name: value".
+	if expected := "sha256:806c4e125a3c2c63d1b0b8e8379346bbf8c9340314ea6ece041a25ee5f9d4603"; res.ValuesDigest != expected {
+		t.Errorf("Expected values digest %q, got %q", expected, res.ValuesDigest)
+	}
 }
 
 func TestGetReleaseStatusDeleted(t *testing.T) {
@@ -17,12 +17,18 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	ctx "golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
@@ -35,6 +41,10 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		s.Log("uninstallRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	policy, err := propagationPolicy(req.Cascade)
+	if err != nil {
+		return nil, err
+	}
 
 	rels, err := s.env.Releases.History(req.Name)
 	if err != nil {
@@ -48,6 +58,10 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	relutil.SortByRevision(rels)
 	rel := rels[len(rels)-1]
 
+	if rel.Protected && !req.ForceProtected {
+		return nil, fmt.Errorf("release %q is protected from deletion, use --force-protected to delete it", req.Name)
+	}
+
 	// TODO: Are there any cases where we want to force a delete even if it's
 	// already marked deleted?
 	if rel.Info.Status.Code == release.Status_DELETED {
@@ -68,7 +82,7 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	res := &services.UninstallReleaseResponse{Release: rel}
 
 	if !req.DisableHooks {
-		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PreDelete, req.Timeout); err != nil {
+		if err := s.execHook(rel, hooks.PreDelete, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -83,6 +97,9 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 
 	kept, errs := s.ReleaseModule.Delete(rel, req, s.env)
 	res.Info = kept
+	if !req.IgnoreResourcePolicy {
+		res.Kept = keptResources(rel, s.env.KubeClient)
+	}
 
 	es := make([]string, 0, len(errs))
 	for _, e := range errs {
@@ -91,11 +108,25 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	}
 
 	if !req.DisableHooks {
-		if err := s.execHook(rel.Hooks, rel.Name, rel.Namespace, hooks.PostDelete, req.Timeout); err != nil {
+		if err := s.execHook(rel, hooks.PostDelete, req.Timeout); err != nil {
 			es = append(es, err.Error())
 		}
 	}
 
+	if removed := removedManifest(rel, req.IgnoreResourcePolicy); req.Wait && strings.TrimSpace(removed) != "" {
+		s.Log("uninstall: waiting for the resources of %s to be removed", req.Name)
+		err := s.env.KubeClient.DeleteWithOptions(rel.Namespace, bytes.NewBufferString(removed), kube.DeleteOptions{
+			Timeout:           req.Timeout,
+			ShouldWait:        true,
+			PropagationPolicy: policy,
+		})
+		if err != nil {
+			rel.Info.Description = fmt.Sprintf("Deletion failed waiting for the resources to be removed: %s", err)
+			s.recordRelease(rel, true)
+			return res, err
+		}
+	}
+
 	rel.Info.Status.Code = release.Status_DELETED
 	if req.Description == "" {
 		rel.Info.Description = "Deletion complete"
@@ -122,6 +153,148 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	return res, nil
 }
 
+// UninstallReleases uninstalls the releases whose names match the filter and
+// whose labels match the selector, one after the other. A failure to uninstall
+// one of them does not stop the others, and is reported in the Failed list of
+// the response.
+func (s *ReleaseServer) UninstallReleases(c ctx.Context, req *services.UninstallReleasesRequest) (*services.UninstallReleasesResponse, error) {
+	if req.Selector == "" && req.Filter == "" {
+		return nil, errors.New("a selector or a filter is required to uninstall several releases")
+	}
+	if _, err := propagationPolicy(req.Cascade); err != nil {
+		return nil, err
+	}
+
+	all, err := s.env.Releases.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+	latest := map[string]*release.Release{}
+	for _, r := range all {
+		if l, ok := latest[r.Name]; !ok || r.Version > l.Version {
+			latest[r.Name] = r
+		}
+	}
+	rels := make([]*release.Release, 0, len(latest))
+	for _, r := range latest {
+		// Deleted releases can only be purged.
+		if req.Purge || r.Info.Status.Code != release.Status_DELETED {
+			rels = append(rels, r)
+		}
+	}
+	if req.Filter != "" {
+		if rels, err = filterReleases(req.Filter, rels); err != nil {
+			return nil, err
+		}
+	}
+	if req.Selector != "" {
+		if rels, err = filterBySelector(req.Selector, rels); err != nil {
+			return nil, err
+		}
+	}
+	relutil.SortByName(rels)
+
+	// The releases that cannot be uninstalled are reported in the response
+	// rather than as an error, as gRPC drops the response of a failed call.
+	res := &services.UninstallReleasesResponse{}
+	if req.DryRun {
+		for _, r := range rels {
+			if r.Protected && !req.ForceProtected {
+				res.Failed = append(res.Failed, &services.FailedUninstall{
+					Name:  r.Name,
+					Error: fmt.Sprintf("release %q is protected from deletion, use --force-protected to delete it", r.Name),
+				})
+				continue
+			}
+			res.Releases = append(res.Releases, r)
+		}
+		return res, nil
+	}
+
+	var infos []string
+	for _, r := range rels {
+		s.Log("uninstall: deleting %s as it matches the request", r.Name)
+		ures, err := s.UninstallRelease(c, &services.UninstallReleaseRequest{
+			Name:                 r.Name,
+			DisableHooks:         req.DisableHooks,
+			Purge:                req.Purge,
+			Timeout:              req.Timeout,
+			Description:          req.Description,
+			Wait:                 req.Wait,
+			Cascade:              req.Cascade,
+			ForceProtected:       req.ForceProtected,
+			IgnoreResourcePolicy: req.IgnoreResourcePolicy,
+		})
+		if ures != nil {
+			if ures.Info != "" {
+				infos = append(infos, ures.Info)
+			}
+			res.Kept = append(res.Kept, ures.Kept...)
+		}
+		if err != nil {
+			res.Failed = append(res.Failed, &services.FailedUninstall{Name: r.Name, Error: err.Error()})
+			continue
+		}
+		res.Releases = append(res.Releases, ures.Release)
+	}
+	res.Info = strings.Join(infos, "\n")
+
+	return res, nil
+}
+
+// propagationPolicy returns the deletion propagation policy named by cascade,
+// which defaults to background.
+func propagationPolicy(cascade string) (metav1.DeletionPropagation, error) {
+	switch cascade {
+	case "", "background":
+		return metav1.DeletePropagationBackground, nil
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	}
+	return "", fmt.Errorf("invalid cascade %q, must be one of background, foreground or orphan", cascade)
+}
+
+// removedManifest returns the documents of the release manifest that are
+// deleted on uninstall, leaving out the resources kept by their resource
+// policy unless ignoreResourcePolicy is set, followed by the uninstall hooks
+// that are deleted once they succeed.
+func removedManifest(rel *release.Release, ignoreResourcePolicy bool) string {
+	manifests := relutil.SplitManifests(rel.Manifest)
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var docs []string
+	for _, name := range names {
+		var head relutil.SimpleHead
+		err := yaml.Unmarshal([]byte(manifests[name]), &head)
+		if !ignoreResourcePolicy && err == nil && head.Metadata != nil && kube.ResourcePolicyIsKeep(head.Metadata.Annotations) {
+			continue
+		}
+		docs = append(docs, manifests[name])
+	}
+	for _, h := range rel.Hooks {
+		if hookHasDeletePolicy(h, hooks.HookSucceeded) && (hookHasEvent(h, release.Hook_PRE_DELETE) || hookHasEvent(h, release.Hook_POST_DELETE)) {
+			docs = append(docs, h.Manifest)
+		}
+	}
+	return strings.Join(docs, "\n---\n")
+}
+
+// hookHasEvent reports whether the hook runs on the given event.
+func hookHasEvent(h *release.Hook, event release.Hook_Event) bool {
+	for _, e := range h.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *ReleaseServer) purgeReleases(rels ...*release.Release) error {
 	for _, rel := range rels {
 		if _, err := s.env.Releases.Delete(rel.Name, rel.Version); err != nil {
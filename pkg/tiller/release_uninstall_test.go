@@ -17,12 +17,20 @@ limitations under the License.
 package tiller
 
 import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestUninstallRelease(t *testing.T) {
@@ -157,6 +165,181 @@ func TestUninstallReleaseWithKeepPolicy(t *testing.T) {
 			t.Errorf("unexpected output: %s", res.Info)
 		}
 	}
+
+	var kept []string
+	for _, k := range res.Kept {
+		kept = append(kept, k.Release+": "+k.Kind+"/"+k.Name)
+	}
+	expected := []string{"angry-bunny: ConfigMap/test-cm-keep-a", "angry-bunny: ConfigMap/test-cm-keep-b"}
+	if !reflect.DeepEqual(kept, expected) {
+		t.Errorf("Expected kept resources %v, got %v", expected, kept)
+	}
+}
+
+func TestUninstallReleaseIgnoreResourcePolicy(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	name := "angry-bunny"
+	rs.env.Releases.Create(releaseWithKeepStub(name))
+
+	res, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: name, IgnoreResourcePolicy: true})
+	if err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+	if res.Info != "" || len(res.Kept) != 0 {
+		t.Errorf("Expected no resources to be kept, got %q and %v", res.Info, res.Kept)
+	}
+}
+
+// waitingKubeClient records the propagation policy of the deletions and the
+// manifest it waits to be deleted, and fails the wait with err.
+type waitingKubeClient struct {
+	environment.PrintingKubeClient
+	policies []metav1.DeletionPropagation
+	waited   string
+	err      error
+}
+
+func (w *waitingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	w.policies = append(w.policies, opts.PropagationPolicy)
+	if !opts.ShouldWait {
+		return w.PrintingKubeClient.DeleteWithOptions(ns, r, opts)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	w.waited = string(b)
+	return w.err
+}
+
+func TestUninstallReleaseWait(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &waitingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kc
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap\nmetadata:\n  name: gone"
+	rs.env.Releases.Create(rel)
+
+	req := &services.UninstallReleaseRequest{
+		Name: "angry-panda",
+		Wait: true,
+	}
+	res, err := rs.UninstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+	if res.Release.Info.Status.Code != release.Status_DELETED {
+		t.Errorf("Expected status code to be DELETED, got %d", res.Release.Info.Status.Code)
+	}
+	if kc.waited != rel.Manifest {
+		t.Errorf("Expected to wait for the release manifest, got %q", kc.waited)
+	}
+}
+
+func TestUninstallReleaseWaitTimeout(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = &waitingKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		err:                errors.New("timed out waiting for the condition"),
+	}
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap\nmetadata:\n  name: gone"
+	rs.env.Releases.Create(rel)
+
+	req := &services.UninstallReleaseRequest{
+		Name:  "angry-panda",
+		Purge: true,
+		Wait:  true,
+	}
+	if _, err := rs.UninstallRelease(c, req); err == nil {
+		t.Fatal("Expected the uninstall to fail")
+	}
+
+	stored, err := rs.env.Releases.Get("angry-panda", 1)
+	if err != nil {
+		t.Fatalf("Expected the release to be kept: %s", err)
+	}
+	if stored.Info.Status.Code != release.Status_DELETING {
+		t.Errorf("Expected status code to be DELETING, got %d", stored.Info.Status.Code)
+	}
+}
+
+func TestUninstallReleaseCascade(t *testing.T) {
+	tests := []struct {
+		cascade  string
+		expected metav1.DeletionPropagation
+	}{
+		{"", metav1.DeletePropagationBackground},
+		{"foreground", metav1.DeletePropagationForeground},
+		{"orphan", metav1.DeletePropagationOrphan},
+	}
+
+	for _, tt := range tests {
+		c := helm.NewContext()
+		rs := rsFixture()
+		kc := &waitingKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+		rs.env.KubeClient = kc
+		rel := releaseStub()
+		rel.Manifest = "kind: ConfigMap\nmetadata:\n  name: gone"
+		rs.env.Releases.Create(rel)
+
+		req := &services.UninstallReleaseRequest{
+			Name:    "angry-panda",
+			Wait:    true,
+			Cascade: tt.cascade,
+		}
+		if _, err := rs.UninstallRelease(c, req); err != nil {
+			t.Fatalf("Failed uninstall with cascade %q: %s", tt.cascade, err)
+		}
+		// The resources are deleted, then deleted again to wait for them.
+		if len(kc.policies) != 2 || kc.policies[0] != tt.expected || kc.policies[1] != tt.expected {
+			t.Errorf("Expected cascade %q to delete with policy %s, got %v", tt.cascade, tt.expected, kc.policies)
+		}
+	}
+
+	rs := rsFixture()
+	rs.env.Releases.Create(releaseStub())
+	_, err := rs.UninstallRelease(helm.NewContext(), &services.UninstallReleaseRequest{Name: "angry-panda", Cascade: "sideways"})
+	if err == nil || !strings.Contains(err.Error(), "invalid cascade") {
+		t.Errorf("Expected an invalid cascade error, got %v", err)
+	}
+}
+
+func TestRemovedManifest(t *testing.T) {
+	rel := &release.Release{
+		Manifest: "kind: ConfigMap\nmetadata:\n  name: gone\n---\n" + manifestWithKeep,
+		Hooks: []*release.Hook{
+			{
+				Name:           "cleanup",
+				Manifest:       "kind: Job\nmetadata:\n  name: cleanup",
+				Events:         []release.Hook_Event{release.Hook_PRE_DELETE},
+				DeletePolicies: []release.Hook_DeletePolicy{release.Hook_SUCCEEDED},
+			},
+			{
+				Name:     "kept",
+				Manifest: "kind: Job\nmetadata:\n  name: kept",
+				Events:   []release.Hook_Event{release.Hook_POST_DELETE},
+			},
+			{
+				Name:           "install",
+				Manifest:       "kind: Job\nmetadata:\n  name: install",
+				Events:         []release.Hook_Event{release.Hook_POST_INSTALL},
+				DeletePolicies: []release.Hook_DeletePolicy{release.Hook_SUCCEEDED},
+			},
+		},
+	}
+
+	expected := "kind: ConfigMap\nmetadata:\n  name: gone\n---\nkind: Job\nmetadata:\n  name: cleanup"
+	if got := removedManifest(rel, false); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	if got := removedManifest(rel, true); !strings.Contains(got, "name: test-cm-keep-a") {
+		t.Errorf("expected the kept resource to be removed when ignoring the resource policy, got\n%s", got)
+	}
 }
 
 func TestUninstallReleaseNoHooks(t *testing.T) {
@@ -228,3 +411,126 @@ data:
 		t.Errorf("Expected delete error message to contain object name, got:" + err.Error())
 	}
 }
+
+func TestUninstallReleases(t *testing.T) {
+	stub := func(name, team string, status release.Status_Code) *release.Release {
+		r := namedReleaseStub(name, status)
+		r.Labels = map[string]string{"team": team}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		req      *services.UninstallReleasesRequest
+		expected []string
+		failed   []string
+		err      bool
+	}{
+		{
+			name:     "by filter",
+			req:      &services.UninstallReleasesRequest{Filter: "^pr-[0-9]+-"},
+			expected: []string{"pr-1-web", "pr-2-api"},
+		},
+		{
+			name:     "by selector",
+			req:      &services.UninstallReleasesRequest{Selector: "team=ci-ephemeral"},
+			expected: []string{"pr-1-web", "pr-2-api", "preview"},
+		},
+		{
+			name:     "by filter and selector",
+			req:      &services.UninstallReleasesRequest{Filter: "^pr-", Selector: "team=ci-ephemeral"},
+			expected: []string{"pr-1-web", "pr-2-api"},
+		},
+		{
+			name:     "purging deleted releases",
+			req:      &services.UninstallReleasesRequest{Filter: "^pr-", Purge: true},
+			expected: []string{"pr-1-web", "pr-2-api", "pr-3-old"},
+		},
+		{
+			name:     "dry run",
+			req:      &services.UninstallReleasesRequest{Filter: "^pr-", DryRun: true},
+			expected: []string{"pr-1-web", "pr-2-api"},
+		},
+		{
+			name:     "with a protected release",
+			req:      &services.UninstallReleasesRequest{Selector: "team=ci-ephemeral"},
+			expected: []string{"pr-1-web", "pr-2-api"},
+			failed:   []string{"preview"},
+		},
+		{
+			name:     "dry run with a protected release",
+			req:      &services.UninstallReleasesRequest{Selector: "team=ci-ephemeral", DryRun: true},
+			expected: []string{"pr-1-web", "pr-2-api"},
+			failed:   []string{"preview"},
+		},
+		{
+			name:     "forcing a protected release",
+			req:      &services.UninstallReleasesRequest{Selector: "team=ci-ephemeral", ForceProtected: true},
+			expected: []string{"pr-1-web", "pr-2-api", "preview"},
+		},
+		{
+			name: "without a selector or a filter",
+			req:  &services.UninstallReleasesRequest{},
+			err:  true,
+		},
+		{
+			name: "with an invalid selector",
+			req:  &services.UninstallReleasesRequest{Selector: "team in"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		rs := rsFixture()
+		for _, r := range []*release.Release{
+			stub("pr-1-web", "ci-ephemeral", release.Status_DEPLOYED),
+			stub("pr-2-api", "ci-ephemeral", release.Status_FAILED),
+			stub("pr-3-old", "ci-ephemeral", release.Status_DELETED),
+			stub("preview", "ci-ephemeral", release.Status_DEPLOYED),
+			stub("payments", "payments", release.Status_DEPLOYED),
+		} {
+			r.Protected = r.Name == "preview" && (tt.failed != nil || tt.req.ForceProtected)
+			rs.env.Releases.Create(r)
+		}
+
+		res, err := rs.UninstallReleases(helm.NewContext(), tt.req)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+
+		var names []string
+		for _, r := range res.Releases {
+			names = append(names, r.Name)
+			stored, err := rs.env.Releases.Get(r.Name, r.Version)
+			switch {
+			case tt.req.DryRun && (err != nil || stored.Info.Status.Code == release.Status_DELETED):
+				t.Errorf("%s: expected %s to be kept", tt.name, r.Name)
+			case !tt.req.DryRun && tt.req.Purge && err == nil:
+				t.Errorf("%s: expected %s to be purged", tt.name, r.Name)
+			case !tt.req.DryRun && !tt.req.Purge && (err != nil || stored.Info.Status.Code != release.Status_DELETED):
+				t.Errorf("%s: expected %s to be deleted", tt.name, r.Name)
+			}
+		}
+		if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: expected releases %v, got %v", tt.name, tt.expected, names)
+		}
+
+		var failed []string
+		for _, f := range res.Failed {
+			failed = append(failed, f.Name)
+			if stored, err := rs.env.Releases.Last(f.Name); err != nil || stored.Info.Status.Code != release.Status_DEPLOYED {
+				t.Errorf("%s: expected %s to be kept", tt.name, f.Name)
+			}
+		}
+		if strings.Join(failed, ",") != strings.Join(tt.failed, ",") {
+			t.Errorf("%s: expected failed releases %v, got %v", tt.name, tt.failed, failed)
+		}
+	}
+}
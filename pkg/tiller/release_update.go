@@ -17,15 +17,19 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	ctx "golang.org/x/net/context"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -35,6 +39,7 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 		s.Log("updateRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	req.DeployedBy = deployedBy(c, req.DeployedBy)
 	s.Log("preparing update for %s", req.Name)
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
@@ -42,12 +47,32 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 		if req.Force {
 			// Use the --force, Luke.
 			s.Log("performing force update for %s", req.Name)
-			return s.performUpdateForce(req)
+			res, err := s.performUpdateForce(req)
+			if res != nil {
+				res.Release = s.withNotes(res.Release)
+			}
+			return res, err
 		}
 		return nil, err
 	}
 
+	// Only CRDs that do not exist yet are installed, once the chart renders;
+	// existing ones are never upgraded.
+	if !req.DryRun && !req.SkipCrds {
+		s.Log("installing new CRDs for %s", req.Name)
+		if err := s.installCRDs(req.Chart, currentRelease.Namespace, req.Timeout); err != nil {
+			s.Log("failed to install CRDs: %s", err)
+			return nil, err
+		}
+	}
+
 	if !req.DryRun {
+		if req.MaxHistory > 0 {
+			// Make space for the updated release.
+			if err := s.env.Releases.PruneHistory(req.Name, int(req.MaxHistory)-1); err != nil {
+				s.Log("warning: failed to prune history of %s: %s", req.Name, err)
+			}
+		}
 		s.Log("creating updated release for %s", req.Name)
 		if err := s.env.Releases.Create(updatedRelease); err != nil {
 			return nil, err
@@ -67,6 +92,7 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 		}
 	}
 
+	res.Release = s.withNotes(res.Release)
 	return res, nil
 }
 
@@ -75,6 +101,9 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	if req.Chart == nil {
 		return nil, nil, errMissingChart
 	}
+	if err := chartutil.CheckInstallable(req.Chart); err != nil {
+		return nil, nil, err
+	}
 
 	// finds the deployed release with the given name
 	currentRelease, err := s.env.Releases.Deployed(req.Name)
@@ -115,7 +144,14 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 		return nil, nil, err
 	}
 
-	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
+	hooks, manifestDoc, notesTxt, warnings, err := s.renderResources(req.Chart, valuesToRender, caps.APIVersions, renderOptions{
+		subNotes:      req.SubNotes,
+		postRendered:  req.PostRenderedManifest,
+		dryRun:        req.DryRun,
+		strict:        req.Strict,
+		missingKey:    req.MissingKey,
+		stripComments: req.StripComments,
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -124,6 +160,8 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	updatedRelease := &release.Release{
 		Name:      req.Name,
 		Namespace: currentRelease.Namespace,
+		Labels:    currentRelease.Labels,
+		Protected: currentRelease.Protected,
 		Chart:     req.Chart,
 		Config:    req.Values,
 		Info: &release.Info{
@@ -131,6 +169,8 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_UPGRADE},
 			Description:   "Preparing upgrade", // This should be overwritten later.
+			DeployedBy:    req.DeployedBy,
+			Warnings:      warnings,
 		},
 		Version:  revision,
 		Manifest: manifestDoc.String(),
@@ -161,9 +201,11 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 		Name:         req.Name,
 		DisableHooks: req.DisableHooks,
 		Namespace:    oldRelease.Namespace,
+		Labels:       oldRelease.Labels,
 		ReuseName:    true,
 		Timeout:      req.Timeout,
 		Wait:         req.Wait,
+		DeployedBy:   req.DeployedBy,
 	})
 	if err != nil {
 		s.Log("failed update prepare step: %s", err)
@@ -177,6 +219,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 
 	// update new release with next revision number so as to append to the old release's history
 	newRelease.Version = oldRelease.Version + 1
+	newRelease.Protected = oldRelease.Protected
 	res.Release = newRelease
 
 	if req.DryRun {
@@ -194,7 +237,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 
 	// pre-delete hooks
 	if !req.DisableHooks {
-		if err := s.execHook(oldRelease.Hooks, oldRelease.Name, oldRelease.Namespace, hooks.PreDelete, req.Timeout); err != nil {
+		if err := s.execHook(oldRelease, hooks.PreDelete, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -219,14 +262,14 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 
 	// post-delete hooks
 	if !req.DisableHooks {
-		if err := s.execHook(oldRelease.Hooks, oldRelease.Name, oldRelease.Namespace, hooks.PostDelete, req.Timeout); err != nil {
+		if err := s.execHook(oldRelease, hooks.PostDelete, req.Timeout); err != nil {
 			return res, err
 		}
 	}
 
 	// pre-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(newRelease.Hooks, newRelease.Name, newRelease.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+		if err := s.execHook(newRelease, hooks.PreInstall, req.Timeout); err != nil {
 			return res, err
 		}
 	}
@@ -243,7 +286,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 
 	// post-install hooks
 	if !req.DisableHooks {
-		if err := s.execHook(newRelease.Hooks, newRelease.Name, newRelease.Namespace, hooks.PostInstall, req.Timeout); err != nil {
+		if err := s.execHook(newRelease, hooks.PostInstall, req.Timeout); err != nil {
 			msg := fmt.Sprintf("Release %q failed post-install: %s", newRelease.Name, err)
 			s.Log("warning: %s", msg)
 			newRelease.Info.Status.Code = release.Status_FAILED
@@ -269,13 +312,26 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	if req.DryRun {
 		s.Log("dry run for %s", updatedRelease.Name)
+		if req.Validate {
+			if err := s.env.KubeClient.ServerDryRun(updatedRelease.Namespace, bytes.NewBufferString(updatedRelease.Manifest)); err != nil {
+				return res, err
+			}
+		}
+		if req.CheckRecreated {
+			recreated, err := s.env.KubeClient.RecreatedResources(updatedRelease.Namespace, bytes.NewBufferString(originalRelease.Manifest), bytes.NewBufferString(updatedRelease.Manifest))
+			if err != nil {
+				// The dry run does not depend on the check, which only informs.
+				s.Log("warning: could not check which resources %s would recreate: %s", updatedRelease.Name, err)
+			}
+			res.RecreatedResources = recreated
+		}
 		res.Release.Info.Description = "Dry run complete"
 		return res, nil
 	}
 
 	// pre-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease, hooks.PreUpgrade, req.Timeout); err != nil {
 			return res, err
 		}
 	} else {
@@ -293,7 +349,10 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	// post-upgrade hooks
 	if !req.DisableHooks {
-		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
+		if err := s.execHook(updatedRelease, hooks.PostUpgrade, req.Timeout); err != nil {
+			if req.CleanupOnFail {
+				s.deleteCreatedResources(originalRelease, updatedRelease)
+			}
 			return res, err
 		}
 	}
@@ -310,3 +369,52 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 
 	return res, nil
 }
+
+// deleteCreatedResources deletes the resources of updated that are not part of
+// original. The kube client already does this when the update itself fails, so
+// this covers failures that happen afterwards, such as a failed post-upgrade hook.
+func (s *ReleaseServer) deleteCreatedResources(original, updated *release.Release) {
+	created := createdManifests(original.Manifest, updated.Manifest)
+	if created == "" {
+		return
+	}
+	s.Log("cleanup on fail enabled: deleting resources created by the upgrade of %s", updated.Name)
+	if err := s.env.KubeClient.Delete(updated.Namespace, bytes.NewBufferString(created)); err != nil {
+		s.Log("warning: failed to delete resources created by the upgrade of %s: %s", updated.Name, err)
+	}
+}
+
+// createdManifests returns the documents of the updated manifest that describe
+// resources which are not in the original manifest.
+func createdManifests(original, updated string) string {
+	existing := map[string]bool{}
+	for _, m := range relutil.SplitManifests(original) {
+		existing[manifestKey(m)] = true
+	}
+
+	docs := relutil.SplitManifests(updated)
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var created []string
+	for _, name := range names {
+		if key := manifestKey(docs[name]); key != "" && !existing[key] {
+			created = append(created, docs[name])
+		}
+	}
+	return strings.Join(created, "\n---\n")
+}
+
+// manifestKey identifies the resource described by a manifest document by its
+// API version, kind and name. It returns an empty string if the document
+// cannot be parsed or has no name.
+func manifestKey(doc string) string {
+	var head relutil.SimpleHead
+	if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Metadata == nil || head.Metadata.Name == "" {
+		return ""
+	}
+	return head.Version + "/" + head.Kind + "/" + head.Metadata.Name
+}
@@ -373,6 +373,42 @@ func TestUpdateRelease_ReuseValues(t *testing.T) {
 	compareStoredAndReturnedRelease(t, *rs, *res)
 }
 
+func TestUpdateRelease_ResetThenReuseValues(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+				{Name: "templates/hooks", Data: []byte(manifestWithUpgradeHooks)},
+			},
+			// Unlike with reuseValues, the defaults of the new chart are kept.
+			Values: &chart.Config{Raw: "foo: bar\n"},
+		},
+		Values:               &chart.Config{Raw: "name2: val2"},
+		ResetThenReuseValues: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	expect := "foo: bar\n"
+	if res.Release.Chart.Values.Raw != expect {
+		t.Errorf("Expected chart values to be %q, got %q", expect, res.Release.Chart.Values.Raw)
+	}
+	// `name: value` comes from release Config via releaseStub()
+	expect = "name: value\nname2: val2\n"
+	if res.Release.Config.Raw != expect {
+		t.Errorf("Expected request config to be %q, got %q", expect, res.Release.Config.Raw)
+	}
+	compareStoredAndReturnedRelease(t, *rs, *res)
+}
+
 func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	// This verifies that when both reset and reuse are set, reset wins.
 	c := helm.NewContext()
@@ -403,6 +439,31 @@ func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	compareStoredAndReturnedRelease(t, *rs, *res)
 }
 
+func TestUpdateRelease_SubNotes(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: buildChart(
+			withNotes(notesText),
+			withDependency(withNotes(notesText+" child")),
+		),
+		SubNotes: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+
+	if expected := notesText + "\n" + notesText + " child"; res.Release.Info.Status.Notes != expected {
+		t.Errorf("Expected notes %q, got %q", expected, res.Release.Info.Status.Notes)
+	}
+	compareStoredAndReturnedRelease(t, *rs, *res)
+}
+
 func TestUpdateReleaseFailure(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -447,6 +508,57 @@ func TestUpdateReleaseFailure(t *testing.T) {
 	}
 }
 
+func TestUpdateReleaseFailure_CleanupOnFail(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap\nmetadata:\n  name: existing\n"
+	rs.env.Releases.Create(rel)
+	kc := &deleteRecordingKubeClient{hookFailingKubeClient: newHookFailingKubeClient()}
+	rs.env.KubeClient = kc
+	rs.Log = t.Logf
+
+	req := &services.UpdateReleaseRequest{
+		Name:          rel.Name,
+		CleanupOnFail: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/existing", Data: []byte("kind: ConfigMap\nmetadata:\n  name: existing\n")},
+				{Name: "templates/created", Data: []byte("kind: ConfigMap\nmetadata:\n  name: created\n")},
+				{Name: "templates/hooks", Data: []byte("kind: ConfigMap\nmetadata:\n  name: test-cm\n  annotations:\n    \"helm.sh/hook\": post-upgrade\n")},
+			},
+		},
+	}
+
+	if _, err := rs.UpdateRelease(c, req); err == nil {
+		t.Fatal("Expected failed update")
+	}
+
+	if len(kc.deleted) != 1 {
+		t.Fatalf("Expected 1 delete, got %d: %v", len(kc.deleted), kc.deleted)
+	}
+	if !strings.Contains(kc.deleted[0], "name: created") {
+		t.Errorf("Expected the created resource to be deleted, got %q", kc.deleted[0])
+	}
+	if strings.Contains(kc.deleted[0], "name: existing") {
+		t.Errorf("Expected the existing resource to be kept, got %q", kc.deleted[0])
+	}
+}
+
+func TestCreatedManifests(t *testing.T) {
+	original := "kind: ConfigMap\nmetadata:\n  name: a\n---\nkind: Secret\nmetadata:\n  name: b\n"
+	updated := "kind: ConfigMap\nmetadata:\n  name: a\n---\nkind: ConfigMap\nmetadata:\n  name: b\n"
+
+	expected := "kind: ConfigMap\nmetadata:\n  name: b"
+	if got := createdManifests(original, updated); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := createdManifests(updated, updated); got != "" {
+		t.Errorf("Expected no created manifests, got %q", got)
+	}
+}
+
 func TestUpdateReleaseFailure_Force(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -520,6 +632,113 @@ func TestUpdateReleaseNoHooks(t *testing.T) {
 
 }
 
+func TestUpdateReleaseDryRunValidate(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	rs.env.KubeClient = newServerDryRunFailingKubeClient()
+
+	req := &services.UpdateReleaseRequest{
+		Name:     rel.Name,
+		DryRun:   true,
+		Validate: true,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+			},
+		},
+	}
+
+	_, err := rs.UpdateRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "admission webhook denied the request") {
+		t.Errorf("Expected the server-side dry run to fail, got %v", err)
+	}
+}
+
+func TestUpdateReleaseDryRunRecreatedResources(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	rs.env.KubeClient = newRecreatingKubeClient("Service/hello")
+
+	req := &services.UpdateReleaseRequest{
+		Name:   rel.Name,
+		DryRun: true,
+		Chart:  rel.GetChart(),
+	}
+
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if len(res.RecreatedResources) != 0 {
+		t.Errorf("Expected no recreated resources unless asked for, got %v", res.RecreatedResources)
+	}
+
+	req.CheckRecreated = true
+	res, err = rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if !reflect.DeepEqual(res.RecreatedResources, []string{"Service/hello"}) {
+		t.Errorf("Expected the recreated resources to be reported, got %v", res.RecreatedResources)
+	}
+
+	req.DryRun = false
+	res, err = rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if len(res.RecreatedResources) != 0 {
+		t.Errorf("Expected no recreated resources outside of dry runs, got %v", res.RecreatedResources)
+	}
+}
+
+func TestUpdateReleaseMaxHistory(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	for v := int32(1); v <= 3; v++ {
+		rel := namedReleaseStub("angry-panda", release.Status_SUPERSEDED)
+		rel.Version = v
+		if v == 3 {
+			rel.Info.Status.Code = release.Status_DEPLOYED
+		}
+		rs.env.Releases.Create(rel)
+	}
+
+	req := &services.UpdateReleaseRequest{
+		Name:         "angry-panda",
+		DisableHooks: true,
+		MaxHistory:   2,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("hello: world")},
+			},
+		},
+	}
+
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+
+	hist, err := rs.env.Releases.History("angry-panda")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist) != 2 {
+		t.Fatalf("Expected 2 revisions in history, got %d", len(hist))
+	}
+	for _, rel := range hist {
+		if rel.Version != 3 && rel.Version != 4 {
+			t.Errorf("Unexpected revision %d in history", rel.Version)
+		}
+	}
+}
+
 func TestUpdateReleaseNoChanges(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -18,9 +18,15 @@ package tiller
 
 import (
 	"bytes"
+	"sort"
 	"strings"
 
+	"github.com/ghodss/yaml"
+
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/tiller/environment"
 )
 
@@ -60,3 +66,42 @@ func summarizeKeptManifests(manifests []Manifest, kubeClient environment.KubeCli
 	}
 	return message
 }
+
+// keptResources lists the resources of the release that its uninstall left in
+// the cluster because of their resource policy. Like summarizeKeptManifests,
+// it leaves out the ones that do not exist.
+func keptResources(rel *release.Release, kubeClient environment.KubeClient) []*services.KeptResource {
+	manifests := relutil.SplitManifests(rel.Manifest)
+	names := make([]string, 0, len(manifests))
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var kept []*services.KeptResource
+	for _, name := range names {
+		var head relutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(manifests[name]), &head); err != nil || head.Metadata == nil {
+			continue
+		}
+		if !kube.ResourcePolicyIsKeep(head.Metadata.Annotations) {
+			continue
+		}
+		output, err := kubeClient.Get(rel.Namespace, bytes.NewBufferString(manifests[name]))
+		if err != nil || strings.Contains(output, kube.MissingGetHeader) {
+			continue
+		}
+
+		namespace := head.Metadata.Namespace
+		if namespace == "" {
+			namespace = rel.Namespace
+		}
+		kept = append(kept, &services.KeptResource{
+			Kind:      head.Kind,
+			Name:      head.Metadata.Name,
+			Namespace: namespace,
+			Release:   rel.Name,
+		})
+	}
+	return kept
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package timeconv
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 // Now creates a timestamp.Timestamp representing the current time.
@@ -49,10 +53,60 @@ func Format(ts *timestamp.Timestamp, layout string) string {
 
 // String formats the timestamp into a user-friendly string.
 //
-// Currently, this uses the 'time.ANSIC' format string, but there is no guarantee
-// that this will not change.
+// The layout is the display format set with SetDisplayFormat, which defaults
+// to the 'time.ANSIC' format string, but there is no guarantee that this
+// default will not change.
 //
 // This is a convenience function for formatting timestamps for user display.
 func String(ts *timestamp.Timestamp) string {
-	return Format(ts, time.ANSIC)
+	t := Time(ts)
+	switch displayFormat {
+	case FormatRFC3339:
+		return t.UTC().Format(time.RFC3339)
+	case FormatRelative:
+		return relative(t, timeNow())
+	case FormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(time.ANSIC)
+	}
+}
+
+// The names of the display formats accepted by SetDisplayFormat.
+const (
+	FormatANSIC    = "ansic"
+	FormatRFC3339  = "rfc3339"
+	FormatRelative = "relative"
+	FormatUnix     = "unix"
+)
+
+// DisplayFormats lists the display formats accepted by SetDisplayFormat.
+var DisplayFormats = []string{FormatANSIC, FormatRFC3339, FormatRelative, FormatUnix}
+
+var (
+	displayFormat = FormatANSIC
+	timeNow       = time.Now
+)
+
+// SetDisplayFormat sets the format used by String: ansic, rfc3339 (in UTC),
+// relative to now, such as "3h ago", or unix seconds. An empty name restores
+// the default.
+func SetDisplayFormat(name string) error {
+	if name == "" {
+		name = FormatANSIC
+	}
+	for _, f := range DisplayFormats {
+		if name == f {
+			displayFormat = name
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown time format %q, expected one of %s", name, strings.Join(DisplayFormats, ", "))
+}
+
+func relative(t, now time.Time) string {
+	if t.After(now) {
+		return "in " + duration.HumanDuration(t.Sub(now))
+	}
+	return duration.HumanDuration(now.Sub(t)) + " ago"
 }
@@ -60,3 +60,41 @@ func TestFormat(t *testing.T) {
 		t.Error("Format mismatch")
 	}
 }
+
+func TestString(t *testing.T) {
+	defer SetDisplayFormat("")
+	defer func() { timeNow = time.Now }()
+
+	deployed := time.Date(2019, time.May, 7, 14, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return deployed.Add(3 * time.Hour) }
+	ts := Timestamp(deployed)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"", deployed.Local().Format(time.ANSIC)},
+		{FormatANSIC, deployed.Local().Format(time.ANSIC)},
+		{FormatRFC3339, "2019-05-07T14:30:00Z"},
+		{FormatRelative, "3h ago"},
+		{FormatUnix, "1557239400"},
+	}
+	for _, tt := range tests {
+		if err := SetDisplayFormat(tt.format); err != nil {
+			t.Fatal(err)
+		}
+		if got := String(ts); got != tt.expected {
+			t.Errorf("format %q: expected %q, got %q", tt.format, tt.expected, got)
+		}
+	}
+
+	timeNow = func() time.Time { return deployed.Add(-90 * time.Second) }
+	SetDisplayFormat(FormatRelative)
+	if got := String(ts); got != "in 90s" {
+		t.Errorf("expected a future time to be %q, got %q", "in 90s", got)
+	}
+
+	if err := SetDisplayFormat("iso"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}